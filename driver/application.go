@@ -16,6 +16,13 @@
 
 package driver
 
+import (
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common"
+)
+
 //go:generate mockgen -source application.go -destination application_mock.go -package driver
 
 // Application is an abstraction of an application running on a Norma net.
@@ -35,7 +42,107 @@ type Application interface {
 	// GetSentTransactions returns the number of transactions sent by a given user.
 	GetSentTransactions(user int) (uint64, error)
 
+	// GetSentTransactionLog returns the latest transactions sent by a given user, in
+	// the order they have been sent.
+	GetSentTransactionLog(user int) (SentTransactionLog, error)
+
+	// GetRoundTripStats returns statistics on the round trips of transactions sent by
+	// a given user in the closed-loop mode. For open-loop applications, no round trips
+	// are tracked.
+	GetRoundTripStats(user int) (RoundTripStats, error)
+
+	// GetLoadStats returns statistics on the load produced by the load generator of
+	// the application, allowing to detect the generator itself being a bottleneck.
+	GetLoadStats() (LoadStats, error)
+
 	// GetReceivedTransactions returns the number fo transactions received by the appliation
 	// on the network.
 	GetReceivedTransactions() (uint64, error)
+
+	// GetComponentStats returns the number of sent and received transactions of each
+	// component of applications combining several application types. For other
+	// applications, no components are reported.
+	GetComponentStats() ([]ComponentStats, error)
+
+	// GetExpectedRejections returns the minimum number of transactions sent by the
+	// application the transaction pools of nodes are expected to reject, indexed by
+	// the Prometheus metric counting them. Applications sending well-formed
+	// transactions only return nil.
+	GetExpectedRejections() map[string]uint64
+
+	// GetExpectedSubmissionErrors returns the minimum number of transactions sent by
+	// the application expected to be rejected on submission, indexed by the class of
+	// the reported error. Applications sending well-formed transactions only return nil.
+	GetExpectedSubmissionErrors() map[string]uint64
+
+	// IsExpectedToFail reports whether the given transaction sent by the application
+	// is deliberately not expected to be executed successfully. Applications sending
+	// well-formed transactions only return false.
+	IsExpectedToFail(hash common.Hash) bool
+
+	// Verify checks application specific invariants on the current on-chain state
+	// of the application. It is intended to be called after the load production stopped.
+	Verify() error
+
+	// VerifyAt checks application specific invariants on the state of the given
+	// past block as reported by the node behind the given RPC client. Blocks
+	// preceding the deployment of the application are not checked.
+	VerifyAt(rpcClient rpc.RpcClient, blockNumber uint64) error
+}
+
+// SentTransaction identifies a transaction sent by a user of an application.
+type SentTransaction struct {
+	// Nonce is the nonce of the transaction in the account of the user.
+	Nonce uint64
+	// Hash is the hash of the signed transaction.
+	Hash common.Hash
+}
+
+// SentTransactionLog lists the latest transactions sent by a single user. To bound
+// the memory used in long runs, only a limited number of transactions is retained.
+type SentTransactionLog struct {
+	// Transactions are the retained transactions in the order they have been sent.
+	Transactions []SentTransaction
+	// Untracked is the number of earlier transactions no longer retained.
+	Untracked uint64
+}
+
+// RoundTripStats summarizes the round trips of transactions of a single user, each
+// starting with sending a transaction and ending with its inclusion in a block.
+type RoundTripStats struct {
+	// Completed is the number of transactions confirmed within the timeout.
+	Completed uint64
+	// TimedOut is the number of transactions not confirmed within the timeout.
+	TimedOut uint64
+	// Latency is the accumulated latency of all completed round trips.
+	Latency time.Duration
+}
+
+// ComponentStats summarizes the transactions of a single component of an application
+// combining several application types.
+type ComponentStats struct {
+	// Name is the name of the component, which is its application type.
+	Name string
+	// Sent is the number of transactions sent by the users of the component.
+	Sent uint64
+	// Received is the number of transactions received by the component.
+	Received uint64
+}
+
+// LoadStats summarizes the transactions produced by the load generator of an
+// application since its start. A gap between the requested and the submitted
+// transactions indicates that the load generator can not keep up.
+type LoadStats struct {
+	// Requested is the number of transactions requested by the load shaper.
+	Requested float64
+	// Issued is the number of transactions picked up by users to be generated.
+	Issued uint64
+	// Submitted is the number of transactions handed over to the network.
+	Submitted uint64
+	// Saturated is the accumulated time the load generator could not keep up
+	// with the requested load.
+	Saturated time.Duration
+	// SustainableRate is the rate in Tx/s an adaptive load shaper converged to
+	// while meeting its service level objective, 0 if it has not converged.
+	SustainableRate float64
 }
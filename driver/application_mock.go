@@ -23,6 +23,8 @@ package driver
 import (
 	reflect "reflect"
 
+	rpc "github.com/Fantom-foundation/Norma/driver/rpc"
+	common "github.com/ethereum/go-ethereum/common"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -63,6 +65,64 @@ func (mr *MockApplicationMockRecorder) Config() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Config", reflect.TypeOf((*MockApplication)(nil).Config))
 }
 
+// GetComponentStats mocks base method.
+func (m *MockApplication) GetComponentStats() ([]ComponentStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComponentStats")
+	ret0, _ := ret[0].([]ComponentStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComponentStats indicates an expected call of GetComponentStats.
+func (mr *MockApplicationMockRecorder) GetComponentStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComponentStats", reflect.TypeOf((*MockApplication)(nil).GetComponentStats))
+}
+
+// GetExpectedRejections mocks base method.
+func (m *MockApplication) GetExpectedRejections() map[string]uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpectedRejections")
+	ret0, _ := ret[0].(map[string]uint64)
+	return ret0
+}
+
+// GetExpectedRejections indicates an expected call of GetExpectedRejections.
+func (mr *MockApplicationMockRecorder) GetExpectedRejections() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpectedRejections", reflect.TypeOf((*MockApplication)(nil).GetExpectedRejections))
+}
+
+// GetExpectedSubmissionErrors mocks base method.
+func (m *MockApplication) GetExpectedSubmissionErrors() map[string]uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpectedSubmissionErrors")
+	ret0, _ := ret[0].(map[string]uint64)
+	return ret0
+}
+
+// GetExpectedSubmissionErrors indicates an expected call of GetExpectedSubmissionErrors.
+func (mr *MockApplicationMockRecorder) GetExpectedSubmissionErrors() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpectedSubmissionErrors", reflect.TypeOf((*MockApplication)(nil).GetExpectedSubmissionErrors))
+}
+
+// GetLoadStats mocks base method.
+func (m *MockApplication) GetLoadStats() (LoadStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoadStats")
+	ret0, _ := ret[0].(LoadStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoadStats indicates an expected call of GetLoadStats.
+func (mr *MockApplicationMockRecorder) GetLoadStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadStats", reflect.TypeOf((*MockApplication)(nil).GetLoadStats))
+}
+
 // GetNumberOfUsers mocks base method.
 func (m *MockApplication) GetNumberOfUsers() int {
 	m.ctrl.T.Helper()
@@ -92,6 +152,36 @@ func (mr *MockApplicationMockRecorder) GetReceivedTransactions() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceivedTransactions", reflect.TypeOf((*MockApplication)(nil).GetReceivedTransactions))
 }
 
+// GetRoundTripStats mocks base method.
+func (m *MockApplication) GetRoundTripStats(user int) (RoundTripStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoundTripStats", user)
+	ret0, _ := ret[0].(RoundTripStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoundTripStats indicates an expected call of GetRoundTripStats.
+func (mr *MockApplicationMockRecorder) GetRoundTripStats(user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoundTripStats", reflect.TypeOf((*MockApplication)(nil).GetRoundTripStats), user)
+}
+
+// GetSentTransactionLog mocks base method.
+func (m *MockApplication) GetSentTransactionLog(user int) (SentTransactionLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSentTransactionLog", user)
+	ret0, _ := ret[0].(SentTransactionLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSentTransactionLog indicates an expected call of GetSentTransactionLog.
+func (mr *MockApplicationMockRecorder) GetSentTransactionLog(user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSentTransactionLog", reflect.TypeOf((*MockApplication)(nil).GetSentTransactionLog), user)
+}
+
 // GetSentTransactions mocks base method.
 func (m *MockApplication) GetSentTransactions(user int) (uint64, error) {
 	m.ctrl.T.Helper()
@@ -107,6 +197,20 @@ func (mr *MockApplicationMockRecorder) GetSentTransactions(user interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSentTransactions", reflect.TypeOf((*MockApplication)(nil).GetSentTransactions), user)
 }
 
+// IsExpectedToFail mocks base method.
+func (m *MockApplication) IsExpectedToFail(hash common.Hash) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsExpectedToFail", hash)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsExpectedToFail indicates an expected call of IsExpectedToFail.
+func (mr *MockApplicationMockRecorder) IsExpectedToFail(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsExpectedToFail", reflect.TypeOf((*MockApplication)(nil).IsExpectedToFail), hash)
+}
+
 // Start mocks base method.
 func (m *MockApplication) Start() error {
 	m.ctrl.T.Helper()
@@ -134,3 +238,31 @@ func (mr *MockApplicationMockRecorder) Stop() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockApplication)(nil).Stop))
 }
+
+// Verify mocks base method.
+func (m *MockApplication) Verify() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockApplicationMockRecorder) Verify() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockApplication)(nil).Verify))
+}
+
+// VerifyAt mocks base method.
+func (m *MockApplication) VerifyAt(rpcClient rpc.RpcClient, blockNumber uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyAt", rpcClient, blockNumber)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyAt indicates an expected call of VerifyAt.
+func (mr *MockApplicationMockRecorder) VerifyAt(rpcClient, blockNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAt", reflect.TypeOf((*MockApplication)(nil).VerifyAt), rpcClient, blockNumber)
+}
@@ -0,0 +1,280 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package benchmark
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/monitoring"
+	nodemon "github.com/Fantom-foundation/Norma/driver/monitoring/node"
+	"github.com/Fantom-foundation/Norma/driver/parser"
+)
+
+const (
+	// maxBacklogGrowth is the maximum growth of the backlog during the steady-state
+	// window, relative to the evaluated rate, for the rate to be considered sustained.
+	maxBacklogGrowth = 0.05
+	// minBacklogBound is the lower limit of the maximum backlog, preventing low rates
+	// from failing due to transactions in flight.
+	minBacklogBound = 100
+	// maxSaturation is the maximum fraction of the steady-state window the load
+	// generator may fail to keep up with the requested rate.
+	maxSaturation = 0.1
+)
+
+// ProbeConfig defines how individual rates are evaluated on a network.
+type ProbeConfig struct {
+	App          string        // the type of the application producing the load
+	Users        int           // the number of users of the application
+	Warmup       time.Duration // the time the rate is held before measuring
+	Window       time.Duration // the steady-state window the rate is measured in
+	MaxBacklog   time.Duration // the maximum backlog in terms of the load produced in this time
+	MaxBlockTime time.Duration // the maximum time between two blocks
+}
+
+// Check tests the validity of the probe configuration.
+func (c *ProbeConfig) Check() error {
+	if c.Users <= 0 {
+		return fmt.Errorf("number of users must be > 0, got %d", c.Users)
+	}
+	if c.Warmup < 0 {
+		return fmt.Errorf("warmup time must be >= 0, got %v", c.Warmup)
+	}
+	if c.Window <= 0 {
+		return fmt.Errorf("steady-state window must be > 0, got %v", c.Window)
+	}
+	if c.MaxBacklog <= 0 {
+		return fmt.Errorf("maximum backlog must be > 0, got %v", c.MaxBacklog)
+	}
+	if c.MaxBlockTime <= 0 {
+		return fmt.Errorf("maximum block time must be > 0, got %v", c.MaxBlockTime)
+	}
+	return nil
+}
+
+// networkProbe evaluates rates by running an application with a constant rate
+// on a network for each rate.
+type networkProbe struct {
+	net     driver.Network
+	monitor *monitoring.Monitor
+	config  ProbeConfig
+	period  time.Duration // < the period in which the backlog is sampled
+	steps   int           // < the number of evaluated steps, used for naming applications
+}
+
+// NewNetworkProbe creates a probe evaluating rates on the given network. Block
+// times are obtained from the data collected by the given monitor.
+func NewNetworkProbe(net driver.Network, monitor *monitoring.Monitor, config ProbeConfig) (Probe, error) {
+	if err := config.Check(); err != nil {
+		return nil, err
+	}
+	probe := &networkProbe{
+		net:     net,
+		monitor: monitor,
+		config:  config,
+		period:  time.Second,
+	}
+	return probe.run, nil
+}
+
+// run holds the given rate for the warmup time and the steady-state window and
+// evaluates the backlog and block times observed during the window.
+func (p *networkProbe) run(rate float64) (Step, error) {
+	p.steps++
+	constant := float32(rate)
+	app, err := p.net.CreateApplication(&driver.ApplicationConfig{
+		Name:  fmt.Sprintf("bench-%d", p.steps),
+		Type:  p.config.App,
+		Users: p.config.Users,
+		Rate:  &parser.Rate{Constant: &constant},
+	})
+	if err != nil {
+		return Step{}, fmt.Errorf("failed to create application; %v", err)
+	}
+	if err := app.Start(); err != nil {
+		return Step{}, fmt.Errorf("failed to start application; %v", err)
+	}
+
+	time.Sleep(p.config.Warmup)
+	step, err := p.measure(app)
+	if stopErr := app.Stop(); stopErr != nil && err == nil {
+		err = fmt.Errorf("failed to stop application; %v", stopErr)
+	}
+	if err != nil {
+		return Step{}, err
+	}
+	p.drain(app)
+
+	checkStep(&step, rate, p.config)
+	return step, nil
+}
+
+// measure samples the backlog of the given application during the steady-state window.
+func (p *networkProbe) measure(app driver.Application) (Step, error) {
+	startStats, err := app.GetLoadStats()
+	if err != nil {
+		return Step{}, err
+	}
+	start := time.Now()
+	startReceived, err := app.GetReceivedTransactions()
+	if err != nil {
+		return Step{}, err
+	}
+
+	var times, backlogs []float64
+	maxBacklog := uint64(0)
+	received := startReceived
+	ticker := time.NewTicker(p.period)
+	defer ticker.Stop()
+	for now := start; now.Sub(start) < p.config.Window; now = <-ticker.C {
+		backlog, cur, err := getBacklog(app)
+		if err != nil {
+			return Step{}, err
+		}
+		if backlog > maxBacklog {
+			maxBacklog = backlog
+		}
+		received = cur
+		times = append(times, now.Sub(start).Seconds())
+		backlogs = append(backlogs, float64(backlog))
+	}
+	end := time.Now()
+
+	endStats, err := app.GetLoadStats()
+	if err != nil {
+		return Step{}, err
+	}
+
+	return Step{
+		Throughput:    float64(received-startReceived) / end.Sub(start).Seconds(),
+		MaxBacklog:    maxBacklog,
+		BacklogGrowth: getSlope(times, backlogs),
+		MaxBlockTime:  p.getMaxBlockTime(start, end),
+		saturated:     endStats.Saturated - startStats.Saturated,
+		window:        end.Sub(start),
+	}, nil
+}
+
+// drain waits for the network to process the backlog of the given application,
+// at most for the duration of the steady-state window.
+func (p *networkProbe) drain(app driver.Application) {
+	deadline := time.Now().Add(p.config.Window)
+	for time.Now().Before(deadline) {
+		backlog, _, err := getBacklog(app)
+		if err != nil || backlog == 0 {
+			return
+		}
+		time.Sleep(p.period)
+	}
+	log.Printf("Warning: backlog of application %s not processed within %v, subsequent steps may be affected", app.Config().Name, p.config.Window)
+}
+
+// getMaxBlockTime obtains the longest time between two consecutive blocks completed
+// by any node within the given time interval, including the time before the first
+// and after the last block in the interval.
+func (p *networkProbe) getMaxBlockTime(start, end time.Time) time.Duration {
+	res := time.Duration(0)
+	for _, node := range monitoring.GetSubjects(p.monitor, nodemon.BlockCompletionTime) {
+		series, exists := monitoring.GetData(p.monitor, node, nodemon.BlockCompletionTime)
+		if !exists || series == nil {
+			continue
+		}
+		latest := series.GetLatest()
+		if latest == nil {
+			continue
+		}
+		times := []time.Time{start, end}
+		for _, point := range series.GetRange(0, latest.Position+1) {
+			if point.Value.After(start) && point.Value.Before(end) {
+				times = append(times, point.Value)
+			}
+		}
+		sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+		for i := 1; i < len(times); i++ {
+			if gap := times[i].Sub(times[i-1]); gap > res {
+				res = gap
+			}
+		}
+	}
+	return res
+}
+
+// getBacklog obtains the number of transactions sent but not yet received by the
+// given application and the number of received transactions.
+func getBacklog(app driver.Application) (uint64, uint64, error) {
+	sent := uint64(0)
+	for user := 0; user < app.GetNumberOfUsers(); user++ {
+		count, err := app.GetSentTransactions(user)
+		if err != nil {
+			return 0, 0, err
+		}
+		sent += count
+	}
+	received, err := app.GetReceivedTransactions()
+	if err != nil {
+		return 0, 0, err
+	}
+	if received > sent {
+		return 0, received, nil
+	}
+	return sent - received, received, nil
+}
+
+// getSlope computes the slope of the least squares regression line of the given points.
+func getSlope(xs, ys []float64) float64 {
+	n := float64(len(xs))
+	if n < 2 {
+		return 0
+	}
+	sumX, sumY, sumXY, sumXX := 0.0, 0.0, 0.0, 0.0
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+		sumXY += xs[i] * ys[i]
+		sumXX += xs[i] * xs[i]
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
+
+// checkStep decides whether the measurements of the given step show that the
+// network sustained the given rate.
+func checkStep(step *Step, rate float64, config ProbeConfig) {
+	step.Passed = false
+	bound := uint64(math.Max(minBacklogBound, rate*config.MaxBacklog.Seconds()))
+	switch {
+	case step.saturated > time.Duration(maxSaturation*float64(step.window)):
+		step.Reason = fmt.Sprintf("load generator saturated for %v", step.saturated.Round(time.Millisecond))
+	case step.MaxBacklog > bound:
+		step.Reason = fmt.Sprintf("backlog of %d txs exceeds bound of %d txs", step.MaxBacklog, bound)
+	case step.BacklogGrowth > maxBacklogGrowth*rate:
+		step.Reason = fmt.Sprintf("backlog grows by %.1f Tx/s", step.BacklogGrowth)
+	case step.MaxBlockTime > config.MaxBlockTime:
+		step.Reason = fmt.Sprintf("block time of %v exceeds bound of %v", step.MaxBlockTime.Round(time.Millisecond), config.MaxBlockTime)
+	default:
+		step.Passed = true
+		step.Reason = ""
+	}
+}
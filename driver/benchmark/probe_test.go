@@ -0,0 +1,114 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package benchmark
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/golang/mock/gomock"
+)
+
+func TestCheckStep_FailureReasonsAreDetected(t *testing.T) {
+	config := ProbeConfig{MaxBacklog: 10 * time.Second, MaxBlockTime: 2 * time.Second}
+	tests := map[string]struct {
+		step  Step
+		issue string
+	}{
+		"passing":          {Step{MaxBacklog: 500, BacklogGrowth: 1, MaxBlockTime: time.Second, window: time.Minute}, ""},
+		"small backlog":    {Step{MaxBacklog: 100, window: time.Minute}, ""},
+		"large backlog":    {Step{MaxBacklog: 1001, window: time.Minute}, "backlog of 1001 txs exceeds"},
+		"growing backlog":  {Step{MaxBacklog: 500, BacklogGrowth: 6, window: time.Minute}, "backlog grows"},
+		"slow blocks":      {Step{MaxBlockTime: 3 * time.Second, window: time.Minute}, "block time of 3s exceeds"},
+		"saturated loader": {Step{saturated: 7 * time.Second, window: time.Minute}, "load generator saturated"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			step := test.step
+			checkStep(&step, 100, config)
+			if test.issue == "" && (!step.Passed || step.Reason != "") {
+				t.Errorf("step should pass, got reason %q", step.Reason)
+			}
+			if test.issue != "" && (step.Passed || !strings.Contains(step.Reason, test.issue)) {
+				t.Errorf("expected failure containing %q, got %q", test.issue, step.Reason)
+			}
+		})
+	}
+}
+
+func TestGetSlope_ComputesLeastSquaresSlope(t *testing.T) {
+	tests := []struct {
+		xs, ys []float64
+		want   float64
+	}{
+		{nil, nil, 0},
+		{[]float64{1}, []float64{5}, 0},
+		{[]float64{0, 1, 2, 3}, []float64{5, 5, 5, 5}, 0},
+		{[]float64{0, 1, 2, 3}, []float64{1, 3, 5, 7}, 2},
+		{[]float64{0, 1, 2, 3}, []float64{0, 2, 0, 2}, 0.4},
+	}
+	for _, test := range tests {
+		if got := getSlope(test.xs, test.ys); math.Abs(got-test.want) > 1e-9 {
+			t.Errorf("unexpected slope of %v/%v, wanted %f, got %f", test.xs, test.ys, test.want, got)
+		}
+	}
+}
+
+func TestNetworkProbe_RateIsHeldByApplication(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	app := driver.NewMockApplication(ctrl)
+	net.EXPECT().RegisterListener(gomock.Any()).AnyTimes()
+	net.EXPECT().GetActiveNodes().AnyTimes().Return([]driver.Node{})
+
+	monitor, err := monitoring.NewMonitor(net, monitoring.MonitorConfig{OutputDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to initiate monitor: %v", err)
+	}
+
+	net.EXPECT().CreateApplication(gomock.Any()).DoAndReturn(func(config *driver.ApplicationConfig) (driver.Application, error) {
+		if config.Type != "counter" || config.Users != 2 || config.Rate == nil || config.Rate.Constant == nil || *config.Rate.Constant != 50 {
+			t.Errorf("unexpected application configuration %v", config)
+		}
+		return app, nil
+	})
+	gomock.InOrder(
+		app.EXPECT().Start(),
+		app.EXPECT().Stop(),
+	)
+	app.EXPECT().GetLoadStats().AnyTimes().Return(driver.LoadStats{}, nil)
+	app.EXPECT().GetNumberOfUsers().AnyTimes().Return(2)
+	app.EXPECT().GetSentTransactions(gomock.Any()).AnyTimes().Return(uint64(10), nil)
+	app.EXPECT().GetReceivedTransactions().AnyTimes().Return(uint64(20), nil)
+
+	config := ProbeConfig{App: "counter", Users: 2, Window: 50 * time.Millisecond, MaxBacklog: time.Second, MaxBlockTime: time.Second}
+	probe, err := NewNetworkProbe(net, monitor, config)
+	if err != nil {
+		t.Fatalf("failed to create probe: %v", err)
+	}
+	step, err := probe(50)
+	if err != nil {
+		t.Fatalf("failed to evaluate rate: %v", err)
+	}
+	if !step.Passed || step.MaxBacklog != 0 || step.BacklogGrowth != 0 {
+		t.Errorf("unexpected step result %v", step)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package benchmark
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"time"
+)
+
+// Config defines the range and precision of a search for the saturation rate.
+type Config struct {
+	MinRate   float64 // the lowest rate to be evaluated in Tx/s
+	MaxRate   float64 // the highest rate to be considered in Tx/s
+	Precision float64 // the search ends once the saturation rate is bounded by an interval of this width in Tx/s
+}
+
+// Check tests the validity of the search configuration.
+func (c *Config) Check() error {
+	if c.MinRate <= 0 {
+		return fmt.Errorf("minimum rate must be > 0, got %f", c.MinRate)
+	}
+	if c.MaxRate <= c.MinRate {
+		return fmt.Errorf("maximum rate must be > minimum rate, got %f <= %f", c.MaxRate, c.MinRate)
+	}
+	if c.Precision <= 0 {
+		return fmt.Errorf("precision must be > 0, got %f", c.Precision)
+	}
+	return nil
+}
+
+// Step summarizes the evaluation of a single constant rate.
+type Step struct {
+	Rate          float64       // the evaluated rate in Tx/s
+	Passed        bool          // true if the network sustained the rate
+	Reason        string        // the reason for failing, empty if passed
+	Throughput    float64       // the rate of received transactions in Tx/s
+	MaxBacklog    uint64        // the maximum number of sent but not yet received transactions
+	BacklogGrowth float64       // the growth of the backlog in Tx/s
+	MaxBlockTime  time.Duration // the longest time between two blocks
+
+	saturated time.Duration // < the time the load generator could not keep up
+	window    time.Duration // < the length of the steady-state window
+}
+
+// Probe evaluates whether the network sustains the given constant rate in Tx/s.
+type Probe func(rate float64) (Step, error)
+
+// Result summarizes a search for the saturation rate. The saturation rate is
+// between the highest passing and the lowest failing rate.
+type Result struct {
+	Lower     float64 // the highest rate sustained, 0 if none
+	Upper     float64 // the lowest rate not sustained, the maximum rate if all rates passed
+	Saturated bool    // true if a rate not sustained by the network was found
+	Steps     []Step  // all evaluated steps in the order of their evaluation
+}
+
+// GetEstimate returns the estimated saturation rate and the resolution of the search,
+// i.e. the half-width of the interval between the highest passing and the lowest failing
+// rate. The resolution is no statistical confidence, each rate is evaluated only once.
+func (r *Result) GetEstimate() (rate, resolution float64) {
+	return (r.Lower + r.Upper) / 2, (r.Upper - r.Lower) / 2
+}
+
+// Search conducts a binary search for the highest constant rate sustained by
+// the network. Starting with the minimum rate, rates are evaluated using the
+// given probe until the saturation rate is bounded by the configured precision.
+func Search(config Config, probe Probe) (Result, error) {
+	if err := config.Check(); err != nil {
+		return Result{}, err
+	}
+
+	res := Result{Upper: config.MaxRate}
+	evaluate := func(rate float64) (bool, error) {
+		log.Printf("Evaluating rate of %.1f Tx/s ...", rate)
+		step, err := probe(rate)
+		if err != nil {
+			return false, fmt.Errorf("failed to evaluate rate of %.1f Tx/s; %v", rate, err)
+		}
+		step.Rate = rate
+		res.Steps = append(res.Steps, step)
+		if step.Passed {
+			log.Printf("Rate of %.1f Tx/s passed, throughput %.1f Tx/s", rate, step.Throughput)
+			res.Lower = rate
+		} else {
+			log.Printf("Rate of %.1f Tx/s failed: %s", rate, step.Reason)
+			res.Upper = rate
+			res.Saturated = true
+		}
+		return step.Passed, nil
+	}
+
+	passed, err := evaluate(config.MinRate)
+	if err != nil || !passed {
+		return res, err
+	}
+	for res.Upper-res.Lower > config.Precision {
+		if _, err := evaluate((res.Lower + res.Upper) / 2); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// WriteCsv writes the evaluated steps of the search to the given output.
+func (r *Result) WriteCsv(out io.Writer) error {
+	if _, err := fmt.Fprintln(out, "rate, passed, throughput, max_backlog, backlog_growth, max_block_time_ms, reason"); err != nil {
+		return err
+	}
+	for _, step := range r.Steps {
+		if _, err := fmt.Fprintf(out, "%.3f, %t, %.3f, %d, %.3f, %d, %s\n",
+			step.Rate, step.Passed, step.Throughput, step.MaxBacklog, step.BacklogGrowth, step.MaxBlockTime.Milliseconds(), step.Reason,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package benchmark
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSearch_SaturationRateIsBoundedByPrecision(t *testing.T) {
+	for _, saturation := range []float64{15, 100, 333, 999} {
+		t.Run(fmt.Sprintf("%.0f", saturation), func(t *testing.T) {
+			probe := func(rate float64) (Step, error) {
+				return Step{Passed: rate <= saturation}, nil
+			}
+			res, err := Search(Config{MinRate: 10, MaxRate: 1000, Precision: 5}, probe)
+			if err != nil {
+				t.Fatalf("search failed: %v", err)
+			}
+			if !res.Saturated {
+				t.Errorf("saturation should have been detected")
+			}
+			if res.Lower > saturation || res.Upper <= saturation {
+				t.Errorf("saturation rate %f not within bounds [%f, %f)", saturation, res.Lower, res.Upper)
+			}
+			if res.Upper-res.Lower > 5 {
+				t.Errorf("bounds [%f, %f] exceed precision", res.Lower, res.Upper)
+			}
+			if rate, resolution := res.GetEstimate(); rate-resolution != res.Lower || rate+resolution != res.Upper {
+				t.Errorf("estimate %f +/- %f does not match bounds [%f, %f]", rate, resolution, res.Lower, res.Upper)
+			}
+			if got, want := res.Steps[0].Rate, 10.0; got != want {
+				t.Errorf("search should start with the minimum rate, wanted %f, got %f", want, got)
+			}
+		})
+	}
+}
+
+func TestSearch_FailingMinimumRateEndsSearch(t *testing.T) {
+	probe := func(rate float64) (Step, error) {
+		return Step{Reason: "overloaded"}, nil
+	}
+	res, err := Search(Config{MinRate: 10, MaxRate: 1000, Precision: 5}, probe)
+	if err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if got, want := len(res.Steps), 1; got != want {
+		t.Errorf("unexpected number of steps, wanted %d, got %d", want, got)
+	}
+	if !res.Saturated || res.Lower != 0 || res.Upper != 10 {
+		t.Errorf("unexpected result %v", res)
+	}
+}
+
+func TestSearch_UnsaturatedNetworkIsReported(t *testing.T) {
+	probe := func(rate float64) (Step, error) {
+		return Step{Passed: true}, nil
+	}
+	res, err := Search(Config{MinRate: 10, MaxRate: 1000, Precision: 5}, probe)
+	if err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if res.Saturated || res.Upper != 1000 || res.Lower < 995 {
+		t.Errorf("unexpected result %v", res)
+	}
+}
+
+func TestSearch_ProbeErrorsAreReported(t *testing.T) {
+	probe := func(rate float64) (Step, error) {
+		return Step{}, fmt.Errorf("injected error")
+	}
+	if _, err := Search(Config{MinRate: 10, MaxRate: 1000, Precision: 5}, probe); err == nil || !strings.Contains(err.Error(), "injected error") {
+		t.Errorf("probe error should be reported, got %v", err)
+	}
+}
+
+func TestSearch_InvalidConfigIsDetected(t *testing.T) {
+	probe := func(rate float64) (Step, error) {
+		t.Errorf("no rate should be evaluated")
+		return Step{}, nil
+	}
+	for _, config := range []Config{
+		{MinRate: 0, MaxRate: 1000, Precision: 5},
+		{MinRate: 100, MaxRate: 10, Precision: 5},
+		{MinRate: 10, MaxRate: 1000, Precision: 0},
+	} {
+		if _, err := Search(config, probe); err == nil {
+			t.Errorf("invalid configuration %v should be detected", config)
+		}
+	}
+}
+
+func TestResult_StepsAreExportedAsCsv(t *testing.T) {
+	res := Result{Steps: []Step{
+		{Rate: 10, Passed: true, Throughput: 9.5, MaxBacklog: 20, BacklogGrowth: 0.1},
+		{Rate: 20, Reason: "overloaded", Throughput: 15, MaxBacklog: 400, BacklogGrowth: 5},
+	}}
+	var out bytes.Buffer
+	if err := res.WriteCsv(&out); err != nil {
+		t.Fatalf("failed to write CSV: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if got, want := len(lines), 3; got != want {
+		t.Fatalf("unexpected number of lines, wanted %d, got %d", want, got)
+	}
+	if got, want := lines[2], "20.000, false, 15.000, 400, 5.000, 0, overloaded"; got != want {
+		t.Errorf("unexpected line, wanted %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package checking
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Fantom-foundation/Norma/driver"
+)
+
+// ApplicationsStateChecker is a Checker checking the application specific invariants
+// of the final on-chain state of all applications in the network. Violations hint at
+// functional issues of the client, e.g. bugs in the EVM implementation.
+type ApplicationsStateChecker struct {
+}
+
+func (*ApplicationsStateChecker) Check(net driver.Network) error {
+	errs := []error{}
+	for _, app := range net.GetActiveApplications() {
+		if err := app.Verify(); err != nil {
+			errs = append(errs, fmt.Errorf("state of app %s is invalid; %v", app.Config().Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
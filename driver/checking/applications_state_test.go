@@ -0,0 +1,62 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package checking
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/golang/mock/gomock"
+)
+
+func TestApplicationsStateCheckerValid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	app1 := driver.NewMockApplication(ctrl)
+	app2 := driver.NewMockApplication(ctrl)
+	net.EXPECT().GetActiveApplications().Return([]driver.Application{app1, app2})
+	app1.EXPECT().Verify().Return(nil)
+	app2.EXPECT().Verify().Return(nil)
+
+	if err := new(ApplicationsStateChecker).Check(net); err != nil {
+		t.Errorf("unexpected error from ApplicationsStateChecker: %v", err)
+	}
+}
+
+func TestApplicationsStateCheckerReportsAllInvalidApps(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	app1 := driver.NewMockApplication(ctrl)
+	app2 := driver.NewMockApplication(ctrl)
+	net.EXPECT().GetActiveApplications().Return([]driver.Application{app1, app2})
+	app1.EXPECT().Config().AnyTimes().Return(&driver.ApplicationConfig{Name: "A"})
+	app2.EXPECT().Config().AnyTimes().Return(&driver.ApplicationConfig{Name: "B"})
+	app1.EXPECT().Verify().Return(fmt.Errorf("counter mismatch"))
+	app2.EXPECT().Verify().Return(fmt.Errorf("supply mismatch"))
+
+	err := new(ApplicationsStateChecker).Check(net)
+	if err == nil {
+		t.Fatalf("expected an error from ApplicationsStateChecker")
+	}
+	for _, want := range []string{"state of app A is invalid; counter mismatch", "state of app B is invalid; supply mismatch"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not report %q, got %v", want, err)
+		}
+	}
+}
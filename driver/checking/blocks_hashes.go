@@ -17,23 +17,67 @@
 package checking
 
 import (
+	"errors"
 	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+
 	"github.com/Fantom-foundation/Norma/driver"
 	"github.com/Fantom-foundation/Norma/driver/rpc"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// BlocksHashesCheckMode selects which blocks are compared by the BlocksHashesChecker.
+type BlocksHashesCheckMode string
+
+const (
+	// FullBlocksHashesCheck compares every block of the chain.
+	FullBlocksHashesCheck BlocksHashesCheckMode = "full"
+	// SampledBlocksHashesCheck compares the first blocks, the latest common block
+	// and a random sample of blocks in between.
+	SampledBlocksHashesCheck BlocksHashesCheckMode = "sampled"
+	// BisectBlocksHashesCheck compares the latest common block only and searches
+	// for the first divergent block if it does not match.
+	BisectBlocksHashesCheck BlocksHashesCheckMode = "bisect"
+)
+
+// IsValid returns true if the mode is one of the supported modes.
+func (m BlocksHashesCheckMode) IsValid() bool {
+	return m == FullBlocksHashesCheck || m == SampledBlocksHashesCheck || m == BisectBlocksHashesCheck
+}
+
+const (
+	defaultBlocksHashesBatchSize   = 100
+	defaultBlocksHashesParallelism = 16
+	defaultBlocksHashesSampleSize  = 100
 )
 
-// BlocksHashesChecker is a Checker checking if all Opera nodes provides the same hashes for all blocks/stateRoots.
+// BlocksHashesChecker is a Checker checking if all nodes report the same hashes for the
+// blocks of the chain. Blocks are fetched using JSON-RPC batch requests, with requests to
+// different nodes being issued concurrently. Zero values of the configuration fields are
+// replaced by defaults, i.e., a zero checker compares all blocks.
 type BlocksHashesChecker struct {
+	Mode        BlocksHashesCheckMode
+	BatchSize   int // < number of blocks fetched in a single batch request
+	Parallelism int // < maximum number of concurrently running batch requests
+	SampleSize  int // < number of random blocks compared in the sampled mode
 }
 
-func (*BlocksHashesChecker) Check(net driver.Network) (err error) {
+func (c *BlocksHashesChecker) Check(net driver.Network) (err error) {
 	nodes := net.GetActiveNodes()
+	if len(nodes) == 0 {
+		return nil
+	}
 	rpcClients := make([]rpc.RpcClient, len(nodes))
 	for i, n := range nodes {
 		rpcClients[i], err = n.DialRpc()
 		if err != nil {
+			for _, rpcClient := range rpcClients[:i] {
+				rpcClient.Close()
+			}
 			return fmt.Errorf("failed to dial RPC for node %s; %v", n.GetLabel(), err)
 		}
 	}
@@ -43,39 +87,191 @@ func (*BlocksHashesChecker) Check(net driver.Network) (err error) {
 		}
 	}()
 
-	for blockNumber := uint64(0); ; blockNumber++ {
+	run := &blocksHashesCheck{
+		nodes:       nodes,
+		rpcClients:  rpcClients,
+		batchSize:   withDefault(c.BatchSize, defaultBlocksHashesBatchSize),
+		parallelism: withDefault(c.Parallelism, defaultBlocksHashesParallelism),
+	}
+
+	heads, err := run.getHeads()
+	if err != nil {
+		return err
+	}
+	minHead, maxHead := heads[0], heads[0]
+	for _, head := range heads {
+		if head < minHead {
+			minHead = head
+		}
+		if head > maxHead {
+			maxHead = head
+		}
+	}
+
+	switch c.Mode {
+	case "", FullBlocksHashesCheck:
+		return run.checkRange(0, maxHead)
+	case SampledBlocksHashesCheck:
+		return run.checkSample(minHead, withDefault(c.SampleSize, defaultBlocksHashesSampleSize))
+	case BisectBlocksHashesCheck:
+		return run.checkBisect(minHead)
+	default:
+		return fmt.Errorf("unknown block hashes check mode: %v", c.Mode)
+	}
+}
+
+// blocksHashesCheck holds the state of a single run of the BlocksHashesChecker.
+type blocksHashesCheck struct {
+	nodes       []driver.Node
+	rpcClients  []rpc.RpcClient
+	batchSize   int
+	parallelism int
+}
+
+// getHeads obtains the number of the latest block of each node.
+func (c *blocksHashesCheck) getHeads() ([]uint64, error) {
+	heads := make([]uint64, len(c.nodes))
+	err := runInParallel(len(c.nodes), c.parallelism, func(i int) error {
+		var head hexutil.Uint64
+		if err := c.rpcClients[i].Call(&head, "eth_blockNumber"); err != nil {
+			return fmt.Errorf("failed to get block number of node %s; %v", c.nodes[i].GetLabel(), err)
+		}
+		heads[i] = uint64(head)
+		return nil
+	})
+	return heads, err
+}
+
+// checkRange compares all blocks in the range [from, to].
+func (c *blocksHashesCheck) checkRange(from, to uint64) error {
+	// Blocks are processed in windows to keep the memory usage bounded
+	// while still utilizing all workers.
+	window := uint64(c.batchSize * c.parallelism)
+	for start := from; start <= to; start += window {
+		numbers := make([]uint64, 0, window)
+		for i := start; i <= to && i < start+window; i++ {
+			numbers = append(numbers, i)
+		}
+		if err := c.checkBlocks(numbers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkSample compares the first blocks, the latest common block and a random
+// sample of blocks in between.
+func (c *blocksHashesCheck) checkSample(head uint64, sampleSize int) error {
+	selected := map[uint64]bool{0: true, 1: true, 2: true, head: true}
+	if head > 0 {
+		for i := 0; i < sampleSize; i++ {
+			selected[uint64(rand.Int63n(int64(head)))] = true
+		}
+	}
+	numbers := make([]uint64, 0, len(selected))
+	for number := range selected {
+		numbers = append(numbers, number)
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+	return c.checkBlocks(numbers)
+}
+
+// checkBisect compares the latest common block. If it does not match, the first divergent
+// block is searched using a binary search, assuming that once the nodes diverged, they
+// do not agree on any later block.
+func (c *blocksHashesCheck) checkBisect(head uint64) error {
+	numbers := []uint64{0, 1, 2}
+	if head > 2 {
+		numbers = append(numbers, head)
+	}
+	err := c.checkBlocks(numbers)
+	var mismatch *blockMismatchError
+	if !errors.As(err, &mismatch) || mismatch.block <= 2 {
+		return err
+	}
+
+	// invariant: block lo matches, block hi does not
+	lo, hi := uint64(2), head
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		err := c.checkBlocks([]uint64{mid})
+		if err == nil {
+			lo = mid
+		} else if errors.As(err, &mismatch) {
+			hi = mid
+		} else {
+			return err
+		}
+	}
+	return mismatch
+}
+
+// checkBlocks fetches the given blocks, which must be sorted, from all nodes and
+// reports the first block the nodes do not agree on.
+func (c *blocksHashesCheck) checkBlocks(numbers []uint64) error {
+	blocks, err := c.getBlocks(numbers)
+	if err != nil {
+		return err
+	}
+	for j, blockNumber := range numbers {
 		var referenceHashes *blockHashes
-		var nodesLackingTheBlock = 0
-		for i, n := range nodes {
-			block, err := getBlockHashes(rpcClients[i], blockNumber)
-			if err != nil {
-				return fmt.Errorf("failed to get block %d detail at node %s; %v", blockNumber, n.GetLabel(), err)
-			}
+		for i, n := range c.nodes {
+			block := blocks[i][j]
 			if block == nil { // block does not exist on the node
 				if blockNumber <= 2 {
 					return fmt.Errorf("unable to check block hashes - block %d does not exists at node %s", blockNumber, n.GetLabel())
 				}
-				nodesLackingTheBlock++
 				continue
 			}
 			if referenceHashes == nil {
 				referenceHashes = block
-			} else {
-				if referenceHashes.StateRoot != block.StateRoot {
-					return fmt.Errorf("stateRoot of the block %d does not match", blockNumber)
-				}
-				if referenceHashes.ReceiptsRoot != block.ReceiptsRoot {
-					return fmt.Errorf("receiptsRoot of the block %d does not match", blockNumber)
-				}
-				if referenceHashes.Hash != block.Hash {
-					return fmt.Errorf("hash of the block %d does not match", blockNumber)
-				}
+				continue
+			}
+			if referenceHashes.StateRoot != block.StateRoot {
+				return &blockMismatchError{field: "stateRoot", block: blockNumber}
+			}
+			if referenceHashes.ReceiptsRoot != block.ReceiptsRoot {
+				return &blockMismatchError{field: "receiptsRoot", block: blockNumber}
+			}
+			if referenceHashes.Hash != block.Hash {
+				return &blockMismatchError{field: "hash", block: blockNumber}
 			}
-		}
-		if nodesLackingTheBlock == len(nodes) { // no node has the last block
-			return nil // finish successfully
 		}
 	}
+	return nil
+}
+
+// getBlocks fetches the given blocks from all nodes. The block j of node i is
+// located at index [i][j] of the result, nil if the node does not have the block.
+func (c *blocksHashesCheck) getBlocks(numbers []uint64) ([][]*blockHashes, error) {
+	blocks := make([][]*blockHashes, len(c.nodes))
+	for i := range blocks {
+		blocks[i] = make([]*blockHashes, len(numbers))
+	}
+	numBatches := (len(numbers) + c.batchSize - 1) / c.batchSize
+	err := runInParallel(len(c.nodes)*numBatches, c.parallelism, func(job int) error {
+		node, from := job/numBatches, (job%numBatches)*c.batchSize
+		to := from + c.batchSize
+		if to > len(numbers) {
+			to = len(numbers)
+		}
+		err := getBlocksHashes(c.rpcClients[node], numbers[from:to], blocks[node][from:to])
+		if err != nil {
+			return fmt.Errorf("failed to get blocks %d-%d detail at node %s; %v", numbers[from], numbers[to-1], c.nodes[node].GetLabel(), err)
+		}
+		return nil
+	})
+	return blocks, err
+}
+
+// blockMismatchError reports a block the nodes do not agree on.
+type blockMismatchError struct {
+	field string
+	block uint64
+}
+
+func (e *blockMismatchError) Error() string {
+	return fmt.Sprintf("%s of the block %d does not match", e.field, e.block)
 }
 
 type blockHashes struct {
@@ -84,11 +280,55 @@ type blockHashes struct {
 	ReceiptsRoot common.Hash
 }
 
-func getBlockHashes(rpcClient rpc.RpcClient, blockNumber uint64) (*blockHashes, error) {
-	var block *blockHashes
-	err := rpcClient.Call(&block, "eth_getBlockByNumber", hexutil.EncodeUint64(blockNumber), false)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get block state root from RPC; %v", err)
+// getBlocksHashes fetches the given blocks using a single batch request and stores
+// them to the given result slice.
+func getBlocksHashes(rpcClient rpc.RpcClient, numbers []uint64, result []*blockHashes) error {
+	batch := make([]gethrpc.BatchElem, len(numbers))
+	for i, blockNumber := range numbers {
+		batch[i] = gethrpc.BatchElem{
+			Method: "eth_getBlockByNumber",
+			Args:   []interface{}{hexutil.EncodeUint64(blockNumber), false},
+			Result: &result[i],
+		}
+	}
+	if err := rpcClient.BatchCall(batch); err != nil {
+		return fmt.Errorf("failed to get blocks from RPC; %v", err)
+	}
+	for i, elem := range batch {
+		if elem.Error != nil {
+			return fmt.Errorf("failed to get block %d from RPC; %v", numbers[i], elem.Error)
+		}
+	}
+	return nil
+}
+
+// runInParallel runs the given task for all indexes in [0, n) using at most
+// the given number of concurrent workers. Errors of all tasks are joined.
+func runInParallel(n, parallelism int, task func(int) error) error {
+	jobs := make(chan int, n)
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for w := 0; w < n && w < parallelism; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				errs[i] = task(i)
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func withDefault(value, defaultValue int) int {
+	if value <= 0 {
+		return defaultValue
 	}
-	return block, nil
+	return value
 }
@@ -17,111 +17,171 @@
 package checking
 
 import (
+	"fmt"
+	"testing"
+
 	"github.com/Fantom-foundation/Norma/driver"
 	"github.com/Fantom-foundation/Norma/driver/rpc"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 	"github.com/golang/mock/gomock"
-	"testing"
 )
 
 func TestBlockHashesCheckerValid(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	net := driver.NewMockNetwork(ctrl)
-	node1 := driver.NewMockNode(ctrl)
-	node2 := driver.NewMockNode(ctrl)
-	rpc := rpc.NewMockRpcClient(ctrl)
-	net.EXPECT().GetActiveNodes().MinTimes(1).Return([]driver.Node{node1, node2})
-	node1.EXPECT().DialRpc().MinTimes(1).Return(rpc, nil)
-	node2.EXPECT().DialRpc().MinTimes(1).Return(rpc, nil)
-	result := blockHashes{
-		Hash:         common.Hash{0x11},
-		StateRoot:    common.Hash{0x22},
-		ReceiptsRoot: common.Hash{0x33},
-	}
-	gomock.InOrder(
-		rpc.EXPECT().Call(gomock.Any(), "eth_getBlockByNumber", gomock.Any(), false).Times(6).SetArg(0, &result),
-		rpc.EXPECT().Call(gomock.Any(), "eth_getBlockByNumber", gomock.Any(), false).AnyTimes(),
-		rpc.EXPECT().Close().Times(2),
-	)
-	err := new(BlocksHashesChecker).Check(net)
-	if err != nil {
-		t.Errorf("unexpected error from BlocksHashesChecker: %v", err)
+	for _, mode := range []BlocksHashesCheckMode{"", FullBlocksHashesCheck, SampledBlocksHashesCheck, BisectBlocksHashesCheck} {
+		t.Run(string(mode), func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			chain := makeChain(50)
+			net := mockNetworkWithChains(ctrl, chain, chain)
+			checker := BlocksHashesChecker{Mode: mode}
+			if err := checker.Check(net); err != nil {
+				t.Errorf("unexpected error from BlocksHashesChecker: %v", err)
+			}
+		})
 	}
 }
 
 func TestBlockHashesCheckerInvalidStateRoot(t *testing.T) {
 	ctrl := gomock.NewController(t)
-	net := driver.NewMockNetwork(ctrl)
-	node1 := driver.NewMockNode(ctrl)
-	node2 := driver.NewMockNode(ctrl)
-	rpc1 := rpc.NewMockRpcClient(ctrl)
-	rpc2 := rpc.NewMockRpcClient(ctrl)
-	net.EXPECT().GetActiveNodes().MinTimes(1).Return([]driver.Node{node1, node2})
-	node1.EXPECT().DialRpc().MinTimes(1).Return(rpc1, nil)
-	node2.EXPECT().DialRpc().MinTimes(1).Return(rpc2, nil)
-	result1 := blockHashes{
-		Hash:         common.Hash{0x11},
-		StateRoot:    common.Hash{0x22},
-		ReceiptsRoot: common.Hash{0x33},
-	}
-	result2 := blockHashes{
-		Hash:         common.Hash{0x11},
-		StateRoot:    common.Hash{0xFF}, // different
-		ReceiptsRoot: common.Hash{0x33},
-	}
-
-	rpc1.EXPECT().Call(gomock.Any(), "eth_getBlockByNumber", gomock.Any(), false).AnyTimes().SetArg(0, &result1)
-	rpc2.EXPECT().Call(gomock.Any(), "eth_getBlockByNumber", gomock.Any(), false).Times(3).SetArg(0, &result1)
-	rpc2.EXPECT().Call(gomock.Any(), "eth_getBlockByNumber", gomock.Any(), false).SetArg(0, &result2)
-	rpc1.EXPECT().Close()
-	rpc2.EXPECT().Close()
+	chain1 := makeChain(6)
+	chain2 := makeChain(6)
+	chain2[3].StateRoot = common.Hash{0xFF} // different
 
+	net := mockNetworkWithChains(ctrl, chain1, chain2)
 	err := new(BlocksHashesChecker).Check(net)
-	if err.Error() != "stateRoot of the block 3 does not match" {
+	if err == nil || err.Error() != "stateRoot of the block 3 does not match" {
 		t.Errorf("unexpected error from BlocksHashesChecker: %v", err)
 	}
 }
 
 func TestBlockHashesCheckerInvalidLastBlock(t *testing.T) {
 	ctrl := gomock.NewController(t)
-	net := driver.NewMockNetwork(ctrl)
-	node1 := driver.NewMockNode(ctrl)
-	node2 := driver.NewMockNode(ctrl)
-	node3 := driver.NewMockNode(ctrl)
-	rpc1 := rpc.NewMockRpcClient(ctrl)
-	rpc2 := rpc.NewMockRpcClient(ctrl)
-	rpc3 := rpc.NewMockRpcClient(ctrl)
-	net.EXPECT().GetActiveNodes().MinTimes(1).Return([]driver.Node{node1, node2, node3})
-	node1.EXPECT().DialRpc().MinTimes(1).Return(rpc1, nil)
-	node2.EXPECT().DialRpc().MinTimes(1).Return(rpc2, nil)
-	node3.EXPECT().DialRpc().MinTimes(1).Return(rpc3, nil)
-	result1 := blockHashes{
-		Hash:         common.Hash{0x11},
-		StateRoot:    common.Hash{0x22},
-		ReceiptsRoot: common.Hash{0x33},
+	chain1 := makeChain(4)
+	chain2 := makeChain(3) // does not have block 3 (should be ignored)
+	chain3 := makeChain(4)
+	chain3[3].ReceiptsRoot = common.Hash{0xFF} // different block 3
+
+	net := mockNetworkWithChains(ctrl, chain1, chain2, chain3)
+	err := new(BlocksHashesChecker).Check(net)
+	if err == nil || err.Error() != "receiptsRoot of the block 3 does not match" {
+		t.Errorf("unexpected error from BlocksHashesChecker: %v", err)
 	}
-	result2 := blockHashes{
-		Hash:         common.Hash{0x11},
-		StateRoot:    common.Hash{0x22},
-		ReceiptsRoot: common.Hash{0xFF}, // different
+}
+
+func TestBlockHashesCheckerMissingFirstBlocks(t *testing.T) {
+	for _, mode := range []BlocksHashesCheckMode{FullBlocksHashesCheck, SampledBlocksHashesCheck, BisectBlocksHashesCheck} {
+		t.Run(string(mode), func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			net := mockNetworkWithChains(ctrl, makeChain(10), makeChain(2))
+			checker := BlocksHashesChecker{Mode: mode}
+			err := checker.Check(net)
+			if err == nil || err.Error() != "unable to check block hashes - block 2 does not exists at node node-1" {
+				t.Errorf("unexpected error from BlocksHashesChecker: %v", err)
+			}
+		})
 	}
+}
 
-	rpc1.EXPECT().Call(gomock.Any(), "eth_getBlockByNumber", gomock.Any(), false).Times(4).SetArg(0, &result1)
-	rpc1.EXPECT().Call(gomock.Any(), "eth_getBlockByNumber", gomock.Any(), false).AnyTimes()
+func TestBlockHashesCheckerFindsFirstDivergentBlock(t *testing.T) {
+	for _, mode := range []BlocksHashesCheckMode{FullBlocksHashesCheck, BisectBlocksHashesCheck} {
+		t.Run(string(mode), func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			chain1 := makeChain(100)
+			chain2 := makeChain(100)
+			for i := 37; i < len(chain2); i++ {
+				chain2[i].StateRoot = common.Hash{0xFF}
+			}
 
-	rpc2.EXPECT().Call(gomock.Any(), "eth_getBlockByNumber", gomock.Any(), false).Times(3).SetArg(0, &result1)
-	rpc2.EXPECT().Call(gomock.Any(), "eth_getBlockByNumber", gomock.Any(), false).AnyTimes() // does not have block 3 (should be ignored)
+			net := mockNetworkWithChains(ctrl, chain1, chain2, chain1)
+			checker := BlocksHashesChecker{Mode: mode, BatchSize: 7, Parallelism: 2}
+			err := checker.Check(net)
+			if err == nil || err.Error() != "stateRoot of the block 37 does not match" {
+				t.Errorf("unexpected error from BlocksHashesChecker: %v", err)
+			}
+		})
+	}
+}
+
+func TestBlockHashesCheckerSampledModeComparesLatestCommonBlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	chain1 := makeChain(100)
+	chain2 := makeChain(90)
+	chain2[89].Hash = common.Hash{0xFF}
 
-	rpc3.EXPECT().Call(gomock.Any(), "eth_getBlockByNumber", gomock.Any(), false).Times(3).SetArg(0, &result1)
-	rpc3.EXPECT().Call(gomock.Any(), "eth_getBlockByNumber", "0x3", false).SetArg(0, &result2) // different block 3
-	rpc3.EXPECT().Call(gomock.Any(), "eth_getBlockByNumber", gomock.Any(), false).AnyTimes()
+	net := mockNetworkWithChains(ctrl, chain1, chain2)
+	checker := BlocksHashesChecker{Mode: SampledBlocksHashesCheck, SampleSize: 5}
+	err := checker.Check(net)
+	if err == nil || err.Error() != "hash of the block 89 does not match" {
+		t.Errorf("unexpected error from BlocksHashesChecker: %v", err)
+	}
+}
 
-	rpc1.EXPECT().Close()
-	rpc2.EXPECT().Close()
-	rpc3.EXPECT().Close()
+func TestBlockHashesCheckerUsesBatchRequests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	chain := makeChain(1000)
+	net := driver.NewMockNetwork(ctrl)
+	node := driver.NewMockNode(ctrl)
+	client := rpc.NewMockRpcClient(ctrl)
+	net.EXPECT().GetActiveNodes().Return([]driver.Node{node})
+	node.EXPECT().DialRpc().Return(client, nil)
+	client.EXPECT().Call(gomock.Any(), "eth_blockNumber").SetArg(0, hexutil.Uint64(len(chain)-1))
+	client.EXPECT().BatchCall(gomock.Any()).Times(10).DoAndReturn(serveChain(chain))
+	client.EXPECT().Close()
 
-	err := new(BlocksHashesChecker).Check(net)
-	if err.Error() != "receiptsRoot of the block 3 does not match" {
+	checker := BlocksHashesChecker{BatchSize: 100}
+	if err := checker.Check(net); err != nil {
 		t.Errorf("unexpected error from BlocksHashesChecker: %v", err)
 	}
 }
+
+// makeChain creates a chain of the given number of blocks with distinct hashes.
+func makeChain(numBlocks int) []blockHashes {
+	chain := make([]blockHashes, numBlocks)
+	for i := range chain {
+		chain[i] = blockHashes{
+			Hash:         common.Hash{0x11, byte(i), byte(i >> 8)},
+			StateRoot:    common.Hash{0x22, byte(i), byte(i >> 8)},
+			ReceiptsRoot: common.Hash{0x33, byte(i), byte(i >> 8)},
+		}
+	}
+	return chain
+}
+
+// mockNetworkWithChains creates a network of nodes, each serving one of the given chains.
+func mockNetworkWithChains(ctrl *gomock.Controller, chains ...[]blockHashes) driver.Network {
+	net := driver.NewMockNetwork(ctrl)
+	nodes := make([]driver.Node, len(chains))
+	for i, chain := range chains {
+		node := driver.NewMockNode(ctrl)
+		client := rpc.NewMockRpcClient(ctrl)
+		node.EXPECT().DialRpc().Return(client, nil)
+		node.EXPECT().GetLabel().AnyTimes().Return(fmt.Sprintf("node-%d", i))
+		client.EXPECT().Call(gomock.Any(), "eth_blockNumber").SetArg(0, hexutil.Uint64(len(chain)-1))
+		client.EXPECT().BatchCall(gomock.Any()).AnyTimes().DoAndReturn(serveChain(chain))
+		client.EXPECT().Close()
+		nodes[i] = node
+	}
+	net.EXPECT().GetActiveNodes().Return(nodes)
+	return net
+}
+
+// serveChain creates a handler answering batch requests for blocks of the given chain.
+func serveChain(chain []blockHashes) func([]gethrpc.BatchElem) error {
+	return func(batch []gethrpc.BatchElem) error {
+		for _, elem := range batch {
+			number, err := hexutil.DecodeUint64(elem.Args[0].(string))
+			if err != nil {
+				return err
+			}
+			result := elem.Result.(**blockHashes)
+			if number < uint64(len(chain)) {
+				block := chain[number]
+				*result = &block
+			} else {
+				*result = nil
+			}
+		}
+		return nil
+	}
+}
@@ -19,6 +19,7 @@ package checking
 import (
 	"errors"
 	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/monitoring"
 )
 
 // Checker do the network consistency check at the end of the scenario.
@@ -26,10 +27,33 @@ type Checker interface {
 	Check(net driver.Network) error
 }
 
-func CheckNetworkConsistency(net driver.Network) error {
+// Config configures the checks run by CheckNetworkConsistency.
+type Config struct {
+	// BlocksHashesCheckMode selects which blocks are compared among the nodes,
+	// all blocks are compared if not set.
+	BlocksHashesCheckMode BlocksHashesCheckMode
+	// FailOnSaturatedLoadGenerator enables a check failing if the load generator
+	// of any application could not keep up with the requested load.
+	FailOnSaturatedLoadGenerator bool
+	// Monitor provides the metrics collected from the nodes, checks based on
+	// them are skipped if not set.
+	Monitor *monitoring.Monitor
+}
+
+func CheckNetworkConsistency(net driver.Network, config Config) error {
 	checkers := []Checker{
 		new(BlockHeightChecker),
-		new(BlocksHashesChecker),
+		&BlocksHashesChecker{Mode: config.BlocksHashesCheckMode},
+		new(TransactionsInclusionChecker),
+		new(ApplicationsStateChecker),
+		new(HistoricalStateChecker),
+		new(NotificationsChecker),
+	}
+	if config.FailOnSaturatedLoadGenerator {
+		checkers = append(checkers, new(LoadGeneratorChecker))
+	}
+	if config.Monitor != nil {
+		checkers = append(checkers, NewTxPoolRejectionsChecker(config.Monitor))
 	}
 	errs := make([]error, len(checkers))
 	for i, checker := range checkers {
@@ -0,0 +1,112 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+package checking
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const defaultHistoricalStateNumBlocks = 20
+
+// HistoricalStateChecker is a Checker querying the state of randomly selected past blocks
+// on all archive nodes of the network. The results of the queries must be the same on all
+// archive nodes, and the application specific invariants must hold on the past states.
+// The check is skipped if there are no archive nodes in the network.
+type HistoricalStateChecker struct {
+	NumBlocks int // < number of past blocks to be checked, a default is used if not set
+}
+
+func (c *HistoricalStateChecker) Check(net driver.Network) error {
+	nodes := []driver.Node{}
+	for _, n := range net.GetActiveNodes() {
+		if n.IsArchive() {
+			nodes = append(nodes, n)
+		}
+	}
+	if len(nodes) == 0 {
+		log.Printf("no archive nodes in the network, historical state checks skipped")
+		return nil
+	}
+
+	rpcClients := make([]rpc.RpcClient, 0, len(nodes))
+	defer func() {
+		for _, rpcClient := range rpcClients {
+			rpcClient.Close()
+		}
+	}()
+	head := uint64(0)
+	for i, n := range nodes {
+		rpcClient, err := n.DialRpc()
+		if err != nil {
+			return fmt.Errorf("failed to dial RPC for node %s; %v", n.GetLabel(), err)
+		}
+		rpcClients = append(rpcClients, rpcClient)
+		var blockNumber hexutil.Uint64
+		if err := rpcClient.Call(&blockNumber, "eth_blockNumber"); err != nil {
+			return fmt.Errorf("failed to get block number of node %s; %v", n.GetLabel(), err)
+		}
+		if i == 0 || uint64(blockNumber) < head {
+			head = uint64(blockNumber)
+		}
+	}
+	if head == 0 {
+		return nil
+	}
+
+	numBlocks := withDefault(c.NumBlocks, defaultHistoricalStateNumBlocks)
+	blocks := make([]uint64, numBlocks)
+	for i := range blocks {
+		blocks[i] = 1 + uint64(rand.Int63n(int64(head)))
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i] < blocks[j] })
+
+	apps := net.GetActiveApplications()
+	errs := []error{}
+	for _, blockNumber := range blocks {
+		for _, query := range rpc.HistoricalStateQueries {
+			var reference string
+			for i, n := range nodes {
+				res, err := query.Query(rpcClients[i], blockNumber)
+				if err != nil {
+					return fmt.Errorf("failed to run %s on block %d at node %s; %v", query.Method, blockNumber, n.GetLabel(), err)
+				}
+				if i == 0 {
+					reference = res
+				} else if res != reference {
+					errs = append(errs, fmt.Errorf("%s on block %d differs between nodes %s and %s: %s != %s",
+						query.Method, blockNumber, nodes[0].GetLabel(), n.GetLabel(), reference, res))
+				}
+			}
+		}
+		for i, n := range nodes {
+			for _, app := range apps {
+				if err := app.VerifyAt(rpcClients[i], blockNumber); err != nil {
+					errs = append(errs, fmt.Errorf("state of app %s at block %d on node %s is invalid; %v",
+						app.Config().Name, blockNumber, n.GetLabel(), err))
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,118 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+package checking
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHistoricalStateCheckerSkipsNetworksWithoutArchives(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	node := driver.NewMockNode(ctrl)
+	net.EXPECT().GetActiveNodes().Return([]driver.Node{node})
+	node.EXPECT().IsArchive().Return(false)
+
+	if err := new(HistoricalStateChecker).Check(net); err != nil {
+		t.Errorf("unexpected error from HistoricalStateChecker: %v", err)
+	}
+}
+
+func TestHistoricalStateCheckerValid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	app := driver.NewMockApplication(ctrl)
+	archive1, rpc1 := mockArchiveNode(ctrl, "A", 10, "0x1")
+	archive2, rpc2 := mockArchiveNode(ctrl, "B", 12, "0x1")
+	validator := driver.NewMockNode(ctrl)
+	validator.EXPECT().IsArchive().Return(false)
+
+	net.EXPECT().GetActiveNodes().Return([]driver.Node{archive1, validator, archive2})
+	net.EXPECT().GetActiveApplications().Return([]driver.Application{app})
+	app.EXPECT().VerifyAt(rpc1, gomock.Any()).Times(5).Return(nil)
+	app.EXPECT().VerifyAt(rpc2, gomock.Any()).Times(5).Do(func(_ rpc.RpcClient, blockNumber uint64) {
+		if blockNumber < 1 || blockNumber > 10 {
+			t.Errorf("block %d is not a past block common to all archive nodes", blockNumber)
+		}
+	}).Return(nil)
+
+	checker := HistoricalStateChecker{NumBlocks: 5}
+	if err := checker.Check(net); err != nil {
+		t.Errorf("unexpected error from HistoricalStateChecker: %v", err)
+	}
+}
+
+func TestHistoricalStateCheckerReportsDifferentResults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	archive1, _ := mockArchiveNode(ctrl, "A", 10, "0x1")
+	archive2, _ := mockArchiveNode(ctrl, "B", 10, "0x2")
+
+	net.EXPECT().GetActiveNodes().Return([]driver.Node{archive1, archive2})
+	net.EXPECT().GetActiveApplications().Return(nil)
+
+	checker := HistoricalStateChecker{NumBlocks: 1}
+	err := checker.Check(net)
+	if err == nil {
+		t.Fatalf("expected an error from HistoricalStateChecker")
+	}
+	for _, method := range []string{"eth_getBalance", "eth_getStorageAt", "eth_call"} {
+		if !strings.Contains(err.Error(), method) || !strings.Contains(err.Error(), "differs between nodes A and B: 0x1 != 0x2") {
+			t.Errorf("error does not report the difference of %s, got %v", method, err)
+		}
+	}
+}
+
+func TestHistoricalStateCheckerReportsInvalidApplicationState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	app := driver.NewMockApplication(ctrl)
+	archive, _ := mockArchiveNode(ctrl, "A", 10, "0x1")
+
+	net.EXPECT().GetActiveNodes().Return([]driver.Node{archive})
+	net.EXPECT().GetActiveApplications().Return([]driver.Application{app})
+	app.EXPECT().Config().Return(&driver.ApplicationConfig{Name: "counter"})
+	app.EXPECT().VerifyAt(gomock.Any(), gomock.Any()).Return(fmt.Errorf("counter mismatch"))
+
+	checker := HistoricalStateChecker{NumBlocks: 1}
+	err := checker.Check(net)
+	if err == nil || !strings.Contains(err.Error(), "on node A is invalid; counter mismatch") {
+		t.Errorf("unexpected error from HistoricalStateChecker: %v", err)
+	}
+}
+
+// mockArchiveNode creates an archive node at the given head block answering
+// all historical state queries with the given result.
+func mockArchiveNode(ctrl *gomock.Controller, label string, head uint64, result string) (*driver.MockNode, *rpc.MockRpcClient) {
+	node := driver.NewMockNode(ctrl)
+	client := rpc.NewMockRpcClient(ctrl)
+	node.EXPECT().IsArchive().Return(true)
+	node.EXPECT().GetLabel().AnyTimes().Return(label)
+	node.EXPECT().DialRpc().Return(client, nil)
+	client.EXPECT().Call(gomock.Any(), "eth_blockNumber").SetArg(0, hexutil.Uint64(head))
+	client.EXPECT().Call(gomock.Any(), "eth_getBalance", gomock.Any(), gomock.Any()).AnyTimes().SetArg(0, result)
+	client.EXPECT().Call(gomock.Any(), "eth_getStorageAt", gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().SetArg(0, result)
+	client.EXPECT().Call(gomock.Any(), "eth_call", gomock.Any(), gomock.Any()).AnyTimes().SetArg(0, result)
+	client.EXPECT().Close()
+	return node, client
+}
@@ -0,0 +1,46 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package checking
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Fantom-foundation/Norma/driver"
+)
+
+// LoadGeneratorChecker is a Checker failing if the load generator of any application
+// could not keep up with the requested load. In this case, the observed throughput
+// is limited by Norma rather than by the network under test.
+type LoadGeneratorChecker struct {
+}
+
+func (*LoadGeneratorChecker) Check(net driver.Network) error {
+	errs := []error{}
+	for _, app := range net.GetActiveApplications() {
+		stats, err := app.GetLoadStats()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get load stats of app %s; %v", app.Config().Name, err))
+			continue
+		}
+		if stats.Saturated > 0 {
+			errs = append(errs, fmt.Errorf("load generator of app %s could not keep up with the requested load for %v; requested %.0f txs, submitted %d txs",
+				app.Config().Name, stats.Saturated, stats.Requested, stats.Submitted))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,61 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package checking
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/golang/mock/gomock"
+)
+
+func TestLoadGeneratorCheckerValid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	app := driver.NewMockApplication(ctrl)
+	net.EXPECT().GetActiveApplications().Return([]driver.Application{app})
+	app.EXPECT().GetLoadStats().Return(driver.LoadStats{Requested: 100, Issued: 100, Submitted: 100}, nil)
+
+	if err := new(LoadGeneratorChecker).Check(net); err != nil {
+		t.Errorf("unexpected error from LoadGeneratorChecker: %v", err)
+	}
+}
+
+func TestLoadGeneratorCheckerReportsSaturatedApps(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	app1 := driver.NewMockApplication(ctrl)
+	app2 := driver.NewMockApplication(ctrl)
+	net.EXPECT().GetActiveApplications().Return([]driver.Application{app1, app2})
+	app1.EXPECT().Config().AnyTimes().Return(&driver.ApplicationConfig{Name: "A"})
+	app2.EXPECT().Config().AnyTimes().Return(&driver.ApplicationConfig{Name: "B"})
+	app1.EXPECT().GetLoadStats().Return(driver.LoadStats{Requested: 100, Submitted: 100}, nil)
+	app2.EXPECT().GetLoadStats().Return(driver.LoadStats{Requested: 1000, Submitted: 500, Saturated: 5 * time.Second}, nil)
+
+	err := new(LoadGeneratorChecker).Check(net)
+	if err == nil {
+		t.Fatalf("expected an error from LoadGeneratorChecker")
+	}
+	if strings.Contains(err.Error(), "app A") {
+		t.Errorf("error reports app keeping up with the load, got %v", err)
+	}
+	if want := "load generator of app B could not keep up with the requested load for 5s"; !strings.Contains(err.Error(), want) {
+		t.Errorf("error does not report %q, got %v", want, err)
+	}
+}
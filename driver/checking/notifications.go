@@ -0,0 +1,57 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package checking
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/Fantom-foundation/Norma/driver"
+)
+
+// NotificationsChecker is a Checker failing if the subscriptions opened on any
+// node missed notifications or received them repeatedly. Networks without
+// subscriptions always pass this check.
+type NotificationsChecker struct {
+}
+
+func (*NotificationsChecker) Check(net driver.Network) error {
+	stats := net.GetNotificationStats()
+	keys := make([]driver.NotificationKey, 0, len(stats))
+	for key := range stats {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Node != keys[j].Node {
+			return keys[i].Node < keys[j].Node
+		}
+		return keys[i].Kind < keys[j].Kind
+	})
+
+	errs := []error{}
+	for _, key := range keys {
+		cur := stats[key]
+		if cur.Missed > 0 {
+			errs = append(errs, fmt.Errorf("subscriptions on node %s missed %d of %s notifications", key.Node, cur.Missed, key.Kind))
+		}
+		if cur.Duplicated > 0 {
+			errs = append(errs, fmt.Errorf("subscriptions on node %s received %d duplicated %s notifications", key.Node, cur.Duplicated, key.Kind))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,74 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package checking
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/golang/mock/gomock"
+)
+
+func TestNotificationsCheckerValid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	net.EXPECT().GetNotificationStats().Return(map[driver.NotificationKey]driver.NotificationStats{
+		{Node: "A", Kind: driver.NewHeadsSubscription}: {Received: 100},
+		{Node: "A", Kind: driver.LogsSubscription}:     {Received: 50},
+	})
+
+	if err := new(NotificationsChecker).Check(net); err != nil {
+		t.Errorf("unexpected error from NotificationsChecker: %v", err)
+	}
+}
+
+func TestNotificationsCheckerPassesWithoutSubscriptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	net.EXPECT().GetNotificationStats().Return(nil)
+
+	if err := new(NotificationsChecker).Check(net); err != nil {
+		t.Errorf("unexpected error from NotificationsChecker: %v", err)
+	}
+}
+
+func TestNotificationsCheckerReportsMissedAndDuplicatedNotifications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	net.EXPECT().GetNotificationStats().Return(map[driver.NotificationKey]driver.NotificationStats{
+		{Node: "A", Kind: driver.NewHeadsSubscription}: {Received: 100},
+		{Node: "B", Kind: driver.NewHeadsSubscription}: {Received: 98, Missed: 2},
+		{Node: "B", Kind: driver.LogsSubscription}:     {Received: 50, Duplicated: 3},
+	})
+
+	err := new(NotificationsChecker).Check(net)
+	if err == nil {
+		t.Fatalf("expected an error from NotificationsChecker")
+	}
+	if strings.Contains(err.Error(), "node A") {
+		t.Errorf("error reports node without issues, got %v", err)
+	}
+	for _, want := range []string{
+		"subscriptions on node B missed 2 of newHeads notifications",
+		"subscriptions on node B received 3 duplicated logs notifications",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not report %q, got %v", want, err)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package checking
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	defaultInclusionBatchSize   = 100
+	defaultInclusionParallelism = 16
+)
+
+// TransactionsInclusionChecker is a Checker checking if every transaction sent by the
+// applications of the network landed on the chain exactly once and was executed successfully.
+// Transactions reusing the nonce of a different transaction of the same user are reported as
+// nonce collisions, since at most one of them can land. Transactions still pending in the txpool at the end of the run are reported, but they
+// are not considered a failure. Transactions applications expect to fail, e.g. since they
+// are deliberately invalid, are not checked. Receipts are fetched using JSON-RPC batch
+// requests, several of which are issued concurrently. Zero values of the configuration
+// fields are replaced by defaults.
+type TransactionsInclusionChecker struct {
+	BatchSize   int // < number of transactions fetched in a single batch request
+	Parallelism int // < maximum number of concurrently running batch requests
+}
+
+func (c *TransactionsInclusionChecker) Check(net driver.Network) error {
+	rpcClient, err := net.DialRandomRpc()
+	if err != nil {
+		return fmt.Errorf("failed to dial RPC; %v", err)
+	}
+	defer rpcClient.Close()
+
+	run := &inclusionCheck{
+		rpcClient:   rpcClient,
+		batchSize:   withDefault(c.BatchSize, defaultInclusionBatchSize),
+		parallelism: withDefault(c.Parallelism, defaultInclusionParallelism),
+	}
+
+	errs := []error{}
+	for _, app := range net.GetActiveApplications() {
+		name := app.Config().Name
+		for user := 0; user < app.GetNumberOfUsers(); user++ {
+			sent, err := app.GetSentTransactionLog(user)
+			if err != nil {
+				return fmt.Errorf("failed to get sent transactions of app %s, user %d; %v", name, user, err)
+			}
+			if sent.Untracked > 0 {
+				log.Printf("app %s, user %d: %d earliest transactions are no longer tracked and not checked", name, user, sent.Untracked)
+			}
+			txs := make([]driver.SentTransaction, 0, len(sent.Transactions))
+			for _, tx := range sent.Transactions {
+				if !app.IsExpectedToFail(tx.Hash) {
+					txs = append(txs, tx)
+				}
+			}
+			if excluded := len(sent.Transactions) - len(txs); excluded > 0 {
+				log.Printf("app %s, user %d: %d transactions expected to fail are not checked", name, user, excluded)
+			}
+			report, err := run.checkTransactionsInclusion(txs)
+			if err != nil {
+				return fmt.Errorf("failed to check transactions of app %s, user %d; %v", name, user, err)
+			}
+			if report.pending > 0 {
+				log.Printf("app %s, user %d: %d transactions still pending at end of run", name, user, report.pending)
+			}
+			if report.hasFailures() {
+				errs = append(errs, fmt.Errorf("transactions of app %s, user %d not included as expected: %v", name, user, report))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// inclusionReport summarizes the on-chain fate of a list of sent transactions.
+type inclusionReport struct {
+	sent       int // < number of transactions passed to the network, including duplicates
+	included   int // < executed successfully
+	reverted   int // < included, but failed during execution
+	duplicated int // < sent more than once
+	collided   int // < reusing the nonce of a different transaction sent before
+	dropped    int // < neither included nor known to the node anymore
+	pending    int // < still waiting in the txpool
+}
+
+func (r *inclusionReport) hasFailures() bool {
+	return r.reverted > 0 || r.duplicated > 0 || r.collided > 0 || r.dropped > 0
+}
+
+func (r *inclusionReport) String() string {
+	return fmt.Sprintf("sent %d, included %d, reverted %d, duplicated %d, nonce collisions %d, dropped by txpool %d, still pending %d",
+		r.sent, r.included, r.reverted, r.duplicated, r.collided, r.dropped, r.pending)
+}
+
+// inclusionCheck holds the state of a single run of the TransactionsInclusionChecker.
+type inclusionCheck struct {
+	rpcClient   rpc.RpcClient
+	batchSize   int
+	parallelism int
+}
+
+func (c *inclusionCheck) checkTransactionsInclusion(txs []driver.SentTransaction) (*inclusionReport, error) {
+	report := &inclusionReport{sent: len(txs)}
+	seen := make(map[common.Hash]bool, len(txs))
+	nonces := make(map[uint64]bool, len(txs))
+	unique := make([]common.Hash, 0, len(txs))
+	for _, tx := range txs {
+		if seen[tx.Hash] {
+			report.duplicated++
+			continue
+		}
+		seen[tx.Hash] = true
+		unique = append(unique, tx.Hash)
+		if nonces[tx.Nonce] {
+			report.collided++
+		}
+		nonces[tx.Nonce] = true
+	}
+
+	receipts := make([]*txReceipt, len(unique))
+	if err := fetchInBatches(c, "eth_getTransactionReceipt", unique, receipts); err != nil {
+		return nil, err
+	}
+	missing := []common.Hash{}
+	for i, receipt := range receipts {
+		switch {
+		case receipt == nil:
+			missing = append(missing, unique[i])
+		case receipt.Status == 0:
+			report.reverted++
+		default:
+			report.included++
+		}
+	}
+
+	// no receipt - the transaction is either pending or lost
+	txs := make([]*txInfo, len(missing))
+	if err := fetchInBatches(c, "eth_getTransactionByHash", missing, txs); err != nil {
+		return nil, err
+	}
+	for _, tx := range txs {
+		if tx == nil {
+			report.dropped++
+		} else {
+			report.pending++
+		}
+	}
+	return report, nil
+}
+
+type txReceipt struct {
+	Status      hexutil.Uint64
+	BlockNumber *hexutil.Big
+}
+
+type txInfo struct {
+	BlockNumber *hexutil.Big
+}
+
+// fetchInBatches calls the given method for each of the given transactions and stores
+// the results to the given result slice. Transactions are split into batch requests,
+// several of which are run concurrently.
+func fetchInBatches[T any](c *inclusionCheck, method string, hashes []common.Hash, result []*T) error {
+	numBatches := (len(hashes) + c.batchSize - 1) / c.batchSize
+	return runInParallel(numBatches, c.parallelism, func(job int) error {
+		from := job * c.batchSize
+		to := from + c.batchSize
+		if to > len(hashes) {
+			to = len(hashes)
+		}
+		batch := make([]gethrpc.BatchElem, to-from)
+		for i := range batch {
+			batch[i] = gethrpc.BatchElem{
+				Method: method,
+				Args:   []interface{}{hashes[from+i]},
+				Result: &result[from+i],
+			}
+		}
+		if err := c.rpcClient.BatchCall(batch); err != nil {
+			return fmt.Errorf("failed to call %s from RPC; %v", method, err)
+		}
+		for i, elem := range batch {
+			if elem.Error != nil {
+				return fmt.Errorf("failed to call %s for tx %v from RPC; %v", method, hashes[from+i], elem.Error)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,250 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package checking
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/golang/mock/gomock"
+)
+
+func TestTransactionsInclusionCheckerValid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	app := driver.NewMockApplication(ctrl)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+
+	net.EXPECT().DialRandomRpc().Return(rpcClient, nil)
+	net.EXPECT().GetActiveApplications().Return([]driver.Application{app})
+	app.EXPECT().Config().AnyTimes().Return(&driver.ApplicationConfig{Name: "A"})
+	app.EXPECT().IsExpectedToFail(gomock.Any()).AnyTimes().Return(false)
+	app.EXPECT().GetNumberOfUsers().AnyTimes().Return(2)
+	app.EXPECT().GetSentTransactionLog(0).Return(sentLog(common.Hash{0x01}, common.Hash{0x02}), nil)
+	app.EXPECT().GetSentTransactionLog(1).Return(sentLog(common.Hash{0x03}), nil)
+
+	chain := &txStatuses{receipts: map[common.Hash]txReceipt{
+		{0x01}: {Status: 1},
+		{0x02}: {Status: 1},
+		{0x03}: {Status: 1},
+	}}
+	rpcClient.EXPECT().BatchCall(gomock.Any()).AnyTimes().DoAndReturn(chain.serve)
+	rpcClient.EXPECT().Close()
+
+	if err := new(TransactionsInclusionChecker).Check(net); err != nil {
+		t.Errorf("unexpected error from TransactionsInclusionChecker: %v", err)
+	}
+}
+
+func TestTransactionsInclusionCheckerPendingTransactionsAreNoFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	app := driver.NewMockApplication(ctrl)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+
+	net.EXPECT().DialRandomRpc().Return(rpcClient, nil)
+	net.EXPECT().GetActiveApplications().Return([]driver.Application{app})
+	app.EXPECT().Config().AnyTimes().Return(&driver.ApplicationConfig{Name: "A"})
+	app.EXPECT().IsExpectedToFail(gomock.Any()).AnyTimes().Return(false)
+	app.EXPECT().GetNumberOfUsers().AnyTimes().Return(1)
+	app.EXPECT().GetSentTransactionLog(0).Return(sentLog(common.Hash{0x01}), nil)
+
+	chain := &txStatuses{pending: map[common.Hash]bool{{0x01}: true}}
+	rpcClient.EXPECT().BatchCall(gomock.Any()).AnyTimes().DoAndReturn(chain.serve)
+	rpcClient.EXPECT().Close()
+
+	if err := new(TransactionsInclusionChecker).Check(net); err != nil {
+		t.Errorf("unexpected error from TransactionsInclusionChecker: %v", err)
+	}
+}
+
+func TestTransactionsInclusionCheckerReportsFailures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	app := driver.NewMockApplication(ctrl)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+
+	included := common.Hash{0x01}
+	reverted := common.Hash{0x02}
+	dropped := common.Hash{0x03}
+	pending := common.Hash{0x04}
+
+	net.EXPECT().DialRandomRpc().Return(rpcClient, nil)
+	net.EXPECT().GetActiveApplications().Return([]driver.Application{app})
+	app.EXPECT().Config().AnyTimes().Return(&driver.ApplicationConfig{Name: "A"})
+	app.EXPECT().IsExpectedToFail(gomock.Any()).AnyTimes().Return(false)
+	app.EXPECT().GetNumberOfUsers().AnyTimes().Return(1)
+	app.EXPECT().GetSentTransactionLog(0).Return(sentLog(included, reverted, dropped, pending, included), nil)
+
+	chain := &txStatuses{
+		receipts: map[common.Hash]txReceipt{included: {Status: 1}, reverted: {Status: 0}},
+		pending:  map[common.Hash]bool{pending: true},
+	}
+	rpcClient.EXPECT().BatchCall(gomock.Any()).AnyTimes().DoAndReturn(chain.serve)
+	rpcClient.EXPECT().Close()
+
+	err := new(TransactionsInclusionChecker).Check(net)
+	if err == nil {
+		t.Fatalf("expected an error from TransactionsInclusionChecker")
+	}
+	want := "sent 5, included 1, reverted 1, duplicated 1, nonce collisions 0, dropped by txpool 1, still pending 1"
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("unexpected error from TransactionsInclusionChecker, wanted %q, got %v", want, err)
+	}
+}
+
+func TestTransactionsInclusionCheckerFetchesReceiptsInBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	app := driver.NewMockApplication(ctrl)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+
+	hashes := make([]common.Hash, 25)
+	chain := &txStatuses{receipts: map[common.Hash]txReceipt{}}
+	for i := range hashes {
+		hashes[i] = common.Hash{0x01, byte(i)}
+		chain.receipts[hashes[i]] = txReceipt{Status: 1}
+	}
+
+	net.EXPECT().DialRandomRpc().Return(rpcClient, nil)
+	net.EXPECT().GetActiveApplications().Return([]driver.Application{app})
+	app.EXPECT().Config().AnyTimes().Return(&driver.ApplicationConfig{Name: "A"})
+	app.EXPECT().IsExpectedToFail(gomock.Any()).AnyTimes().Return(false)
+	app.EXPECT().GetNumberOfUsers().AnyTimes().Return(1)
+	app.EXPECT().GetSentTransactionLog(0).Return(sentLog(hashes...), nil)
+	rpcClient.EXPECT().BatchCall(gomock.Any()).Times(3).DoAndReturn(chain.serve)
+	rpcClient.EXPECT().Close()
+
+	checker := TransactionsInclusionChecker{BatchSize: 10}
+	if err := checker.Check(net); err != nil {
+		t.Errorf("unexpected error from TransactionsInclusionChecker: %v", err)
+	}
+}
+
+func TestTransactionsInclusionCheckerSkipsOnlyTransactionsExpectedToFail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	app := driver.NewMockApplication(ctrl)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+
+	valid := common.Hash{0x01}
+	invalid := common.Hash{0x02}
+	lost := common.Hash{0x03}
+
+	net.EXPECT().DialRandomRpc().Return(rpcClient, nil)
+	net.EXPECT().GetActiveApplications().Return([]driver.Application{app})
+	app.EXPECT().Config().AnyTimes().Return(&driver.ApplicationConfig{Name: "A"})
+	app.EXPECT().IsExpectedToFail(valid).AnyTimes().Return(false)
+	app.EXPECT().IsExpectedToFail(invalid).AnyTimes().Return(true)
+	app.EXPECT().IsExpectedToFail(lost).AnyTimes().Return(false)
+	app.EXPECT().GetNumberOfUsers().AnyTimes().Return(1)
+	app.EXPECT().GetSentTransactionLog(0).Return(sentLog(valid, invalid, lost), nil)
+
+	chain := &txStatuses{receipts: map[common.Hash]txReceipt{valid: {Status: 1}}}
+	rpcClient.EXPECT().BatchCall(gomock.Any()).AnyTimes().DoAndReturn(chain.serve)
+	rpcClient.EXPECT().Close()
+
+	err := new(TransactionsInclusionChecker).Check(net)
+	if err == nil {
+		t.Fatalf("expected an error from TransactionsInclusionChecker")
+	}
+	want := "sent 2, included 1, reverted 0, duplicated 0, nonce collisions 0, dropped by txpool 1, still pending 0"
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("unexpected error from TransactionsInclusionChecker, wanted %q, got %v", want, err)
+	}
+}
+
+func TestTransactionsInclusionCheckerReportsNonceCollisions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	app := driver.NewMockApplication(ctrl)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+
+	first := common.Hash{0x01}
+	second := common.Hash{0x02}
+
+	net.EXPECT().DialRandomRpc().Return(rpcClient, nil)
+	net.EXPECT().GetActiveApplications().Return([]driver.Application{app})
+	app.EXPECT().Config().AnyTimes().Return(&driver.ApplicationConfig{Name: "A"})
+	app.EXPECT().IsExpectedToFail(gomock.Any()).AnyTimes().Return(false)
+	app.EXPECT().GetNumberOfUsers().AnyTimes().Return(1)
+	app.EXPECT().GetSentTransactionLog(0).Return(driver.SentTransactionLog{
+		Transactions: []driver.SentTransaction{{Nonce: 7, Hash: first}, {Nonce: 7, Hash: second}},
+	}, nil)
+
+	chain := &txStatuses{receipts: map[common.Hash]txReceipt{first: {Status: 1}}}
+	rpcClient.EXPECT().BatchCall(gomock.Any()).AnyTimes().DoAndReturn(chain.serve)
+	rpcClient.EXPECT().Close()
+
+	err := new(TransactionsInclusionChecker).Check(net)
+	if err == nil {
+		t.Fatalf("expected an error from TransactionsInclusionChecker")
+	}
+	want := "sent 2, included 1, reverted 0, duplicated 0, nonce collisions 1, dropped by txpool 1, still pending 0"
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("unexpected error from TransactionsInclusionChecker, wanted %q, got %v", want, err)
+	}
+}
+
+// sentLog creates a log of the given transactions using consecutive nonces, only
+// repeated hashes share a nonce.
+func sentLog(hashes ...common.Hash) driver.SentTransactionLog {
+	res := driver.SentTransactionLog{}
+	nonces := map[common.Hash]uint64{}
+	for _, hash := range hashes {
+		nonce, found := nonces[hash]
+		if !found {
+			nonce = uint64(len(nonces))
+			nonces[hash] = nonce
+		}
+		res.Transactions = append(res.Transactions, driver.SentTransaction{Nonce: nonce, Hash: hash})
+	}
+	return res
+}
+
+// txStatuses serves batch requests for receipts and transactions from a fixed set of
+// included and pending transactions; all other transactions are unknown.
+type txStatuses struct {
+	receipts map[common.Hash]txReceipt
+	pending  map[common.Hash]bool
+}
+
+func (s *txStatuses) serve(batch []gethrpc.BatchElem) error {
+	for _, elem := range batch {
+		hash := elem.Args[0].(common.Hash)
+		switch elem.Method {
+		case "eth_getTransactionReceipt":
+			result := elem.Result.(**txReceipt)
+			if receipt, found := s.receipts[hash]; found {
+				*result = &receipt
+			}
+		case "eth_getTransactionByHash":
+			result := elem.Result.(**txInfo)
+			if s.pending[hash] {
+				*result = &txInfo{}
+			}
+		default:
+			return fmt.Errorf("unexpected method %s", elem.Method)
+		}
+	}
+	return nil
+}
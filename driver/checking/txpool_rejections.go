@@ -0,0 +1,147 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package checking
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/monitoring"
+	nodemon "github.com/Fantom-foundation/Norma/driver/monitoring/node"
+)
+
+// defaultTxPoolRejectionsTimeout is the time the rejections counted by the nodes are
+// given to catch up with the rejections expected by the applications.
+const defaultTxPoolRejectionsTimeout = 30 * time.Second
+
+// TxPoolRejectionsChecker is a Checker comparing the rejections of transactions expected
+// by applications deliberately sending invalid transactions with the rejections counted
+// by the transaction pools of the nodes. Since pool metrics may count several categories
+// of rejections together, the classes of the errors reported to each application on
+// submission are checked as well. Since nodes also reject transactions for other
+// reasons, e.g. gossiped transactions already included in a block, the expected numbers
+// are lower bounds.
+type TxPoolRejectionsChecker struct {
+	// Counter provides the number of rejections counted by the given metric, summed
+	// over all nodes of the network.
+	Counter func(metric string) (uint64, error)
+	// Timeout is the time given to the counted rejections to catch up.
+	Timeout time.Duration
+}
+
+// NewTxPoolRejectionsChecker creates a checker comparing the expected rejections with
+// the transaction pool metrics collected by the given monitor.
+func NewTxPoolRejectionsChecker(monitor *monitoring.Monitor) *TxPoolRejectionsChecker {
+	return &TxPoolRejectionsChecker{
+		Counter: func(name string) (uint64, error) {
+			return getTxPoolRejections(monitor, name)
+		},
+		Timeout: defaultTxPoolRejectionsTimeout,
+	}
+}
+
+func (c *TxPoolRejectionsChecker) Check(net driver.Network) error {
+	expected := map[string]uint64{}
+	expectedErrors := map[driver.SubmissionErrorKey]uint64{}
+	for _, app := range net.GetActiveApplications() {
+		for metric, count := range app.GetExpectedRejections() {
+			expected[metric] += count
+		}
+		for class, count := range app.GetExpectedSubmissionErrors() {
+			key := driver.SubmissionErrorKey{App: app.Config().Name, Class: driver.SubmissionErrorClass(class)}
+			expectedErrors[key] += count
+		}
+	}
+	metrics := make([]string, 0, len(expected))
+	for metric := range expected {
+		metrics = append(metrics, metric)
+	}
+	sort.Strings(metrics)
+
+	// counters of nodes are collected periodically, the latest rejections may take a while to show up
+	deadline := time.Now().Add(c.Timeout)
+	for {
+		errs := []error{}
+		for _, metric := range metrics {
+			counted, err := c.Counter(metric)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if counted < expected[metric] {
+				errs = append(errs, fmt.Errorf("transaction pools counted %d rejections in %s, expected at least %d", counted, metric, expected[metric]))
+			}
+		}
+		errs = append(errs, checkSubmissionErrors(net, expectedErrors)...)
+		if len(errs) == 0 || time.Now().After(deadline) {
+			return errors.Join(errs...)
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// checkSubmissionErrors tests that the applications got at least the expected number of
+// submission errors of each class, summed over all nodes.
+func checkSubmissionErrors(net driver.Network, expected map[driver.SubmissionErrorKey]uint64) []error {
+	if len(expected) == 0 {
+		return nil
+	}
+	counted := map[driver.SubmissionErrorKey]uint64{}
+	for key, count := range net.GetTransactionSubmissionErrors() {
+		counted[driver.SubmissionErrorKey{App: key.App, Class: key.Class}] += count
+	}
+	keys := make([]driver.SubmissionErrorKey, 0, len(expected))
+	for key := range expected {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].App != keys[j].App {
+			return keys[i].App < keys[j].App
+		}
+		return keys[i].Class < keys[j].Class
+	})
+	errs := []error{}
+	for _, key := range keys {
+		if counted[key] < expected[key] {
+			errs = append(errs, fmt.Errorf("application %s got %d submission errors of class %s, expected at least %d", key.App, counted[key], key.Class, expected[key]))
+		}
+	}
+	return errs
+}
+
+// getTxPoolRejections sums up the latest values of the given transaction pool metric
+// reported by all nodes.
+func getTxPoolRejections(monitor *monitoring.Monitor, name string) (uint64, error) {
+	metric, found := nodemon.TxPoolRejections[name]
+	if !found {
+		return 0, fmt.Errorf("unknown transaction pool metric %s", name)
+	}
+	res := uint64(0)
+	for _, node := range monitoring.GetSubjects(monitor, metric) {
+		series, exists := monitoring.GetData(monitor, node, metric)
+		if !exists || series == nil {
+			continue
+		}
+		if point := series.GetLatest(); point != nil {
+			res += uint64(point.Value)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package checking
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/golang/mock/gomock"
+)
+
+func TestTxPoolRejectionsChecker_EnoughRejectionsAreFine(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	app1 := driver.NewMockApplication(ctrl)
+	app2 := driver.NewMockApplication(ctrl)
+	net.EXPECT().GetActiveApplications().Return([]driver.Application{app1, app2})
+	app1.EXPECT().GetExpectedRejections().Return(map[string]uint64{"txpool_invalid": 10})
+	app2.EXPECT().GetExpectedRejections().Return(map[string]uint64{"txpool_invalid": 5})
+	app1.EXPECT().GetExpectedSubmissionErrors().Return(nil)
+	app2.EXPECT().GetExpectedSubmissionErrors().Return(nil)
+
+	counted := map[string]uint64{"txpool_invalid": 17}
+	checker := &TxPoolRejectionsChecker{Counter: func(metric string) (uint64, error) {
+		return counted[metric], nil
+	}}
+	if err := checker.Check(net); err != nil {
+		t.Errorf("unexpected error from TxPoolRejectionsChecker: %v", err)
+	}
+}
+
+func TestTxPoolRejectionsChecker_MissingRejectionsAreReported(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	app := driver.NewMockApplication(ctrl)
+	net.EXPECT().GetActiveApplications().Return([]driver.Application{app})
+	app.EXPECT().GetExpectedRejections().Return(map[string]uint64{"txpool_invalid": 10, "txpool_overflowed": 1})
+	app.EXPECT().GetExpectedSubmissionErrors().Return(nil)
+
+	counted := map[string]uint64{"txpool_invalid": 7, "txpool_overflowed": 1}
+	checker := &TxPoolRejectionsChecker{Counter: func(metric string) (uint64, error) {
+		return counted[metric], nil
+	}}
+	err := checker.Check(net)
+	if err == nil {
+		t.Fatalf("expected an error from TxPoolRejectionsChecker")
+	}
+	if want := "counted 7 rejections in txpool_invalid, expected at least 10"; !strings.Contains(err.Error(), want) {
+		t.Errorf("error does not report %q, got %v", want, err)
+	}
+	if strings.Contains(err.Error(), "txpool_overflowed") {
+		t.Errorf("error reports metric with enough rejections, got %v", err)
+	}
+}
+
+func TestTxPoolRejectionsChecker_CounterErrorsAreReported(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	app := driver.NewMockApplication(ctrl)
+	net.EXPECT().GetActiveApplications().Return([]driver.Application{app})
+	app.EXPECT().GetExpectedRejections().Return(map[string]uint64{"unknown": 1})
+	app.EXPECT().GetExpectedSubmissionErrors().Return(nil)
+
+	checker := &TxPoolRejectionsChecker{Counter: func(metric string) (uint64, error) {
+		return 0, fmt.Errorf("unknown transaction pool metric %s", metric)
+	}}
+	if err := checker.Check(net); err == nil || !strings.Contains(err.Error(), "unknown transaction pool metric") {
+		t.Errorf("counter error was not reported, got %v", err)
+	}
+}
+
+func TestTxPoolRejectionsChecker_AppsWithoutRejectionsAreNotChecked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	app := driver.NewMockApplication(ctrl)
+	net.EXPECT().GetActiveApplications().Return([]driver.Application{app})
+	app.EXPECT().GetExpectedRejections().Return(nil)
+	app.EXPECT().GetExpectedSubmissionErrors().Return(nil)
+
+	checker := &TxPoolRejectionsChecker{Counter: func(metric string) (uint64, error) {
+		t.Errorf("no metric should be queried, got %s", metric)
+		return 0, nil
+	}}
+	if err := checker.Check(net); err != nil {
+		t.Errorf("unexpected error from TxPoolRejectionsChecker: %v", err)
+	}
+}
+
+func TestTxPoolRejectionsChecker_SubmissionErrorsAreCheckedPerAppAndClass(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	app1 := driver.NewMockApplication(ctrl)
+	app2 := driver.NewMockApplication(ctrl)
+	net.EXPECT().GetActiveApplications().Return([]driver.Application{app1, app2})
+	app1.EXPECT().GetExpectedRejections().Return(nil)
+	app2.EXPECT().GetExpectedRejections().Return(nil)
+	app1.EXPECT().GetExpectedSubmissionErrors().Return(map[string]uint64{"Underpriced": 3, "GasLimit": 2})
+	app2.EXPECT().GetExpectedSubmissionErrors().Return(map[string]uint64{"Underpriced": 4})
+	app1.EXPECT().Config().AnyTimes().Return(&driver.ApplicationConfig{Name: "A"})
+	app2.EXPECT().Config().AnyTimes().Return(&driver.ApplicationConfig{Name: "B"})
+	net.EXPECT().GetTransactionSubmissionErrors().Return(map[driver.SubmissionErrorKey]uint64{
+		{Node: "node-1", App: "A", Class: driver.SubmissionErrorUnderpriced}:   2,
+		{Node: "node-2", App: "A", Class: driver.SubmissionErrorUnderpriced}:   1,
+		{Node: "node-1", App: "A", Class: driver.SubmissionErrorGasLimit}:      1,
+		{Node: "node-1", App: "B", Class: driver.SubmissionErrorUnderpriced}:   1,
+		{Node: "node-1", App: "B", Class: driver.SubmissionErrorInvalidSender}: 5,
+	})
+
+	checker := &TxPoolRejectionsChecker{Counter: func(metric string) (uint64, error) {
+		t.Errorf("no metric should be queried, got %s", metric)
+		return 0, nil
+	}}
+	err := checker.Check(net)
+	if err == nil {
+		t.Fatalf("expected an error from TxPoolRejectionsChecker")
+	}
+	for _, want := range []string{
+		"application A got 1 submission errors of class GasLimit, expected at least 2",
+		"application B got 1 submission errors of class Underpriced, expected at least 4",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not report %q, got %v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "application A got 3") {
+		t.Errorf("error reports class with enough submission errors, got %v", err)
+	}
+}
@@ -21,10 +21,12 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"time"
 
 	"github.com/Fantom-foundation/Norma/driver"
 	"github.com/Fantom-foundation/Norma/driver/parser"
 	pq "github.com/jupp0r/go-priority-queue"
+	"golang.org/x/exp/slices"
 )
 
 // Run executes the given scenario on the given network using the provided clock
@@ -184,7 +186,8 @@ func scheduleNodeEvents(node *parser.Node, queue *eventQueue, net driver.Network
 		var instance = new(driver.Node)
 		queue.add(toSingleEvent(startTime, fmt.Sprintf("starting node %s", name), func() error {
 			newNode, err := net.CreateNode(&driver.NodeConfig{
-				Name: name,
+				Name:    name,
+				Archive: slices.Contains(node.Features, "archive"),
 			})
 			*instance = newNode
 			return err
@@ -225,13 +228,36 @@ func scheduleApplicationEvents(source *parser.Application, queue *eventQueue, ne
 		endTime = Seconds(*source.End)
 	}
 
+	closedLoop := source.Mode == parser.ClosedLoopMode
+	thinkTime := time.Duration(0)
+	if source.ThinkTime != nil {
+		thinkTime = time.Duration(float64(*source.ThinkTime) * float64(time.Second))
+	}
+	receiptTimeout := time.Duration(0)
+	if source.ReceiptTimeout != nil {
+		receiptTimeout = time.Duration(float64(*source.ReceiptTimeout) * float64(time.Second))
+	}
+
 	for i := 0; i < instances; i++ {
 		name := fmt.Sprintf("%s-%d", source.Name, i)
 		if newApp, err := net.CreateApplication(&driver.ApplicationConfig{
-			Name:  name,
-			Type:  source.Type,
-			Rate:  &source.Rate,
-			Users: users,
+			Name:           name,
+			Type:           source.Type,
+			Rate:           &source.Rate,
+			Transfer:       source.Transfer,
+			Custom:         source.Custom,
+			Mix:            source.Mix,
+			NFT:            source.NFT,
+			Deployer:       source.Deployer,
+			Storage:        source.Storage,
+			Adversarial:    source.Adversarial,
+			Query:          source.Query,
+			TxType:         source.TxType,
+			Fees:           source.Fees,
+			Users:          users,
+			ClosedLoop:     closedLoop,
+			ThinkTime:      thinkTime,
+			ReceiptTimeout: receiptTimeout,
 		}); err == nil { // schedule application only when it could be created
 			queue.add(toSingleEvent(startTime, fmt.Sprintf("starting app %s", name), func() error {
 				return newApp.Start()
@@ -0,0 +1,135 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package appmon
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/Fantom-foundation/Norma/driver/monitoring/utils"
+)
+
+var (
+	// ComponentSentTransactions is a metric capturing the number of transactions sent to
+	// each component of a mix application. The subjects of the metric are named
+	// <app>/<component>, where the component is identified by its application type.
+	ComponentSentTransactions = monitoring.Metric[monitoring.App, monitoring.Series[monitoring.Time, int]]{
+		Name:        "ComponentSentTransactions",
+		Description: "The number of transactions sent to the individual components of a mix application over time",
+	}
+
+	// ComponentReceivedTransactions is a metric capturing the number of transactions received
+	// by each component of a mix application. The subjects of the metric are named
+	// <app>/<component>, where the component is identified by its application type.
+	ComponentReceivedTransactions = monitoring.Metric[monitoring.App, monitoring.Series[monitoring.Time, int]]{
+		Name:        "ComponentReceivedTransactions",
+		Description: "The number of transactions received by the individual components of a mix application over time",
+	}
+)
+
+func init() {
+	counters := map[monitoring.Metric[monitoring.App, monitoring.Series[monitoring.Time, int]]]func(driver.ComponentStats) uint64{
+		ComponentSentTransactions:     func(stats driver.ComponentStats) uint64 { return stats.Sent },
+		ComponentReceivedTransactions: func(stats driver.ComponentStats) uint64 { return stats.Received },
+	}
+	for metric, counter := range counters {
+		metric, counter := metric, counter // capture current values
+		factory := func(monitor *monitoring.Monitor) monitoring.Source[monitoring.App, monitoring.Series[monitoring.Time, int]] {
+			return newComponentDataSource(metric, monitor, counter)
+		}
+		if err := monitoring.RegisterSource(metric, factory); err != nil {
+			panic(fmt.Sprintf("failed to register metric source: %v", err))
+		}
+	}
+}
+
+// GetComponentSubject obtains the subject under which data of the given component
+// of the given application is reported.
+func GetComponentSubject(app, component string) monitoring.App {
+	return monitoring.App(app + "/" + strings.ToLower(component))
+}
+
+// componentDataSource is a data source periodically collecting a transaction counter
+// for each component of mix applications.
+type componentDataSource struct {
+	*utils.PeriodicDataSource[monitoring.App, int]
+	counter func(driver.ComponentStats) uint64
+}
+
+func newComponentDataSource(
+	metric monitoring.Metric[monitoring.App, monitoring.Series[monitoring.Time, int]],
+	monitor *monitoring.Monitor,
+	counter func(driver.ComponentStats) uint64,
+) monitoring.Source[monitoring.App, monitoring.Series[monitoring.Time, int]] {
+	res := &componentDataSource{
+		PeriodicDataSource: utils.NewPeriodicDataSource(metric, monitor),
+		counter:            counter,
+	}
+
+	monitor.Network().RegisterListener(res)
+	for _, app := range monitor.Network().GetActiveApplications() {
+		res.AfterApplicationCreation(app)
+	}
+
+	return res
+}
+
+func (s *componentDataSource) AfterNodeCreation(driver.Node) {
+	// ignored
+}
+
+func (s *componentDataSource) AfterNodeRemoval(driver.Node) {
+	// ignored
+}
+
+func (s *componentDataSource) AfterApplicationCreation(app driver.Application) {
+	label := app.Config().Name
+	for _, component := range app.Config().Mix {
+		subject := GetComponentSubject(label, component.Type)
+		sensor := &componentSensor{
+			app:       app,
+			component: strings.ToLower(component.Type),
+			counter:   s.counter,
+		}
+		if err := s.AddSubject(subject, sensor); err != nil {
+			log.Printf("failed to add subject %s to metric %v: %v", subject, s.GetMetric().Name, err)
+		}
+	}
+}
+
+// componentSensor reads a transaction counter of a single component of an application.
+type componentSensor struct {
+	app       driver.Application
+	component string
+	counter   func(driver.ComponentStats) uint64
+}
+
+func (s *componentSensor) ReadValue() (int, error) {
+	stats, err := s.app.GetComponentStats()
+	if err != nil {
+		return 0, err
+	}
+	for _, cur := range stats {
+		if cur.Name == s.component {
+			return int(s.counter(cur)), nil
+		}
+	}
+	return 0, fmt.Errorf("no statistics for component %s", s.component)
+}
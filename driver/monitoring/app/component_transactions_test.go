@@ -0,0 +1,82 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package appmon
+
+import (
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/Fantom-foundation/Norma/driver/monitoring/utils"
+	"github.com/Fantom-foundation/Norma/driver/parser"
+	"github.com/golang/mock/gomock"
+)
+
+func TestComponentSensor_ReportsCountersOfComponent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	application := driver.NewMockApplication(ctrl)
+	application.EXPECT().GetComponentStats().Return([]driver.ComponentStats{
+		{Name: "erc20", Sent: 10, Received: 8},
+		{Name: "store", Sent: 4, Received: 3},
+	}, nil).AnyTimes()
+
+	sent := &componentSensor{app: application, component: "store", counter: func(s driver.ComponentStats) uint64 { return s.Sent }}
+	if got, err := sent.ReadValue(); err != nil || got != 4 {
+		t.Errorf("unexpected number of sent transactions, wanted 4, got %d, err %v", got, err)
+	}
+	received := &componentSensor{app: application, component: "erc20", counter: func(s driver.ComponentStats) uint64 { return s.Received }}
+	if got, err := received.ReadValue(); err != nil || got != 8 {
+		t.Errorf("unexpected number of received transactions, wanted 8, got %d, err %v", got, err)
+	}
+	missing := &componentSensor{app: application, component: "uniswap", counter: func(s driver.ComponentStats) uint64 { return s.Sent }}
+	if _, err := missing.ReadValue(); err == nil {
+		t.Errorf("missing component should be reported")
+	}
+}
+
+func TestComponentDataSource_SubjectsAreCreatedForComponents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mixApp := driver.NewMockApplication(ctrl)
+	mixApp.EXPECT().Config().Return(&driver.ApplicationConfig{
+		Name: "mix-0",
+		Mix:  []parser.MixComponent{{Type: "ERC20"}, {Type: "store"}},
+	}).AnyTimes()
+	mixApp.EXPECT().GetComponentStats().Return(nil, nil).AnyTimes()
+	plainApp := driver.NewMockApplication(ctrl)
+	plainApp.EXPECT().Config().Return(&driver.ApplicationConfig{Name: "counter-0"}).AnyTimes()
+
+	source := &componentDataSource{
+		PeriodicDataSource: utils.NewPeriodicDataSource(ComponentSentTransactions, nil),
+	}
+	source.AfterApplicationCreation(mixApp)
+	source.AfterApplicationCreation(plainApp)
+	defer source.Shutdown()
+
+	subjects := source.GetSubjects()
+	want := []monitoring.App{GetComponentSubject("mix-0", "erc20"), GetComponentSubject("mix-0", "store")}
+	if len(subjects) != len(want) {
+		t.Fatalf("unexpected subjects, wanted %v, got %v", want, subjects)
+	}
+	for _, subject := range want {
+		if _, found := source.GetData(subject); !found {
+			t.Errorf("missing subject %v", subject)
+		}
+	}
+	if want, got := monitoring.App("mix-0/erc20"), GetComponentSubject("mix-0", "ERC20"); want != got {
+		t.Errorf("unexpected subject name, wanted %v, got %v", want, got)
+	}
+}
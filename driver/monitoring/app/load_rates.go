@@ -0,0 +1,138 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package appmon
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/Fantom-foundation/Norma/driver/monitoring/utils"
+)
+
+var (
+	// RequestedTxRate is a metric capturing the rate of transactions requested by the
+	// load shaper of an application in Tx/s.
+	RequestedTxRate = monitoring.Metric[monitoring.App, monitoring.Series[monitoring.Time, float32]]{
+		Name:        "RequestedTxRate",
+		Description: "The rate of transactions requested by the shaper of an application in Tx/s",
+	}
+
+	// IssuedTxRate is a metric capturing the rate of transactions picked up by the users
+	// of an application to be generated in Tx/s. It falls behind the requested rate if
+	// the users can not keep up with generating and sending transactions.
+	IssuedTxRate = monitoring.Metric[monitoring.App, monitoring.Series[monitoring.Time, float32]]{
+		Name:        "IssuedTxRate",
+		Description: "The rate of transactions issued to the users of an application in Tx/s",
+	}
+
+	// SubmittedTxRate is a metric capturing the rate of transactions of an application
+	// handed over to the network in Tx/s.
+	SubmittedTxRate = monitoring.Metric[monitoring.App, monitoring.Series[monitoring.Time, float32]]{
+		Name:        "SubmittedTxRate",
+		Description: "The rate of transactions of an application submitted to the network in Tx/s",
+	}
+
+	// SustainableTxRate is a metric capturing the rate in Tx/s an adaptive load shaper
+	// of an application converged to while meeting its service level objective, which
+	// is an estimate of the throughput the network can sustain. It is 0 as long as the
+	// shaper has not converged and for applications not using an adaptive shaper.
+	SustainableTxRate = monitoring.Metric[monitoring.App, monitoring.Series[monitoring.Time, float32]]{
+		Name:        "SustainableTxRate",
+		Description: "The sustainable rate of transactions in Tx/s determined by the adaptive shaper of an application",
+	}
+)
+
+func init() {
+	counters := map[monitoring.Metric[monitoring.App, monitoring.Series[monitoring.Time, float32]]]func(driver.LoadStats) float64{
+		RequestedTxRate: func(stats driver.LoadStats) float64 { return stats.Requested },
+		IssuedTxRate:    func(stats driver.LoadStats) float64 { return float64(stats.Issued) },
+		SubmittedTxRate: func(stats driver.LoadStats) float64 { return float64(stats.Submitted) },
+	}
+	for metric, counter := range counters {
+		metric, counter := metric, counter // capture current values
+		factory := func(monitor *monitoring.Monitor) monitoring.Source[monitoring.App, monitoring.Series[monitoring.Time, float32]] {
+			return NewPeriodicAppDataSource[float32](metric, monitor, &loadRateSensorFactory{counter: counter})
+		}
+		if err := monitoring.RegisterSource(metric, factory); err != nil {
+			panic(fmt.Sprintf("failed to register metric source: %v", err))
+		}
+	}
+
+	sustainableFactory := func(monitor *monitoring.Monitor) monitoring.Source[monitoring.App, monitoring.Series[monitoring.Time, float32]] {
+		return NewPeriodicAppDataSource[float32](SustainableTxRate, monitor, &sustainableRateSensorFactory{})
+	}
+	if err := monitoring.RegisterSource(SustainableTxRate, sustainableFactory); err != nil {
+		panic(fmt.Sprintf("failed to register metric source: %v", err))
+	}
+}
+
+type loadRateSensorFactory struct {
+	counter func(driver.LoadStats) float64
+}
+
+func (f *loadRateSensorFactory) CreateSensor(app driver.Application) (utils.Sensor[float32], error) {
+	return &loadRateSensor{
+		app:     app,
+		counter: f.counter,
+		now:     time.Now,
+	}, nil
+}
+
+// loadRateSensor reports the rate a counter of the load stats of an application
+// has increased with since the previous reading.
+type loadRateSensor struct {
+	app       driver.Application
+	counter   func(driver.LoadStats) float64
+	now       func() time.Time
+	lastValue float64
+	lastTime  time.Time
+}
+
+func (s *loadRateSensor) ReadValue() (float32, error) {
+	stats, err := s.app.GetLoadStats()
+	if err != nil {
+		return 0, err
+	}
+	value, now := s.counter(stats), s.now()
+	rate := float32(0)
+	if !s.lastTime.IsZero() && now.After(s.lastTime) {
+		rate = float32((value - s.lastValue) / now.Sub(s.lastTime).Seconds())
+	}
+	s.lastValue, s.lastTime = value, now
+	return rate, nil
+}
+
+type sustainableRateSensorFactory struct{}
+
+func (f *sustainableRateSensorFactory) CreateSensor(app driver.Application) (utils.Sensor[float32], error) {
+	return &sustainableRateSensor{app: app}, nil
+}
+
+// sustainableRateSensor reports the sustainable rate determined for an application.
+type sustainableRateSensor struct {
+	app driver.Application
+}
+
+func (s *sustainableRateSensor) ReadValue() (float32, error) {
+	stats, err := s.app.GetLoadStats()
+	if err != nil {
+		return 0, err
+	}
+	return float32(stats.SustainableRate), nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package appmon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/golang/mock/gomock"
+)
+
+func TestLoadRateSensor_ReportsRateSincePreviousReading(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := driver.NewMockApplication(ctrl)
+	gomock.InOrder(
+		app.EXPECT().GetLoadStats().Return(driver.LoadStats{Requested: 10, Issued: 8, Submitted: 5}, nil),
+		app.EXPECT().GetLoadStats().Return(driver.LoadStats{Requested: 30, Issued: 20, Submitted: 15}, nil),
+	)
+
+	factory := &loadRateSensorFactory{counter: func(stats driver.LoadStats) float64 { return float64(stats.Submitted) }}
+	sensor, err := factory.CreateSensor(app)
+	if err != nil {
+		t.Fatalf("creation of sensor failed: %v", err)
+	}
+	start := time.Unix(100, 0)
+	times := []time.Time{start, start.Add(2 * time.Second)}
+	sensor.(*loadRateSensor).now = func() time.Time {
+		res := times[0]
+		times = times[1:]
+		return res
+	}
+
+	for _, want := range []float32{0, 5} {
+		if got, err := sensor.ReadValue(); err != nil || got != want {
+			t.Errorf("sensor fetched wrong value, wanted %f, got %f, err %v", want, got, err)
+		}
+	}
+}
+
+func TestSustainableRateSensor_ReportsSustainableRate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	app := driver.NewMockApplication(ctrl)
+	gomock.InOrder(
+		app.EXPECT().GetLoadStats().Return(driver.LoadStats{}, nil),
+		app.EXPECT().GetLoadStats().Return(driver.LoadStats{SustainableRate: 250}, nil),
+	)
+
+	sensor, err := (&sustainableRateSensorFactory{}).CreateSensor(app)
+	if err != nil {
+		t.Fatalf("creation of sensor failed: %v", err)
+	}
+	for _, want := range []float32{0, 250} {
+		if got, err := sensor.ReadValue(); err != nil || got != want {
+			t.Errorf("sensor fetched wrong value, wanted %f, got %f, err %v", want, got, err)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package appmon
+
+import (
+	"fmt"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/Fantom-foundation/Norma/driver/monitoring/utils"
+)
+
+var (
+	// AppTxSubmissionErrors is a metric capturing the number of transactions of an application
+	// rejected by the nodes when being submitted.
+	AppTxSubmissionErrors = monitoring.Metric[monitoring.App, monitoring.Series[monitoring.Time, int]]{
+		Name:        "AppTxSubmissionErrors",
+		Description: "The number of failed transaction submissions of an application over time",
+	}
+)
+
+func init() {
+	if err := monitoring.RegisterSource(AppTxSubmissionErrors, newAppTxSubmissionErrorsSource); err != nil {
+		panic(fmt.Sprintf("failed to register metric source: %v", err))
+	}
+}
+
+// newAppTxSubmissionErrorsSource is an internal factory for the AppTxSubmissionErrors metric.
+func newAppTxSubmissionErrorsSource(monitor *monitoring.Monitor) monitoring.Source[monitoring.App, monitoring.Series[monitoring.Time, int]] {
+	return NewPeriodicAppDataSource[int](AppTxSubmissionErrors, monitor, &submissionErrorsSensorFactory{monitor.Network()})
+}
+
+type submissionErrorsSensorFactory struct {
+	network driver.Network
+}
+
+func (f *submissionErrorsSensorFactory) CreateSensor(app driver.Application) (utils.Sensor[int], error) {
+	return &submissionErrorsSensor{
+		network: f.network,
+		app:     app.Config().Name,
+	}, nil
+}
+
+type submissionErrorsSensor struct {
+	network driver.Network
+	app     string
+}
+
+func (s *submissionErrorsSensor) ReadValue() (int, error) {
+	sum := 0
+	for key, count := range s.network.GetTransactionSubmissionErrors() {
+		if key.App == s.app {
+			sum += int(count)
+		}
+	}
+	return sum, nil
+}
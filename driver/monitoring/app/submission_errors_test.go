@@ -0,0 +1,49 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package appmon
+
+import (
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/golang/mock/gomock"
+)
+
+func TestSubmissionErrorsSensorCountsErrorsOfApp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	network := driver.NewMockNetwork(ctrl)
+	network.EXPECT().GetTransactionSubmissionErrors().AnyTimes().Return(map[driver.SubmissionErrorKey]uint64{
+		{Node: "node-1", App: "A", Class: driver.SubmissionErrorNonceTooLow}: 2,
+		{Node: "node-1", App: "B", Class: driver.SubmissionErrorTxPoolFull}:  3,
+		{Node: "node-2", App: "A", Class: driver.SubmissionErrorNonceTooLow}: 5,
+	})
+
+	factory := &submissionErrorsSensorFactory{network}
+	tests := map[string]int{"A": 7, "B": 3, "C": 0}
+	for name, expected := range tests {
+		application := driver.NewMockApplication(ctrl)
+		application.EXPECT().Config().Return(&driver.ApplicationConfig{Name: name})
+
+		sensor, err := factory.CreateSensor(application)
+		if err != nil {
+			t.Fatalf("creation of sensor failed: %v", err)
+		}
+		if res, err := sensor.ReadValue(); err != nil || res != expected {
+			t.Errorf("sensor fetched wrong value for app %s, wanted %d, got %d, err %v", name, expected, res, err)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package netmon
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+
+	mon "github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/Fantom-foundation/Norma/driver/monitoring/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	// EffectiveGasPrice is a metric capturing the mean gas price, in Gwei, paid by
+	// the transactions sent by Norma and included in each block of the network.
+	EffectiveGasPrice = mon.Metric[mon.Network, mon.Series[mon.BlockNumber, float64]]{
+		Name:        "EffectiveGasPrice",
+		Description: "The mean gas price in Gwei paid by the transactions included in a block.",
+	}
+
+	// AppEffectiveGasPrice is the same as EffectiveGasPrice, restricted to the
+	// transactions of individual applications.
+	AppEffectiveGasPrice = mon.Metric[mon.App, mon.Series[mon.BlockNumber, float64]]{
+		Name:        "AppEffectiveGasPrice",
+		Description: "The mean gas price in Gwei paid by the transactions of an application included in a block.",
+	}
+)
+
+func init() {
+	networkFactory := func(monitor *mon.Monitor) mon.Source[mon.Network, mon.Series[mon.BlockNumber, float64]] {
+		return newGasPriceSource(monitor, EffectiveGasPrice, (*inclusionTracker).addGasPriceNetworkSource)
+	}
+	if err := mon.RegisterSource(EffectiveGasPrice, networkFactory); err != nil {
+		panic(fmt.Sprintf("failed to register metric source: %v", err))
+	}
+	appFactory := func(monitor *mon.Monitor) mon.Source[mon.App, mon.Series[mon.BlockNumber, float64]] {
+		return newGasPriceSource(monitor, AppEffectiveGasPrice, (*inclusionTracker).addGasPriceAppSource)
+	}
+	if err := mon.RegisterSource(AppEffectiveGasPrice, appFactory); err != nil {
+		panic(fmt.Sprintf("failed to register metric source: %v", err))
+	}
+}
+
+// gasPriceSource is a source providing the mean effective gas price per block. The
+// data is produced by an inclusion tracker shared by all sources of a monitor.
+type gasPriceSource[S comparable] struct {
+	*utils.SyncedSeriesSource[S, mon.BlockNumber, float64]
+	tracker *inclusionTracker
+}
+
+func newGasPriceSource[S comparable](
+	monitor *mon.Monitor,
+	metric mon.Metric[S, mon.Series[mon.BlockNumber, float64]],
+	register func(*inclusionTracker, *utils.SyncedSeriesSource[S, mon.BlockNumber, float64]),
+) *gasPriceSource[S] {
+	res := &gasPriceSource[S]{
+		SyncedSeriesSource: utils.NewSyncedSeriesSource(metric),
+		tracker:            acquireInclusionTracker(monitor),
+	}
+	register(res.tracker, res.SyncedSeriesSource)
+	return res
+}
+
+func (s *gasPriceSource[S]) Shutdown() error {
+	s.tracker.release()
+	return s.SyncedSeriesSource.Shutdown()
+}
+
+// recordGasPrices computes the mean gas price paid by the given transactions included
+// in a block with the given base fee and records it in all registered sources. For
+// dynamic-fee transactions, this is the base fee of the block plus the tip, limited
+// by the fee cap of the transaction. The mutex of the tracker must be held by the caller.
+func (t *inclusionTracker) recordGasPrices(block mon.Block, baseFee *big.Int, included []includedTransaction) {
+	var all gasPriceSum
+	perApp := map[mon.App]*gasPriceSum{}
+	for _, cur := range included {
+		price := cur.tx.getEffectiveGasPrice(baseFee)
+		all.add(price)
+		if app := cur.submission.App; app != "" {
+			sum, exists := perApp[mon.App(app)]
+			if !exists {
+				sum = &gasPriceSum{}
+				perApp[mon.App(app)] = sum
+			}
+			sum.add(price)
+		}
+	}
+
+	position := mon.BlockNumber(block.Height)
+	for _, source := range t.gasPriceNetworkData {
+		if err := source.GetOrAddSubject(mon.Network{}).Append(position, all.getMeanGwei()); err != nil {
+			log.Printf("error to add to the series: %s", err)
+		}
+	}
+	for app, sum := range perApp {
+		for _, source := range t.gasPriceAppData {
+			if err := source.GetOrAddSubject(app).Append(position, sum.getMeanGwei()); err != nil {
+				log.Printf("error to add to the series: %s", err)
+			}
+		}
+	}
+}
+
+// pricedTransaction is the part of a transaction needed for deriving the gas price paid by it.
+type pricedTransaction struct {
+	Hash      common.Hash
+	GasPrice  *hexutil.Big `json:"gasPrice"`
+	GasFeeCap *hexutil.Big `json:"maxFeePerGas"`
+	GasTipCap *hexutil.Big `json:"maxPriorityFeePerGas"`
+}
+
+// getEffectiveGasPrice computes the gas price paid by the transaction in a block with
+// the given base fee. Legacy and access-list transactions pay their gas price.
+func (tx *pricedTransaction) getEffectiveGasPrice(baseFee *big.Int) *big.Int {
+	if tx.GasFeeCap == nil || tx.GasTipCap == nil || baseFee == nil {
+		if tx.GasPrice == nil {
+			return new(big.Int)
+		}
+		return (*big.Int)(tx.GasPrice)
+	}
+	res := new(big.Int).Add(baseFee, (*big.Int)(tx.GasTipCap))
+	if res.Cmp((*big.Int)(tx.GasFeeCap)) > 0 {
+		return (*big.Int)(tx.GasFeeCap)
+	}
+	return res
+}
+
+// gasPriceSum accumulates gas prices for computing their mean.
+type gasPriceSum struct {
+	sum   big.Int
+	count int64
+}
+
+func (s *gasPriceSum) add(price *big.Int) {
+	s.sum.Add(&s.sum, price)
+	s.count++
+}
+
+func (s *gasPriceSum) getMeanGwei() float64 {
+	mean := new(big.Float).Quo(new(big.Float).SetInt(&s.sum), big.NewFloat(float64(s.count)))
+	res, _ := mean.Quo(mean, big.NewFloat(params.GWei)).Float64()
+	return res
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package netmon
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	mon "github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang/mock/gomock"
+)
+
+func TestEffectiveGasPrice_MeanIsRecordedPerNetworkAndApp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	submissions := map[common.Hash]driver.TransactionSubmission{
+		{1}: {App: "A"},
+		{2}: {App: "A"},
+		{3}: {App: "B"},
+	}
+
+	// the base fee is 10 Gwei, hash {4} is not known to the network
+	block := `{"baseFeePerGas":"0x2540be400","transactions":[
+		{"hash":"0x0100000000000000000000000000000000000000000000000000000000000000","gasPrice":"0x4a817c800"},
+		{"hash":"0x0200000000000000000000000000000000000000000000000000000000000000","gasPrice":"0x2540be400","maxFeePerGas":"0x3b9aca000","maxPriorityFeePerGas":"0x77359400"},
+		{"hash":"0x0300000000000000000000000000000000000000000000000000000000000000","gasPrice":"0x2540be400","maxFeePerGas":"0x2540be400","maxPriorityFeePerGas":"0x12a05f200"},
+		{"hash":"0x0400000000000000000000000000000000000000000000000000000000000000","gasPrice":"0xe8d4a51000"}
+	]}`
+	client := rpc.NewMockRpcClient(ctrl)
+	client.EXPECT().Call(gomock.Any(), "eth_getBlockByNumber", "0x5", true).DoAndReturn(
+		func(result interface{}, _ string, _ ...interface{}) error {
+			return json.Unmarshal([]byte(block), result)
+		})
+	client.EXPECT().Close()
+
+	net := driver.NewMockNetwork(ctrl)
+	net.EXPECT().RegisterListener(gomock.Any()).AnyTimes()
+	net.EXPECT().GetActiveNodes().AnyTimes().Return([]driver.Node{})
+	net.EXPECT().DialRandomRpc().Return(client, nil)
+	net.EXPECT().GetTransactionSubmission(gomock.Any()).AnyTimes().DoAndReturn(func(hash common.Hash) (driver.TransactionSubmission, bool) {
+		res, found := submissions[hash]
+		return res, found
+	})
+
+	monitor, err := mon.NewMonitor(net, mon.MonitorConfig{OutputDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to initiate monitor: %v", err)
+	}
+
+	network := newGasPriceSource(monitor, EffectiveGasPrice, (*inclusionTracker).addGasPriceNetworkSource)
+	apps := newGasPriceSource(monitor, AppEffectiveGasPrice, (*inclusionTracker).addGasPriceAppSource)
+	if network.tracker != apps.tracker {
+		t.Fatalf("sources of the same monitor should share a tracker")
+	}
+
+	// blocks without transactions and repeated blocks reported by other nodes are ignored
+	network.tracker.OnBlock(mon.Node1TestId, mon.Block{Height: 4})
+	network.tracker.OnBlock(mon.Node1TestId, mon.Block{Height: 5, Txs: 4})
+	network.tracker.OnBlock(mon.Node2TestId, mon.Block{Height: 5, Txs: 4})
+
+	// tx 1 pays its gas price of 20 Gwei, tx 2 the base fee plus a tip of 2 Gwei,
+	// and tx 3 is limited by its fee cap of 10 Gwei
+	waitForGasPrice(t, network, mon.Network{}, 5, 14)
+	waitForGasPrice(t, apps, "A", 5, 16)
+	waitForGasPrice(t, apps, "B", 5, 10)
+
+	for _, source := range []interface{ Shutdown() error }{network, apps} {
+		if err := source.Shutdown(); err != nil {
+			t.Errorf("failed to shutdown source: %v", err)
+		}
+	}
+	if _, exists := inclusionTrackers[monitor]; exists {
+		t.Errorf("tracker should be released once all sources are shut down")
+	}
+}
+
+func TestEffectiveGasPrice_DependsOnTransactionType(t *testing.T) {
+	tx := pricedTransaction{GasPrice: (*hexutil.Big)(big.NewInt(7))}
+	if got, want := tx.getEffectiveGasPrice(big.NewInt(5)), big.NewInt(7); got.Cmp(want) != 0 {
+		t.Errorf("unexpected effective gas price, wanted %v, got %v", want, got)
+	}
+	tx = pricedTransaction{GasFeeCap: (*hexutil.Big)(big.NewInt(10)), GasTipCap: (*hexutil.Big)(big.NewInt(2))}
+	if got, want := tx.getEffectiveGasPrice(big.NewInt(5)), big.NewInt(7); got.Cmp(want) != 0 {
+		t.Errorf("unexpected effective gas price, wanted %v, got %v", want, got)
+	}
+}
+
+// waitForGasPrice waits until the gas price of the given block is recorded for the given
+// subject and checks its value.
+func waitForGasPrice[S comparable](t *testing.T, source *gasPriceSource[S], subject S, block mon.BlockNumber, want float64) {
+	t.Helper()
+	for start := time.Now(); time.Since(start) < 5*time.Second; time.Sleep(10 * time.Millisecond) {
+		series, exists := source.GetData(subject)
+		if !exists || series.GetLatest() == nil {
+			continue
+		}
+		if got := series.GetLatest(); got.Position != block || got.Value != want {
+			t.Errorf("unexpected gas price of %v, wanted %v at block %d, got %v at block %d", subject, want, block, got.Value, got.Position)
+		}
+		return
+	}
+	t.Errorf("gas price of %v was not recorded", subject)
+}
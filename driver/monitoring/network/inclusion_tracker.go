@@ -0,0 +1,247 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package netmon
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	mon "github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/Fantom-foundation/Norma/driver/monitoring/utils"
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var (
+	inclusionTrackers      = map[*mon.Monitor]*inclusionTracker{}
+	inclusionTrackersMutex sync.Mutex
+)
+
+// inclusionTracker matches transactions included in blocks with their submissions
+// recorded by the network, feeding the transaction latency and effective gas price
+// metrics. Blocks are observed in the logs of the nodes, and the time the block was
+// first completed by any node is considered the inclusion time of its transactions.
+// The transactions of a block are fetched once through the RPC interface.
+type inclusionTracker struct {
+	monitor   *mon.Monitor
+	refs      int            // < number of sources using this tracker
+	blocks    chan mon.Block // < blocks waiting to be processed
+	lastBlock int            // < the last block queued for processing
+	rpcClient rpc.RpcClient  // < lazily connected client for fetching blocks
+
+	latencyNetworkData           map[int][]*utils.SyncedSeriesSource[mon.Network, mon.BlockNumber, time.Duration]
+	latencyAppData               map[int][]*utils.SyncedSeriesSource[mon.App, mon.BlockNumber, time.Duration]
+	cumulativeLatencyNetworkData map[int][]*utils.SyncedSeriesSource[mon.Network, mon.BlockNumber, time.Duration]
+	cumulativeLatencyAppData     map[int][]*utils.SyncedSeriesSource[mon.App, mon.BlockNumber, time.Duration]
+	gasPriceNetworkData          []*utils.SyncedSeriesSource[mon.Network, mon.BlockNumber, float64]
+	gasPriceAppData              []*utils.SyncedSeriesSource[mon.App, mon.BlockNumber, float64]
+
+	networkLatencies *latencyHistogram             // < latencies of all transactions so far
+	appLatencies     map[mon.App]*latencyHistogram // < latencies of all transactions per app so far
+
+	mutex sync.Mutex
+	done  chan bool
+}
+
+// acquireInclusionTracker obtains the tracker of the given monitor, creating it if needed.
+// Each call must be paired with a call to release.
+func acquireInclusionTracker(monitor *mon.Monitor) *inclusionTracker {
+	inclusionTrackersMutex.Lock()
+	defer inclusionTrackersMutex.Unlock()
+	if tracker, exists := inclusionTrackers[monitor]; exists {
+		tracker.refs++
+		return tracker
+	}
+
+	tracker := &inclusionTracker{
+		monitor:                      monitor,
+		refs:                         1,
+		blocks:                       make(chan mon.Block, 1000),
+		lastBlock:                    -1,
+		latencyNetworkData:           map[int][]*utils.SyncedSeriesSource[mon.Network, mon.BlockNumber, time.Duration]{},
+		latencyAppData:               map[int][]*utils.SyncedSeriesSource[mon.App, mon.BlockNumber, time.Duration]{},
+		cumulativeLatencyNetworkData: map[int][]*utils.SyncedSeriesSource[mon.Network, mon.BlockNumber, time.Duration]{},
+		cumulativeLatencyAppData:     map[int][]*utils.SyncedSeriesSource[mon.App, mon.BlockNumber, time.Duration]{},
+		networkLatencies:             &latencyHistogram{},
+		appLatencies:                 map[mon.App]*latencyHistogram{},
+		done:                         make(chan bool),
+	}
+	go func() {
+		defer close(tracker.done)
+		for block := range tracker.blocks {
+			tracker.process(block)
+		}
+	}()
+	monitor.NodeLogProvider().RegisterLogListener(tracker)
+	inclusionTrackers[monitor] = tracker
+	return tracker
+}
+
+// release stops the tracker once it is no longer used by any source.
+func (t *inclusionTracker) release() {
+	inclusionTrackersMutex.Lock()
+	t.refs--
+	if t.refs > 0 {
+		inclusionTrackersMutex.Unlock()
+		return
+	}
+	delete(inclusionTrackers, t.monitor)
+	inclusionTrackersMutex.Unlock()
+
+	t.monitor.NodeLogProvider().UnregisterLogListener(t)
+	t.mutex.Lock()
+	blocks := t.blocks
+	t.blocks = nil
+	close(blocks)
+	t.mutex.Unlock()
+	<-t.done
+	if t.rpcClient != nil {
+		t.rpcClient.Close()
+	}
+}
+
+func (t *inclusionTracker) addLatencyNetworkSource(quantile int, source *utils.SyncedSeriesSource[mon.Network, mon.BlockNumber, time.Duration]) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.latencyNetworkData[quantile] = append(t.latencyNetworkData[quantile], source)
+}
+
+func (t *inclusionTracker) addLatencyAppSource(quantile int, source *utils.SyncedSeriesSource[mon.App, mon.BlockNumber, time.Duration]) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.latencyAppData[quantile] = append(t.latencyAppData[quantile], source)
+}
+
+func (t *inclusionTracker) addCumulativeLatencyNetworkSource(quantile int, source *utils.SyncedSeriesSource[mon.Network, mon.BlockNumber, time.Duration]) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.cumulativeLatencyNetworkData[quantile] = append(t.cumulativeLatencyNetworkData[quantile], source)
+}
+
+func (t *inclusionTracker) addCumulativeLatencyAppSource(quantile int, source *utils.SyncedSeriesSource[mon.App, mon.BlockNumber, time.Duration]) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.cumulativeLatencyAppData[quantile] = append(t.cumulativeLatencyAppData[quantile], source)
+}
+
+func (t *inclusionTracker) addGasPriceNetworkSource(source *utils.SyncedSeriesSource[mon.Network, mon.BlockNumber, float64]) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.gasPriceNetworkData = append(t.gasPriceNetworkData, source)
+}
+
+func (t *inclusionTracker) addGasPriceAppSource(source *utils.SyncedSeriesSource[mon.App, mon.BlockNumber, float64]) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.gasPriceAppData = append(t.gasPriceAppData, source)
+}
+
+func (t *inclusionTracker) OnBlock(_ mon.Node, block mon.Block) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	// the first node reporting a block defines its completion time
+	if t.blocks == nil || block.Height <= t.lastBlock {
+		return
+	}
+	t.lastBlock = block.Height
+	if block.Txs == 0 {
+		return
+	}
+	select {
+	case t.blocks <- block:
+	default:
+		log.Printf("transaction inclusion tracker is overloaded, skipping block %d", block.Height)
+	}
+}
+
+// includedTransaction is a transaction submitted by the network included in a block.
+type includedTransaction struct {
+	submission driver.TransactionSubmission
+	tx         *pricedTransaction
+}
+
+// process matches the transactions of the given block with their submissions and
+// records the derived metrics in all registered sources.
+func (t *inclusionTracker) process(block mon.Block) {
+	content, err := t.getBlock(block.Height)
+	if err != nil {
+		log.Printf("failed to get transactions of block %d; %v", block.Height, err)
+		return
+	}
+
+	included := make([]includedTransaction, 0, len(content.Transactions))
+	for i := range content.Transactions {
+		tx := &content.Transactions[i]
+		submission, found := t.monitor.Network().GetTransactionSubmission(tx.Hash)
+		if !found {
+			continue // not sent by Norma, or too old
+		}
+		included = append(included, includedTransaction{submission: submission, tx: tx})
+	}
+	if len(included) == 0 {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.recordLatencies(block, included)
+	t.recordGasPrices(block, (*big.Int)(content.BaseFee), included)
+}
+
+// trackedBlock is the part of a block needed for deriving the metrics of its transactions.
+type trackedBlock struct {
+	BaseFee      *hexutil.Big `json:"baseFeePerGas"`
+	Transactions []pricedTransaction
+}
+
+// getBlock fetches the given block including its transactions. Since the node serving
+// the request may lag behind the node reporting the block, the request is retried a
+// few times if the block is not yet available.
+func (t *inclusionTracker) getBlock(height int) (*trackedBlock, error) {
+	const attempts = 10
+	for i := 0; ; i++ {
+		if t.rpcClient == nil {
+			rpcClient, err := t.monitor.Network().DialRandomRpc()
+			if err != nil {
+				return nil, fmt.Errorf("failed to dial random RPC; %v", err)
+			}
+			t.rpcClient = rpcClient
+		}
+
+		var block *trackedBlock
+		err := t.rpcClient.Call(&block, "eth_getBlockByNumber", hexutil.EncodeUint64(uint64(height)), true)
+		if err != nil {
+			// the connection may be broken, a new one is dialed on the next attempt
+			t.rpcClient.Close()
+			t.rpcClient = nil
+		}
+		if err == nil && block != nil {
+			return block, nil
+		}
+		if i+1 >= attempts {
+			if err == nil {
+				err = fmt.Errorf("block not found")
+			}
+			return nil, err
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package netmon
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	mon "github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+)
+
+func TestInclusionTracker_BlocksAreFetchedOnceForAllMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	now := time.Now()
+	submissions := map[common.Hash]driver.TransactionSubmission{
+		{1}: {App: "A", Time: now.Add(-2 * time.Second)},
+	}
+
+	// a single request provides the transactions to both the latency and the gas price
+	client := rpc.NewMockRpcClient(ctrl)
+	client.EXPECT().Call(gomock.Any(), "eth_getBlockByNumber", "0x5", true).DoAndReturn(
+		func(result interface{}, _ string, _ ...interface{}) error {
+			return json.Unmarshal([]byte(`{"transactions":[
+				{"hash":"0x0100000000000000000000000000000000000000000000000000000000000000","gasPrice":"0x4a817c800"}
+			]}`), result)
+		})
+	client.EXPECT().Close()
+
+	net := driver.NewMockNetwork(ctrl)
+	net.EXPECT().RegisterListener(gomock.Any()).AnyTimes()
+	net.EXPECT().GetActiveNodes().AnyTimes().Return([]driver.Node{})
+	net.EXPECT().DialRandomRpc().Return(client, nil)
+	net.EXPECT().GetTransactionSubmission(gomock.Any()).AnyTimes().DoAndReturn(func(hash common.Hash) (driver.TransactionSubmission, bool) {
+		res, found := submissions[hash]
+		return res, found
+	})
+
+	monitor, err := mon.NewMonitor(net, mon.MonitorConfig{OutputDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to initiate monitor: %v", err)
+	}
+
+	latency := newTransactionLatencySource(monitor, mon.Metric[mon.Network, mon.Series[mon.BlockNumber, time.Duration]]{Name: "p50"}, 50, (*inclusionTracker).addLatencyNetworkSource)
+	gasPrice := newGasPriceSource(monitor, EffectiveGasPrice, (*inclusionTracker).addGasPriceNetworkSource)
+	if latency.tracker != gasPrice.tracker {
+		t.Fatalf("latency and gas price sources of the same monitor should share a tracker")
+	}
+
+	latency.tracker.OnBlock(mon.Node1TestId, mon.Block{Height: 5, Time: now, Txs: 1})
+
+	waitForLatency(t, latency, mon.Network{}, 5, 2*time.Second)
+	waitForGasPrice(t, gasPrice, mon.Network{}, 5, 20)
+
+	for _, source := range []interface{ Shutdown() error }{latency, gasPrice} {
+		if err := source.Shutdown(); err != nil {
+			t.Errorf("failed to shutdown source: %v", err)
+		}
+	}
+	if _, exists := inclusionTrackers[monitor]; exists {
+		t.Errorf("tracker should be released once all sources are shut down")
+	}
+}
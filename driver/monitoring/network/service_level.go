@@ -0,0 +1,94 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package netmon
+
+import (
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	mon "github.com/Fantom-foundation/Norma/driver/monitoring"
+	nodemon "github.com/Fantom-foundation/Norma/driver/monitoring/node"
+)
+
+// serviceLevelSource derives service level indicators from the data collected
+// by a monitor. It is intended to be installed on the monitored network to
+// provide feedback on the service level to load generators.
+type serviceLevelSource struct {
+	monitor *mon.Monitor
+}
+
+// NewServiceLevelSource creates a source of service level indicators based on
+// the latest data collected by the given monitor.
+func NewServiceLevelSource(monitor *mon.Monitor) driver.ServiceLevelSource {
+	return &serviceLevelSource{monitor: monitor}
+}
+
+func (s *serviceLevelSource) GetServiceLevel(indicator driver.ServiceLevelIndicator) (driver.ServiceLevel, bool) {
+	switch indicator {
+	case driver.TransactionLatencyP95:
+		return s.getLatency(95)
+	case driver.TxPoolPending:
+		return s.getMaxPending()
+	}
+	return driver.ServiceLevel{}, false
+}
+
+// getLatency obtains the given quantile of the latency of the transactions in
+// the latest block, in milliseconds. The position of the result is the block number.
+func (s *serviceLevelSource) getLatency(quantile int) (driver.ServiceLevel, bool) {
+	metric, found := TransactionLatency[quantile]
+	if !found {
+		return driver.ServiceLevel{}, false
+	}
+	series, exists := mon.GetData(s.monitor, mon.Network{}, metric)
+	if !exists || series == nil {
+		return driver.ServiceLevel{}, false
+	}
+	point := series.GetLatest()
+	if point == nil {
+		return driver.ServiceLevel{}, false
+	}
+	return driver.ServiceLevel{
+		Value:    float64(point.Value) / float64(time.Millisecond),
+		Position: uint64(point.Position),
+	}, true
+}
+
+// getMaxPending obtains the maximum of the latest number of pending transactions
+// reported by the nodes of the network. The position of the result is the time of
+// the most recent report.
+func (s *serviceLevelSource) getMaxPending() (driver.ServiceLevel, bool) {
+	res, found := driver.ServiceLevel{}, false
+	for _, node := range mon.GetSubjects(s.monitor, nodemon.TxPoolPending) {
+		series, exists := mon.GetData(s.monitor, node, nodemon.TxPoolPending)
+		if !exists || series == nil {
+			continue
+		}
+		point := series.GetLatest()
+		if point == nil {
+			continue
+		}
+		if !found || point.Value > res.Value {
+			res.Value = point.Value
+		}
+		if uint64(point.Position) > res.Position {
+			res.Position = uint64(point.Position)
+		}
+		found = true
+	}
+	return res, found
+}
@@ -0,0 +1,106 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package netmon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	mon "github.com/Fantom-foundation/Norma/driver/monitoring"
+	nodemon "github.com/Fantom-foundation/Norma/driver/monitoring/node"
+	"github.com/Fantom-foundation/Norma/driver/monitoring/utils"
+	"github.com/golang/mock/gomock"
+)
+
+func TestServiceLevelSource_NoDataIsReportedAsMissing(t *testing.T) {
+	monitor := newServiceLevelTestMonitor(t)
+	source := NewServiceLevelSource(monitor)
+
+	for _, indicator := range driver.ServiceLevelIndicators {
+		if _, found := source.GetServiceLevel(indicator); found {
+			t.Errorf("no value should be available for %s", indicator)
+		}
+	}
+	if _, found := source.GetServiceLevel("unknown"); found {
+		t.Errorf("no value should be available for unknown indicators")
+	}
+}
+
+func TestServiceLevelSource_IndicatorsAreDerivedFromLatestData(t *testing.T) {
+	monitor := newServiceLevelTestMonitor(t)
+
+	latency := utils.NewSyncedSeriesSource(TransactionLatency[95])
+	latencies := latency.GetOrAddSubject(mon.Network{})
+	if err := latencies.Append(1, 2*time.Second); err != nil {
+		t.Fatalf("failed to add data: %v", err)
+	}
+	if err := latencies.Append(2, 250*time.Millisecond); err != nil {
+		t.Fatalf("failed to add data: %v", err)
+	}
+	installTestSource[mon.Network, mon.Series[mon.BlockNumber, time.Duration]](t, monitor, latency)
+
+	pending := utils.NewSyncedSeriesSource(nodemon.TxPoolPending)
+	for node, values := range map[mon.Node][]float64{"A": {500, 20}, "B": {10, 40}, "C": {}} {
+		series := pending.GetOrAddSubject(node)
+		for i, value := range values {
+			if err := series.Append(mon.Time(i+1), value); err != nil {
+				t.Fatalf("failed to add data: %v", err)
+			}
+		}
+	}
+	installTestSource[mon.Node, mon.Series[mon.Time, float64]](t, monitor, pending)
+
+	source := NewServiceLevelSource(monitor)
+	if got, found := source.GetServiceLevel(driver.TransactionLatencyP95); !found || got.Value != 250 || got.Position != 2 {
+		t.Errorf("unexpected latency, wanted 250 ms at block 2, got %v (found %t)", got, found)
+	}
+	if got, found := source.GetServiceLevel(driver.TxPoolPending); !found || got.Value != 40 || got.Position != 2 {
+		t.Errorf("unexpected number of pending transactions, wanted 40 at time 2, got %v (found %t)", got, found)
+	}
+}
+
+func newServiceLevelTestMonitor(t *testing.T) *mon.Monitor {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	net.EXPECT().RegisterListener(gomock.Any()).AnyTimes()
+	net.EXPECT().GetActiveNodes().AnyTimes().Return([]driver.Node{})
+
+	monitor, err := mon.NewMonitor(net, mon.MonitorConfig{OutputDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to initiate monitor: %v", err)
+	}
+	return monitor
+}
+
+func installTestSource[S any, T any](t *testing.T, monitor *mon.Monitor, source mon.Source[S, T]) {
+	if err := mon.InstallSource[S, T](monitor, &testSourceFactory[S, T]{source}); err != nil {
+		t.Fatalf("failed to install source: %v", err)
+	}
+}
+
+type testSourceFactory[S any, T any] struct {
+	source mon.Source[S, T]
+}
+
+func (f *testSourceFactory[S, T]) GetMetric() mon.Metric[S, T] {
+	return f.source.GetMetric()
+}
+
+func (f *testSourceFactory[S, T]) CreateSource(*mon.Monitor) mon.Source[S, T] {
+	return f.source
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package netmon
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	mon "github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/Fantom-foundation/Norma/driver/monitoring/utils"
+)
+
+// TxSubmissionErrors contains for each class of submission errors a metric capturing
+// the total number of transactions rejected by the network for this reason.
+var TxSubmissionErrors = map[driver.SubmissionErrorClass]mon.Metric[mon.Network, mon.Series[mon.Time, int]]{}
+
+func init() {
+	for _, class := range driver.SubmissionErrorClasses {
+		class := class // capture current value of the class
+
+		metric := mon.Metric[mon.Network, mon.Series[mon.Time, int]]{
+			Name:        fmt.Sprintf("TxSubmissionErrors_%s", class),
+			Description: fmt.Sprintf("The number of failed transaction submissions of class %s at various times.", class),
+		}
+		factory := func(monitor *mon.Monitor) mon.Source[mon.Network, mon.Series[mon.Time, int]] {
+			return newTxSubmissionErrorsSource(monitor, metric, class)
+		}
+		if err := mon.RegisterSource(metric, factory); err != nil {
+			panic(fmt.Sprintf("failed to register metric source: %v", err))
+		}
+		TxSubmissionErrors[class] = metric
+	}
+}
+
+// newTxSubmissionErrorsSource creates a new data source periodically collecting the
+// number of failed transaction submissions of the given class.
+func newTxSubmissionErrorsSource(
+	monitor *mon.Monitor,
+	metric mon.Metric[mon.Network, mon.Series[mon.Time, int]],
+	class driver.SubmissionErrorClass,
+) mon.Source[mon.Network, mon.Series[mon.Time, int]] {
+	res := utils.NewPeriodicDataSource(metric, monitor)
+	sensor := &submissionErrorsSensor{network: monitor.Network(), class: class}
+	if err := res.AddSubject(mon.Network{}, sensor); err != nil {
+		log.Printf("failed to add subject for metric %v: %v", metric.Name, err)
+	}
+	return res
+}
+
+type submissionErrorsSensor struct {
+	network driver.Network
+	class   driver.SubmissionErrorClass
+}
+
+func (s *submissionErrorsSensor) ReadValue() (int, error) {
+	sum := 0
+	for key, count := range s.network.GetTransactionSubmissionErrors() {
+		if key.Class == s.class {
+			sum += int(count)
+		}
+	}
+	return sum, nil
+}
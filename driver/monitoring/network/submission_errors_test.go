@@ -0,0 +1,54 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package netmon
+
+import (
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/golang/mock/gomock"
+)
+
+func TestSubmissionErrorsSensorCountsErrorsOfClass(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	network := driver.NewMockNetwork(ctrl)
+	network.EXPECT().GetTransactionSubmissionErrors().AnyTimes().Return(map[driver.SubmissionErrorKey]uint64{
+		{Node: "node-1", App: "A", Class: driver.SubmissionErrorNonceTooLow}: 2,
+		{Node: "node-1", App: "B", Class: driver.SubmissionErrorTxPoolFull}:  3,
+		{Node: "node-2", App: "A", Class: driver.SubmissionErrorNonceTooLow}: 5,
+	})
+
+	tests := map[driver.SubmissionErrorClass]int{
+		driver.SubmissionErrorNonceTooLow: 7,
+		driver.SubmissionErrorTxPoolFull:  3,
+		driver.SubmissionErrorConnection:  0,
+	}
+	for class, expected := range tests {
+		sensor := &submissionErrorsSensor{network: network, class: class}
+		if res, err := sensor.ReadValue(); err != nil || res != expected {
+			t.Errorf("sensor fetched wrong value for class %s, wanted %d, got %d, err %v", class, expected, res, err)
+		}
+	}
+}
+
+func TestSubmissionErrorsMetricsAreDefinedForAllClasses(t *testing.T) {
+	for _, class := range driver.SubmissionErrorClasses {
+		if _, found := TxSubmissionErrors[class]; !found {
+			t.Errorf("missing metric for class %s", class)
+		}
+	}
+}
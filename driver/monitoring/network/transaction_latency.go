@@ -0,0 +1,271 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package netmon
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	mon "github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/Fantom-foundation/Norma/driver/monitoring/utils"
+)
+
+// latencyQuantiles are the quantiles, in percent, of the transaction latency
+// recorded for each block.
+var latencyQuantiles = []int{50, 90, 95, 99}
+
+// TransactionLatency contains for each recorded quantile, in percent, a metric
+// capturing this quantile of the latency of the transactions included in each
+// block of the network.
+var TransactionLatency = map[int]mon.Metric[mon.Network, mon.Series[mon.BlockNumber, time.Duration]]{}
+
+// CumulativeTransactionLatency contains for each recorded quantile, in percent, a metric
+// capturing this quantile of the latency of all transactions included in the network
+// since the start of the run, recorded at each block. The latest value of the series
+// thus is the run-wide quantile.
+var CumulativeTransactionLatency = map[int]mon.Metric[mon.Network, mon.Series[mon.BlockNumber, time.Duration]]{}
+
+func init() {
+	for _, quantile := range latencyQuantiles {
+		quantile := quantile // capture current value of the quantile
+
+		networkMetric := mon.Metric[mon.Network, mon.Series[mon.BlockNumber, time.Duration]]{
+			Name:        fmt.Sprintf("TransactionLatency_P%d", quantile),
+			Description: fmt.Sprintf("The %d-th percentile of the time between the submission of transactions and the completion of the block including them.", quantile),
+		}
+		networkFactory := func(monitor *mon.Monitor) mon.Source[mon.Network, mon.Series[mon.BlockNumber, time.Duration]] {
+			return newTransactionLatencySource(monitor, networkMetric, quantile, (*inclusionTracker).addLatencyNetworkSource)
+		}
+		if err := mon.RegisterSource(networkMetric, networkFactory); err != nil {
+			panic(fmt.Sprintf("failed to register metric source: %v", err))
+		}
+		TransactionLatency[quantile] = networkMetric
+
+		// AppTransactionLatency is the same as TransactionLatency, restricted to the
+		// transactions of individual applications.
+		AppTransactionLatency := mon.Metric[mon.App, mon.Series[mon.BlockNumber, time.Duration]]{
+			Name:        fmt.Sprintf("AppTransactionLatency_P%d", quantile),
+			Description: fmt.Sprintf("The %d-th percentile of the time between the submission of transactions of an application and the completion of the block including them.", quantile),
+		}
+		appFactory := func(monitor *mon.Monitor) mon.Source[mon.App, mon.Series[mon.BlockNumber, time.Duration]] {
+			return newTransactionLatencySource(monitor, AppTransactionLatency, quantile, (*inclusionTracker).addLatencyAppSource)
+		}
+		if err := mon.RegisterSource(AppTransactionLatency, appFactory); err != nil {
+			panic(fmt.Sprintf("failed to register metric source: %v", err))
+		}
+
+		cumulativeNetworkMetric := mon.Metric[mon.Network, mon.Series[mon.BlockNumber, time.Duration]]{
+			Name:        fmt.Sprintf("CumulativeTransactionLatency_P%d", quantile),
+			Description: fmt.Sprintf("The %d-th percentile of the time between the submission of transactions and the completion of the block including them, over all transactions included so far.", quantile),
+		}
+		cumulativeNetworkFactory := func(monitor *mon.Monitor) mon.Source[mon.Network, mon.Series[mon.BlockNumber, time.Duration]] {
+			return newTransactionLatencySource(monitor, cumulativeNetworkMetric, quantile, (*inclusionTracker).addCumulativeLatencyNetworkSource)
+		}
+		if err := mon.RegisterSource(cumulativeNetworkMetric, cumulativeNetworkFactory); err != nil {
+			panic(fmt.Sprintf("failed to register metric source: %v", err))
+		}
+		CumulativeTransactionLatency[quantile] = cumulativeNetworkMetric
+
+		// AppCumulativeTransactionLatency is the same as CumulativeTransactionLatency,
+		// restricted to the transactions of individual applications.
+		AppCumulativeTransactionLatency := mon.Metric[mon.App, mon.Series[mon.BlockNumber, time.Duration]]{
+			Name:        fmt.Sprintf("AppCumulativeTransactionLatency_P%d", quantile),
+			Description: fmt.Sprintf("The %d-th percentile of the time between the submission of transactions of an application and the completion of the block including them, over all transactions included so far.", quantile),
+		}
+		cumulativeAppFactory := func(monitor *mon.Monitor) mon.Source[mon.App, mon.Series[mon.BlockNumber, time.Duration]] {
+			return newTransactionLatencySource(monitor, AppCumulativeTransactionLatency, quantile, (*inclusionTracker).addCumulativeLatencyAppSource)
+		}
+		if err := mon.RegisterSource(AppCumulativeTransactionLatency, cumulativeAppFactory); err != nil {
+			panic(fmt.Sprintf("failed to register metric source: %v", err))
+		}
+	}
+}
+
+// transactionLatencySource is a source providing one quantile of the transaction latency
+// per block, either of the transactions of the block or of all transactions included so far. The data is produced by an inclusion tracker shared by all sources of a monitor.
+type transactionLatencySource[S comparable] struct {
+	*utils.SyncedSeriesSource[S, mon.BlockNumber, time.Duration]
+	tracker *inclusionTracker
+}
+
+func newTransactionLatencySource[S comparable](
+	monitor *mon.Monitor,
+	metric mon.Metric[S, mon.Series[mon.BlockNumber, time.Duration]],
+	quantile int,
+	register func(*inclusionTracker, int, *utils.SyncedSeriesSource[S, mon.BlockNumber, time.Duration]),
+) *transactionLatencySource[S] {
+	res := &transactionLatencySource[S]{
+		SyncedSeriesSource: utils.NewSyncedSeriesSource(metric),
+		tracker:            acquireInclusionTracker(monitor),
+	}
+	register(res.tracker, quantile, res.SyncedSeriesSource)
+	return res
+}
+
+func (s *transactionLatencySource[S]) Shutdown() error {
+	s.tracker.release()
+	return s.SyncedSeriesSource.Shutdown()
+}
+
+// recordLatencies computes the latencies of the given transactions included in the
+// given block and records their quantiles, as well as the quantiles of all latencies
+// observed so far, in all registered sources. The mutex of the tracker must be held
+// by the caller.
+func (t *inclusionTracker) recordLatencies(block mon.Block, included []includedTransaction) {
+	all := make([]time.Duration, 0, len(included))
+	perApp := map[mon.App][]time.Duration{}
+	for _, cur := range included {
+		latency := block.Time.Sub(cur.submission.Time)
+		if latency < 0 {
+			latency = 0 // clocks of nodes and the driver may not be perfectly in sync
+		}
+		all = append(all, latency)
+		if app := cur.submission.App; app != "" {
+			perApp[mon.App(app)] = append(perApp[mon.App(app)], latency)
+		}
+	}
+	sortDurations(all)
+	for _, latency := range all {
+		t.networkLatencies.add(latency)
+	}
+	for app, latencies := range perApp {
+		sortDurations(latencies)
+		histogram, exists := t.appLatencies[app]
+		if !exists {
+			histogram = &latencyHistogram{}
+			t.appLatencies[app] = histogram
+		}
+		for _, latency := range latencies {
+			histogram.add(latency)
+		}
+	}
+
+	position := mon.BlockNumber(block.Height)
+	for quantile, sources := range t.latencyNetworkData {
+		value := getQuantile(all, quantile)
+		for _, source := range sources {
+			if err := source.GetOrAddSubject(mon.Network{}).Append(position, value); err != nil {
+				log.Printf("error to add to the series: %s", err)
+			}
+		}
+	}
+	for app, latencies := range perApp {
+		for quantile, sources := range t.latencyAppData {
+			value := getQuantile(latencies, quantile)
+			for _, source := range sources {
+				if err := source.GetOrAddSubject(app).Append(position, value); err != nil {
+					log.Printf("error to add to the series: %s", err)
+				}
+			}
+		}
+	}
+
+	for quantile, sources := range t.cumulativeLatencyNetworkData {
+		value := t.networkLatencies.getQuantile(quantile)
+		for _, source := range sources {
+			if err := source.GetOrAddSubject(mon.Network{}).Append(position, value); err != nil {
+				log.Printf("error to add to the series: %s", err)
+			}
+		}
+	}
+	for app := range perApp {
+		for quantile, sources := range t.cumulativeLatencyAppData {
+			value := t.appLatencies[app].getQuantile(quantile)
+			for _, source := range sources {
+				if err := source.GetOrAddSubject(app).Append(position, value); err != nil {
+					log.Printf("error to add to the series: %s", err)
+				}
+			}
+		}
+	}
+}
+
+func sortDurations(durations []time.Duration) {
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+}
+
+// getQuantile computes the given quantile, in percent, of the given sorted and
+// non-empty list of durations using the nearest-rank method.
+func getQuantile(durations []time.Duration, quantile int) time.Duration {
+	rank := (quantile*len(durations) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return durations[rank-1]
+}
+
+// latencyHistogramResolution is the number of buckets of a latency histogram per
+// doubling of the latency, bounding the relative error of its quantiles to about 9%.
+const latencyHistogramResolution = 8
+
+// latencyHistogram counts latencies in buckets growing exponentially from one
+// millisecond, summarizing the latencies of all transactions of a run in constant space.
+type latencyHistogram struct {
+	counts []uint64 // < number of latencies per bucket
+	total  uint64
+	max    time.Duration
+}
+
+func (h *latencyHistogram) add(latency time.Duration) {
+	bucket := getLatencyBucket(latency)
+	for len(h.counts) <= bucket {
+		h.counts = append(h.counts, 0)
+	}
+	h.counts[bucket]++
+	h.total++
+	if latency > h.max {
+		h.max = latency
+	}
+}
+
+// getQuantile estimates the given quantile, in percent, of the recorded latencies using
+// the nearest-rank method. The upper bound of the bucket containing the quantile is
+// reported, capped by the maximum recorded latency. The histogram must not be empty.
+func (h *latencyHistogram) getQuantile(quantile int) time.Duration {
+	rank := (uint64(quantile)*h.total + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	seen := uint64(0)
+	for bucket, count := range h.counts {
+		seen += count
+		if seen >= rank {
+			if bound := getLatencyBucketBound(bucket); bound < h.max {
+				return bound
+			}
+			break
+		}
+	}
+	return h.max
+}
+
+// getLatencyBucket returns the index of the bucket covering the given latency. Bucket i
+// covers the latencies in (bound(i-1), bound(i)], bucket 0 all latencies up to 1ms.
+func getLatencyBucket(latency time.Duration) int {
+	if latency <= time.Millisecond {
+		return 0
+	}
+	return int(math.Ceil(latencyHistogramResolution * math.Log2(float64(latency)/float64(time.Millisecond))))
+}
+
+// getLatencyBucketBound returns the upper bound of the latencies of the given bucket.
+func getLatencyBucketBound(bucket int) time.Duration {
+	return time.Duration(float64(time.Millisecond) * math.Exp2(float64(bucket)/latencyHistogramResolution))
+}
@@ -0,0 +1,196 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package netmon
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	mon "github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/Fantom-foundation/Norma/driver/monitoring/utils"
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+)
+
+func TestTransactionLatency_QuantilesAreRecordedPerNetworkAndApp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	now := time.Now()
+	submissions := map[common.Hash]driver.TransactionSubmission{
+		{1}: {App: "A", Time: now.Add(-1 * time.Second)},
+		{2}: {App: "A", Time: now.Add(-2 * time.Second)},
+		{3}: {App: "B", Time: now.Add(-3 * time.Second)},
+	}
+
+	client := rpc.NewMockRpcClient(ctrl)
+	client.EXPECT().Call(gomock.Any(), "eth_getBlockByNumber", "0x5", true).DoAndReturn(
+		func(result interface{}, _ string, _ ...interface{}) error {
+			// hash {4} is not known to the network, e.g. sent by a different tool
+			return json.Unmarshal([]byte(fmt.Sprintf(`{"transactions":[{"hash":"%v"},{"hash":"%v"},{"hash":"%v"},{"hash":"%v"}]}`,
+				common.Hash{1}, common.Hash{2}, common.Hash{3}, common.Hash{4})), result)
+		})
+	client.EXPECT().Close()
+
+	net := driver.NewMockNetwork(ctrl)
+	net.EXPECT().RegisterListener(gomock.Any()).AnyTimes()
+	net.EXPECT().GetActiveNodes().AnyTimes().Return([]driver.Node{})
+	net.EXPECT().DialRandomRpc().Return(client, nil)
+	net.EXPECT().GetTransactionSubmission(gomock.Any()).AnyTimes().DoAndReturn(func(hash common.Hash) (driver.TransactionSubmission, bool) {
+		res, found := submissions[hash]
+		return res, found
+	})
+
+	monitor, err := mon.NewMonitor(net, mon.MonitorConfig{OutputDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to initiate monitor: %v", err)
+	}
+
+	p50 := newTransactionLatencySource(monitor, mon.Metric[mon.Network, mon.Series[mon.BlockNumber, time.Duration]]{Name: "p50"}, 50, (*inclusionTracker).addLatencyNetworkSource)
+	p90 := newTransactionLatencySource(monitor, mon.Metric[mon.Network, mon.Series[mon.BlockNumber, time.Duration]]{Name: "p90"}, 90, (*inclusionTracker).addLatencyNetworkSource)
+	appP90 := newTransactionLatencySource(monitor, mon.Metric[mon.App, mon.Series[mon.BlockNumber, time.Duration]]{Name: "app_p90"}, 90, (*inclusionTracker).addLatencyAppSource)
+
+	if p50.tracker != p90.tracker || p50.tracker != appP90.tracker {
+		t.Fatalf("sources of the same monitor should share a tracker")
+	}
+
+	// blocks without transactions and repeated blocks reported by other nodes are ignored
+	p50.tracker.OnBlock(mon.Node1TestId, mon.Block{Height: 4, Time: now})
+	p50.tracker.OnBlock(mon.Node1TestId, mon.Block{Height: 5, Time: now, Txs: 4})
+	p50.tracker.OnBlock(mon.Node2TestId, mon.Block{Height: 5, Time: now.Add(time.Second), Txs: 4})
+
+	waitForLatency(t, p50, mon.Network{}, 5, 2*time.Second)
+	waitForLatency(t, p90, mon.Network{}, 5, 3*time.Second)
+	waitForLatency(t, appP90, "A", 5, 2*time.Second)
+	waitForLatency(t, appP90, "B", 5, 3*time.Second)
+
+	for _, source := range []interface{ Shutdown() error }{p50, p90, appP90} {
+		if err := source.Shutdown(); err != nil {
+			t.Errorf("failed to shutdown source: %v", err)
+		}
+	}
+	if _, exists := inclusionTrackers[monitor]; exists {
+		t.Errorf("tracker should be released once all sources are shut down")
+	}
+}
+
+func TestTransactionLatency_QuantilesUseNearestRank(t *testing.T) {
+	durations := []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	tests := map[int]time.Duration{0: 1, 10: 1, 50: 5, 51: 6, 90: 9, 99: 10, 100: 10}
+	for quantile, want := range tests {
+		if got := getQuantile(durations, quantile); got != want {
+			t.Errorf("unexpected %d-th percentile, wanted %v, got %v", quantile, want, got)
+		}
+	}
+	if got, want := getQuantile([]time.Duration{7}, 99), time.Duration(7); got != want {
+		t.Errorf("unexpected percentile of a single value, wanted %v, got %v", want, got)
+	}
+}
+
+func TestTransactionLatency_CumulativeQuantilesCoverAllBlocks(t *testing.T) {
+	p50 := utils.NewSyncedSeriesSource(mon.Metric[mon.Network, mon.Series[mon.BlockNumber, time.Duration]]{Name: "p50"})
+	cumulativeP50 := utils.NewSyncedSeriesSource(mon.Metric[mon.Network, mon.Series[mon.BlockNumber, time.Duration]]{Name: "cumulative_p50"})
+	cumulativeAppP50 := utils.NewSyncedSeriesSource(mon.Metric[mon.App, mon.Series[mon.BlockNumber, time.Duration]]{Name: "cumulative_app_p50"})
+	tracker := &inclusionTracker{
+		latencyNetworkData:           map[int][]*utils.SyncedSeriesSource[mon.Network, mon.BlockNumber, time.Duration]{50: {p50}},
+		latencyAppData:               map[int][]*utils.SyncedSeriesSource[mon.App, mon.BlockNumber, time.Duration]{},
+		cumulativeLatencyNetworkData: map[int][]*utils.SyncedSeriesSource[mon.Network, mon.BlockNumber, time.Duration]{50: {cumulativeP50}},
+		cumulativeLatencyAppData:     map[int][]*utils.SyncedSeriesSource[mon.App, mon.BlockNumber, time.Duration]{50: {cumulativeAppP50}},
+		networkLatencies:             &latencyHistogram{},
+		appLatencies:                 map[mon.App]*latencyHistogram{},
+	}
+
+	now := time.Now()
+	submittedAgo := func(app string, latency time.Duration) includedTransaction {
+		return includedTransaction{submission: driver.TransactionSubmission{App: app, Time: now.Add(-latency)}}
+	}
+	tracker.recordLatencies(mon.Block{Height: 1, Time: now}, []includedTransaction{
+		submittedAgo("A", 4*time.Second), submittedAgo("A", 4*time.Second), submittedAgo("B", 4*time.Second),
+	})
+	tracker.recordLatencies(mon.Block{Height: 2, Time: now}, []includedTransaction{
+		submittedAgo("A", time.Second),
+	})
+
+	latest := func(series mon.Series[mon.BlockNumber, time.Duration], exists bool) time.Duration {
+		t.Helper()
+		if !exists || series.GetLatest() == nil || series.GetLatest().Position != 2 {
+			t.Fatalf("latency of block 2 was not recorded")
+		}
+		return series.GetLatest().Value
+	}
+	if got, want := latest(p50.GetData(mon.Network{})), time.Second; got != want {
+		t.Errorf("unexpected median of block 2, wanted %v, got %v", want, got)
+	}
+	// the run-wide median is estimated by the bound of its bucket, capped by the maximum
+	if got, want := latest(cumulativeP50.GetData(mon.Network{})), 4*time.Second; got != want {
+		t.Errorf("unexpected cumulative median, wanted %v, got %v", want, got)
+	}
+	if got, want := latest(cumulativeAppP50.GetData("A")), 4*time.Second; got != want {
+		t.Errorf("unexpected cumulative median of app A, wanted %v, got %v", want, got)
+	}
+	if series, exists := cumulativeAppP50.GetData("B"); !exists || series.GetLatest().Position != 1 {
+		t.Errorf("cumulative latency of app B should only be recorded for blocks including its transactions")
+	}
+}
+
+func TestLatencyHistogram_QuantilesAreAccurate(t *testing.T) {
+	histogram := &latencyHistogram{}
+	for i := 1; i <= 1000; i++ {
+		histogram.add(time.Duration(i) * time.Millisecond)
+	}
+	for _, quantile := range []int{1, 50, 90, 99, 100} {
+		exact := time.Duration(quantile*10) * time.Millisecond
+		got := histogram.getQuantile(quantile)
+		if got < exact || float64(got) > 1.1*float64(exact) {
+			t.Errorf("inaccurate %d-th percentile, wanted about %v, got %v", quantile, exact, got)
+		}
+	}
+	if got, want := histogram.getQuantile(100), time.Second; got != want {
+		t.Errorf("maximum should be reported exactly, wanted %v, got %v", want, got)
+	}
+}
+
+func TestLatencyHistogram_SubMillisecondLatenciesShareFirstBucket(t *testing.T) {
+	histogram := &latencyHistogram{}
+	histogram.add(0)
+	histogram.add(500 * time.Microsecond)
+	if got, want := histogram.getQuantile(50), 500*time.Microsecond; got != want {
+		t.Errorf("unexpected median, wanted %v, got %v", want, got)
+	}
+	if len(histogram.counts) != 1 {
+		t.Errorf("unexpected number of buckets, wanted 1, got %d", len(histogram.counts))
+	}
+}
+
+// waitForLatency waits until the latency of the given block is recorded for the given subject
+// and checks its value.
+func waitForLatency[S comparable](t *testing.T, source *transactionLatencySource[S], subject S, block mon.BlockNumber, want time.Duration) {
+	t.Helper()
+	for start := time.Now(); time.Since(start) < 5*time.Second; time.Sleep(10 * time.Millisecond) {
+		series, exists := source.GetData(subject)
+		if !exists || series.GetLatest() == nil {
+			continue
+		}
+		if got := series.GetLatest(); got.Position != block || got.Value != want {
+			t.Errorf("unexpected latency of %v, wanted %v at block %d, got %v at block %d", subject, want, block, got.Value, got.Position)
+		}
+		return
+	}
+	t.Errorf("latency of %v was not recorded", subject)
+}
@@ -0,0 +1,86 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+package nodemon
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	mon "github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/Fantom-foundation/Norma/driver/monitoring/utils"
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// NodeArchiveQueryLatency collects a per-node time series of the latency of queries
+// on the state of randomly selected past blocks. Only archive nodes are queried, at a
+// rate of one query per second, sampling the latency without producing a notable load.
+// A sustained load of historical queries is produced by query applications configured
+// to query past blocks.
+var NodeArchiveQueryLatency = mon.Metric[mon.Node, mon.Series[mon.Time, time.Duration]]{
+	Name:        "NodeArchiveQueryLatency",
+	Description: "The latency of historical state queries on archive nodes at various times.",
+}
+
+func init() {
+	if err := mon.RegisterSource(NodeArchiveQueryLatency, NewNodeArchiveQueryLatencySource); err != nil {
+		panic(fmt.Sprintf("failed to register metric source: %v", err))
+	}
+}
+
+// NewNodeArchiveQueryLatencySource creates a new data source periodically querying
+// the state of past blocks on archive nodes and collecting the latency of the queries.
+func NewNodeArchiveQueryLatencySource(monitor *mon.Monitor) mon.Source[mon.Node, mon.Series[mon.Time, time.Duration]] {
+	return newNodeArchiveQueryLatencySource(monitor, time.Second)
+}
+
+func newNodeArchiveQueryLatencySource(monitor *mon.Monitor, period time.Duration) mon.Source[mon.Node, mon.Series[mon.Time, time.Duration]] {
+	return newPeriodicNodeDataSource[time.Duration](NodeArchiveQueryLatency, monitor, period, &archiveQuerySensorFactory{})
+}
+
+type archiveQuerySensorFactory struct{}
+
+func (f *archiveQuerySensorFactory) CreateSensor(node driver.Node) (utils.Sensor[time.Duration], error) {
+	if !node.IsArchive() {
+		return nil, nil
+	}
+	rpcClient, err := node.DialRpc()
+	if err != nil {
+		return nil, err
+	}
+	return &archiveQuerySensor{rpcClient}, nil
+}
+
+type archiveQuerySensor struct {
+	rpcClient rpc.RpcClient
+}
+
+func (s *archiveQuerySensor) ReadValue() (time.Duration, error) {
+	var head hexutil.Uint64
+	if err := s.rpcClient.Call(&head, "eth_blockNumber"); err != nil {
+		return 0, err
+	}
+	blockNumber := uint64(rand.Int63n(int64(head) + 1))
+	query := rpc.HistoricalStateQueries[rand.Intn(len(rpc.HistoricalStateQueries))]
+
+	start := time.Now()
+	if _, err := query.Query(s.rpcClient, blockNumber); err != nil {
+		return 0, fmt.Errorf("failed to run %s on block %d; %v", query.Method, blockNumber, err)
+	}
+	return time.Since(start), nil
+}
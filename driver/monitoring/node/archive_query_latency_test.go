@@ -0,0 +1,115 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+package nodemon
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	mon "github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang/mock/gomock"
+	"golang.org/x/exp/slices"
+)
+
+func TestArchiveQuerySensorFactory_IgnoresNonArchiveNodes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	node := driver.NewMockNode(ctrl)
+	node.EXPECT().IsArchive().Return(false)
+
+	sensor, err := new(archiveQuerySensorFactory).CreateSensor(node)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sensor != nil {
+		t.Errorf("no sensor should be created for non-archive nodes")
+	}
+}
+
+func TestArchiveQuerySensor_QueriesPastBlocks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := rpc.NewMockRpcClient(ctrl)
+	client.EXPECT().Call(gomock.Any(), "eth_blockNumber").Times(10).SetArg(0, hexutil.Uint64(5))
+	client.EXPECT().Call(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Do(checkPastBlock(t, 5))
+	client.EXPECT().Call(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Do(checkPastBlock(t, 5))
+
+	sensor := &archiveQuerySensor{client}
+	for i := 0; i < 10; i++ {
+		if _, err := sensor.ReadValue(); err != nil {
+			t.Errorf("failed to read value: %v", err)
+		}
+	}
+}
+
+func TestNodeArchiveQueryLatencySource_TracksArchiveNodesOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	client := rpc.NewMockRpcClient(ctrl)
+	client.EXPECT().Call(gomock.Any(), "eth_blockNumber").AnyTimes().SetArg(0, hexutil.Uint64(5))
+	client.EXPECT().Call(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+	client.EXPECT().Call(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	archive := driver.NewMockNode(ctrl)
+	validator := driver.NewMockNode(ctrl)
+	archive.EXPECT().GetLabel().AnyTimes().Return("A")
+	validator.EXPECT().GetLabel().AnyTimes().Return("B")
+	archive.EXPECT().IsArchive().AnyTimes().Return(true)
+	validator.EXPECT().IsArchive().AnyTimes().Return(false)
+	archive.EXPECT().DialRpc().Return(client, nil)
+	archive.EXPECT().StreamLog().AnyTimes().Return(io.NopCloser(strings.NewReader("")), nil)
+	validator.EXPECT().StreamLog().AnyTimes().Return(io.NopCloser(strings.NewReader("")), nil)
+
+	net.EXPECT().RegisterListener(gomock.Any()).AnyTimes()
+	net.EXPECT().UnregisterListener(gomock.Any()).AnyTimes()
+	net.EXPECT().GetActiveNodes().Return([]driver.Node{archive, validator}).AnyTimes()
+
+	monitor, err := mon.NewMonitor(net, mon.MonitorConfig{OutputDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to initiate monitor: %v", err)
+	}
+	source := newNodeArchiveQueryLatencySource(monitor, 50*time.Millisecond)
+
+	time.Sleep(200 * time.Millisecond)
+	if err := source.Shutdown(); err != nil {
+		t.Errorf("erros encountered during shutdown: %v", err)
+	}
+
+	if got, want := source.GetSubjects(), []mon.Node{"A"}; !slices.Equal(got, want) {
+		t.Errorf("invalid list of subjects, wanted %v, got %v", want, got)
+	}
+	data, exists := source.GetData("A")
+	if !exists || data.GetLatest() == nil {
+		t.Errorf("no data collected for archive node")
+	}
+}
+
+// checkPastBlock creates a check for RPC calls, whose last argument is a block number
+// not exceeding the given head.
+func checkPastBlock(t *testing.T, head uint64) func(result interface{}, method string, args ...interface{}) {
+	return func(result interface{}, method string, args ...interface{}) {
+		blockNumber, err := hexutil.DecodeUint64(args[len(args)-1].(string))
+		if err != nil {
+			t.Fatalf("invalid block number in %s: %v", method, err)
+		}
+		if blockNumber > head {
+			t.Errorf("%s queries block %d, which is beyond the head %d", method, blockNumber, head)
+		}
+	}
+}
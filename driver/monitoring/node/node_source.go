@@ -27,7 +27,8 @@ import (
 	mon "github.com/Fantom-foundation/Norma/driver/monitoring"
 )
 
-// SensorFactory is a factory for sensors targeting selected nodes.
+// SensorFactory is a factory for sensors targeting selected nodes. Factories
+// may return a nil sensor for nodes not covered by the produced metric.
 type SensorFactory[T any] interface {
 	CreateSensor(driver.Node) (utils.Sensor[T], error)
 }
@@ -76,6 +77,9 @@ func (s *periodicNodeDataSource[T]) AfterNodeCreation(node driver.Node) {
 	if err != nil {
 		log.Printf("failed to create sensor for metric %v / node %s: %v", s.GetMetric().Name, label, err)
 	}
+	if sensor == nil {
+		return
+	}
 	s.AddSubject(mon.Node(label), sensor)
 }
 
@@ -0,0 +1,187 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package nodemon
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	mon "github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/Fantom-foundation/Norma/driver/monitoring/utils"
+)
+
+// maxPendingNotifications is the number of completed blocks per node waiting for
+// the delivery of their notification before the oldest ones are dropped.
+const maxPendingNotifications = 100
+
+// NodeNotificationDelay contains for the kinds of subscriptions notifying about blocks a
+// metric capturing the delay between the completion of a block on a node, as reported by
+// BlockCompletionTime, and the delivery of its notification to all subscriptions on the node.
+var NodeNotificationDelay = map[driver.SubscriptionKind]mon.Metric[mon.Node, mon.Series[mon.BlockNumber, time.Duration]]{}
+
+// NodeMissedNotifications contains for each kind of subscriptions a metric capturing
+// the total number of notifications the subscriptions on each node have missed.
+var NodeMissedNotifications = map[driver.SubscriptionKind]mon.Metric[mon.Node, mon.Series[mon.Time, int]]{}
+
+// NodeDuplicatedNotifications contains for each kind of subscriptions a metric capturing
+// the total number of notifications the subscriptions on each node have received repeatedly.
+var NodeDuplicatedNotifications = map[driver.SubscriptionKind]mon.Metric[mon.Node, mon.Series[mon.Time, int]]{}
+
+func init() {
+	for _, kind := range []driver.SubscriptionKind{driver.NewHeadsSubscription, driver.LogsSubscription} {
+		kind := kind // capture current value of the kind
+
+		metric := mon.Metric[mon.Node, mon.Series[mon.BlockNumber, time.Duration]]{
+			Name:        fmt.Sprintf("NodeNotificationDelay_%s", kind),
+			Description: fmt.Sprintf("The delay between the completion of a block and the delivery of its %s notifications.", kind),
+		}
+		factory := func(monitor *mon.Monitor) mon.Source[mon.Node, mon.Series[mon.BlockNumber, time.Duration]] {
+			return newNotificationDelaySource(monitor, metric, kind)
+		}
+		if err := mon.RegisterSource(metric, factory); err != nil {
+			panic(fmt.Sprintf("failed to register metric source: %v", err))
+		}
+		NodeNotificationDelay[kind] = metric
+	}
+
+	for _, kind := range driver.SubscriptionKinds {
+		kind := kind // capture current value of the kind
+
+		missed := mon.Metric[mon.Node, mon.Series[mon.Time, int]]{
+			Name:        fmt.Sprintf("NodeMissedNotifications_%s", kind),
+			Description: fmt.Sprintf("The number of %s notifications missed by subscriptions on a node at various times.", kind),
+		}
+		missedFactory := func(monitor *mon.Monitor) mon.Source[mon.Node, mon.Series[mon.Time, int]] {
+			return NewPeriodicNodeDataSource[int](missed, monitor, &notificationStatsSensorFactory{
+				network: monitor.Network(),
+				kind:    kind,
+				value:   func(stats driver.NotificationStats) uint64 { return stats.Missed },
+			})
+		}
+		if err := mon.RegisterSource(missed, missedFactory); err != nil {
+			panic(fmt.Sprintf("failed to register metric source: %v", err))
+		}
+		NodeMissedNotifications[kind] = missed
+
+		duplicated := mon.Metric[mon.Node, mon.Series[mon.Time, int]]{
+			Name:        fmt.Sprintf("NodeDuplicatedNotifications_%s", kind),
+			Description: fmt.Sprintf("The number of %s notifications repeatedly received by subscriptions on a node at various times.", kind),
+		}
+		duplicatedFactory := func(monitor *mon.Monitor) mon.Source[mon.Node, mon.Series[mon.Time, int]] {
+			return NewPeriodicNodeDataSource[int](duplicated, monitor, &notificationStatsSensorFactory{
+				network: monitor.Network(),
+				kind:    kind,
+				value:   func(stats driver.NotificationStats) uint64 { return stats.Duplicated },
+			})
+		}
+		if err := mon.RegisterSource(duplicated, duplicatedFactory); err != nil {
+			panic(fmt.Sprintf("failed to register metric source: %v", err))
+		}
+		NodeDuplicatedNotifications[kind] = duplicated
+	}
+}
+
+// notificationDelaySource listens for completed blocks of all nodes and records the
+// delay until their notification has been delivered to the subscriptions on the node.
+// Blocks are kept pending until their notification is delivered. Blocks never
+// delivered are dropped once a later block has been delivered.
+type notificationDelaySource struct {
+	*utils.SyncedSeriesSource[mon.Node, mon.BlockNumber, time.Duration]
+	monitor *mon.Monitor
+	kind    driver.SubscriptionKind
+	pending map[mon.Node][]mon.Block
+	mutex   sync.Mutex
+}
+
+func newNotificationDelaySource(
+	monitor *mon.Monitor,
+	metric mon.Metric[mon.Node, mon.Series[mon.BlockNumber, time.Duration]],
+	kind driver.SubscriptionKind,
+) *notificationDelaySource {
+	s := &notificationDelaySource{
+		SyncedSeriesSource: utils.NewSyncedSeriesSource(metric),
+		monitor:            monitor,
+		kind:               kind,
+		pending:            map[mon.Node][]mon.Block{},
+	}
+	monitor.NodeLogProvider().RegisterLogListener(s)
+	return s
+}
+
+func (s *notificationDelaySource) Shutdown() error {
+	s.monitor.NodeLogProvider().UnregisterLogListener(s)
+	return s.SyncedSeriesSource.Shutdown()
+}
+
+func (s *notificationDelaySource) OnBlock(node mon.Node, block mon.Block) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	key := driver.NotificationKey{Node: string(node), Kind: s.kind}
+	pending := append(s.pending[node], block)
+	delivered := make([]time.Time, len(pending))
+	last := -1
+	for i, cur := range pending {
+		if delivery, found := s.monitor.Network().GetNotificationTime(key, uint64(cur.Height)); found {
+			delivered[i] = delivery
+			last = i
+		}
+	}
+
+	for i := 0; i <= last; i++ {
+		if delivered[i].IsZero() {
+			continue
+		}
+		series := s.GetOrAddSubject(node)
+		if err := series.Append(mon.BlockNumber(pending[i].Height), delivered[i].Sub(pending[i].Time)); err != nil {
+			log.Printf("cannot add to series: %s", err)
+		}
+	}
+
+	remaining := pending[last+1:]
+	if len(remaining) > maxPendingNotifications {
+		remaining = remaining[len(remaining)-maxPendingNotifications:]
+	}
+	s.pending[node] = append([]mon.Block(nil), remaining...)
+}
+
+type notificationStatsSensorFactory struct {
+	network driver.Network
+	kind    driver.SubscriptionKind
+	value   func(driver.NotificationStats) uint64
+}
+
+func (f *notificationStatsSensorFactory) CreateSensor(node driver.Node) (utils.Sensor[int], error) {
+	return &notificationStatsSensor{
+		network: f.network,
+		key:     driver.NotificationKey{Node: node.GetLabel(), Kind: f.kind},
+		value:   f.value,
+	}, nil
+}
+
+type notificationStatsSensor struct {
+	network driver.Network
+	key     driver.NotificationKey
+	value   func(driver.NotificationStats) uint64
+}
+
+func (s *notificationStatsSensor) ReadValue() (int, error) {
+	return int(s.value(s.network.GetNotificationStats()[s.key])), nil
+}
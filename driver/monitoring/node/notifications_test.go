@@ -0,0 +1,124 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package nodemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/golang/mock/gomock"
+)
+
+func TestNotifications_MetricsAreRegisteredForAllKinds(t *testing.T) {
+	for _, kind := range driver.SubscriptionKinds {
+		if _, found := NodeMissedNotifications[kind]; !found {
+			t.Errorf("no missed notifications metric for kind %s", kind)
+		}
+		if _, found := NodeDuplicatedNotifications[kind]; !found {
+			t.Errorf("no duplicated notifications metric for kind %s", kind)
+		}
+	}
+	for _, kind := range []driver.SubscriptionKind{driver.NewHeadsSubscription, driver.LogsSubscription} {
+		if _, found := NodeNotificationDelay[kind]; !found {
+			t.Errorf("no delay metric for kind %s", kind)
+		}
+	}
+}
+
+func TestNotificationDelaySource_RecordsDelayOfDeliveredBlocks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	net := driver.NewMockNetwork(ctrl)
+	net.EXPECT().RegisterListener(gomock.Any()).AnyTimes()
+	net.EXPECT().GetActiveNodes().AnyTimes().Return([]driver.Node{})
+
+	start := time.Unix(1000, 0)
+	key := driver.NotificationKey{Node: string(monitoring.Node1TestId), Kind: driver.NewHeadsSubscription}
+	delivered := map[uint64]time.Time{
+		1: start.Add(10 * time.Millisecond),
+		3: start.Add(2*time.Second + 30*time.Millisecond),
+	}
+	net.EXPECT().GetNotificationTime(key, gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ driver.NotificationKey, block uint64) (time.Time, bool) {
+			res, found := delivered[block]
+			return res, found
+		})
+
+	monitor, err := monitoring.NewMonitor(net, monitoring.MonitorConfig{OutputDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to initiate monitor: %v", err)
+	}
+	source := newNotificationDelaySource(monitor, NodeNotificationDelay[driver.NewHeadsSubscription], driver.NewHeadsSubscription)
+	defer source.Shutdown()
+
+	// block 2 is never delivered, block 4 is still pending at the end
+	for i := 1; i <= 4; i++ {
+		source.OnBlock(monitoring.Node1TestId, monitoring.Block{Height: i, Time: start.Add(time.Duration(i-1) * time.Second)})
+	}
+
+	series, found := source.GetData(monitoring.Node1TestId)
+	if !found {
+		t.Fatalf("no data for node")
+	}
+	points := series.GetRange(0, 10)
+	if got, want := len(points), 2; got != want {
+		t.Fatalf("unexpected number of data points, wanted %d, got %d", want, got)
+	}
+	if got, want := points[0].Value, 10*time.Millisecond; points[0].Position != 1 || got != want {
+		t.Errorf("unexpected delay of block %d, wanted %v, got %v", points[0].Position, want, got)
+	}
+	if got, want := points[1].Value, 30*time.Millisecond; points[1].Position != 3 || got != want {
+		t.Errorf("unexpected delay of block %d, wanted %v, got %v", points[1].Position, want, got)
+	}
+	if got, want := len(source.pending[monitoring.Node1TestId]), 1; got != want {
+		t.Errorf("unexpected number of pending blocks, wanted %d, got %d", want, got)
+	}
+}
+
+func TestNotificationStatsSensor_ReportsCountersOfNode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	network := driver.NewMockNetwork(ctrl)
+	key := driver.NotificationKey{Node: "node-1", Kind: driver.LogsSubscription}
+	network.EXPECT().GetNotificationStats().Return(map[driver.NotificationKey]driver.NotificationStats{
+		key: {Received: 10, Missed: 2, Duplicated: 1},
+		{Node: "node-2", Kind: driver.LogsSubscription}: {Missed: 5},
+	}).Times(2)
+
+	node := driver.NewMockNode(ctrl)
+	node.EXPECT().GetLabel().Return("node-1").Times(2)
+	missed := &notificationStatsSensorFactory{
+		network: network,
+		kind:    driver.LogsSubscription,
+		value:   func(stats driver.NotificationStats) uint64 { return stats.Missed },
+	}
+	duplicated := &notificationStatsSensorFactory{
+		network: network,
+		kind:    driver.LogsSubscription,
+		value:   func(stats driver.NotificationStats) uint64 { return stats.Duplicated },
+	}
+
+	for want, factory := range map[int]*notificationStatsSensorFactory{2: missed, 1: duplicated} {
+		sensor, err := factory.CreateSensor(node)
+		if err != nil {
+			t.Fatalf("creation of sensor failed: %v", err)
+		}
+		if got, err := sensor.ReadValue(); err != nil || got != want {
+			t.Errorf("unexpected value, wanted %d, got %d, err %v", want, got, err)
+		}
+	}
+}
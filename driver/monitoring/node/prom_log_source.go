@@ -41,6 +41,17 @@ var (
 		monitoring.NewPrometheusNameKey("db_size"),
 		monitoring.NewPrometheusNameKey("statedb_disksize"),
 	}
+
+	// TxPoolPending is the number of pending transactions in the transaction pool of a node.
+	TxPoolPending = toMetric(monitoring.NewPrometheusNameKey("txpool_pending"))
+
+	// TxPoolRejections are the metrics counting the transactions rejected by the transaction
+	// pool of a node since its start, indexed by their name.
+	TxPoolRejections = map[string]monitoring.Metric[monitoring.Node, monitoring.Series[monitoring.Time, float64]]{
+		"txpool_invalid":     toMetric(monitoring.NewPrometheusNameKey("txpool_invalid")),
+		"txpool_underpriced": toMetric(monitoring.NewPrometheusNameKey("txpool_underpriced")),
+		"txpool_overflowed":  toMetric(monitoring.NewPrometheusNameKey("txpool_overflowed")),
+	}
 )
 
 func init() {
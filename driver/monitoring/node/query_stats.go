@@ -0,0 +1,118 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package nodemon
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	mon "github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/Fantom-foundation/Norma/driver/monitoring/utils"
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+)
+
+// NodeQueryLatency contains for each RPC method issued by query applications a metric
+// capturing the mean latency of the requests served by each node since the previous sample.
+var NodeQueryLatency = map[string]mon.Metric[mon.Node, mon.Series[mon.Time, time.Duration]]{}
+
+// NodeQueryErrorRate contains for each RPC method issued by query applications a metric
+// capturing the fraction of failed requests sent to each node since the previous sample.
+var NodeQueryErrorRate = map[string]mon.Metric[mon.Node, mon.Series[mon.Time, float64]]{}
+
+func init() {
+	for _, method := range rpc.QueryMethods {
+		method := method // capture current value of the method
+
+		latency := mon.Metric[mon.Node, mon.Series[mon.Time, time.Duration]]{
+			Name:        fmt.Sprintf("NodeQueryLatency_%s", method),
+			Description: fmt.Sprintf("The mean latency of %s requests served by a node at various times.", method),
+		}
+		latencyFactory := func(monitor *mon.Monitor) mon.Source[mon.Node, mon.Series[mon.Time, time.Duration]] {
+			return NewPeriodicNodeDataSource[time.Duration](latency, monitor, &queryStatsSensorFactory[time.Duration]{
+				network: monitor.Network(),
+				method:  method,
+				value:   getMeanQueryLatency,
+			})
+		}
+		if err := mon.RegisterSource(latency, latencyFactory); err != nil {
+			panic(fmt.Sprintf("failed to register metric source: %v", err))
+		}
+		NodeQueryLatency[method] = latency
+
+		errorRate := mon.Metric[mon.Node, mon.Series[mon.Time, float64]]{
+			Name:        fmt.Sprintf("NodeQueryErrorRate_%s", method),
+			Description: fmt.Sprintf("The fraction of failed %s requests sent to a node at various times.", method),
+		}
+		errorRateFactory := func(monitor *mon.Monitor) mon.Source[mon.Node, mon.Series[mon.Time, float64]] {
+			return NewPeriodicNodeDataSource[float64](errorRate, monitor, &queryStatsSensorFactory[float64]{
+				network: monitor.Network(),
+				method:  method,
+				value:   getQueryErrorRate,
+			})
+		}
+		if err := mon.RegisterSource(errorRate, errorRateFactory); err != nil {
+			panic(fmt.Sprintf("failed to register metric source: %v", err))
+		}
+		NodeQueryErrorRate[method] = errorRate
+	}
+}
+
+func getMeanQueryLatency(stats driver.QueryStats) time.Duration {
+	return stats.Latency / time.Duration(stats.Count)
+}
+
+func getQueryErrorRate(stats driver.QueryStats) float64 {
+	return float64(stats.Errors) / float64(stats.Count)
+}
+
+type queryStatsSensorFactory[T any] struct {
+	network driver.Network
+	method  string
+	value   func(driver.QueryStats) T // < derives the value from requests sent since the previous sample
+}
+
+func (f *queryStatsSensorFactory[T]) CreateSensor(node driver.Node) (utils.Sensor[T], error) {
+	return &queryStatsSensor[T]{
+		network: f.network,
+		key:     driver.QueryStatsKey{Node: node.GetLabel(), Method: f.method},
+		value:   f.value,
+	}, nil
+}
+
+// queryStatsSensor derives its value from the requests sent to a node since the previous
+// sample. If no request has been sent in the meantime, the previous value is reported.
+type queryStatsSensor[T any] struct {
+	network  driver.Network
+	key      driver.QueryStatsKey
+	value    func(driver.QueryStats) T
+	previous driver.QueryStats
+	current  T
+}
+
+func (s *queryStatsSensor[T]) ReadValue() (T, error) {
+	stats := s.network.GetQueryStats()[s.key]
+	if stats.Count > s.previous.Count {
+		s.current = s.value(driver.QueryStats{
+			Count:   stats.Count - s.previous.Count,
+			Errors:  stats.Errors - s.previous.Errors,
+			Latency: stats.Latency - s.previous.Latency,
+		})
+		s.previous = stats
+	}
+	return s.current, nil
+}
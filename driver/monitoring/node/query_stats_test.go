@@ -0,0 +1,78 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package nodemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/golang/mock/gomock"
+)
+
+func TestQueryStats_MetricsAreRegisteredForAllMethods(t *testing.T) {
+	for _, method := range rpc.QueryMethods {
+		if _, found := NodeQueryLatency[method]; !found {
+			t.Errorf("no latency metric for method %s", method)
+		}
+		if _, found := NodeQueryErrorRate[method]; !found {
+			t.Errorf("no error rate metric for method %s", method)
+		}
+	}
+}
+
+func TestQueryStatsSensor_ReportsRequestsSincePreviousSample(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	network := driver.NewMockNetwork(ctrl)
+	key := driver.QueryStatsKey{Node: "node-1", Method: rpc.GetLogsMethod}
+	other := driver.QueryStatsKey{Node: "node-2", Method: rpc.GetLogsMethod}
+	gomock.InOrder(
+		network.EXPECT().GetQueryStats().Return(map[driver.QueryStatsKey]driver.QueryStats{
+			key:   {Count: 4, Errors: 1, Latency: 40 * time.Millisecond},
+			other: {Count: 10, Errors: 10, Latency: time.Second},
+		}),
+		network.EXPECT().GetQueryStats().Return(map[driver.QueryStatsKey]driver.QueryStats{
+			key: {Count: 6, Errors: 2, Latency: 100 * time.Millisecond},
+		}),
+		network.EXPECT().GetQueryStats().Return(map[driver.QueryStatsKey]driver.QueryStats{
+			key: {Count: 6, Errors: 2, Latency: 100 * time.Millisecond},
+		}),
+	)
+
+	node := driver.NewMockNode(ctrl)
+	node.EXPECT().GetLabel().Return("node-1")
+	factory := &queryStatsSensorFactory[float64]{network: network, method: rpc.GetLogsMethod, value: getQueryErrorRate}
+	sensor, err := factory.CreateSensor(node)
+	if err != nil {
+		t.Fatalf("creation of sensor failed: %v", err)
+	}
+
+	// the second and third sample cover the 2 requests sent in between
+	for i, want := range []float64{0.25, 0.5, 0.5} {
+		if got, err := sensor.ReadValue(); err != nil || got != want {
+			t.Errorf("unexpected value of sample %d, wanted %f, got %f, err %v", i, want, got, err)
+		}
+	}
+}
+
+func TestQueryStats_MeanLatencyIsDerivedFromTotal(t *testing.T) {
+	stats := driver.QueryStats{Count: 4, Latency: 100 * time.Millisecond}
+	if got, want := getMeanQueryLatency(stats), 25*time.Millisecond; got != want {
+		t.Errorf("unexpected mean latency, wanted %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package nodemon
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/Fantom-foundation/Norma/driver/monitoring/utils"
+)
+
+// StateDbGrowth is the growth of the on-disk size of the state database of nodes since
+// their first report, derived from the statedb_disksize Prometheus metric.
+var StateDbGrowth = monitoring.Metric[monitoring.Node, monitoring.Series[monitoring.Time, float64]]{
+	Name:        "StateDbGrowth",
+	Description: "The growth of the on-disk size of the state database of nodes in bytes.",
+}
+
+func init() {
+	if err := monitoring.RegisterSource(StateDbGrowth, NewStateDbGrowthSource); err != nil {
+		panic(fmt.Sprintf("failed to register metric source: %v", err))
+	}
+}
+
+// StateDbGrowthSource listens for reports of the size of the state database of all
+// nodes and records their difference to the first size reported by each node.
+type StateDbGrowthSource struct {
+	*utils.SyncedSeriesSource[monitoring.Node, monitoring.Time, float64]
+	initial      map[monitoring.Node]float64
+	initialMutex sync.Mutex
+}
+
+// NewStateDbGrowthSource creates a new source tracking the growth of the state
+// database of all network nodes.
+func NewStateDbGrowthSource(monitor *monitoring.Monitor) monitoring.Source[monitoring.Node, monitoring.Series[monitoring.Time, float64]] {
+	return newStateDbGrowthSource(monitor)
+}
+
+func newStateDbGrowthSource(monitor *monitoring.Monitor) *StateDbGrowthSource {
+	s := &StateDbGrowthSource{
+		SyncedSeriesSource: utils.NewSyncedSeriesSource(StateDbGrowth),
+		initial:            map[monitoring.Node]float64{},
+	}
+	monitor.PrometheusLogProvider().RegisterLogListener(monitoring.NewPrometheusNameKey("statedb_disksize"), s)
+	return s
+}
+
+func (s *StateDbGrowthSource) OnLog(node monitoring.Node, time monitoring.Time, value float64) {
+	s.initialMutex.Lock()
+	initial, found := s.initial[node]
+	if !found {
+		initial = value
+		s.initial[node] = value
+	}
+	s.initialMutex.Unlock()
+
+	series := s.GetOrAddSubject(node)
+	if err := series.Append(time, value-initial); err != nil {
+		log.Printf("cannot add to series: %s", err)
+	}
+}
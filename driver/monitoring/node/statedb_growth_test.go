@@ -0,0 +1,65 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package nodemon
+
+import (
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/golang/mock/gomock"
+)
+
+func TestStateDbGrowth_GrowthIsRelativeToFirstReport(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	net := driver.NewMockNetwork(ctrl)
+	net.EXPECT().RegisterListener(gomock.Any()).AnyTimes()
+	net.EXPECT().GetActiveNodes().AnyTimes().Return([]driver.Node{})
+
+	monitor, err := monitoring.NewMonitor(net, monitoring.MonitorConfig{OutputDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to initiate monitor: %v", err)
+	}
+
+	source := newStateDbGrowthSource(monitor)
+	source.OnLog("A", monitoring.Time(1), 1000)
+	source.OnLog("B", monitoring.Time(1), 5000)
+	source.OnLog("A", monitoring.Time(2), 1500)
+	source.OnLog("A", monitoring.Time(3), 1200)
+	source.OnLog("B", monitoring.Time(2), 9000)
+
+	want := map[monitoring.Node][]float64{
+		"A": {0, 500, 200},
+		"B": {0, 4000},
+	}
+	for node, values := range want {
+		series, exists := source.GetData(node)
+		if !exists {
+			t.Fatalf("series of node %v should exist", node)
+		}
+		points := series.GetRange(monitoring.Time(0), monitoring.Time(10))
+		if len(points) != len(values) {
+			t.Fatalf("unexpected number of points for node %v, wanted %d, got %d", node, len(values), len(points))
+		}
+		for i, point := range points {
+			if point.Value != values[i] {
+				t.Errorf("unexpected growth of node %v at %v, wanted %f, got %f", node, point.Position, values[i], point.Value)
+			}
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package nodemon
+
+import (
+	"fmt"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	mon "github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/Fantom-foundation/Norma/driver/monitoring/utils"
+)
+
+// NodeTxSubmissionErrors is a metric capturing the total number of transactions
+// rejected by each node when being submitted.
+var NodeTxSubmissionErrors = mon.Metric[mon.Node, mon.Series[mon.Time, int]]{
+	Name:        "NodeTxSubmissionErrors",
+	Description: "The number of failed transaction submissions to a node at various times.",
+}
+
+func init() {
+	if err := mon.RegisterSource(NodeTxSubmissionErrors, newNodeTxSubmissionErrorsSource); err != nil {
+		panic(fmt.Sprintf("failed to register metric source: %v", err))
+	}
+}
+
+func newNodeTxSubmissionErrorsSource(monitor *mon.Monitor) mon.Source[mon.Node, mon.Series[mon.Time, int]] {
+	return NewPeriodicNodeDataSource[int](NodeTxSubmissionErrors, monitor, &submissionErrorsSensorFactory{monitor.Network()})
+}
+
+type submissionErrorsSensorFactory struct {
+	network driver.Network
+}
+
+func (f *submissionErrorsSensorFactory) CreateSensor(node driver.Node) (utils.Sensor[int], error) {
+	return &submissionErrorsSensor{
+		network: f.network,
+		node:    node.GetLabel(),
+	}, nil
+}
+
+type submissionErrorsSensor struct {
+	network driver.Network
+	node    string
+}
+
+func (s *submissionErrorsSensor) ReadValue() (int, error) {
+	sum := 0
+	for key, count := range s.network.GetTransactionSubmissionErrors() {
+		if key.Node == s.node {
+			sum += int(count)
+		}
+	}
+	return sum, nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package nodemon
+
+import (
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/golang/mock/gomock"
+)
+
+func TestSubmissionErrorsSensorCountsErrorsOfNode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	network := driver.NewMockNetwork(ctrl)
+	network.EXPECT().GetTransactionSubmissionErrors().AnyTimes().Return(map[driver.SubmissionErrorKey]uint64{
+		{Node: "node-1", App: "A", Class: driver.SubmissionErrorNonceTooLow}: 2,
+		{Node: "node-1", App: "B", Class: driver.SubmissionErrorTxPoolFull}:  3,
+		{Node: "node-2", App: "A", Class: driver.SubmissionErrorNonceTooLow}: 5,
+	})
+
+	factory := &submissionErrorsSensorFactory{network}
+	tests := map[string]int{"node-1": 5, "node-2": 5, "node-3": 0}
+	for label, expected := range tests {
+		node := driver.NewMockNode(ctrl)
+		node.EXPECT().GetLabel().Return(label)
+
+		sensor, err := factory.CreateSensor(node)
+		if err != nil {
+			t.Fatalf("creation of sensor failed: %v", err)
+		}
+		if res, err := sensor.ReadValue(); err != nil || res != expected {
+			t.Errorf("sensor fetched wrong value for node %s, wanted %d, got %d, err %v", label, expected, res, err)
+		}
+	}
+}
@@ -31,41 +31,77 @@ var (
 		Name:        "TransactionsThroughput",
 		Description: "The number of transactions processed per certain time period by each node",
 	}
+
+	// GasThroughput is a metric capturing the gas used per second, i.e. the gas throughput
+	GasThroughput = monitoring.Metric[monitoring.Node, monitoring.Series[monitoring.BlockNumber, float32]]{
+		Name:        "GasThroughput",
+		Description: "The gas used per second by blocks processed by each node",
+	}
 )
 
 func init() {
 	if err := monitoring.RegisterSource(TransactionsThroughput, newTransactionsThroughputSource); err != nil {
 		panic(fmt.Sprintf("failed to register metric source: %v", err))
 	}
+	if err := monitoring.RegisterSource(GasThroughput, newGasThroughputSource); err != nil {
+		panic(fmt.Sprintf("failed to register metric source: %v", err))
+	}
 }
 
-// TransactionsThroughputSource is a metric source that captures transaction throughput.
+// TransactionsThroughputSource is a metric source that captures the throughput of
+// a block property, e.g. the transaction throughput.
 type TransactionsThroughputSource struct {
 	BlockNodeMetricSource[float32]
-	lastTimes map[monitoring.Node]time.Time // timestamps of the latest received blocks
+	lastTimes        map[monitoring.Node]time.Time // timestamps of the latest received blocks
+	getBlockProperty func(b monitoring.Block) float64
 }
 
 // NewTransactionsThroughputSource creates a metric capturing transaction throughput.
 func NewTransactionsThroughputSource(monitor *monitoring.Monitor) *TransactionsThroughputSource {
+	f := func(b monitoring.Block) float64 {
+		return float64(b.Txs)
+	}
+	return newThroughputSource(monitor, TransactionsThroughput, f)
+}
+
+// NewGasThroughputSource creates a metric capturing gas throughput.
+func NewGasThroughputSource(monitor *monitoring.Monitor) *TransactionsThroughputSource {
+	f := func(b monitoring.Block) float64 {
+		return float64(b.GasUsed)
+	}
+	return newThroughputSource(monitor, GasThroughput, f)
+}
+
+// newTransactionsThroughputSource is the same as its public counterpart, it only returns the Source interface instead of the struct to be used in factories
+func newTransactionsThroughputSource(monitor *monitoring.Monitor) monitoring.Source[monitoring.Node, monitoring.Series[monitoring.BlockNumber, float32]] {
+	return NewTransactionsThroughputSource(monitor)
+}
+
+// newGasThroughputSource is the same as its public counterpart, it only returns the Source interface instead of the struct to be used in factories
+func newGasThroughputSource(monitor *monitoring.Monitor) monitoring.Source[monitoring.Node, monitoring.Series[monitoring.BlockNumber, float32]] {
+	return NewGasThroughputSource(monitor)
+}
+
+func newThroughputSource(
+	monitor *monitoring.Monitor,
+	metric monitoring.Metric[monitoring.Node, monitoring.Series[monitoring.BlockNumber, float32]],
+	getBlockProperty func(b monitoring.Block) float64,
+) *TransactionsThroughputSource {
 	blockMetrics := BlockNodeMetricSource[float32]{
-		SyncedSeriesSource: utils.NewSyncedSeriesSource(TransactionsThroughput),
+		SyncedSeriesSource: utils.NewSyncedSeriesSource(metric),
 		monitor:            monitor,
 	}
 
 	m := &TransactionsThroughputSource{
 		BlockNodeMetricSource: blockMetrics,
 		lastTimes:             make(map[monitoring.Node]time.Time, 50),
+		getBlockProperty:      getBlockProperty,
 	}
 	monitor.NodeLogProvider().RegisterLogListener(m)
 
 	return m
 }
 
-// newTransactionsThroughputSource is the same as its public counterpart, it only returns the Source interface instead of the struct to be used in factories
-func newTransactionsThroughputSource(monitor *monitoring.Monitor) monitoring.Source[monitoring.Node, monitoring.Series[monitoring.BlockNumber, float32]] {
-	return NewTransactionsThroughputSource(monitor)
-}
-
 func (s *TransactionsThroughputSource) OnBlock(node monitoring.Node, block monitoring.Block) {
 
 	prevTime, exists := s.lastTimes[node]
@@ -79,9 +115,9 @@ func (s *TransactionsThroughputSource) OnBlock(node monitoring.Node, block monit
 	timeDiff := block.Time.Sub(prevTime).Nanoseconds()
 	// prevent NaN or Inf: when the time difference is bellow measured value, skip the block.
 	if timeDiff != 0 {
-		txs := float64(block.Txs) * 1e9 / float64(timeDiff)
+		rate := s.getBlockProperty(block) * 1e9 / float64(timeDiff)
 		series := s.GetOrAddSubject(node)
-		if err := series.Append(monitoring.BlockNumber(block.Height), float32(txs)); err != nil {
+		if err := series.Append(monitoring.BlockNumber(block.Height), float32(rate)); err != nil {
 			log.Printf("error to add to the series: %s", err)
 		}
 	}
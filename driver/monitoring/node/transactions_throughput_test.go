@@ -199,3 +199,29 @@ func TestTransactionsZeroTransactionsBellowMeasurableDiff(t *testing.T) {
 		t.Errorf("there should be no value")
 	}
 }
+
+func TestGasThroughputSource(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	net := driver.NewMockNetwork(ctrl)
+	net.EXPECT().RegisterListener(gomock.Any()).AnyTimes()
+	net.EXPECT().GetActiveNodes().AnyTimes().Return([]driver.Node{})
+
+	monitor, err := monitoring.NewMonitor(net, monitoring.MonitorConfig{OutputDir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to initiate monitor: %v", err)
+	}
+	source := NewGasThroughputSource(monitor)
+
+	seconds := time.Now().Unix()
+	source.OnBlock("A", monitoring.Block{Height: 10, Time: time.Unix(seconds, 0), Txs: 1, GasUsed: 21_000})
+	source.OnBlock("A", monitoring.Block{Height: 11, Time: time.Unix(seconds+2, 0), Txs: 2, GasUsed: 100_000})
+
+	series, exists := source.GetData("A")
+	if !exists {
+		t.Fatalf("data should exist")
+	}
+	if got, want := series.GetLatest().Value, float32(50_000); got != want {
+		t.Errorf("gas througput incorect: %3.2f != %3.2f", got, want)
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/monitoring"
+	"github.com/Fantom-foundation/Norma/driver/monitoring/utils"
+)
+
+var (
+	// RoundTripLatency is a metric capturing the latency of transactions sent by users of
+	// applications running in the closed-loop mode, measured from sending a transaction until
+	// its inclusion in a block. Each value is the mean latency of the round trips completed
+	// since the previous sample, or zero if no round trip has been completed in the meantime.
+	RoundTripLatency = monitoring.Metric[monitoring.User, monitoring.Series[monitoring.Time, time.Duration]]{
+		Name:        "RoundTripLatency",
+		Description: "The mean latency of transaction round trips of closed-loop users",
+	}
+
+	// RoundTripTimeouts is a metric capturing the number of transactions sent by users of
+	// applications running in the closed-loop mode which have not been included in a block
+	// within the configured receipt timeout.
+	RoundTripTimeouts = monitoring.Metric[monitoring.User, monitoring.Series[monitoring.Time, int]]{
+		Name:        "RoundTripTimeouts",
+		Description: "The number of timed out transaction round trips of closed-loop users",
+	}
+)
+
+func init() {
+	if err := monitoring.RegisterSource(RoundTripLatency, newRoundTripLatencySource); err != nil {
+		panic(fmt.Sprintf("failed to register metric source: %v", err))
+	}
+	if err := monitoring.RegisterSource(RoundTripTimeouts, newRoundTripTimeoutsSource); err != nil {
+		panic(fmt.Sprintf("failed to register metric source: %v", err))
+	}
+}
+
+// newRoundTripLatencySource is an internal factory for the RoundTripLatency metric.
+func newRoundTripLatencySource(monitor *monitoring.Monitor) monitoring.Source[monitoring.User, monitoring.Series[monitoring.Time, time.Duration]] {
+	return NewPeriodicUserDataSource[time.Duration](RoundTripLatency, monitor, &roundTripLatencySensorFactory{})
+}
+
+// newRoundTripTimeoutsSource is an internal factory for the RoundTripTimeouts metric.
+func newRoundTripTimeoutsSource(monitor *monitoring.Monitor) monitoring.Source[monitoring.User, monitoring.Series[monitoring.Time, int]] {
+	return NewPeriodicUserDataSource[int](RoundTripTimeouts, monitor, &roundTripTimeoutsSensorFactory{})
+}
+
+type roundTripLatencySensorFactory struct{}
+
+func (f *roundTripLatencySensorFactory) CreateSensor(app driver.Application, user int) (utils.Sensor[time.Duration], error) {
+	if !app.Config().ClosedLoop {
+		return nil, nil
+	}
+	return &roundTripLatencySensor{
+		app:  app,
+		user: user,
+	}, nil
+}
+
+// roundTripLatencySensor reports the mean latency of the round trips completed
+// since its last reading.
+type roundTripLatencySensor struct {
+	app  driver.Application
+	user int
+	last driver.RoundTripStats
+}
+
+func (s *roundTripLatencySensor) ReadValue() (time.Duration, error) {
+	stats, err := s.app.GetRoundTripStats(s.user)
+	if err != nil {
+		return 0, err
+	}
+	completed := stats.Completed - s.last.Completed
+	latency := stats.Latency - s.last.Latency
+	s.last = stats
+	if completed == 0 {
+		return 0, nil
+	}
+	return latency / time.Duration(completed), nil
+}
+
+type roundTripTimeoutsSensorFactory struct{}
+
+func (f *roundTripTimeoutsSensorFactory) CreateSensor(app driver.Application, user int) (utils.Sensor[int], error) {
+	if !app.Config().ClosedLoop {
+		return nil, nil
+	}
+	return &roundTripTimeoutsSensor{
+		app:  app,
+		user: user,
+	}, nil
+}
+
+type roundTripTimeoutsSensor struct {
+	app  driver.Application
+	user int
+}
+
+func (s *roundTripTimeoutsSensor) ReadValue() (int, error) {
+	stats, err := s.app.GetRoundTripStats(s.user)
+	if err != nil {
+		return 0, err
+	}
+	return int(stats.TimedOut), nil
+}
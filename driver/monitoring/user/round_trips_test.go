@@ -0,0 +1,74 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/golang/mock/gomock"
+)
+
+func TestRoundTripLatencySensor_ReportsMeanOfNewRoundTrips(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	application := driver.NewMockApplication(ctrl)
+	application.EXPECT().Config().Return(&driver.ApplicationConfig{ClosedLoop: true})
+	gomock.InOrder(
+		application.EXPECT().GetRoundTripStats(1).Return(driver.RoundTripStats{Completed: 2, Latency: 4 * time.Second}, nil),
+		application.EXPECT().GetRoundTripStats(1).Return(driver.RoundTripStats{Completed: 2, Latency: 4 * time.Second}, nil),
+		application.EXPECT().GetRoundTripStats(1).Return(driver.RoundTripStats{Completed: 5, Latency: 10 * time.Second}, nil),
+	)
+
+	sensor, err := (&roundTripLatencySensorFactory{}).CreateSensor(application, 1)
+	if err != nil {
+		t.Fatalf("creation of sensor failed: %v", err)
+	}
+	for _, want := range []time.Duration{2 * time.Second, 0, 2 * time.Second} {
+		if got, err := sensor.ReadValue(); err != nil || got != want {
+			t.Errorf("sensor fetched wrong value, wanted %v, got %v, err %v", want, got, err)
+		}
+	}
+}
+
+func TestRoundTripTimeoutsSensor_ReportsTimedOutRoundTrips(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	application := driver.NewMockApplication(ctrl)
+	application.EXPECT().Config().Return(&driver.ApplicationConfig{ClosedLoop: true})
+	application.EXPECT().GetRoundTripStats(0).Return(driver.RoundTripStats{Completed: 2, TimedOut: 3}, nil)
+
+	sensor, err := (&roundTripTimeoutsSensorFactory{}).CreateSensor(application, 0)
+	if err != nil {
+		t.Fatalf("creation of sensor failed: %v", err)
+	}
+	if got, err := sensor.ReadValue(); err != nil || got != 3 {
+		t.Errorf("sensor fetched wrong value, wanted 3, got %d, err %v", got, err)
+	}
+}
+
+func TestRoundTripSensors_AreNotCreatedForOpenLoopApplications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	application := driver.NewMockApplication(ctrl)
+	application.EXPECT().Config().Return(&driver.ApplicationConfig{}).Times(2)
+
+	if sensor, err := (&roundTripLatencySensorFactory{}).CreateSensor(application, 0); err != nil || sensor != nil {
+		t.Errorf("unexpected latency sensor for open-loop application, got %v, err %v", sensor, err)
+	}
+	if sensor, err := (&roundTripTimeoutsSensorFactory{}).CreateSensor(application, 0); err != nil || sensor != nil {
+		t.Errorf("unexpected timeouts sensor for open-loop application, got %v, err %v", sensor, err)
+	}
+}
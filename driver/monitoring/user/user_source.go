@@ -26,7 +26,8 @@ import (
 	mon "github.com/Fantom-foundation/Norma/driver/monitoring"
 )
 
-// SensorFactory is a factory for sensors targeting selected users.
+// SensorFactory is a factory for sensors targeting selected users. Factories
+// may return a nil sensor for users not covered by the produced metric.
 type SensorFactory[T any] interface {
 	CreateSensor(driver.Application, int) (utils.Sensor[T], error)
 }
@@ -85,6 +86,9 @@ func (s *periodicUserDataSource[T]) AfterApplicationCreation(app driver.Applicat
 			log.Printf("failed to create sensor for metric %v / app %s / user %d: %v", s.GetMetric().Name, label, i, err)
 			return
 		}
+		if sensor == nil {
+			continue
+		}
 		s.AddSubject(mon.User{
 			App: label,
 			Id:  i,
@@ -17,8 +17,11 @@
 package driver
 
 import (
+	"time"
+
 	"github.com/Fantom-foundation/Norma/driver/parser"
 	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
@@ -61,9 +64,203 @@ type Network interface {
 
 	SendTransaction(tx *types.Transaction)
 
+	// GetTransactionSubmission obtains information on the submission of the
+	// transaction with the given hash to the network. The information is only
+	// available for recently submitted transactions.
+	GetTransactionSubmission(hash common.Hash) (TransactionSubmission, bool)
+
+	// GetTransactionSubmissionErrors obtains the number of failed submissions of
+	// transactions since the start of the network, grouped by the node the
+	// transactions were submitted to, the issuing application and the error class.
+	GetTransactionSubmissionErrors() map[SubmissionErrorKey]uint64
+
+	// GetTransactionSubmissionCounts obtains the number of transactions successfully
+	// submitted to each node since the start of the network, indexed by node labels.
+	GetTransactionSubmissionCounts() map[string]uint64
+
+	// SendQuery sends a read-only RPC request to a node of the network and stores
+	// the response in result. The latency and the outcome of the request are
+	// recorded for the node the request was sent to.
+	SendQuery(result interface{}, method string, args ...interface{}) error
+
+	// SendArchiveQuery is like SendQuery, but sends the request to an archive node,
+	// which is required for requests on the state of past blocks.
+	SendArchiveQuery(result interface{}, method string, args ...interface{}) error
+
+	// GetQueryStats obtains statistics on the read-only requests sent using SendQuery
+	// and SendArchiveQuery since the start of the network, grouped by node and RPC method.
+	GetQueryStats() map[QueryStatsKey]QueryStats
+
+	// GetNotificationStats obtains the number of received, missed, and duplicated
+	// notifications of the WebSocket subscriptions opened to the nodes of the
+	// network, grouped by node and the kind of the subscription.
+	GetNotificationStats() map[NotificationKey]NotificationStats
+
+	// GetNotificationTime obtains the time the notification of the given block has
+	// been delivered to all subscriptions of the given kind on the given node. The
+	// information is only available for recent blocks of subscriptions on blocks or logs.
+	GetNotificationTime(key NotificationKey, block uint64) (time.Time, bool)
+
+	// SetServiceLevelSource installs the source of service level indicators
+	// provided by this network, e.g. derived from monitoring data.
+	SetServiceLevelSource(source ServiceLevelSource)
+
+	// GetServiceLevel obtains the latest value of the given service level indicator
+	// from the installed source. False is returned if no value is available.
+	GetServiceLevel(indicator ServiceLevelIndicator) (ServiceLevel, bool)
+
 	DialRandomRpc() (rpc.RpcClient, error)
 }
 
+// ServiceLevelIndicator names an observation on the service level provided by a network.
+type ServiceLevelIndicator string
+
+const (
+	// TransactionLatencyP95 is the 95th percentile of the latency of recently included
+	// transactions, measured from their submission until their inclusion, in milliseconds.
+	TransactionLatencyP95 ServiceLevelIndicator = parser.LatencyP95Indicator
+	// TxPoolPending is the maximum number of pending transactions in the transaction
+	// pool of any node.
+	TxPoolPending ServiceLevelIndicator = parser.TxPoolPendingIndicator
+)
+
+// ServiceLevelIndicators lists all supported service level indicators.
+var ServiceLevelIndicators = []ServiceLevelIndicator{TransactionLatencyP95, TxPoolPending}
+
+// ServiceLevel is an observation of a service level indicator.
+type ServiceLevel struct {
+	// Value is the observed value of the indicator.
+	Value float64
+	// Position identifies the observation, e.g. the block or the time it was made
+	// for. It increases with every new observation of an indicator, allowing users
+	// to distinguish new observations from repeated queries of the same one.
+	Position uint64
+}
+
+// ServiceLevelSource provides the latest values of service level indicators.
+type ServiceLevelSource interface {
+	// GetServiceLevel returns the latest value of the given indicator, false if no
+	// value is available.
+	GetServiceLevel(indicator ServiceLevelIndicator) (ServiceLevel, bool)
+}
+
+// TransactionSubmission describes the submission of a transaction to the network.
+type TransactionSubmission struct {
+	// App is the name of the application issuing the transaction, empty if unknown.
+	App string
+	// Time is the time the transaction has been submitted to a node.
+	Time time.Time
+}
+
+// SubmissionErrorClass classifies the reasons for rejecting the submission of a transaction.
+type SubmissionErrorClass string
+
+const (
+	// SubmissionErrorNonceTooLow is reported for transactions with an already used nonce.
+	SubmissionErrorNonceTooLow SubmissionErrorClass = "NonceTooLow"
+	// SubmissionErrorUnderpriced is reported for transactions with an insufficient gas price.
+	SubmissionErrorUnderpriced SubmissionErrorClass = "Underpriced"
+	// SubmissionErrorTxPoolFull is reported if the transaction pool of the node is full.
+	SubmissionErrorTxPoolFull SubmissionErrorClass = "TxPoolFull"
+	// SubmissionErrorAlreadyKnown is reported for transactions already in the transaction pool.
+	SubmissionErrorAlreadyKnown SubmissionErrorClass = "AlreadyKnown"
+	// SubmissionErrorInsufficientFunds is reported if the sender cannot pay for the transaction.
+	SubmissionErrorInsufficientFunds SubmissionErrorClass = "InsufficientFunds"
+	// SubmissionErrorGasLimit is reported for transactions exceeding the block gas limit.
+	SubmissionErrorGasLimit SubmissionErrorClass = "GasLimit"
+	// SubmissionErrorInvalidSender is reported for transactions with an invalid signature.
+	SubmissionErrorInvalidSender SubmissionErrorClass = "InvalidSender"
+	// SubmissionErrorConnection is reported if the node could not be reached.
+	SubmissionErrorConnection SubmissionErrorClass = "Connection"
+	// SubmissionErrorOther is reported for all other errors.
+	SubmissionErrorOther SubmissionErrorClass = "Other"
+)
+
+// IndicatesOverload reports whether errors of this class are caused by the network
+// being unable to keep up with the load, i.e. a full or saturated transaction pool.
+func (c SubmissionErrorClass) IndicatesOverload() bool {
+	return c == SubmissionErrorTxPoolFull || c == SubmissionErrorUnderpriced
+}
+
+// SubmissionErrorClasses lists all classes of submission errors.
+var SubmissionErrorClasses = []SubmissionErrorClass{
+	SubmissionErrorNonceTooLow,
+	SubmissionErrorUnderpriced,
+	SubmissionErrorTxPoolFull,
+	SubmissionErrorAlreadyKnown,
+	SubmissionErrorInsufficientFunds,
+	SubmissionErrorGasLimit,
+	SubmissionErrorInvalidSender,
+	SubmissionErrorConnection,
+	SubmissionErrorOther,
+}
+
+// SubmissionErrorKey identifies a group of failed transaction submissions.
+type SubmissionErrorKey struct {
+	// Node is the label of the node the transactions were submitted to.
+	Node string
+	// App is the name of the application issuing the transactions, empty if unknown.
+	App string
+	// Class is the class of the reported error.
+	Class SubmissionErrorClass
+}
+
+// QueryStatsKey identifies a group of read-only requests sent to the network.
+type QueryStatsKey struct {
+	// Node is the label of the node the requests were sent to.
+	Node string
+	// Method is the RPC method of the requests.
+	Method string
+}
+
+// QueryStats summarizes read-only requests sent to the network.
+type QueryStats struct {
+	// Count is the number of sent requests.
+	Count uint64
+	// Errors is the number of requests which failed.
+	Errors uint64
+	// Latency is the total time spent waiting for the responses of the requests.
+	Latency time.Duration
+}
+
+// SubscriptionKind is a kind of WebSocket subscription offered by nodes.
+type SubscriptionKind string
+
+const (
+	// NewHeadsSubscription notifies about each new block.
+	NewHeadsSubscription SubscriptionKind = "newHeads"
+	// LogsSubscription notifies about each log matching a filter.
+	LogsSubscription SubscriptionKind = "logs"
+	// PendingTransactionsSubscription notifies about each transaction entering the
+	// transaction pool of the node.
+	PendingTransactionsSubscription SubscriptionKind = "newPendingTransactions"
+)
+
+// SubscriptionKinds lists all kinds of subscriptions.
+var SubscriptionKinds = []SubscriptionKind{
+	NewHeadsSubscription,
+	LogsSubscription,
+	PendingTransactionsSubscription,
+}
+
+// NotificationKey identifies the notifications of subscriptions of one kind on one node.
+type NotificationKey struct {
+	// Node is the label of the node the subscriptions were opened to.
+	Node string
+	// Kind is the kind of the subscriptions.
+	Kind SubscriptionKind
+}
+
+// NotificationStats summarizes the notifications received by subscriptions.
+type NotificationStats struct {
+	// Received is the number of received notifications.
+	Received uint64
+	// Missed is the number of notifications which should have been received, but were not.
+	Missed uint64
+	// Duplicated is the number of notifications received more than once by a subscription.
+	Duplicated uint64
+}
+
 // NetworkConfig is a collection of network parameters to be used by factories
 // creating network instances.
 type NetworkConfig struct {
@@ -73,8 +270,68 @@ type NetworkConfig struct {
 	StateDbImplementation string
 	// The name of the EVM implementation to be used by network nodes.
 	VmImplementation string
+	// RpcPool defines how transactions are sent to the nodes of the network.
+	RpcPool RpcPoolConfig
+	// Subscriptions defines the WebSocket subscriptions opened to the nodes of the network.
+	Subscriptions SubscriptionConfig
 }
 
+// SubscriptionConfig defines the WebSocket subscriptions opened to each node of the
+// network, mimicking the clients of dapps following the chain.
+type SubscriptionConfig struct {
+	// PerNode is the number of connections opened to each node, each connection
+	// subscribing to all configured kinds. No subscriptions are opened if zero.
+	PerNode int
+	// Kinds are the kinds of subscriptions of each connection, all kinds if empty.
+	Kinds []SubscriptionKind
+	// Topics are the topics of the events of interest for subscriptions on logs,
+	// all logs are subscribed to if empty.
+	Topics []common.Hash
+}
+
+// RpcPoolConfig defines how transactions produced by applications are distributed
+// among the nodes of the network. Zero values are replaced by defaults.
+type RpcPoolConfig struct {
+	// WorkersPerNode is the number of concurrent connections sending transactions
+	// to each node.
+	WorkersPerNode int
+	// Transport is the protocol used for sending transactions, WebSocket by default.
+	Transport RpcTransport
+	// Routing is the policy selecting the nodes receiving transactions.
+	Routing RpcRouting
+	// Weights are the weights of node groups used by the weighted routing. Nodes of
+	// groups not listed have the weight 1. Validators form the group "validator",
+	// other nodes are grouped by the name of the node configuration in the scenario.
+	Weights map[string]float32
+}
+
+// RpcTransport is a protocol used for sending transactions to nodes.
+type RpcTransport string
+
+const (
+	WsTransport   RpcTransport = "ws"
+	HttpTransport RpcTransport = "http"
+)
+
+// RpcRouting is a policy selecting the node a transaction is sent to.
+type RpcRouting string
+
+const (
+	// AnyNodeRouting sends transactions through any free worker of any node.
+	AnyNodeRouting RpcRouting = "any"
+	// RpcNodesRouting sends transactions through any free worker of a non-validator node.
+	RpcNodesRouting RpcRouting = "rpc-nodes"
+	// StickyRouting sends all transactions of a sender to the same node, such that
+	// they arrive at the node in the order of their nonces.
+	StickyRouting RpcRouting = "sticky"
+	// RoundRobinRouting assigns transactions to nodes in turn.
+	RoundRobinRouting RpcRouting = "round-robin"
+	// WeightedRouting assigns transactions to random nodes, each node selected with
+	// a probability proportional to the weight of its group. While no node of positive
+	// weight is available, transactions wait for such a node to join.
+	WeightedRouting RpcRouting = "weighted"
+)
+
 // NetworkListener can be registered to networks to get callbacks whenever there
 // are changes in the network.
 type NetworkListener interface {
@@ -88,8 +345,12 @@ type NetworkListener interface {
 
 type NodeConfig struct {
 	Name string
+
+	// Archive defines whether the node should maintain the history of the
+	// state and thus be able to answer queries on past blocks.
+	Archive bool
+
 	// TODO: add other parameters as needed
-	//  - features to include on the node
 	//  - state DB configuration
 	//  - EVM configuration
 }
@@ -103,9 +364,62 @@ type ApplicationConfig struct {
 	// Rate defines the Tx/s config the source should produce while active.
 	Rate *parser.Rate
 
+	// Transfer defines the parameters of applications of the transfer type,
+	// nil if defaults should be used.
+	Transfer *parser.Transfer
+
+	// Custom defines the contract and calls of applications of the custom type,
+	// nil for other application types.
+	Custom *parser.Custom
+
+	// Mix defines the components of applications of the mix type, nil for
+	// other application types.
+	Mix []parser.MixComponent
+
+	// NFT defines the proportions of operations of applications of the nft type,
+	// nil if defaults should be used.
+	NFT *parser.NFT
+
+	// Deployer defines the contracts deployed by applications of the deployer
+	// type, nil if defaults should be used.
+	Deployer *parser.Deployer
+
+	// Storage defines the state accessed by applications of the storage type,
+	// nil if defaults should be used.
+	Storage *parser.Storage
+
+	// Adversarial defines the transactions sent by applications of the adversarial
+	// type, nil if all categories should be sent.
+	Adversarial parser.Adversarial
+
+	// Query defines the read-only requests sent by applications of the query type,
+	// nil if all methods should be used.
+	Query *parser.Query
+
+	// TxType defines the type of the transactions sent by the users of the app,
+	// empty for legacy transactions.
+	TxType string
+
+	// Fees defines the fee cap and tip of dynamic-fee transactions, nil if
+	// defaults should be used.
+	Fees *parser.Fees
+
 	// Users defines the number of users sending transactions to the app.
 	Users int
 
+	// ClosedLoop defines whether users wait for each of their transactions to be
+	// included in a block before sending the next one. In this mode, the Rate
+	// is ignored.
+	ClosedLoop bool
+
+	// ThinkTime is the time users in closed-loop mode pause between receiving the
+	// confirmation of a transaction and sending the next one.
+	ThinkTime time.Duration
+
+	// ReceiptTimeout is the maximum time users in closed-loop mode wait for the
+	// confirmation of a transaction before sending the next one.
+	ReceiptTimeout time.Duration
+
 	// TODO: add other parameters as needed
 	//  - application type
 }
@@ -24,8 +24,10 @@ import (
 	"math/rand"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	rpc2 "github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/Fantom-foundation/Norma/driver/network/rpc"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -33,6 +35,7 @@ import (
 	"github.com/Fantom-foundation/Norma/driver"
 	"github.com/Fantom-foundation/Norma/driver/docker"
 	"github.com/Fantom-foundation/Norma/driver/node"
+	"github.com/Fantom-foundation/Norma/driver/parser"
 	"github.com/Fantom-foundation/Norma/load/app"
 	"github.com/Fantom-foundation/Norma/load/controller"
 	"github.com/Fantom-foundation/Norma/load/shaper"
@@ -74,6 +77,16 @@ type LocalNetwork struct {
 	listenerMutex sync.Mutex
 
 	rpcWorkerPool *rpc.RpcWorkerPool
+
+	// queryPool sends read-only requests of applications to the nodes.
+	queryPool *rpc.QueryPool
+
+	// subscriptionPool maintains WebSocket subscriptions to the nodes.
+	subscriptionPool *rpc.SubscriptionPool
+
+	// serviceLevelSource provides service level indicators, nil if not installed.
+	serviceLevelSource      driver.ServiceLevelSource
+	serviceLevelSourceMutex sync.Mutex
 }
 
 func NewLocalNetwork(config *driver.NetworkConfig) (*LocalNetwork, error) {
@@ -93,20 +106,33 @@ func NewLocalNetwork(config *driver.NetworkConfig) (*LocalNetwork, error) {
 		return nil, fmt.Errorf("failed to create primary account; %v", err)
 	}
 
+	// Subscriptions on logs follow the events of the applications, unless configured otherwise.
+	subscriptions := config.Subscriptions
+	if subscriptions.PerNode > 0 && len(subscriptions.Topics) == 0 {
+		subscriptions.Topics, err = app.GetEventTopics()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get event topics of applications; %v", err)
+		}
+	}
+
 	// Create the empty network.
 	net := &LocalNetwork{
-		docker:         client,
-		network:        dn,
-		config:         *config,
-		primaryAccount: primaryAccount,
-		nodes:          map[driver.NodeID]*node.OperaNode{},
-		apps:           []driver.Application{},
-		listeners:      map[driver.NetworkListener]bool{},
-		rpcWorkerPool:  rpc.NewRpcWorkerPool(),
+		docker:           client,
+		network:          dn,
+		config:           *config,
+		primaryAccount:   primaryAccount,
+		nodes:            map[driver.NodeID]*node.OperaNode{},
+		apps:             []driver.Application{},
+		listeners:        map[driver.NetworkListener]bool{},
+		rpcWorkerPool:    rpc.NewRpcWorkerPoolWithConfig(config.RpcPool),
+		queryPool:        rpc.NewQueryPool(),
+		subscriptionPool: rpc.NewSubscriptionPool(subscriptions),
 	}
 
 	// Let the RPC pool to start RPC workers when a node start.
 	net.RegisterListener(net.rpcWorkerPool)
+	net.RegisterListener(net.queryPool)
+	net.RegisterListener(net.subscriptionPool)
 
 	// Start all validators.
 	net.validators = make([]*node.OperaNode, config.NumberOfValidators)
@@ -178,6 +204,7 @@ func (n *LocalNetwork) CreateNode(config *driver.NodeConfig) (driver.Node, error
 		Label:            config.Name,
 		NetworkConfig:    &n.config,
 		VmImplementation: n.config.VmImplementation,
+		Archive:          config.Archive,
 	})
 }
 
@@ -210,6 +237,76 @@ func (n *LocalNetwork) SendTransaction(tx *types.Transaction) {
 	n.rpcWorkerPool.SendTransaction(tx)
 }
 
+func (n *LocalNetwork) GetTransactionSubmission(hash common.Hash) (driver.TransactionSubmission, bool) {
+	return n.rpcWorkerPool.GetTransactionSubmission(hash)
+}
+
+func (n *LocalNetwork) GetTransactionSubmissionErrors() map[driver.SubmissionErrorKey]uint64 {
+	return n.rpcWorkerPool.GetTransactionSubmissionErrors()
+}
+
+func (n *LocalNetwork) GetTransactionSubmissionCounts() map[string]uint64 {
+	return n.rpcWorkerPool.GetTransactionSubmissionCounts()
+}
+
+func (n *LocalNetwork) SendQuery(result interface{}, method string, args ...interface{}) error {
+	return n.queryPool.Send(result, method, args...)
+}
+
+func (n *LocalNetwork) SendArchiveQuery(result interface{}, method string, args ...interface{}) error {
+	return n.queryPool.SendToArchive(result, method, args...)
+}
+
+func (n *LocalNetwork) GetQueryStats() map[driver.QueryStatsKey]driver.QueryStats {
+	return n.queryPool.GetStats()
+}
+
+func (n *LocalNetwork) GetNotificationStats() map[driver.NotificationKey]driver.NotificationStats {
+	return n.subscriptionPool.GetNotificationStats()
+}
+
+func (n *LocalNetwork) GetNotificationTime(key driver.NotificationKey, block uint64) (time.Time, bool) {
+	return n.subscriptionPool.GetNotificationTime(key, block)
+}
+
+func (n *LocalNetwork) SetServiceLevelSource(source driver.ServiceLevelSource) {
+	n.serviceLevelSourceMutex.Lock()
+	defer n.serviceLevelSourceMutex.Unlock()
+	n.serviceLevelSource = source
+}
+
+func (n *LocalNetwork) GetServiceLevel(indicator driver.ServiceLevelIndicator) (driver.ServiceLevel, bool) {
+	n.serviceLevelSourceMutex.Lock()
+	source := n.serviceLevelSource
+	n.serviceLevelSourceMutex.Unlock()
+	if source == nil {
+		return driver.ServiceLevel{}, false
+	}
+	return source.GetServiceLevel(indicator)
+}
+
+// applicationNetwork is a view on the network used by a single application. Transactions
+// sent through it are attributed to the application, and only submission errors of the
+// application are reported.
+type applicationNetwork struct {
+	*LocalNetwork
+	app string
+}
+
+func (n *applicationNetwork) SendTransaction(tx *types.Transaction) {
+	n.rpcWorkerPool.SendApplicationTransaction(n.app, tx)
+}
+
+func (n *applicationNetwork) GetTransactionSubmissionErrors() map[driver.SubmissionErrorKey]uint64 {
+	res := map[driver.SubmissionErrorKey]uint64{}
+	for key, count := range n.LocalNetwork.GetTransactionSubmissionErrors() {
+		if key.App == n.app {
+			res[key] = count
+		}
+	}
+	return res
+}
+
 func (n *LocalNetwork) DialRandomRpc() (rpc2.RpcClient, error) {
 	nodes := n.GetActiveNodes()
 	return nodes[rand.Intn(len(nodes))].DialRpc()
@@ -271,10 +368,83 @@ func (a *localApplication) GetSentTransactions(user int) (uint64, error) {
 	return a.controller.GetTransactionsSentBy(user)
 }
 
+func (a *localApplication) GetSentTransactionLog(user int) (driver.SentTransactionLog, error) {
+	return a.controller.GetTransactionLogOf(user)
+}
+
+func (a *localApplication) GetRoundTripStats(user int) (driver.RoundTripStats, error) {
+	return a.controller.GetRoundTripStats(user)
+}
+
+func (a *localApplication) GetLoadStats() (driver.LoadStats, error) {
+	return a.controller.GetLoadStats(), nil
+}
+
 func (a *localApplication) GetReceivedTransactions() (uint64, error) {
 	return a.controller.GetReceivedTransactions()
 }
 
+func (a *localApplication) GetComponentStats() ([]driver.ComponentStats, error) {
+	return a.controller.GetComponentStats()
+}
+
+func (a *localApplication) GetExpectedRejections() map[string]uint64 {
+	return a.controller.GetExpectedRejections()
+}
+
+func (a *localApplication) GetExpectedSubmissionErrors() map[string]uint64 {
+	return a.controller.GetExpectedSubmissionErrors()
+}
+
+func (a *localApplication) IsExpectedToFail(hash common.Hash) bool {
+	return a.controller.IsExpectedToFail(hash)
+}
+
+func (a *localApplication) Verify() error {
+	return a.controller.Verify()
+}
+
+func (a *localApplication) VerifyAt(rpcClient rpc2.RpcClient, blockNumber uint64) error {
+	return a.controller.VerifyAt(rpcClient, blockNumber)
+}
+
+// getAppConfig derives the type-specific parameters of an on-chain application
+// from the given application configuration.
+func getAppConfig(config *driver.ApplicationConfig) app.Config {
+	res := app.Config{}
+	if transfer := config.Transfer; transfer != nil {
+		res.Transfer = transfer.AppConfig()
+	}
+	if custom := config.Custom; custom != nil {
+		res.Custom = custom.AppConfig()
+	}
+	if nft := config.NFT; nft != nil {
+		res.NFT = nft.AppConfig()
+	}
+	if storage := config.Storage; storage != nil {
+		res.Storage = storage.AppConfig()
+	}
+	if adversarial := config.Adversarial; adversarial != nil {
+		res.Adversarial = adversarial.AppConfig()
+	}
+	if query := config.Query; query != nil {
+		res.Query = query.AppConfig()
+	}
+	if fees := config.Fees; fees != nil {
+		res.Tx = fees.AppConfig()
+	}
+	res.Tx.Type = config.TxType
+	deployer := config.Deployer
+	if deployer == nil {
+		deployer = &parser.Deployer{}
+	}
+	res.Deployer = deployer.AppConfig()
+	for _, component := range config.Mix {
+		res.Mix = append(res.Mix, component.AppConfig())
+	}
+	return res
+}
+
 func (n *LocalNetwork) CreateApplication(config *driver.ApplicationConfig) (driver.Application, error) {
 	rpcClient, err := n.dialRandomValidatorRpc()
 	if err != nil {
@@ -283,17 +453,26 @@ func (n *LocalNetwork) CreateApplication(config *driver.ApplicationConfig) (driv
 	defer rpcClient.Close()
 
 	appId := n.nextAppId.Add(1)
-	application, err := app.NewApplication(config.Type, rpcClient, n.primaryAccount, config.Users, 0, appId)
+	application, err := app.NewApplication(config.Type, rpcClient, n.primaryAccount, config.Users, 0, appId, getAppConfig(config))
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize on-chain app; %v", err)
 	}
 
-	sh, err := shaper.ParseRate(config.Rate)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse shaper; %v", err)
+	var appController *controller.AppController
+	appNetwork := &applicationNetwork{n, config.Name}
+	if config.ClosedLoop {
+		appController, err = controller.NewClosedLoopAppController(application, config.Users, appNetwork, controller.ClosedLoopConfig{
+			ThinkTime:      config.ThinkTime,
+			ReceiptTimeout: config.ReceiptTimeout,
+		})
+	} else {
+		var sh shaper.Shaper
+		sh, err = shaper.ParseRate(config.Rate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse shaper; %v", err)
+		}
+		appController, err = controller.NewAppController(application, sh, config.Users, appNetwork)
 	}
-
-	appController, err := controller.NewAppController(application, sh, config.Users, n)
 	if err != nil {
 		return nil, err
 	}
@@ -380,6 +559,8 @@ func (n *LocalNetwork) Shutdown() error {
 	}
 
 	errs = append(errs, n.rpcWorkerPool.Close())
+	errs = append(errs, n.queryPool.Close())
+	errs = append(errs, n.subscriptionPool.Close())
 
 	return errors.Join(errs...)
 }
@@ -0,0 +1,255 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// notificationRetention is the number of recent blocks the delivery times of
+	// notifications are retained for.
+	notificationRetention = 1000
+	// pendingTxRetention is the number of recent transactions tracked by each
+	// subscription on pending transactions to detect duplicated notifications.
+	pendingTxRetention = 10_000
+)
+
+// notificationLog records the notifications received by all subscriptions of one
+// kind on one node. Instances are thread-safe.
+type notificationLog struct {
+	stats  driver.NotificationStats
+	times  map[uint64]time.Time // < the time the latest subscription received a block
+	latest uint64               // < the latest block with a recorded delivery time
+	mutex  sync.Mutex
+}
+
+func newNotificationLog() *notificationLog {
+	return &notificationLog{times: map[uint64]time.Time{}}
+}
+
+// received registers a notification received by a subscription.
+func (l *notificationLog) received() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.stats.Received++
+}
+
+// delivered registers the first notification of the given block received by a
+// subscription. The delivery time of the block is the time of the latest subscription.
+func (l *notificationLog) delivered(block uint64, now time.Time) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if block+notificationRetention <= l.latest {
+		return
+	}
+	if previous, found := l.times[block]; !found || now.After(previous) {
+		l.times[block] = now
+	}
+	if block > l.latest {
+		for old := range l.times {
+			if old+notificationRetention <= block {
+				delete(l.times, old)
+			}
+		}
+		l.latest = block
+	}
+}
+
+func (l *notificationLog) missed(count uint64) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.stats.Missed += count
+}
+
+func (l *notificationLog) duplicated(count uint64) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.stats.Duplicated += count
+}
+
+func (l *notificationLog) getStats() driver.NotificationStats {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.stats
+}
+
+func (l *notificationLog) getTime(block uint64) (time.Time, bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	res, found := l.times[block]
+	return res, found
+}
+
+// headNotification is the part of a newHeads notification used for tracking.
+type headNotification struct {
+	Number hexutil.Uint64 `json:"number"`
+	Hash   common.Hash    `json:"hash"`
+}
+
+// logNotification is the part of a logs notification, or of a log returned by
+// eth_getLogs, used for tracking.
+type logNotification struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	TxHash      common.Hash    `json:"transactionHash"`
+	Index       hexutil.Uint   `json:"logIndex"`
+	Removed     bool           `json:"removed"`
+}
+
+// logKey identifies a log within the chain.
+type logKey struct {
+	tx    common.Hash
+	index uint
+}
+
+// headTracker tracks the blocks announced to a single subscription on new heads.
+// Instances are not thread-safe.
+type headTracker struct {
+	log     *notificationLog
+	last    uint64
+	started bool
+}
+
+// observe checks that the blocks are announced without gaps and repetitions.
+func (t *headTracker) observe(head headNotification, now time.Time) {
+	t.log.received()
+	number := uint64(head.Number)
+	switch {
+	case !t.started:
+		t.started = true
+	case number <= t.last:
+		t.log.duplicated(1)
+		return
+	case number > t.last+1:
+		t.log.missed(number - t.last - 1)
+	}
+	t.last = number
+	t.log.delivered(number, now)
+}
+
+// logTracker tracks the logs announced to a single subscription on logs. Logs are
+// kept until they are compared with the logs of the chain by verify.
+// Instances are thread-safe.
+type logTracker struct {
+	log      *notificationLog
+	since    uint64 // < the first block a log has been received for
+	last     uint64
+	started  bool
+	received map[uint64]map[logKey]bool
+	mutex    sync.Mutex
+}
+
+func newLogTracker(log *notificationLog) *logTracker {
+	return &logTracker{log: log, received: map[uint64]map[logKey]bool{}}
+}
+
+// observe records the given log, checking that it has not been announced before.
+func (t *logTracker) observe(entry logNotification, now time.Time) {
+	if entry.Removed {
+		return
+	}
+	t.log.received()
+	block := uint64(entry.BlockNumber)
+	key := logKey{tx: entry.TxHash, index: uint(entry.Index)}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if !t.started {
+		t.started = true
+		t.since = block
+	}
+	if block < t.since {
+		return
+	}
+	logs, found := t.received[block]
+	if !found {
+		logs = map[logKey]bool{}
+		t.received[block] = logs
+	}
+	if logs[key] {
+		t.log.duplicated(1)
+		return
+	}
+	logs[key] = true
+	if block > t.last {
+		t.last = block
+		t.log.delivered(block, now)
+	}
+}
+
+// verify compares the logs received for the blocks in the given range with the
+// expected logs, counting expected logs not received as missed. Blocks before the
+// first received log are not verified. Verified blocks are dropped afterwards.
+func (t *logTracker) verify(from, to uint64, expected map[uint64][]logKey) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if !t.started {
+		return
+	}
+	missed := uint64(0)
+	for block := from; block <= to; block++ {
+		if block < t.since {
+			continue
+		}
+		for _, key := range expected[block] {
+			if !t.received[block][key] {
+				missed++
+			}
+		}
+	}
+	for block := range t.received {
+		if block <= to {
+			delete(t.received, block)
+		}
+	}
+	if missed > 0 {
+		t.log.missed(missed)
+	}
+}
+
+// pendingTxTracker tracks the transactions announced to a single subscription on
+// pending transactions. Since the transactions entering the pool of a node are not
+// known in advance, only duplicated notifications are detected.
+// Instances are not thread-safe.
+type pendingTxTracker struct {
+	log   *notificationLog
+	seen  map[common.Hash]bool
+	order []common.Hash
+}
+
+func newPendingTxTracker(log *notificationLog) *pendingTxTracker {
+	return &pendingTxTracker{log: log, seen: map[common.Hash]bool{}}
+}
+
+func (t *pendingTxTracker) observe(hash common.Hash) {
+	t.log.received()
+	if t.seen[hash] {
+		t.log.duplicated(1)
+		return
+	}
+	t.seen[hash] = true
+	t.order = append(t.order, hash)
+	if len(t.order) > pendingTxRetention {
+		delete(t.seen, t.order[0])
+		t.order = t.order[1:]
+	}
+}
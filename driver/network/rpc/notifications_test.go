@@ -0,0 +1,147 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestHeadTracker_GapsAndRepetitionsAreDetected(t *testing.T) {
+	log := newNotificationLog()
+	tracker := &headTracker{log: log}
+	now := time.Now()
+	for _, number := range []uint64{5, 6, 6, 9, 10, 8} {
+		tracker.observe(headNotification{Number: hexutil.Uint64(number)}, now)
+	}
+	want := driver.NotificationStats{Received: 6, Missed: 2, Duplicated: 2}
+	if got := log.getStats(); got != want {
+		t.Errorf("unexpected stats, wanted %v, got %v", want, got)
+	}
+}
+
+func TestNotificationLog_DeliveryTimeIsTimeOfLatestSubscription(t *testing.T) {
+	log := newNotificationLog()
+	first := &headTracker{log: log}
+	second := &headTracker{log: log}
+	start := time.Now()
+	first.observe(headNotification{Number: 1}, start)
+	second.observe(headNotification{Number: 1}, start.Add(time.Second))
+	first.observe(headNotification{Number: 2}, start.Add(2*time.Second))
+
+	if got, found := log.getTime(1); !found || !got.Equal(start.Add(time.Second)) {
+		t.Errorf("unexpected delivery time of block 1: %v, %t", got, found)
+	}
+	if got, found := log.getTime(2); !found || !got.Equal(start.Add(2*time.Second)) {
+		t.Errorf("unexpected delivery time of block 2: %v, %t", got, found)
+	}
+	if _, found := log.getTime(3); found {
+		t.Errorf("block without notifications should not have a delivery time")
+	}
+}
+
+func TestNotificationLog_OldBlocksAreDropped(t *testing.T) {
+	log := newNotificationLog()
+	now := time.Now()
+	log.delivered(1, now)
+	log.delivered(notificationRetention, now)
+	if _, found := log.getTime(1); !found {
+		t.Errorf("block within retention should be retained")
+	}
+	log.delivered(notificationRetention+1, now)
+	if _, found := log.getTime(1); found {
+		t.Errorf("block beyond retention should be dropped")
+	}
+}
+
+func TestLogTracker_DuplicatedAndMissedLogsAreDetected(t *testing.T) {
+	log := newNotificationLog()
+	tracker := newLogTracker(log)
+	now := time.Now()
+	entry := func(block uint64, tx byte, index uint) logNotification {
+		return logNotification{BlockNumber: hexutil.Uint64(block), TxHash: common.Hash{tx}, Index: hexutil.Uint(index)}
+	}
+	tracker.observe(entry(10, 1, 0), now)
+	tracker.observe(entry(10, 1, 1), now)
+	tracker.observe(entry(10, 1, 1), now)
+	tracker.observe(entry(12, 2, 0), now)
+	tracker.observe(logNotification{BlockNumber: 12, TxHash: common.Hash{3}, Removed: true}, now)
+
+	expected := map[uint64][]logKey{
+		9:  {{tx: common.Hash{9}}}, // before the first notification, not verified
+		10: {{tx: common.Hash{1}, index: 0}, {tx: common.Hash{1}, index: 1}},
+		11: {{tx: common.Hash{4}}},
+		12: {{tx: common.Hash{2}}, {tx: common.Hash{5}}},
+	}
+	tracker.verify(9, 12, expected)
+
+	want := driver.NotificationStats{Received: 4, Missed: 2, Duplicated: 1}
+	if got := log.getStats(); got != want {
+		t.Errorf("unexpected stats, wanted %v, got %v", want, got)
+	}
+	if len(tracker.received) != 0 {
+		t.Errorf("verified blocks should be dropped, got %v", tracker.received)
+	}
+}
+
+func TestPendingTxTracker_DuplicatesAreDetected(t *testing.T) {
+	log := newNotificationLog()
+	tracker := newPendingTxTracker(log)
+	for _, hash := range []common.Hash{{1}, {2}, {1}, {3}} {
+		tracker.observe(hash)
+	}
+	want := driver.NotificationStats{Received: 4, Duplicated: 1}
+	if got := log.getStats(); got != want {
+		t.Errorf("unexpected stats, wanted %v, got %v", want, got)
+	}
+}
+
+func TestSubscriptionPool_NoSubscriptionsAreOpenedByDefault(t *testing.T) {
+	pool := NewSubscriptionPool(driver.SubscriptionConfig{})
+	pool.AfterNodeCreation(nil) // < the node is not accessed if no subscriptions are configured
+	if got := pool.GetNotificationStats(); len(got) != 0 {
+		t.Errorf("unexpected notification stats: %v", got)
+	}
+	if err := pool.Close(); err != nil {
+		t.Errorf("failed to close pool: %v", err)
+	}
+}
+
+func TestNodeSubscriptions_TrackersOfEndedLogSubscriptionsAreRemoved(t *testing.T) {
+	subscriptions := &nodeSubscriptions{}
+	first := newLogTracker(newNotificationLog())
+	second := newLogTracker(newNotificationLog())
+	subscriptions.addLogTracker(first)
+	subscriptions.addLogTracker(second)
+	verified := subscriptions.logTrackers
+
+	subscriptions.removeLogTracker(first)
+	if got := subscriptions.logTrackers; len(got) != 1 || got[0] != second {
+		t.Errorf("unexpected log trackers after removal: %v", got)
+	}
+	if len(verified) != 2 || verified[0] != first {
+		t.Errorf("trackers obtained before the removal should not be modified, got %v", verified)
+	}
+	subscriptions.removeLogTracker(second)
+	if got := subscriptions.logTrackers; len(got) != 0 {
+		t.Errorf("unexpected log trackers after removal: %v", got)
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	rpc2 "github.com/Fantom-foundation/Norma/driver/rpc"
+)
+
+// QueryPool sends read-only requests to randomly selected nodes of the network, or
+// archive nodes only for requests on past states, and records the latency and the outcome of the requests per node and RPC method.
+// Connections to nodes are established on their first request and kept open until
+// the node leaves the network. Instances are thread-safe.
+type QueryPool struct {
+	nodes   []driver.Node
+	clients map[driver.Node]rpc2.RpcClient
+	stats   map[driver.QueryStatsKey]driver.QueryStats
+	closed  bool
+	mutex   sync.Mutex
+}
+
+func NewQueryPool() *QueryPool {
+	return &QueryPool{
+		clients: map[driver.Node]rpc2.RpcClient{},
+		stats:   map[driver.QueryStatsKey]driver.QueryStats{},
+	}
+}
+
+// Send sends the given request to a random node of the network and stores the
+// response in result. Requests failing to connect to the node are recorded as
+// errors without latency.
+func (p *QueryPool) Send(result interface{}, method string, args ...interface{}) error {
+	return p.send(false, result, method, args...)
+}
+
+// SendToArchive is like Send, but only considers archive nodes for the request.
+func (p *QueryPool) SendToArchive(result interface{}, method string, args ...interface{}) error {
+	return p.send(true, result, method, args...)
+}
+
+func (p *QueryPool) send(archive bool, result interface{}, method string, args ...interface{}) error {
+	node, err := p.pickNode(archive)
+	if err != nil {
+		return err
+	}
+	key := driver.QueryStatsKey{Node: node.GetLabel(), Method: method}
+
+	rpcClient, err := p.getClient(node)
+	if err != nil {
+		p.record(key, 0, err)
+		return err
+	}
+
+	start := time.Now()
+	err = rpcClient.Call(result, method, args...)
+	p.record(key, time.Since(start), err)
+	return err
+}
+
+// GetStats obtains the statistics of all requests sent so far, grouped by the node
+// and the RPC method.
+func (p *QueryPool) GetStats() map[driver.QueryStatsKey]driver.QueryStats {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	res := make(map[driver.QueryStatsKey]driver.QueryStats, len(p.stats))
+	for key, stats := range p.stats {
+		res[key] = stats
+	}
+	return res
+}
+
+func (p *QueryPool) pickNode(archive bool) (driver.Node, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	candidates := p.nodes
+	if archive {
+		candidates = make([]driver.Node, 0, len(p.nodes))
+		for _, node := range p.nodes {
+			if node.IsArchive() {
+				candidates = append(candidates, node)
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		if archive {
+			return nil, fmt.Errorf("no archive node available for sending queries")
+		}
+		return nil, fmt.Errorf("no node available for sending queries")
+	}
+	return candidates[rand.Intn(len(candidates))], nil
+}
+
+// getClient obtains the connection to the given node, dialing the node if no
+// connection has been established yet.
+func (p *QueryPool) getClient(node driver.Node) (rpc2.RpcClient, error) {
+	p.mutex.Lock()
+	rpcClient, found := p.clients[node]
+	p.mutex.Unlock()
+	if found {
+		return rpcClient, nil
+	}
+
+	// dialing may take a while, other requests are not blocked in the meantime
+	rpcClient, err := node.DialRpc()
+	if err != nil {
+		return nil, err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if existing, found := p.clients[node]; found {
+		rpcClient.Close()
+		return existing, nil
+	}
+	if p.closed || !p.contains(node) {
+		rpcClient.Close()
+		return nil, fmt.Errorf("node %s has left the network", node.GetLabel())
+	}
+	p.clients[node] = rpcClient
+	return rpcClient, nil
+}
+
+func (p *QueryPool) contains(node driver.Node) bool {
+	for _, cur := range p.nodes {
+		if cur == node {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *QueryPool) record(key driver.QueryStatsKey, latency time.Duration, err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	stats := p.stats[key]
+	stats.Count++
+	stats.Latency += latency
+	if err != nil {
+		stats.Errors++
+	}
+	p.stats[key] = stats
+}
+
+func (p *QueryPool) AfterNodeCreation(node driver.Node) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.closed {
+		return
+	}
+	p.nodes = append(p.nodes, node)
+}
+
+func (p *QueryPool) AfterNodeRemoval(node driver.Node) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for i, cur := range p.nodes {
+		if cur == node {
+			p.nodes = append(p.nodes[:i], p.nodes[i+1:]...)
+			break
+		}
+	}
+	if rpcClient, found := p.clients[node]; found {
+		rpcClient.Close()
+		delete(p.clients, node)
+	}
+}
+
+func (p *QueryPool) AfterApplicationCreation(application driver.Application) {
+	// ignored
+}
+
+// Close closes the connections to all nodes. Requests sent afterwards fail.
+func (p *QueryPool) Close() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for _, rpcClient := range p.clients {
+		rpcClient.Close()
+	}
+	p.clients = map[driver.Node]rpc2.RpcClient{}
+	p.nodes = nil
+	p.closed = true
+	return nil
+}
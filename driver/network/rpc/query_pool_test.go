@@ -0,0 +1,128 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	rpc2 "github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/golang/mock/gomock"
+)
+
+func TestQueryPool_StatsAreRecordedPerNodeAndMethod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rpcClient := rpc2.NewMockRpcClient(ctrl)
+	rpcClient.EXPECT().Call(gomock.Any(), "eth_getBalance", gomock.Any()).Times(3).Return(nil)
+	rpcClient.EXPECT().Call(gomock.Any(), "eth_call", gomock.Any()).Return(errors.New("execution reverted"))
+	rpcClient.EXPECT().Close()
+
+	node := driver.NewMockNode(ctrl)
+	node.EXPECT().GetLabel().AnyTimes().Return("node-1")
+	node.EXPECT().DialRpc().Times(1).Return(rpcClient, nil)
+
+	pool := NewQueryPool()
+	pool.AfterNodeCreation(node)
+	for i := 0; i < 3; i++ {
+		if err := pool.Send(nil, "eth_getBalance", "0x0"); err != nil {
+			t.Fatalf("failed to send query: %v", err)
+		}
+	}
+	if err := pool.Send(nil, "eth_call", "0x0"); err == nil {
+		t.Errorf("failure of query should be reported")
+	}
+
+	stats := pool.GetStats()
+	if got := stats[driver.QueryStatsKey{Node: "node-1", Method: "eth_getBalance"}]; got.Count != 3 || got.Errors != 0 {
+		t.Errorf("unexpected stats of eth_getBalance: %v", got)
+	}
+	if got := stats[driver.QueryStatsKey{Node: "node-1", Method: "eth_call"}]; got.Count != 1 || got.Errors != 1 {
+		t.Errorf("unexpected stats of eth_call: %v", got)
+	}
+	if err := pool.Close(); err != nil {
+		t.Errorf("failed to close pool: %v", err)
+	}
+}
+
+func TestQueryPool_FailedConnectionsAreRecordedAsErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	node := driver.NewMockNode(ctrl)
+	node.EXPECT().GetLabel().AnyTimes().Return("node-1")
+	node.EXPECT().DialRpc().Return(nil, errors.New("connection refused"))
+
+	pool := NewQueryPool()
+	pool.AfterNodeCreation(node)
+	if err := pool.Send(nil, "eth_getLogs"); err == nil {
+		t.Errorf("failed connection should be reported")
+	}
+	if got := pool.GetStats()[driver.QueryStatsKey{Node: "node-1", Method: "eth_getLogs"}]; got.Count != 1 || got.Errors != 1 || got.Latency != 0 {
+		t.Errorf("unexpected stats: %v", got)
+	}
+}
+
+func TestQueryPool_ArchiveQueriesAreOnlySentToArchiveNodes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rpcClient := rpc2.NewMockRpcClient(ctrl)
+	rpcClient.EXPECT().Call(gomock.Any(), "eth_getBalance", "0x0", "0x1").Times(10).Return(nil)
+	rpcClient.EXPECT().Close()
+
+	regular := driver.NewMockNode(ctrl)
+	regular.EXPECT().IsArchive().AnyTimes().Return(false)
+	archive := driver.NewMockNode(ctrl)
+	archive.EXPECT().GetLabel().AnyTimes().Return("archive")
+	archive.EXPECT().IsArchive().AnyTimes().Return(true)
+	archive.EXPECT().DialRpc().Return(rpcClient, nil)
+
+	pool := NewQueryPool()
+	pool.AfterNodeCreation(regular)
+	pool.AfterNodeCreation(archive)
+	for i := 0; i < 10; i++ {
+		if err := pool.SendToArchive(nil, "eth_getBalance", "0x0", "0x1"); err != nil {
+			t.Fatalf("failed to send query: %v", err)
+		}
+	}
+	if got := pool.GetStats()[driver.QueryStatsKey{Node: "archive", Method: "eth_getBalance"}]; got.Count != 10 {
+		t.Errorf("unexpected stats of archive node: %v", got)
+	}
+
+	pool.AfterNodeRemoval(archive)
+	if err := pool.SendToArchive(nil, "eth_getBalance", "0x0", "0x1"); err == nil {
+		t.Errorf("sending archive queries without archive nodes should fail")
+	}
+}
+
+func TestQueryPool_RemovedNodesAreNotQueried(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rpcClient := rpc2.NewMockRpcClient(ctrl)
+	rpcClient.EXPECT().Call(gomock.Any(), "eth_call").Return(nil)
+	rpcClient.EXPECT().Close()
+
+	node := driver.NewMockNode(ctrl)
+	node.EXPECT().GetLabel().AnyTimes().Return("node-1")
+	node.EXPECT().DialRpc().Return(rpcClient, nil)
+
+	pool := NewQueryPool()
+	pool.AfterNodeCreation(node)
+	if err := pool.Send(nil, "eth_call"); err != nil {
+		t.Fatalf("failed to send query: %v", err)
+	}
+	pool.AfterNodeRemoval(node)
+	if err := pool.Send(nil, "eth_call"); err == nil {
+		t.Errorf("sending queries without nodes should fail")
+	}
+}
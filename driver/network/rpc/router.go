@@ -0,0 +1,179 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"math/rand"
+	"strings"
+	"sync"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// validatorGroup is the name of the node group all validators are part of.
+const validatorGroup = "validator"
+
+// routesPerNode returns true if the given routing policy assigns transactions
+// to individual nodes instead of using a queue shared by all nodes.
+func routesPerNode(routing driver.RpcRouting) bool {
+	return routing == driver.StickyRouting || routing == driver.RoundRobinRouting || routing == driver.WeightedRouting
+}
+
+// nodeQueue is the queue of transactions assigned to a single node.
+type nodeQueue struct {
+	node    driver.Node
+	txs     chan pendingTransaction
+	weight  float64
+	removed chan struct{} // < closed when the node is removed from the network
+}
+
+func newNodeQueue(node driver.Node, weight float64) *nodeQueue {
+	return &nodeQueue{
+		node:    node,
+		txs:     make(chan pendingTransaction),
+		weight:  weight,
+		removed: make(chan struct{}),
+	}
+}
+
+// router selects the node a transaction is sent to according to a routing policy.
+type router struct {
+	routing driver.RpcRouting
+	queues  []*nodeQueue
+	next    int                           // < the next queue to be used by round-robin routing
+	senders map[common.Address]*nodeQueue // < the queues assigned to senders by sticky routing
+	mutex   sync.Mutex
+}
+
+func newRouter(routing driver.RpcRouting) *router {
+	return &router{
+		routing: routing,
+		senders: map[common.Address]*nodeQueue{},
+	}
+}
+
+// add registers the queue of a new node.
+func (r *router) add(queue *nodeQueue) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.queues = append(r.queues, queue)
+}
+
+// remove unregisters the queue of the given node and notifies senders waiting
+// on this queue to route their transactions again.
+func (r *router) remove(node driver.Node) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for i, queue := range r.queues {
+		if queue.node != node {
+			continue
+		}
+		close(queue.removed)
+		r.queues = append(r.queues[:i], r.queues[i+1:]...)
+		for sender, assigned := range r.senders {
+			if assigned == queue {
+				delete(r.senders, sender)
+			}
+		}
+		return
+	}
+}
+
+// route selects the queue of the node the given transaction should be sent to.
+// If there is no such node, nil is returned.
+func (r *router) route(tx *types.Transaction) *nodeQueue {
+	// The sender is recovered before acquiring the lock since the signature
+	// recovery is expensive and would serialize all sending goroutines.
+	var sender common.Address
+	var senderErr error
+	if r.routing == driver.StickyRouting {
+		sender, senderErr = types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if len(r.queues) == 0 {
+		return nil
+	}
+	switch r.routing {
+	case driver.StickyRouting:
+		if senderErr != nil {
+			return r.nextQueue() // unsigned transactions are not bound to a node
+		}
+		if queue, found := r.senders[sender]; found {
+			return queue
+		}
+		queue := r.nextQueue()
+		r.senders[sender] = queue
+		return queue
+	case driver.WeightedRouting:
+		return r.weightedQueue()
+	default:
+		return r.nextQueue()
+	}
+}
+
+// nextQueue selects the queues in turn, the mutex must be held by the caller.
+func (r *router) nextQueue() *nodeQueue {
+	queue := r.queues[r.next%len(r.queues)]
+	r.next = (r.next + 1) % len(r.queues)
+	return queue
+}
+
+// weightedQueue selects a random queue with a probability proportional to its weight,
+// the mutex must be held by the caller. If all weights are zero, nil is returned.
+func (r *router) weightedQueue() *nodeQueue {
+	total := 0.0
+	for _, queue := range r.queues {
+		total += queue.weight
+	}
+	if total <= 0 {
+		return nil
+	}
+	selected := rand.Float64() * total
+	for _, queue := range r.queues {
+		if selected < queue.weight {
+			return queue
+		}
+		selected -= queue.weight
+	}
+	return r.queues[len(r.queues)-1] // only reached due to rounding errors
+}
+
+// getNodeGroup obtains the name of the group of the given node. Validators form
+// their own group, other nodes are grouped by the name of their node configuration,
+// which is the label of the node without the instance number.
+func getNodeGroup(node driver.Node) string {
+	if node.IsValidator() {
+		return validatorGroup
+	}
+	label := node.GetLabel()
+	if i := strings.LastIndex(label, "-"); i > 0 {
+		return label[:i]
+	}
+	return label
+}
+
+// getNodeWeight obtains the weight of the given node for the weighted routing.
+func getNodeWeight(node driver.Node, weights map[string]float32) float64 {
+	if weight, found := weights[getNodeGroup(node)]; found {
+		return float64(weight)
+	}
+	return 1
+}
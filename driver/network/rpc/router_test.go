@@ -0,0 +1,201 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/golang/mock/gomock"
+)
+
+func TestRouter_RoundRobinSelectsNodesInTurn(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	queues := makeQueues(ctrl, 3)
+	r := newRouter(driver.RoundRobinRouting)
+	for _, queue := range queues {
+		r.add(queue)
+	}
+
+	tx := types.NewTx(&types.LegacyTx{})
+	for i := 0; i < 6; i++ {
+		if got, want := r.route(tx), queues[i%3]; got != want {
+			t.Errorf("unexpected queue selected in step %d", i)
+		}
+	}
+}
+
+func TestRouter_StickyRoutingKeepsSendersAtTheirNode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	queues := makeQueues(ctrl, 3)
+	r := newRouter(driver.StickyRouting)
+	for _, queue := range queues {
+		r.add(queue)
+	}
+
+	txA, txB := makeSignedTx(t), makeSignedTx(t)
+	queueA, queueB := r.route(txA), r.route(txB)
+	if queueA == queueB {
+		t.Fatalf("different senders should be assigned to different nodes in turn")
+	}
+	for i := 0; i < 5; i++ {
+		if r.route(txA) != queueA || r.route(txB) != queueB {
+			t.Errorf("senders should stick to their nodes")
+		}
+	}
+
+	// once the node of a sender is removed, the sender is assigned to another node
+	r.remove(queueA.node)
+	select {
+	case <-queueA.removed:
+	default:
+		t.Errorf("removal of the queue should be signaled")
+	}
+	if got := r.route(txA); got == queueA || got == nil {
+		t.Errorf("sender of a removed node should be assigned to a remaining node")
+	}
+	if r.route(txB) != queueB {
+		t.Errorf("senders of remaining nodes should stick to their nodes")
+	}
+}
+
+func TestRouter_WeightedRoutingRespectsWeights(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	queues := makeQueues(ctrl, 3)
+	queues[0].weight = 0
+	queues[1].weight = 1
+	queues[2].weight = 3
+	r := newRouter(driver.WeightedRouting)
+	for _, queue := range queues {
+		r.add(queue)
+	}
+
+	counts := map[*nodeQueue]int{}
+	tx := types.NewTx(&types.LegacyTx{})
+	for i := 0; i < 4000; i++ {
+		counts[r.route(tx)]++
+	}
+	if counts[queues[0]] != 0 {
+		t.Errorf("nodes with zero weight should not be selected")
+	}
+	if got := counts[queues[2]]; got < 2700 || got > 3300 {
+		t.Errorf("node with weight 3 of 4 should receive about 3000 of 4000 transactions, got %d", got)
+	}
+}
+
+func TestRouter_EmptyRouterSelectsNoNode(t *testing.T) {
+	for _, routing := range []driver.RpcRouting{driver.RoundRobinRouting, driver.StickyRouting, driver.WeightedRouting} {
+		if got := newRouter(routing).route(makeSignedTx(t)); got != nil {
+			t.Errorf("no node should be selected by %v routing without nodes", routing)
+		}
+	}
+}
+
+func TestRpcWorkerPool_TransactionsAreRoutedToNodeQueues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	queues := makeQueues(ctrl, 2)
+	pool := NewRpcWorkerPoolWithConfig(driver.RpcPoolConfig{Routing: driver.RoundRobinRouting})
+	for _, queue := range queues {
+		pool.router.add(queue)
+	}
+
+	tx := types.NewTx(&types.LegacyTx{})
+	for i := 0; i < 4; i++ {
+		go pool.SendApplicationTransaction("app", tx)
+		select {
+		case got := <-queues[i%2].txs:
+			if got.tx != tx || got.app != "app" {
+				t.Errorf("unexpected transaction received: %v", got)
+			}
+		case <-pool.txs:
+			t.Errorf("transaction should not be put in the shared queue")
+		}
+	}
+}
+
+func TestTakesSharedTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	validator := driver.NewMockNode(ctrl)
+	validator.EXPECT().IsValidator().AnyTimes().Return(true)
+	rpcNode := driver.NewMockNode(ctrl)
+	rpcNode.EXPECT().IsValidator().AnyTimes().Return(false)
+
+	tests := []struct {
+		routing driver.RpcRouting
+		node    driver.Node
+		weight  float64
+		want    bool
+	}{
+		{driver.RoundRobinRouting, validator, 0, true},
+		{driver.RpcNodesRouting, validator, 1, false},
+		{driver.RpcNodesRouting, rpcNode, 1, true},
+		{driver.WeightedRouting, validator, 1, true},
+		{driver.WeightedRouting, rpcNode, 0, false},
+	}
+	for _, test := range tests {
+		if got := takesSharedTransactions(test.routing, test.node, test.weight); got != test.want {
+			t.Errorf("unexpected result for %v routing and weight %f, wanted %t, got %t", test.routing, test.weight, test.want, got)
+		}
+	}
+}
+
+func TestGetNodeGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tests := map[string]string{"rpc-1": "rpc", "my-rpc-12": "my-rpc", "A": "A"}
+	for label, want := range tests {
+		node := driver.NewMockNode(ctrl)
+		node.EXPECT().IsValidator().Return(false)
+		node.EXPECT().GetLabel().Return(label)
+		if got := getNodeGroup(node); got != want {
+			t.Errorf("unexpected group of node %s, wanted %s, got %s", label, want, got)
+		}
+	}
+
+	validator := driver.NewMockNode(ctrl)
+	validator.EXPECT().IsValidator().AnyTimes().Return(true)
+	if got, want := getNodeGroup(validator), validatorGroup; got != want {
+		t.Errorf("unexpected group of validator, wanted %s, got %s", want, got)
+	}
+	weights := map[string]float32{validatorGroup: 0.5}
+	if got, want := getNodeWeight(validator, weights), 0.5; got != want {
+		t.Errorf("unexpected weight of validator, wanted %f, got %f", want, got)
+	}
+}
+
+func makeQueues(ctrl *gomock.Controller, n int) []*nodeQueue {
+	res := make([]*nodeQueue, n)
+	for i := range res {
+		res[i] = newNodeQueue(driver.NewMockNode(ctrl), 1)
+	}
+	return res
+}
+
+func makeSignedTx(t *testing.T) *types.Transaction {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	signer := types.NewEIP155Signer(big.NewInt(0xfa3))
+	tx, err := types.SignTx(types.NewTx(&types.LegacyTx{}), signer, key)
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	return tx
+}
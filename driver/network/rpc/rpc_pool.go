@@ -25,30 +25,109 @@ import (
 
 	"github.com/Fantom-foundation/Norma/driver"
 	"github.com/Fantom-foundation/Norma/driver/node"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
+// defaultWorkersPerNode is the number of workers started for each node if not configured.
+const defaultWorkersPerNode = 150
+
+// RpcWorkerPool sends transactions to the nodes of the network using a configurable
+// number of workers per node. Depending on the routing policy, transactions are either
+// put in a queue shared by the workers of all nodes, or assigned to individual nodes.
 type RpcWorkerPool struct {
-	txs     chan *types.Transaction
-	workers map[driver.Node]*workerGroup
-	ctx     context.Context
-	cancel  context.CancelFunc
+	config      driver.RpcPoolConfig
+	txs         chan pendingTransaction // < the queue shared by the workers of all nodes
+	workers     map[driver.Node]*workerGroup
+	router      *router
+	submissions *submissionLog
+	ctx         context.Context
+	cancel      context.CancelFunc
+}
+
+// pendingTransaction is a transaction waiting to be sent by one of the workers.
+type pendingTransaction struct {
+	tx  *types.Transaction
+	app string // < the name of the application issuing the transaction, if known
 }
 
 func NewRpcWorkerPool() *RpcWorkerPool {
+	return NewRpcWorkerPoolWithConfig(driver.RpcPoolConfig{})
+}
+
+// NewRpcWorkerPoolWithConfig is the same as NewRpcWorkerPool but with a customizable
+// number of workers, transport, and routing of transactions.
+func NewRpcWorkerPoolWithConfig(config driver.RpcPoolConfig) *RpcWorkerPool {
 	ctx, cancel := context.WithCancel(context.Background())
 
+	if config.WorkersPerNode <= 0 {
+		config.WorkersPerNode = defaultWorkersPerNode
+	}
+	if config.Transport == "" {
+		config.Transport = driver.WsTransport
+	}
+	if config.Routing == "" {
+		config.Routing = driver.AnyNodeRouting
+	}
+
 	return &RpcWorkerPool{
-		txs:     make(chan *types.Transaction),
-		workers: make(map[driver.Node]*workerGroup, 10),
-		ctx:     ctx,
-		cancel:  cancel,
+		config:      config,
+		txs:         make(chan pendingTransaction),
+		workers:     make(map[driver.Node]*workerGroup, 10),
+		router:      newRouter(config.Routing),
+		submissions: newSubmissionLog(submissionRetention),
+		ctx:         ctx,
+		cancel:      cancel,
 	}
 }
 
 func (p *RpcWorkerPool) SendTransaction(tx *types.Transaction) {
-	p.txs <- tx
+	p.SendApplicationTransaction("", tx)
+}
+
+// SendApplicationTransaction sends the given transaction on behalf of the given
+// application. The time the transaction is submitted to a node is recorded and
+// can be retrieved using GetTransactionSubmission.
+func (p *RpcWorkerPool) SendApplicationTransaction(app string, tx *types.Transaction) {
+	pending := pendingTransaction{tx: tx, app: app}
+	if !routesPerNode(p.config.Routing) {
+		p.txs <- pending
+		return
+	}
+	for {
+		queue := p.router.route(tx)
+		if queue == nil {
+			// no node to route to, the transaction is sent by the first suitable node joining the network
+			p.txs <- pending
+			return
+		}
+		select {
+		case queue.txs <- pending:
+			return
+		case <-queue.removed:
+			// the node has left the network in the meantime, the transaction is routed again
+		}
+	}
+}
+
+// GetTransactionSubmission obtains the time and the application of the submission
+// of the transaction with the given hash. Only recently submitted transactions are
+// retained.
+func (p *RpcWorkerPool) GetTransactionSubmission(hash common.Hash) (driver.TransactionSubmission, bool) {
+	return p.submissions.get(hash)
+}
+
+// GetTransactionSubmissionErrors obtains the number of failed submissions of transactions
+// grouped by the node, the application and the class of the error.
+func (p *RpcWorkerPool) GetTransactionSubmissionErrors() map[driver.SubmissionErrorKey]uint64 {
+	return p.submissions.getErrors()
+}
+
+// GetTransactionSubmissionCounts obtains the number of transactions successfully
+// submitted to each node, indexed by the node labels.
+func (p *RpcWorkerPool) GetTransactionSubmissionCounts() map[string]uint64 {
+	return p.submissions.getCounts()
 }
 
 func (p *RpcWorkerPool) AfterNodeCreation(newNode driver.Node) {
@@ -56,19 +135,52 @@ func (p *RpcWorkerPool) AfterNodeCreation(newNode driver.Node) {
 		return
 	}
 
-	rpcUrl := newNode.GetServiceUrl(&node.OperaWsService)
+	service := &node.OperaWsService
+	if p.config.Transport == driver.HttpTransport {
+		service = &node.OperaRpcService
+	}
+	rpcUrl := newNode.GetServiceUrl(service)
 	if rpcUrl == nil {
 		return
 	}
+
+	weight := getNodeWeight(newNode, p.config.Weights)
+	shared := p.txs
+	if !takesSharedTransactions(p.config.Routing, newNode, weight) {
+		shared = nil
+	}
+	queue := newNodeQueue(newNode, weight)
+
 	wg := workerGroup{}
 	p.workers[newNode] = &wg
-	for i := 0; i < 150; i++ {
-		wg.add(*rpcUrl, p.txs)
+	submissions := &nodeSubmissionLog{log: p.submissions, node: newNode.GetLabel()}
+	for i := 0; i < p.config.WorkersPerNode; i++ {
+		wg.add(*rpcUrl, queue.txs, shared, submissions)
+	}
+	p.router.add(queue)
+}
+
+// takesSharedTransactions determines whether the workers of the given node take
+// transactions from the shared queue. Validators do not if transactions are to be sent
+// to non-validator nodes only, and nodes of zero weight do not under the weighted
+// routing, where the shared queue only holds transactions waiting for a node of
+// positive weight.
+func takesSharedTransactions(routing driver.RpcRouting, node driver.Node, weight float64) bool {
+	switch routing {
+	case driver.RpcNodesRouting:
+		return !node.IsValidator()
+	case driver.WeightedRouting:
+		return weight > 0
+	default:
+		return true
 	}
 }
 
 func (p *RpcWorkerPool) AfterNodeRemoval(node driver.Node) {
-	p.workers[node].close()
+	p.router.remove(node)
+	if wg, found := p.workers[node]; found {
+		wg.close()
+	}
 }
 
 func (p *RpcWorkerPool) AfterApplicationCreation(application driver.Application) {
@@ -95,8 +207,8 @@ func (p *RpcWorkerPool) Close() error {
 // When the group is closed, it should not be re-used and should be forgotten.
 type workerGroup []*worker
 
-func (wg *workerGroup) add(rpcUrl driver.URL, txs chan *types.Transaction) {
-	w := newWorker(rpcUrl, txs)
+func (wg *workerGroup) add(rpcUrl driver.URL, own, shared chan pendingTransaction, submissions *nodeSubmissionLog) {
+	w := newWorker(rpcUrl, own, shared, submissions)
 	*wg = append(*wg, w)
 }
 
@@ -114,28 +226,34 @@ func (wg *workerGroup) close() {
 }
 
 // worker maintains one worker that sends transactions to an RPC client.
-// It listens to incoming transactions and sends them to the client.
+// It listens to incoming transactions assigned to its node as well as
+// transactions in the shared queue, if any, and sends them to the client.
 // The worker can be closed, and it stops listening and sending the transactions.
 // The worker is initialised (i.e. the RPC connection is established) before
 // it starts dispatching asynchronously. This process can be interrupted by
 // closing the worker before it starts dispatching.
+// Successful and failed submissions are recorded in the submission log, if provided.
 type worker struct {
-	rpcUrl driver.URL
-	done   chan bool
-	txs    chan *types.Transaction
-	ctx    context.Context
-	cancel context.CancelFunc
+	rpcUrl      driver.URL
+	done        chan bool
+	own         chan pendingTransaction // < transactions assigned to the node of the worker
+	shared      chan pendingTransaction // < transactions to be sent by any node, may be nil
+	submissions *nodeSubmissionLog
+	ctx         context.Context
+	cancel      context.CancelFunc
 }
 
-func newWorker(rpcUrl driver.URL, txs chan *types.Transaction) *worker {
+func newWorker(rpcUrl driver.URL, own, shared chan pendingTransaction, submissions *nodeSubmissionLog) *worker {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	w := &worker{
-		rpcUrl: rpcUrl,
-		done:   make(chan bool),
-		txs:    txs,
-		ctx:    ctx,
-		cancel: cancel,
+		rpcUrl:      rpcUrl,
+		done:        make(chan bool),
+		own:         own,
+		shared:      shared,
+		submissions: submissions,
+		ctx:         ctx,
+		cancel:      cancel,
 	}
 
 	go func() {
@@ -172,13 +290,28 @@ func (p *worker) runRpcSenderLoop() error {
 	defer rpcClient.Close()
 	for {
 		select {
-		case tx := <-p.txs:
-			err := rpcClient.SendTransaction(context.Background(), tx)
-			if err != nil {
-				log.Printf("failed to send tx; %v", err)
-			}
+		case pending := <-p.own:
+			p.send(rpcClient, pending)
+		case pending := <-p.shared:
+			p.send(rpcClient, pending)
 		case <-p.ctx.Done():
 			return nil
 		}
 	}
 }
+
+// send submits the given transaction using the given client and records the result.
+// Failures are not logged individually, they are classified and counted by the
+// submission log instead.
+func (p *worker) send(rpcClient *ethclient.Client, pending pendingTransaction) {
+	submitted := time.Now()
+	err := rpcClient.SendTransaction(context.Background(), pending.tx)
+	if p.submissions == nil {
+		return
+	}
+	if err != nil {
+		p.submissions.failed(pending, err)
+	} else {
+		p.submissions.submitted(pending, submitted)
+	}
+}
@@ -28,8 +28,8 @@ func TestRetryRpcReturnGracefully(t *testing.T) {
 	t.Parallel()
 
 	start := time.Now()
-	txs := make(chan *types.Transaction)
-	w := newWorker("wrong", txs)
+	txs := make(chan pendingTransaction)
+	w := newWorker("wrong", txs, nil, nil)
 
 	time.Sleep(6 * time.Second)
 	w.close()
@@ -79,16 +79,16 @@ func TestClosePool(t *testing.T) {
 }
 
 func TestCloseWorkerStartStop(t *testing.T) {
-	txs := make(chan *types.Transaction)
-	w := newWorker("wrong", txs)
+	txs := make(chan pendingTransaction)
+	w := newWorker("wrong", txs, nil, nil)
 	w.close()
 }
 
 func TestCloseWorkerGroupStartStop(t *testing.T) {
-	txs := make(chan *types.Transaction)
+	txs := make(chan pendingTransaction)
 	wg := workerGroup{}
 	for i := 0; i < 150; i++ {
-		wg.add("wrong", txs)
+		wg.add("wrong", txs, nil, nil)
 	}
 	wg.close()
 }
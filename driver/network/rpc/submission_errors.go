@@ -0,0 +1,65 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/Fantom-foundation/Norma/driver"
+)
+
+// submissionErrorMessages maps fragments of error messages reported by the
+// transaction pool of nodes to the respective error classes.
+var submissionErrorMessages = []struct {
+	fragment string
+	class    driver.SubmissionErrorClass
+}{
+	{"nonce too low", driver.SubmissionErrorNonceTooLow},
+	{"underpriced", driver.SubmissionErrorUnderpriced},
+	{"txpool is full", driver.SubmissionErrorTxPoolFull},
+	{"transaction pool is full", driver.SubmissionErrorTxPoolFull},
+	{"already known", driver.SubmissionErrorAlreadyKnown},
+	{"known transaction", driver.SubmissionErrorAlreadyKnown},
+	{"insufficient funds", driver.SubmissionErrorInsufficientFunds},
+	{"exceeds block gas limit", driver.SubmissionErrorGasLimit},
+	{"invalid sender", driver.SubmissionErrorInvalidSender},
+	{"connection refused", driver.SubmissionErrorConnection},
+	{"connection reset", driver.SubmissionErrorConnection},
+	{"broken pipe", driver.SubmissionErrorConnection},
+	{"use of closed network connection", driver.SubmissionErrorConnection},
+	{"websocket: close", driver.SubmissionErrorConnection},
+}
+
+// classifySubmissionError determines the class of an error reported when
+// submitting a transaction to a node.
+func classifySubmissionError(err error) driver.SubmissionErrorClass {
+	message := strings.ToLower(err.Error())
+	for _, cur := range submissionErrorMessages {
+		if strings.Contains(message, cur.fragment) {
+			return cur.class
+		}
+	}
+	var netErr net.Error
+	if errors.Is(err, io.EOF) || errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr) {
+		return driver.SubmissionErrorConnection
+	}
+	return driver.SubmissionErrorOther
+}
@@ -0,0 +1,49 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver"
+)
+
+func TestClassifySubmissionError(t *testing.T) {
+	tests := map[error]driver.SubmissionErrorClass{
+		errors.New("nonce too low"):                                         driver.SubmissionErrorNonceTooLow,
+		errors.New("transaction underpriced"):                               driver.SubmissionErrorUnderpriced,
+		errors.New("replacement transaction underpriced"):                   driver.SubmissionErrorUnderpriced,
+		errors.New("txpool is full"):                                        driver.SubmissionErrorTxPoolFull,
+		errors.New("already known"):                                         driver.SubmissionErrorAlreadyKnown,
+		errors.New("insufficient funds for gas * price + value"):            driver.SubmissionErrorInsufficientFunds,
+		errors.New("exceeds block gas limit"):                               driver.SubmissionErrorGasLimit,
+		errors.New("invalid sender"):                                        driver.SubmissionErrorInvalidSender,
+		errors.New("dial tcp 127.0.0.1:18545: connect: connection refused"): driver.SubmissionErrorConnection,
+		fmt.Errorf("failed to read; %w", io.EOF):                            driver.SubmissionErrorConnection,
+		&net.OpError{Op: "read", Err: errors.New("timeout")}:                driver.SubmissionErrorConnection,
+		errors.New("execution reverted"):                                    driver.SubmissionErrorOther,
+	}
+	for err, want := range tests {
+		if got := classifySubmissionError(err); got != want {
+			t.Errorf("unexpected class of error %q, wanted %v, got %v", err, want, got)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// submissionRetention is the time for which submissions are retained in the log.
+// Transactions not included in a block within this period are not expected to be
+// looked up anymore.
+const submissionRetention = 10 * time.Minute
+
+// submissionLog records the time transactions have been submitted to the network,
+// and counts failed submissions. Entries of successful submissions are retained for
+// a limited period of time only to keep the memory usage bounded during long-running
+// scenarios.
+type submissionLog struct {
+	entries   map[common.Hash]driver.TransactionSubmission
+	order     []common.Hash // < hashes in the order they were submitted
+	errors    map[driver.SubmissionErrorKey]uint64
+	counts    map[string]uint64 // < number of successful submissions per node
+	retention time.Duration
+	mutex     sync.Mutex
+}
+
+func newSubmissionLog(retention time.Duration) *submissionLog {
+	return &submissionLog{
+		entries:   map[common.Hash]driver.TransactionSubmission{},
+		errors:    map[driver.SubmissionErrorKey]uint64{},
+		counts:    map[string]uint64{},
+		retention: retention,
+	}
+}
+
+// add records the submission of the transaction with the given hash, and drops
+// all submissions older than the retention period.
+func (l *submissionLog) add(hash common.Hash, submission driver.TransactionSubmission) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.entries[hash] = submission
+	l.order = append(l.order, hash)
+
+	limit := submission.Time.Add(-l.retention)
+	for len(l.order) > 0 {
+		oldest, found := l.entries[l.order[0]]
+		if found && !oldest.Time.Before(limit) {
+			break
+		}
+		delete(l.entries, l.order[0])
+		l.order = l.order[1:]
+	}
+}
+
+// addCount counts a successful submission to the given node.
+func (l *submissionLog) addCount(node string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.counts[node]++
+}
+
+// getCounts obtains a snapshot of the counters of successful submissions per node.
+func (l *submissionLog) getCounts() map[string]uint64 {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	res := make(map[string]uint64, len(l.counts))
+	for node, count := range l.counts {
+		res[node] = count
+	}
+	return res
+}
+
+// get obtains the submission of the transaction with the given hash, if known.
+func (l *submissionLog) get(hash common.Hash) (driver.TransactionSubmission, bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	res, found := l.entries[hash]
+	return res, found
+}
+
+// addError counts a failed submission.
+func (l *submissionLog) addError(key driver.SubmissionErrorKey) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.errors[key]++
+}
+
+// getErrors obtains a snapshot of the counters of failed submissions.
+func (l *submissionLog) getErrors() map[driver.SubmissionErrorKey]uint64 {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	res := make(map[driver.SubmissionErrorKey]uint64, len(l.errors))
+	for key, count := range l.errors {
+		res[key] = count
+	}
+	return res
+}
+
+// nodeSubmissionLog records the submissions of transactions to a single node.
+type nodeSubmissionLog struct {
+	log  *submissionLog
+	node string
+}
+
+func (l *nodeSubmissionLog) submitted(tx pendingTransaction, time time.Time) {
+	l.log.add(tx.tx.Hash(), driver.TransactionSubmission{App: tx.app, Time: time})
+	l.log.addCount(l.node)
+}
+
+func (l *nodeSubmissionLog) failed(tx pendingTransaction, err error) {
+	l.log.addError(driver.SubmissionErrorKey{
+		Node:  l.node,
+		App:   tx.app,
+		Class: classifySubmissionError(err),
+	})
+}
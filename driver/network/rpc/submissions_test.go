@@ -0,0 +1,108 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestSubmissionLog_SubmissionsCanBeRetrieved(t *testing.T) {
+	log := newSubmissionLog(time.Minute)
+	now := time.Now()
+	log.add(common.Hash{1}, driver.TransactionSubmission{App: "A", Time: now})
+	log.add(common.Hash{2}, driver.TransactionSubmission{Time: now})
+
+	if got, found := log.get(common.Hash{1}); !found || got.App != "A" || !got.Time.Equal(now) {
+		t.Errorf("unexpected submission of the first transaction: %v, %t", got, found)
+	}
+	if got, found := log.get(common.Hash{2}); !found || got.App != "" || !got.Time.Equal(now) {
+		t.Errorf("unexpected submission of the second transaction: %v, %t", got, found)
+	}
+	if _, found := log.get(common.Hash{3}); found {
+		t.Errorf("unknown transaction should not be found")
+	}
+}
+
+func TestSubmissionLog_OldSubmissionsAreDropped(t *testing.T) {
+	log := newSubmissionLog(time.Minute)
+	now := time.Now()
+	log.add(common.Hash{1}, driver.TransactionSubmission{Time: now.Add(-2 * time.Minute)})
+	log.add(common.Hash{2}, driver.TransactionSubmission{Time: now.Add(-30 * time.Second)})
+	log.add(common.Hash{3}, driver.TransactionSubmission{Time: now})
+
+	if _, found := log.get(common.Hash{1}); found {
+		t.Errorf("submission older than the retention period should be dropped")
+	}
+	for _, hash := range []common.Hash{{2}, {3}} {
+		if _, found := log.get(hash); !found {
+			t.Errorf("recent submission %v should be retained", hash)
+		}
+	}
+	if got, want := len(log.order), 2; got != want {
+		t.Errorf("unexpected number of retained submissions, wanted %d, got %d", want, got)
+	}
+}
+
+func TestSubmissionLog_FailedSubmissionsAreCounted(t *testing.T) {
+	log := newSubmissionLog(time.Minute)
+	node1 := &nodeSubmissionLog{log: log, node: "node-1"}
+	node2 := &nodeSubmissionLog{log: log, node: "node-2"}
+
+	node1.failed(pendingTransaction{app: "A"}, errors.New("nonce too low"))
+	node1.failed(pendingTransaction{app: "A"}, errors.New("nonce too low"))
+	node1.failed(pendingTransaction{app: "B"}, errors.New("txpool is full"))
+	node2.failed(pendingTransaction{app: "A"}, errors.New("nonce too low"))
+
+	want := map[driver.SubmissionErrorKey]uint64{
+		{Node: "node-1", App: "A", Class: driver.SubmissionErrorNonceTooLow}: 2,
+		{Node: "node-1", App: "B", Class: driver.SubmissionErrorTxPoolFull}:  1,
+		{Node: "node-2", App: "A", Class: driver.SubmissionErrorNonceTooLow}: 1,
+	}
+	got := log.getErrors()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected error counters, wanted %v, got %v", want, got)
+	}
+
+	// the result is a snapshot not affected by later errors
+	node2.failed(pendingTransaction{}, errors.New("unknown"))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("snapshot of error counters has been modified")
+	}
+}
+
+func TestSubmissionLog_SuccessfulSubmissionsAreCountedPerNode(t *testing.T) {
+	log := newSubmissionLog(time.Minute)
+	node1 := &nodeSubmissionLog{log: log, node: "node-1"}
+	node2 := &nodeSubmissionLog{log: log, node: "node-2"}
+
+	tx := types.NewTx(&types.LegacyTx{})
+	node1.submitted(pendingTransaction{tx: tx}, time.Now())
+	node1.submitted(pendingTransaction{tx: tx}, time.Now())
+	node2.submitted(pendingTransaction{tx: tx}, time.Now())
+
+	want := map[string]uint64{"node-1": 2, "node-2": 1}
+	if got := log.getCounts(); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected submission counters, wanted %v, got %v", want, got)
+	}
+}
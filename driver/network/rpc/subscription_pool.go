@@ -0,0 +1,423 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/network"
+	"github.com/Fantom-foundation/Norma/driver/node"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// notificationBuffer is the number of notifications buffered by each subscription.
+	notificationBuffer = 100
+	// logVerificationPeriod is the period in which received logs are compared with
+	// the logs of the chain to detect missed notifications.
+	logVerificationPeriod = 2 * time.Second
+	// logVerificationLag is the number of latest blocks excluded from the verification
+	// of logs, since their notifications may still be in flight.
+	logVerificationLag = 10
+	// maxLogVerificationRange is the maximum number of blocks verified at once.
+	maxLogVerificationRange = 100
+	// minResubscribeBackoff is the initial time waited before re-establishing failed
+	// subscriptions, doubled for each consecutive failure.
+	minResubscribeBackoff = time.Second
+	// maxResubscribeBackoff is the maximum time waited before re-establishing failed
+	// subscriptions. Subscriptions running for longer reset the backoff.
+	maxResubscribeBackoff = 30 * time.Second
+)
+
+// SubscriptionPool opens a configurable number of WebSocket connections to each node
+// of the network, each subscribing to new heads, logs, and pending transactions. The
+// notifications are checked for gaps and repetitions, and the time blocks are
+// delivered to the subscriptions is recorded. Instances are thread-safe.
+type SubscriptionPool struct {
+	config driver.SubscriptionConfig
+	nodes  map[driver.Node]*nodeSubscriptions
+	logs   map[driver.NotificationKey]*notificationLog // < retained after nodes are removed
+	closed bool
+	mutex  sync.Mutex
+}
+
+func NewSubscriptionPool(config driver.SubscriptionConfig) *SubscriptionPool {
+	if len(config.Kinds) == 0 {
+		config.Kinds = driver.SubscriptionKinds
+	}
+	return &SubscriptionPool{
+		config: config,
+		nodes:  map[driver.Node]*nodeSubscriptions{},
+		logs:   map[driver.NotificationKey]*notificationLog{},
+	}
+}
+
+// GetNotificationStats obtains the number of received, missed, and duplicated
+// notifications grouped by node and kind of subscription.
+func (p *SubscriptionPool) GetNotificationStats() map[driver.NotificationKey]driver.NotificationStats {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	res := make(map[driver.NotificationKey]driver.NotificationStats, len(p.logs))
+	for key, notifications := range p.logs {
+		res[key] = notifications.getStats()
+	}
+	return res
+}
+
+// GetNotificationTime obtains the time the given block has been delivered to all
+// subscriptions of the given kind on the given node.
+func (p *SubscriptionPool) GetNotificationTime(key driver.NotificationKey, block uint64) (time.Time, bool) {
+	p.mutex.Lock()
+	notifications, found := p.logs[key]
+	p.mutex.Unlock()
+	if !found {
+		return time.Time{}, false
+	}
+	return notifications.getTime(block)
+}
+
+func (p *SubscriptionPool) AfterNodeCreation(newNode driver.Node) {
+	if p.config.PerNode <= 0 {
+		return
+	}
+	url := newNode.GetServiceUrl(&node.OperaWsService)
+	if url == nil {
+		return
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.closed {
+		return
+	}
+	logs := map[driver.SubscriptionKind]*notificationLog{}
+	for _, kind := range p.config.Kinds {
+		key := driver.NotificationKey{Node: newNode.GetLabel(), Kind: kind}
+		if _, found := p.logs[key]; !found {
+			p.logs[key] = newNotificationLog()
+		}
+		logs[kind] = p.logs[key]
+	}
+	p.nodes[newNode] = startNodeSubscriptions(*url, p.config, logs)
+}
+
+func (p *SubscriptionPool) AfterNodeRemoval(node driver.Node) {
+	p.mutex.Lock()
+	subscriptions, found := p.nodes[node]
+	delete(p.nodes, node)
+	p.mutex.Unlock()
+	if found {
+		subscriptions.close()
+	}
+}
+
+func (p *SubscriptionPool) AfterApplicationCreation(application driver.Application) {
+	// ignored
+}
+
+// Close closes all subscriptions. Statistics remain available afterwards.
+func (p *SubscriptionPool) Close() error {
+	p.mutex.Lock()
+	nodes := p.nodes
+	p.nodes = map[driver.Node]*nodeSubscriptions{}
+	p.closed = true
+	p.mutex.Unlock()
+	for _, subscriptions := range nodes {
+		subscriptions.close()
+	}
+	return nil
+}
+
+// nodeSubscriptions maintains the connections to a single node and verifies the
+// logs received by the subscriptions of the connections.
+type nodeSubscriptions struct {
+	url    driver.URL
+	config driver.SubscriptionConfig
+	logs   map[driver.SubscriptionKind]*notificationLog
+
+	logTrackers      []*logTracker
+	logTrackersMutex sync.Mutex
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   sync.WaitGroup
+}
+
+func startNodeSubscriptions(url driver.URL, config driver.SubscriptionConfig, logs map[driver.SubscriptionKind]*notificationLog) *nodeSubscriptions {
+	ctx, cancel := context.WithCancel(context.Background())
+	res := &nodeSubscriptions{
+		url:    url,
+		config: config,
+		logs:   logs,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	for i := 0; i < config.PerNode; i++ {
+		res.done.Add(1)
+		go func() {
+			defer res.done.Done()
+			res.runConnection()
+		}()
+	}
+	if _, found := logs[driver.LogsSubscription]; found {
+		res.done.Add(1)
+		go func() {
+			defer res.done.Done()
+			if err := res.runLogVerification(); err != nil {
+				log.Printf("failed to verify log notifications; %v", err)
+			}
+		}()
+	}
+	return res
+}
+
+func (s *nodeSubscriptions) close() {
+	s.cancel()
+	s.done.Wait()
+}
+
+func (s *nodeSubscriptions) dial() (*gethrpc.Client, error) {
+	return network.RetryReturn(network.DefaultRetryAttempts, 1*time.Second, func() (*gethrpc.Client, error) {
+		if s.ctx.Err() != nil {
+			return nil, nil
+		}
+		return gethrpc.DialContext(s.ctx, string(s.url))
+	})
+}
+
+// runConnection keeps a connection subscribing to all configured kinds open until the
+// subscriptions are closed. If a subscription fails, e.g. since the connection dropped
+// or the node restarted, the connection and all of its subscriptions are re-established
+// with an increasing backoff.
+func (s *nodeSubscriptions) runConnection() {
+	backoff := minResubscribeBackoff
+	for {
+		started := time.Now()
+		err := s.runSubscriptions()
+		if s.ctx.Err() != nil {
+			return
+		}
+		if time.Since(started) > maxResubscribeBackoff {
+			backoff = minResubscribeBackoff
+		}
+		log.Printf("subscriptions to %v ended, re-subscribing in %v; %v", s.url, backoff, err)
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > maxResubscribeBackoff {
+			backoff = maxResubscribeBackoff
+		}
+	}
+}
+
+// runSubscriptions opens a connection subscribing to all configured kinds and tracks
+// the received notifications until the subscriptions are closed or one of them fails.
+func (s *nodeSubscriptions) runSubscriptions() error {
+	client, err := s.dial()
+	if err != nil {
+		return err
+	}
+	if client == nil {
+		return nil // < closed while dialing
+	}
+	defer client.Close()
+
+	// the failure of a single subscription ends all subscriptions of the connection
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+	var wg sync.WaitGroup
+	errs := make([]error, len(s.config.Kinds))
+	for i, kind := range s.config.Kinds {
+		i, kind := i, kind
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = s.runSubscription(ctx, client, kind)
+			if errs[i] != nil {
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (s *nodeSubscriptions) runSubscription(ctx context.Context, client *gethrpc.Client, kind driver.SubscriptionKind) error {
+	notifications := s.logs[kind]
+	switch kind {
+	case driver.NewHeadsSubscription:
+		tracker := &headTracker{log: notifications}
+		return subscribe(ctx, client, []interface{}{kind}, tracker.observe)
+	case driver.LogsSubscription:
+		tracker := newLogTracker(notifications)
+		s.addLogTracker(tracker)
+		defer s.removeLogTracker(tracker)
+		return subscribe(ctx, client, []interface{}{kind, s.getLogFilter(nil, nil)}, tracker.observe)
+	case driver.PendingTransactionsSubscription:
+		tracker := newPendingTxTracker(notifications)
+		return subscribe(ctx, client, []interface{}{kind}, func(hash common.Hash, _ time.Time) {
+			tracker.observe(hash)
+		})
+	}
+	return fmt.Errorf("unknown subscription kind: %v", kind)
+}
+
+// addLogTracker registers the tracker of a running log subscription for verification.
+func (s *nodeSubscriptions) addLogTracker(tracker *logTracker) {
+	s.logTrackersMutex.Lock()
+	defer s.logTrackersMutex.Unlock()
+	s.logTrackers = append(s.logTrackers, tracker)
+}
+
+// removeLogTracker stops verifying the logs of an ended subscription, which would
+// otherwise report all subsequent logs of the chain as missed.
+func (s *nodeSubscriptions) removeLogTracker(tracker *logTracker) {
+	s.logTrackersMutex.Lock()
+	defer s.logTrackersMutex.Unlock()
+	for i, cur := range s.logTrackers {
+		if cur == tracker {
+			s.logTrackers = append(s.logTrackers[:i:i], s.logTrackers[i+1:]...)
+			return
+		}
+	}
+}
+
+// subscribe opens a subscription with the given arguments and passes all notifications
+// to the given handler until the context is done or the subscription fails.
+func subscribe[T any](ctx context.Context, client *gethrpc.Client, args []interface{}, handle func(T, time.Time)) error {
+	notifications := make(chan T, notificationBuffer)
+	subscription, err := client.EthSubscribe(ctx, notifications, args...)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return fmt.Errorf("failed to subscribe to %v; %v", args[0], err)
+	}
+	defer subscription.Unsubscribe()
+	for {
+		select {
+		case notification := <-notifications:
+			handle(notification, time.Now())
+		case err := <-subscription.Err():
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("subscription to %v failed; %v", args[0], err)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// getLogFilter creates the filter of log subscriptions and queries, covering the
+// given block range if set.
+func (s *nodeSubscriptions) getLogFilter(from, to *uint64) map[string]interface{} {
+	filter := map[string]interface{}{}
+	if len(s.config.Topics) > 0 {
+		filter["topics"] = [][]common.Hash{s.config.Topics}
+	}
+	if from != nil {
+		filter["fromBlock"] = hexutil.EncodeUint64(*from)
+	}
+	if to != nil {
+		filter["toBlock"] = hexutil.EncodeUint64(*to)
+	}
+	return filter
+}
+
+// runLogVerification periodically compares the logs received by the subscriptions
+// with the logs of the chain until the subscriptions are closed.
+func (s *nodeSubscriptions) runLogVerification() error {
+	client, err := s.dial()
+	if client == nil || err != nil {
+		return err
+	}
+	defer client.Close()
+
+	var verified *uint64
+	ticker := time.NewTicker(logVerificationPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return nil
+		case <-ticker.C:
+			next, err := s.verifyLogs(client, verified)
+			if err != nil {
+				if s.ctx.Err() != nil {
+					return nil
+				}
+				log.Printf("failed to verify log notifications; %v", err)
+				continue
+			}
+			verified = next
+		}
+	}
+}
+
+// verifyLogs verifies the logs of the blocks following the given verified block and
+// returns the last verified block. If no block has been verified yet, the verification
+// starts with the current head.
+func (s *nodeSubscriptions) verifyLogs(client *gethrpc.Client, verified *uint64) (*uint64, error) {
+	var head hexutil.Uint64
+	if err := client.CallContext(s.ctx, &head, "eth_blockNumber"); err != nil {
+		return verified, fmt.Errorf("failed to get block number; %v", err)
+	}
+	if uint64(head) < logVerificationLag {
+		return verified, nil
+	}
+	to := uint64(head) - logVerificationLag
+	if verified == nil {
+		return &to, nil
+	}
+	from := *verified + 1
+	if from > to {
+		return verified, nil
+	}
+	if to-from+1 > maxLogVerificationRange {
+		to = from + maxLogVerificationRange - 1
+	}
+
+	var logs []logNotification
+	if err := client.CallContext(s.ctx, &logs, "eth_getLogs", s.getLogFilter(&from, &to)); err != nil {
+		return verified, fmt.Errorf("failed to get logs; %v", err)
+	}
+	expected := map[uint64][]logKey{}
+	for _, entry := range logs {
+		block := uint64(entry.BlockNumber)
+		expected[block] = append(expected[block], logKey{tx: entry.TxHash, index: uint(entry.Index)})
+	}
+
+	s.logTrackersMutex.Lock()
+	trackers := s.logTrackers
+	s.logTrackersMutex.Unlock()
+	for _, tracker := range trackers {
+		tracker.verify(from, to, expected)
+	}
+	return &to, nil
+}
@@ -22,8 +22,10 @@ package driver
 
 import (
 	reflect "reflect"
+	time "time"
 
 	rpc "github.com/Fantom-foundation/Norma/driver/rpc"
+	common "github.com/ethereum/go-ethereum/common"
 	types "github.com/ethereum/go-ethereum/core/types"
 	gomock "github.com/golang/mock/gomock"
 )
@@ -124,6 +126,107 @@ func (mr *MockNetworkMockRecorder) GetActiveNodes() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveNodes", reflect.TypeOf((*MockNetwork)(nil).GetActiveNodes))
 }
 
+// GetNotificationStats mocks base method.
+func (m *MockNetwork) GetNotificationStats() map[NotificationKey]NotificationStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationStats")
+	ret0, _ := ret[0].(map[NotificationKey]NotificationStats)
+	return ret0
+}
+
+// GetNotificationStats indicates an expected call of GetNotificationStats.
+func (mr *MockNetworkMockRecorder) GetNotificationStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationStats", reflect.TypeOf((*MockNetwork)(nil).GetNotificationStats))
+}
+
+// GetNotificationTime mocks base method.
+func (m *MockNetwork) GetNotificationTime(key NotificationKey, block uint64) (time.Time, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationTime", key, block)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetNotificationTime indicates an expected call of GetNotificationTime.
+func (mr *MockNetworkMockRecorder) GetNotificationTime(key, block interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationTime", reflect.TypeOf((*MockNetwork)(nil).GetNotificationTime), key, block)
+}
+
+// GetQueryStats mocks base method.
+func (m *MockNetwork) GetQueryStats() map[QueryStatsKey]QueryStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQueryStats")
+	ret0, _ := ret[0].(map[QueryStatsKey]QueryStats)
+	return ret0
+}
+
+// GetQueryStats indicates an expected call of GetQueryStats.
+func (mr *MockNetworkMockRecorder) GetQueryStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueryStats", reflect.TypeOf((*MockNetwork)(nil).GetQueryStats))
+}
+
+// GetServiceLevel mocks base method.
+func (m *MockNetwork) GetServiceLevel(indicator ServiceLevelIndicator) (ServiceLevel, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceLevel", indicator)
+	ret0, _ := ret[0].(ServiceLevel)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetServiceLevel indicates an expected call of GetServiceLevel.
+func (mr *MockNetworkMockRecorder) GetServiceLevel(indicator interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceLevel", reflect.TypeOf((*MockNetwork)(nil).GetServiceLevel), indicator)
+}
+
+// GetTransactionSubmission mocks base method.
+func (m *MockNetwork) GetTransactionSubmission(hash common.Hash) (TransactionSubmission, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionSubmission", hash)
+	ret0, _ := ret[0].(TransactionSubmission)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetTransactionSubmission indicates an expected call of GetTransactionSubmission.
+func (mr *MockNetworkMockRecorder) GetTransactionSubmission(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionSubmission", reflect.TypeOf((*MockNetwork)(nil).GetTransactionSubmission), hash)
+}
+
+// GetTransactionSubmissionCounts mocks base method.
+func (m *MockNetwork) GetTransactionSubmissionCounts() map[string]uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionSubmissionCounts")
+	ret0, _ := ret[0].(map[string]uint64)
+	return ret0
+}
+
+// GetTransactionSubmissionCounts indicates an expected call of GetTransactionSubmissionCounts.
+func (mr *MockNetworkMockRecorder) GetTransactionSubmissionCounts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionSubmissionCounts", reflect.TypeOf((*MockNetwork)(nil).GetTransactionSubmissionCounts))
+}
+
+// GetTransactionSubmissionErrors mocks base method.
+func (m *MockNetwork) GetTransactionSubmissionErrors() map[SubmissionErrorKey]uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionSubmissionErrors")
+	ret0, _ := ret[0].(map[SubmissionErrorKey]uint64)
+	return ret0
+}
+
+// GetTransactionSubmissionErrors indicates an expected call of GetTransactionSubmissionErrors.
+func (mr *MockNetworkMockRecorder) GetTransactionSubmissionErrors() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionSubmissionErrors", reflect.TypeOf((*MockNetwork)(nil).GetTransactionSubmissionErrors))
+}
+
 // RegisterListener mocks base method.
 func (m *MockNetwork) RegisterListener(arg0 NetworkListener) {
 	m.ctrl.T.Helper()
@@ -150,6 +253,44 @@ func (mr *MockNetworkMockRecorder) RemoveNode(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveNode", reflect.TypeOf((*MockNetwork)(nil).RemoveNode), arg0)
 }
 
+// SendArchiveQuery mocks base method.
+func (m *MockNetwork) SendArchiveQuery(result interface{}, method string, args ...interface{}) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{result, method}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SendArchiveQuery", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendArchiveQuery indicates an expected call of SendArchiveQuery.
+func (mr *MockNetworkMockRecorder) SendArchiveQuery(result, method interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{result, method}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendArchiveQuery", reflect.TypeOf((*MockNetwork)(nil).SendArchiveQuery), varargs...)
+}
+
+// SendQuery mocks base method.
+func (m *MockNetwork) SendQuery(result interface{}, method string, args ...interface{}) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{result, method}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SendQuery", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendQuery indicates an expected call of SendQuery.
+func (mr *MockNetworkMockRecorder) SendQuery(result, method interface{}, args ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{result, method}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendQuery", reflect.TypeOf((*MockNetwork)(nil).SendQuery), varargs...)
+}
+
 // SendTransaction mocks base method.
 func (m *MockNetwork) SendTransaction(tx *types.Transaction) {
 	m.ctrl.T.Helper()
@@ -162,6 +303,18 @@ func (mr *MockNetworkMockRecorder) SendTransaction(tx interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendTransaction", reflect.TypeOf((*MockNetwork)(nil).SendTransaction), tx)
 }
 
+// SetServiceLevelSource mocks base method.
+func (m *MockNetwork) SetServiceLevelSource(source ServiceLevelSource) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetServiceLevelSource", source)
+}
+
+// SetServiceLevelSource indicates an expected call of SetServiceLevelSource.
+func (mr *MockNetworkMockRecorder) SetServiceLevelSource(source interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetServiceLevelSource", reflect.TypeOf((*MockNetwork)(nil).SetServiceLevelSource), source)
+}
+
 // Shutdown mocks base method.
 func (m *MockNetwork) Shutdown() error {
 	m.ctrl.T.Helper()
@@ -188,6 +341,44 @@ func (mr *MockNetworkMockRecorder) UnregisterListener(arg0 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnregisterListener", reflect.TypeOf((*MockNetwork)(nil).UnregisterListener), arg0)
 }
 
+// MockServiceLevelSource is a mock of ServiceLevelSource interface.
+type MockServiceLevelSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceLevelSourceMockRecorder
+}
+
+// MockServiceLevelSourceMockRecorder is the mock recorder for MockServiceLevelSource.
+type MockServiceLevelSourceMockRecorder struct {
+	mock *MockServiceLevelSource
+}
+
+// NewMockServiceLevelSource creates a new mock instance.
+func NewMockServiceLevelSource(ctrl *gomock.Controller) *MockServiceLevelSource {
+	mock := &MockServiceLevelSource{ctrl: ctrl}
+	mock.recorder = &MockServiceLevelSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceLevelSource) EXPECT() *MockServiceLevelSourceMockRecorder {
+	return m.recorder
+}
+
+// GetServiceLevel mocks base method.
+func (m *MockServiceLevelSource) GetServiceLevel(indicator ServiceLevelIndicator) (ServiceLevel, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceLevel", indicator)
+	ret0, _ := ret[0].(ServiceLevel)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetServiceLevel indicates an expected call of GetServiceLevel.
+func (mr *MockServiceLevelSourceMockRecorder) GetServiceLevel(indicator interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceLevel", reflect.TypeOf((*MockServiceLevelSource)(nil).GetServiceLevel), indicator)
+}
+
 // MockNetworkListener is a mock of NetworkListener interface.
 type MockNetworkListener struct {
 	ctrl     *gomock.Controller
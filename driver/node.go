@@ -42,6 +42,13 @@ type Node interface {
 	// IsRunning returns true if the node is still running, false if stopped.
 	IsRunning() bool
 
+	// IsValidator returns true if the node is a validator of the network.
+	IsValidator() bool
+
+	// IsArchive returns true if the node maintains the history of the state and
+	// is thus able to answer queries on past blocks.
+	IsArchive() bool
+
 	// GetNodeID returns an enode identifying this node within the Norma network.
 	// An error shall be produced if no valid node ID could be obtained.
 	GetNodeID() (NodeID, error)
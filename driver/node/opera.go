@@ -69,8 +69,10 @@ const operaDockerImageName = "sonic"
 // OperaNode implements the driver's Node interface by running a go-opera
 // client on a generic host.
 type OperaNode struct {
-	host  network.Host
-	label string
+	host      network.Host
+	label     string
+	validator bool
+	archive   bool
 }
 
 type OperaNodeConfig struct {
@@ -82,6 +84,8 @@ type OperaNodeConfig struct {
 	NetworkConfig *driver.NetworkConfig
 	// The EVM implementation to be used on this node.
 	VmImplementation string
+	// True if the node should maintain the history of the state (archive).
+	Archive bool
 }
 
 // labelPattern restricts labels for nodes to non-empty alpha-numerical strings
@@ -119,6 +123,7 @@ func StartOperaDockerNode(client *docker.Client, dn *docker.Network, config *Ope
 				"VALIDATORS_COUNT": fmt.Sprintf("%d", config.NetworkConfig.NumberOfValidators),
 				"STATE_DB_IMPL":    config.NetworkConfig.StateDbImplementation,
 				"VM_IMPL":          config.VmImplementation,
+				"ARCHIVE":          fmt.Sprintf("%t", config.Archive),
 			},
 			Network: dn,
 		})
@@ -127,8 +132,10 @@ func StartOperaDockerNode(client *docker.Client, dn *docker.Network, config *Ope
 		return nil, err
 	}
 	node := &OperaNode{
-		host:  host,
-		label: config.Label,
+		host:      host,
+		label:     config.Label,
+		validator: config.ValidatorId != nil,
+		archive:   config.Archive,
 	}
 
 	// Wait until the OperaNode inside the Container is ready.
@@ -159,6 +166,14 @@ func (n *OperaNode) MetricsPort() int {
 	return 6060
 }
 
+func (n *OperaNode) IsValidator() bool {
+	return n.validator
+}
+
+func (n *OperaNode) IsArchive() bool {
+	return n.archive
+}
+
 func (n *OperaNode) IsRunning() bool {
 	return n.host.IsRunning()
 }
@@ -138,6 +138,20 @@ func (mr *MockNodeMockRecorder) Hostname() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hostname", reflect.TypeOf((*MockNode)(nil).Hostname))
 }
 
+// IsArchive mocks base method.
+func (m *MockNode) IsArchive() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsArchive")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsArchive indicates an expected call of IsArchive.
+func (mr *MockNodeMockRecorder) IsArchive() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsArchive", reflect.TypeOf((*MockNode)(nil).IsArchive))
+}
+
 // IsRunning mocks base method.
 func (m *MockNode) IsRunning() bool {
 	m.ctrl.T.Helper()
@@ -152,6 +166,20 @@ func (mr *MockNodeMockRecorder) IsRunning() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRunning", reflect.TypeOf((*MockNode)(nil).IsRunning))
 }
 
+// IsValidator mocks base method.
+func (m *MockNode) IsValidator() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsValidator")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsValidator indicates an expected call of IsValidator.
+func (mr *MockNodeMockRecorder) IsValidator() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsValidator", reflect.TypeOf((*MockNode)(nil).IsValidator))
+}
+
 // MetricsPort mocks base method.
 func (m *MockNode) MetricsPort() int {
 	m.ctrl.T.Helper()
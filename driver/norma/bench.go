@@ -0,0 +1,230 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/benchmark"
+	"github.com/Fantom-foundation/Norma/driver/monitoring"
+	netmon "github.com/Fantom-foundation/Norma/driver/monitoring/network"
+	"github.com/Fantom-foundation/Norma/driver/network/local"
+	"github.com/Fantom-foundation/Norma/load/app"
+	"github.com/urfave/cli/v2"
+)
+
+// Run with `go run ./driver/norma bench`
+
+var benchCommand = cli.Command{
+	Action: bench,
+	Name:   "bench",
+	Usage:  "searches for the maximum transaction rate sustained by a network",
+	Flags: []cli.Flag{
+		&benchApp,
+		&benchUsers,
+		&benchMinRate,
+		&benchMaxRate,
+		&benchPrecision,
+		&benchWarmup,
+		&benchWindow,
+		&benchMaxBacklog,
+		&benchMaxBlockTime,
+		&dbImpl,
+		&evalLabel,
+		&numValidators,
+		&vmImpl,
+	},
+}
+
+var (
+	benchApp = cli.StringFlag{
+		Name:  "app",
+		Usage: "the type of the application producing the load",
+		Value: "counter",
+	}
+	benchUsers = cli.IntFlag{
+		Name:  "users",
+		Usage: "the number of users of the application",
+		Value: 10,
+	}
+	benchMinRate = cli.Float64Flag{
+		Name:  "min-rate",
+		Usage: "the lowest rate in Tx/s to be evaluated",
+		Value: 10,
+	}
+	benchMaxRate = cli.Float64Flag{
+		Name:  "max-rate",
+		Usage: "the highest rate in Tx/s to be considered",
+		Value: 5000,
+	}
+	benchPrecision = cli.Float64Flag{
+		Name:  "precision",
+		Usage: "the width in Tx/s of the interval the saturation rate is narrowed down to",
+		Value: 50,
+	}
+	benchWarmup = cli.DurationFlag{
+		Name:  "warmup",
+		Usage: "the time each rate is held before measuring",
+		Value: 15 * time.Second,
+	}
+	benchWindow = cli.DurationFlag{
+		Name:  "window",
+		Usage: "the steady-state window in which each rate is measured",
+		Value: time.Minute,
+	}
+	benchMaxBacklog = cli.DurationFlag{
+		Name:  "max-backlog",
+		Usage: "the maximum backlog of sent but not received transactions, in terms of the load produced in this time",
+		Value: 10 * time.Second,
+	}
+	benchMaxBlockTime = cli.DurationFlag{
+		Name:  "max-block-time",
+		Usage: "the maximum time between two blocks",
+		Value: 5 * time.Second,
+	}
+)
+
+func bench(ctx *cli.Context) (err error) {
+	db, vm, err := getImplementations(ctx)
+	if err != nil {
+		return err
+	}
+
+	appType := ctx.String(benchApp.Name)
+	if !app.IsSupportedApplicationType(appType) {
+		return fmt.Errorf("unknown value for --%v flag: %v", benchApp.Name, appType)
+	}
+	searchConfig := benchmark.Config{
+		MinRate:   ctx.Float64(benchMinRate.Name),
+		MaxRate:   ctx.Float64(benchMaxRate.Name),
+		Precision: ctx.Float64(benchPrecision.Name),
+	}
+	if err := searchConfig.Check(); err != nil {
+		return err
+	}
+	probeConfig := benchmark.ProbeConfig{
+		App:          appType,
+		Users:        ctx.Int(benchUsers.Name),
+		Warmup:       ctx.Duration(benchWarmup.Name),
+		Window:       ctx.Duration(benchWindow.Name),
+		MaxBacklog:   ctx.Duration(benchMaxBacklog.Name),
+		MaxBlockTime: ctx.Duration(benchMaxBlockTime.Name),
+	}
+	if err := probeConfig.Check(); err != nil {
+		return err
+	}
+
+	label := ctx.String(evalLabel.Name)
+	if label == "" {
+		label = fmt.Sprintf("bench_%d", time.Now().Unix())
+	}
+
+	fmt.Printf("Starting benchmark %s\n", label)
+	outputDir, err := os.MkdirTemp("", fmt.Sprintf("norma_data_%s_", label))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Monitoring data is written to %v\n", outputDir)
+
+	// Startup network.
+	netConfig := driver.NetworkConfig{
+		NumberOfValidators:    1,
+		StateDbImplementation: db,
+		VmImplementation:      vm,
+	}
+	if num := ctx.Int(numValidators.Name); num > 0 {
+		netConfig.NumberOfValidators = num
+	}
+	fmt.Printf("Creating network with %d validator(s) using the `%v` DB and `%v` VM implementation ...\n",
+		netConfig.NumberOfValidators, netConfig.StateDbImplementation, netConfig.VmImplementation,
+	)
+	net, err := local.NewLocalNetwork(&netConfig)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		fmt.Printf("Shutting down network ...\n")
+		if err := net.Shutdown(); err != nil {
+			fmt.Printf("error during network shutdown:\n%v", err)
+		}
+	}()
+
+	// Initialize monitoring environment.
+	monitor, err := monitoring.NewMonitor(net, monitoring.MonitorConfig{
+		EvaluationLabel: label,
+		OutputDir:       outputDir,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		fmt.Printf("Shutting down data monitor ...\n")
+		if err := monitor.Shutdown(); err != nil {
+			fmt.Printf("error during monitor shutdown:\n%v\n", err)
+		}
+		fmt.Printf("Raw data was exported to %s\n", monitor.GetMeasurementFileName())
+		fmt.Printf("To render report run `norma render %s`\n", monitor.GetMeasurementFileName())
+	}()
+	if err := monitoring.InstallAllRegisteredSources(monitor); err != nil {
+		return err
+	}
+	net.SetServiceLevelSource(netmon.NewServiceLevelSource(monitor))
+
+	// Run the search.
+	probe, err := benchmark.NewNetworkProbe(net, monitor, probeConfig)
+	if err != nil {
+		return err
+	}
+	logger := startProgressLogger(monitor)
+	defer logger.shutdown()
+	res, err := benchmark.Search(searchConfig, probe)
+	if file, csvErr := writeBenchSteps(&res, outputDir); csvErr != nil {
+		fmt.Printf("failed to export benchmark steps:\n%v\n", csvErr)
+	} else {
+		fmt.Printf("Benchmark steps were exported to %s\n", file)
+	}
+	if err != nil {
+		return err
+	}
+
+	rate, resolution := res.GetEstimate()
+	if res.Saturated {
+		fmt.Printf("Saturation rate: %.1f Tx/s at a search resolution of %.1f Tx/s (sustained %.1f Tx/s, failed %.1f Tx/s)\n", rate, resolution, res.Lower, res.Upper)
+	} else {
+		fmt.Printf("No saturation found up to %.1f Tx/s (--%s), sustained %.1f Tx/s\n", res.Upper, benchMaxRate.Name, res.Lower)
+	}
+	return nil
+}
+
+// writeBenchSteps exports the steps of the given benchmark result as a CSV file
+// in the given directory.
+func writeBenchSteps(res *benchmark.Result, dir string) (string, error) {
+	path := filepath.Join(dir, "bench_steps.csv")
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	if err := res.WriteCsv(file); err != nil {
+		file.Close()
+		return "", err
+	}
+	return path, file.Close()
+}
@@ -33,6 +33,7 @@ func main() {
 		Copyright: "(c) 2023 Fantom Foundation",
 		Flags:     []cli.Flag{},
 		Commands: []*cli.Command{
+			&benchCommand,
 			&checkCommand,
 			&runCommand,
 			&purgeCommand,
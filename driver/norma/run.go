@@ -48,8 +48,10 @@ var runCommand = cli.Command{
 	Name:   "run",
 	Usage:  "runs a scenario",
 	Flags: []cli.Flag{
+		&blocksHashesCheckMode,
 		&dbImpl,
 		&evalLabel,
+		&failOnSaturatedLoad,
 		&keepPrometheusRunning,
 		&numValidators,
 		&skipChecks,
@@ -59,6 +61,11 @@ var runCommand = cli.Command{
 }
 
 var (
+	blocksHashesCheckMode = cli.StringFlag{
+		Name:  "block-hashes-check",
+		Usage: "select which blocks are compared among nodes by the final checks (full, sampled or bisect)",
+		Value: string(checking.FullBlocksHashesCheck),
+	}
 	dbImpl = cli.StringFlag{
 		Name:  "db-impl",
 		Usage: "select the DB implementation to use (geth or carmen)",
@@ -69,6 +76,10 @@ var (
 		Usage: "define a label for to be added to the monitoring data for this run. I empty, a random label is used.",
 		Value: "",
 	}
+	failOnSaturatedLoad = cli.BoolFlag{
+		Name:  "fail-on-saturated-load",
+		Usage: "fails the final checks if a load generator could not keep up with the requested load",
+	}
 	keepPrometheusRunning = cli.BoolFlag{
 		Name:    "keep-prometheus-running",
 		Usage:   "if set, the Prometheus instance will not be shut down after the run is complete.",
@@ -94,19 +105,14 @@ var (
 )
 
 func run(ctx *cli.Context) (err error) {
-	db := strings.ToLower(ctx.String(dbImpl.Name))
-	if db == "carmen" || db == "go-file" {
-		db = "go-file"
-	} else if db != "geth" {
-		return fmt.Errorf("unknown value fore --%v flag: %v", dbImpl.Name, db)
+	db, vm, err := getImplementations(ctx)
+	if err != nil {
+		return err
 	}
 
-	vm := strings.ToLower(ctx.String(vmImpl.Name))
-	if vm == "tosca" {
-		vm = "lfvm"
-	}
-	if !isValidVmImpl(vm) {
-		return fmt.Errorf("unknown value fore --%v flag: %v", vmImpl.Name, vm)
+	checkMode := checking.BlocksHashesCheckMode(strings.ToLower(ctx.String(blocksHashesCheckMode.Name)))
+	if !checkMode.IsValid() {
+		return fmt.Errorf("unknown value fore --%v flag: %v", blocksHashesCheckMode.Name, checkMode)
 	}
 
 	label := ctx.String(evalLabel.Name)
@@ -152,6 +158,25 @@ func run(ctx *cli.Context) (err error) {
 	if scenario.NumValidators != nil {
 		netConfig.NumberOfValidators = *scenario.NumValidators
 	}
+	if pool := scenario.RpcPool; pool != nil {
+		netConfig.RpcPool = driver.RpcPoolConfig{
+			Transport: driver.RpcTransport(pool.Transport),
+			Routing:   driver.RpcRouting(pool.Routing),
+			Weights:   pool.Weights,
+		}
+		if pool.Workers != nil {
+			netConfig.RpcPool.WorkersPerNode = *pool.Workers
+		}
+	}
+	if subscriptions := scenario.Subscriptions; subscriptions != nil {
+		netConfig.Subscriptions = driver.SubscriptionConfig{PerNode: 1}
+		if subscriptions.PerNode != nil {
+			netConfig.Subscriptions.PerNode = *subscriptions.PerNode
+		}
+		for _, kind := range subscriptions.Kinds {
+			netConfig.Subscriptions.Kinds = append(netConfig.Subscriptions.Kinds, driver.SubscriptionKind(kind))
+		}
+	}
 	fmt.Printf("Creating network with %d validator(s) using the `%v` DB and `%v` VM implementation ...\n",
 		netConfig.NumberOfValidators, netConfig.StateDbImplementation, netConfig.VmImplementation,
 	)
@@ -200,6 +225,9 @@ func run(ctx *cli.Context) (err error) {
 		return err
 	}
 
+	// Provide service level feedback derived from monitoring data to adaptive load shapers.
+	net.SetServiceLevelSource(netmon.NewServiceLevelSource(monitor))
+
 	// Run prometheus.
 	fmt.Printf("Starting Prometheus ...\n")
 	prom, err := prometheusmon.Start(net, net.GetDockerNetwork())
@@ -227,7 +255,11 @@ func run(ctx *cli.Context) (err error) {
 
 	if !ctx.Bool(skipChecks.Name) {
 		fmt.Printf("Checking network consistency ...\n")
-		err = checking.CheckNetworkConsistency(net)
+		err = checking.CheckNetworkConsistency(net, checking.Config{
+			BlocksHashesCheckMode:        checkMode,
+			FailOnSaturatedLoadGenerator: ctx.Bool(failOnSaturatedLoad.Name),
+			Monitor:                      monitor,
+		})
 		if err != nil {
 			return fmt.Errorf("checking the network consistency failed: %v", err)
 		}
@@ -281,7 +313,8 @@ func logState(monitor *monitoring.Monitor) {
 	txs := getNumTxs(monitor)
 	gas := getGasUsed(monitor)
 	processingTimes := getBlockProcessingTimes(monitor)
-	log.Printf("Nodes: %s, block heights: %v, tx/s: %v, txs: %v, gas: %s, block processing: %v", numNodes, blockHeights, txPers, txs, gas, processingTimes)
+	submissionErrors := getSubmissionErrors(monitor)
+	log.Printf("Nodes: %s, block heights: %v, tx/s: %v, txs: %v, gas: %s, block processing: %v, submission errors: %v", numNodes, blockHeights, txPers, txs, gas, processingTimes, submissionErrors)
 }
 
 func getNumNodes(monitor *monitoring.Monitor) string {
@@ -304,6 +337,22 @@ func getGasUsed(monitor *monitoring.Monitor) string {
 	return getLastValAsString[monitoring.BlockNumber, int](exists, data)
 }
 
+// getSubmissionErrors lists the number of failed transaction submissions for
+// each class of errors reported so far.
+func getSubmissionErrors(monitor *monitoring.Monitor) []string {
+	res := []string{}
+	for _, class := range driver.SubmissionErrorClasses {
+		data, exists := monitoring.GetData(monitor, monitoring.Network{}, netmon.TxSubmissionErrors[class])
+		if !exists || data == nil {
+			continue
+		}
+		if point := data.GetLatest(); point != nil && point.Value > 0 {
+			res = append(res, fmt.Sprintf("%s=%d", class, point.Value))
+		}
+	}
+	return res
+}
+
 func getBlockHeights(monitor *monitoring.Monitor) []string {
 	metric := nodemon.NodeBlockHeight
 	return getLastValAllSubjects[monitoring.Time, int, monitoring.Series[monitoring.Time, int]](monitor, metric)
@@ -337,6 +386,26 @@ func getLastValAsString[K constraints.Ordered, T any](exists bool, series monito
 	return fmt.Sprintf("%v", point.Value)
 }
 
+// getImplementations obtains the DB and VM implementations selected by the
+// command line flags.
+func getImplementations(ctx *cli.Context) (db string, vm string, err error) {
+	db = strings.ToLower(ctx.String(dbImpl.Name))
+	if db == "carmen" || db == "go-file" {
+		db = "go-file"
+	} else if db != "geth" {
+		return "", "", fmt.Errorf("unknown value fore --%v flag: %v", dbImpl.Name, db)
+	}
+
+	vm = strings.ToLower(ctx.String(vmImpl.Name))
+	if vm == "tosca" {
+		vm = "lfvm"
+	}
+	if !isValidVmImpl(vm) {
+		return "", "", fmt.Errorf("unknown value fore --%v flag: %v", vmImpl.Name, vm)
+	}
+	return db, vm, nil
+}
+
 func isValidVmImpl(name string) bool {
 	switch strings.ToLower(name) {
 	case "geth", "lfvm", "lfvm-si", "evmzero", "evmone":
@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"github.com/Fantom-foundation/Norma/load/app"
+	"golang.org/x/exp/slices"
 )
 
 const namePatternStr = "^[A-Za-z0-9-]+$"
@@ -63,6 +64,19 @@ func (s *Scenario) Check() error {
 			names[application.Name] = true
 		}
 	}
+	if s.RpcPool != nil {
+		if err := s.RpcPool.Check(); err != nil {
+			errs = append(errs, err)
+		}
+		if err := s.RpcPool.checkNodes(s); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if s.Subscriptions != nil {
+		if err := s.Subscriptions.Check(); err != nil {
+			errs = append(errs, err)
+		}
+	}
 	return errors.Join(errs...)
 }
 
@@ -83,6 +97,89 @@ func (n *Node) Check(scenario *Scenario) error {
 	return errors.Join(errs...)
 }
 
+// Check tests semantic constraints on the configuration of the RPC pool.
+func (p *RpcPool) Check() error {
+	errs := []error{}
+
+	if p.Workers != nil && *p.Workers < 1 {
+		errs = append(errs, fmt.Errorf("number of RPC workers per node must be >= 1, is %d", *p.Workers))
+	}
+
+	switch p.Transport {
+	case "", "ws", "http":
+	default:
+		errs = append(errs, fmt.Errorf("unknown RPC transport: %v", p.Transport))
+	}
+
+	switch p.Routing {
+	case "", "any", "rpc-nodes", "sticky", "round-robin", "weighted":
+	default:
+		errs = append(errs, fmt.Errorf("unknown RPC routing: %v", p.Routing))
+	}
+
+	if len(p.Weights) > 0 && p.Routing != "weighted" {
+		errs = append(errs, fmt.Errorf("RPC node weights are only supported by the weighted routing"))
+	}
+	for group, weight := range p.Weights {
+		if weight < 0 {
+			errs = append(errs, fmt.Errorf("RPC weight of node group %s must be >= 0, got %f", group, weight))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkNodes tests that the routing policy of the RPC pool is able to deliver transactions
+// to the nodes of the given scenario. The rpc-nodes routing requires at least one node
+// besides the validators, and the weighted routing requires a node group of positive weight.
+func (p *RpcPool) checkNodes(scenario *Scenario) error {
+	groups := []string{}
+	for _, node := range scenario.Nodes {
+		if node.Instances == nil || *node.Instances > 0 {
+			groups = append(groups, node.Name)
+		}
+	}
+
+	switch p.Routing {
+	case "rpc-nodes":
+		if len(groups) == 0 {
+			return fmt.Errorf("RPC routing rpc-nodes requires at least one non-validator node")
+		}
+	case "weighted":
+		total := float32(0)
+		for _, group := range append(groups, "validator") {
+			if weight, found := p.Weights[group]; found {
+				total += weight
+			} else {
+				total += 1
+			}
+		}
+		if total <= 0 {
+			return fmt.Errorf("RPC weights of all node groups are 0, transactions cannot be routed")
+		}
+	}
+	return nil
+}
+
+// Check tests semantic constraints on the configuration of the subscriptions.
+func (s *Subscriptions) Check() error {
+	errs := []error{}
+
+	if s.PerNode != nil && *s.PerNode < 1 {
+		errs = append(errs, fmt.Errorf("number of subscriptions per node must be >= 1, is %d", *s.PerNode))
+	}
+
+	for _, kind := range s.Kinds {
+		switch kind {
+		case "newHeads", "logs", "newPendingTransactions":
+		default:
+			errs = append(errs, fmt.Errorf("unknown subscription kind: %v", kind))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 // Check tests semantic constraints on the application configuration of a scenario.
 func (a *Application) Check(scenario *Scenario) error {
 	errs := []error{}
@@ -97,6 +194,102 @@ func (a *Application) Check(scenario *Scenario) error {
 		errs = append(errs, fmt.Errorf("unknown application type: %v", a.Type))
 	}
 
+	if a.Transfer != nil {
+		if !a.hasType("transfer") {
+			errs = append(errs, fmt.Errorf("transfer parameters are only supported by transfer applications, got type %v", a.Type))
+		}
+		if err := a.Transfer.Check(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if a.Custom != nil {
+		if !a.hasType("custom") {
+			errs = append(errs, fmt.Errorf("custom parameters are only supported by custom applications, got type %v", a.Type))
+		}
+		if err := a.Custom.Check(); err != nil {
+			errs = append(errs, err)
+		}
+	} else if a.hasType("custom") {
+		errs = append(errs, fmt.Errorf("custom applications must define custom parameters"))
+	}
+
+	if a.NFT != nil {
+		if !a.hasType("nft") {
+			errs = append(errs, fmt.Errorf("nft parameters are only supported by nft applications, got type %v", a.Type))
+		}
+		if err := a.NFT.Check(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if a.Deployer != nil {
+		if !a.hasType("deployer") {
+			errs = append(errs, fmt.Errorf("deployer parameters are only supported by deployer applications, got type %v", a.Type))
+		}
+		if err := a.Deployer.Check(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if a.Storage != nil {
+		if !a.hasType("storage") {
+			errs = append(errs, fmt.Errorf("storage parameters are only supported by storage applications, got type %v", a.Type))
+		}
+		if err := a.Storage.Check(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if a.Adversarial != nil {
+		if !a.hasType("adversarial") {
+			errs = append(errs, fmt.Errorf("adversarial parameters are only supported by adversarial applications, got type %v", a.Type))
+		}
+		if err := app.CheckAdversarialConfig(a.Adversarial.AppConfig()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if a.Query != nil {
+		if !a.hasType("query") {
+			errs = append(errs, fmt.Errorf("query parameters are only supported by query applications, got type %v", a.Type))
+		}
+		if err := a.Query.Check(); err != nil {
+			errs = append(errs, err)
+		}
+		if a.Query.Historical && !scenario.hasArchiveNode() {
+			errs = append(errs, fmt.Errorf("historical queries require at least one archive node"))
+		}
+	}
+
+	if a.TxType != "" || a.Fees != nil {
+		for _, unsupported := range []string{"query", "adversarial"} {
+			if a.hasType(unsupported) {
+				errs = append(errs, fmt.Errorf("transaction types are not supported by %s applications", unsupported))
+			}
+		}
+		config := app.TxConfig{}
+		if a.Fees != nil {
+			config = a.Fees.AppConfig()
+		}
+		config.Type = a.TxType
+		if err := app.CheckTxConfig(config); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if strings.EqualFold(a.Type, "mix") {
+		components := make([]app.MixComponent, 0, len(a.Mix))
+		for _, component := range a.Mix {
+			components = append(components, component.AppConfig())
+		}
+		if err := app.CheckMixComponents(components); err != nil {
+			errs = append(errs, fmt.Errorf("invalid mix components; %v", err))
+		}
+	} else if len(a.Mix) > 0 {
+		errs = append(errs, fmt.Errorf("mix components are only supported by mix applications, got type %v", a.Type))
+	}
+
 	if a.Instances != nil && *a.Instances < 0 {
 		errs = append(errs, fmt.Errorf("number of instances must be >= 0, is %d", *a.Instances))
 	}
@@ -109,32 +302,63 @@ func (a *Application) Check(scenario *Scenario) error {
 		errs = append(errs, err)
 	}
 
-	if err := a.Rate.Check(scenario); err != nil {
-		errs = append(errs, err)
+	switch a.Mode {
+	case "", OpenLoopMode:
+		if err := a.Rate.Check(scenario); err != nil {
+			errs = append(errs, err)
+		}
+		if a.ThinkTime != nil || a.ReceiptTimeout != nil {
+			errs = append(errs, fmt.Errorf("think time and receipt timeout are only supported in the %s mode", ClosedLoopMode))
+		}
+	case ClosedLoopMode:
+		if a.hasType("query") {
+			errs = append(errs, fmt.Errorf("query applications do not support the %s mode", ClosedLoopMode))
+		}
+		if a.Rate.numShapes() != 0 {
+			errs = append(errs, fmt.Errorf("applications in the %s mode must not specify a load shape", ClosedLoopMode))
+		}
+		if a.ThinkTime != nil && *a.ThinkTime < 0 {
+			errs = append(errs, fmt.Errorf("think time must be >= 0, got %f", *a.ThinkTime))
+		}
+		if a.ReceiptTimeout != nil && *a.ReceiptTimeout <= 0 {
+			errs = append(errs, fmt.Errorf("receipt timeout must be > 0, got %f", *a.ReceiptTimeout))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown application mode: %v", a.Mode))
 	}
 
 	return errors.Join(errs...)
 }
 
+// hasArchiveNode tests whether the scenario includes a node group with the archive feature.
+func (s *Scenario) hasArchiveNode() bool {
+	for _, node := range s.Nodes {
+		if slices.Contains(node.Features, "archive") && (node.Instances == nil || *node.Instances > 0) {
+			return true
+		}
+	}
+	return false
+}
+
 // Check tests semantic constraints on the traffic shape configuration of a source.
 func (r *Rate) Check(scenario *Scenario) error {
-	count := 0
-	if r.Constant != nil {
-		count++
-	}
-	if r.Slope != nil {
-		count++
-	}
-	if r.Wave != nil {
-		count++
-	}
-	if r.Auto != nil {
-		count++
-	}
-	if count != 1 {
+	if count := r.numShapes(); count != 1 {
 		return fmt.Errorf("application must specify exactly one load shape, got %d", count)
 	}
 
+	switch r.Unit {
+	case "", TxUnit:
+	case GasUnit:
+		if r.contains(func(r *Rate) bool { return r.Auto != nil }) {
+			return fmt.Errorf("auto load shape does not support rates in %s unit", GasUnit)
+		}
+		if r.contains(func(r *Rate) bool { return r.Adaptive != nil }) {
+			return fmt.Errorf("adaptive load shape does not support rates in %s unit", GasUnit)
+		}
+	default:
+		return fmt.Errorf("unknown rate unit: %v", r.Unit)
+	}
+
 	if r.Constant != nil && *r.Constant < 0 {
 		return fmt.Errorf("constant transaction rate must be >= 0, got %f", *r.Constant)
 	}
@@ -147,9 +371,138 @@ func (r *Rate) Check(scenario *Scenario) error {
 	if r.Auto != nil {
 		return r.Auto.Check()
 	}
+	if r.Trace != nil {
+		return r.Trace.Check()
+	}
+	if r.Poisson != nil {
+		return r.Poisson.Check()
+	}
+	if r.Bursts != nil {
+		return r.Bursts.Check()
+	}
+	if r.Steps != nil {
+		return r.Steps.Check()
+	}
+	if r.Sequence != nil {
+		return checkSequence(scenario, r.Sequence)
+	}
+	if r.Sum != nil {
+		return checkSum(scenario, r.Sum)
+	}
+	if r.Clamp != nil {
+		return r.Clamp.Check(scenario)
+	}
+	if r.Adaptive != nil {
+		return r.Adaptive.Check()
+	}
 	return nil
 }
 
+// numShapes returns the number of load shapes set in this rate.
+func (r *Rate) numShapes() int {
+	count := 0
+	for _, set := range []bool{
+		r.Constant != nil,
+		r.Slope != nil,
+		r.Wave != nil,
+		r.Auto != nil,
+		r.Trace != nil,
+		r.Poisson != nil,
+		r.Bursts != nil,
+		r.Steps != nil,
+		r.Sequence != nil,
+		r.Sum != nil,
+		r.Clamp != nil,
+		r.Adaptive != nil,
+	} {
+		if set {
+			count++
+		}
+	}
+	return count
+}
+
+// contains returns true if this rate or any of its nested rates satisfies the given predicate.
+func (r *Rate) contains(predicate func(*Rate) bool) bool {
+	if predicate(r) {
+		return true
+	}
+	for i := range r.Sequence {
+		if r.Sequence[i].Rate.contains(predicate) {
+			return true
+		}
+	}
+	for i := range r.Sum {
+		if r.Sum[i].contains(predicate) {
+			return true
+		}
+	}
+	return r.Clamp != nil && r.Clamp.Rate.contains(predicate)
+}
+
+// checkNested tests semantic constraints on a rate nested in a composite shape.
+func (r *Rate) checkNested(scenario *Scenario) error {
+	if r.Unit != "" {
+		return fmt.Errorf("unit can only be specified for the top-level rate, got %v", r.Unit)
+	}
+	return r.Check(scenario)
+}
+
+// checkSequence tests semantic constraints on the phases of a sequence traffic pattern.
+func checkSequence(scenario *Scenario, phases []Phase) error {
+	errs := []error{}
+
+	if len(phases) == 0 {
+		errs = append(errs, fmt.Errorf("sequence must contain at least one phase"))
+	}
+	for i, phase := range phases {
+		if phase.Duration == nil && i != len(phases)-1 {
+			errs = append(errs, fmt.Errorf("only the last phase of a sequence may omit its duration"))
+		}
+		if phase.Duration != nil && *phase.Duration <= 0 {
+			errs = append(errs, fmt.Errorf("phase duration must be > 0, got %f", *phase.Duration))
+		}
+		if err := phase.Rate.checkNested(scenario); err != nil {
+			errs = append(errs, fmt.Errorf("invalid rate of phase %d; %v", i, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkSum tests semantic constraints on the rates of a sum traffic pattern.
+func checkSum(scenario *Scenario, rates []Rate) error {
+	errs := []error{}
+
+	if len(rates) == 0 {
+		errs = append(errs, fmt.Errorf("sum must contain at least one rate"))
+	}
+	for i := range rates {
+		if err := rates[i].checkNested(scenario); err != nil {
+			errs = append(errs, fmt.Errorf("invalid rate of summand %d; %v", i, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Check tests semantic constraints on the configuration of a clamped traffic pattern.
+func (c *Clamp) Check(scenario *Scenario) error {
+	errs := []error{}
+
+	if c.Min != nil && *c.Min < 0 {
+		errs = append(errs, fmt.Errorf("minimum transaction rate must be >= 0, got %f", *c.Min))
+	}
+	if c.Min != nil && c.Max != nil && *c.Min > *c.Max {
+		errs = append(errs, fmt.Errorf("minimum transaction rate must be <= maximum rate, got %f > %f", *c.Min, *c.Max))
+	}
+	if err := c.Rate.checkNested(scenario); err != nil {
+		errs = append(errs, fmt.Errorf("invalid clamped rate; %v", err))
+	}
+
+	return errors.Join(errs...)
+}
+
 // Check tests semantic constraints on the configuration of a slope traffic pattern.
 func (s *Slope) Check() error {
 	errs := []error{}
@@ -206,6 +559,141 @@ func (a *Auto) Check() error {
 	return errors.Join(errs...)
 }
 
+// hasType checks whether the application is of the given type or, for mix
+// applications, whether one of its components is.
+func (a *Application) hasType(appType string) bool {
+	if strings.EqualFold(a.Type, appType) {
+		return true
+	}
+	if !strings.EqualFold(a.Type, "mix") {
+		return false
+	}
+	for _, component := range a.Mix {
+		if strings.EqualFold(component.Type, appType) {
+			return true
+		}
+	}
+	return false
+}
+
+// Check tests semantic constraints on the parameters of a transfer application.
+func (t *Transfer) Check() error {
+	if t.NewAccounts != nil && (*t.NewAccounts < 0 || *t.NewAccounts > 1) {
+		return fmt.Errorf("fraction of transfers to new accounts must be between 0 and 1, got %f", *t.NewAccounts)
+	}
+	return nil
+}
+
+// Check tests semantic constraints on the parameters of an NFT application.
+func (n *NFT) Check() error {
+	errs := []error{}
+	proportions := []struct {
+		name  string
+		value *float32
+	}{{"mint", n.Mint}, {"transfer", n.Transfer}, {"burn", n.Burn}}
+	for _, proportion := range proportions {
+		if proportion.value != nil && *proportion.value < 0 {
+			errs = append(errs, fmt.Errorf("proportion of %s operations must be >= 0, got %f", proportion.name, *proportion.value))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Check tests semantic constraints on the parameters of a deployer application.
+func (d *Deployer) Check() error {
+	errs := []error{}
+	if d.CodeSize != nil && (*d.CodeSize < 1 || *d.CodeSize > app.MaxCodeSize) {
+		errs = append(errs, fmt.Errorf("code size must be between 1 and %d bytes, got %d", app.MaxCodeSize, *d.CodeSize))
+	}
+	if d.Factory != nil && (*d.Factory < 0 || *d.Factory > 1) {
+		errs = append(errs, fmt.Errorf("fraction of deployments through the factory must be between 0 and 1, got %f", *d.Factory))
+	}
+	return errors.Join(errs...)
+}
+
+// Check tests semantic constraints on the parameters of a storage application.
+func (s *Storage) Check() error {
+	errs := []error{}
+	if s.Slots != nil && *s.Slots < 1 {
+		errs = append(errs, fmt.Errorf("number of slots must be >= 1, got %d", *s.Slots))
+	}
+	if s.Contracts != nil && *s.Contracts < 1 {
+		errs = append(errs, fmt.Errorf("number of contracts must be >= 1, got %d", *s.Contracts))
+	}
+	if len(errs) == 0 {
+		if err := app.CheckStorageConfig(s.AppConfig()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Check tests semantic constraints on the parameters of a query application.
+func (q *Query) Check() error {
+	if q.LogRange != nil && *q.LogRange < 1 {
+		return fmt.Errorf("log range must be >= 1, got %d", *q.LogRange)
+	}
+	return app.CheckQueryConfig(q.AppConfig())
+}
+
+// Check tests semantic constraints on the parameters of a custom application.
+func (c *Custom) Check() error {
+	errs := []error{}
+	if c.Abi == "" {
+		errs = append(errs, fmt.Errorf("ABI file of custom application must be specified"))
+	}
+	if c.Bin == "" {
+		errs = append(errs, fmt.Errorf("bytecode file of custom application must be specified"))
+	}
+	if len(c.Calls) == 0 {
+		errs = append(errs, fmt.Errorf("custom application must define at least one call"))
+	}
+	for _, call := range c.Calls {
+		if call.Weight != nil && *call.Weight <= 0 {
+			errs = append(errs, fmt.Errorf("weight of call %s must be > 0, got %f", call.Method, *call.Weight))
+		}
+		if call.GasLimit != nil && *call.GasLimit == 0 {
+			errs = append(errs, fmt.Errorf("gas limit of call %s must be > 0", call.Method))
+		}
+	}
+	if len(errs) == 0 {
+		if err := app.CheckCustomConfig(c.AppConfig()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Check tests semantic constraints on the configuration of an adaptive traffic pattern.
+func (a *Adaptive) Check() error {
+	errs := []error{}
+
+	switch a.Indicator {
+	case LatencyP95Indicator, TxPoolPendingIndicator:
+	default:
+		errs = append(errs, fmt.Errorf("unknown service level indicator: %v", a.Indicator))
+	}
+	if a.Target <= 0 {
+		errs = append(errs, fmt.Errorf("service level target must be > 0, got %f", a.Target))
+	}
+	if a.Initial != nil && *a.Initial < 0 {
+		errs = append(errs, fmt.Errorf("initial transaction rate must be >= 0, got %f", *a.Initial))
+	}
+	if a.Max != nil && *a.Max <= 0 {
+		errs = append(errs, fmt.Errorf("maximum transaction rate must be > 0, got %f", *a.Max))
+	}
+	if a.Initial != nil && a.Max != nil && *a.Initial > *a.Max {
+		errs = append(errs, fmt.Errorf("initial transaction rate must be <= maximum rate, got %f > %f", *a.Initial, *a.Max))
+	}
+	for name, gain := range map[string]*float32{"proportional": a.Kp, "integral": a.Ki, "derivative": a.Kd} {
+		if gain != nil && *gain < 0 {
+			errs = append(errs, fmt.Errorf("%s gain must be >= 0, got %f", name, *gain))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 // checkTimeInterval is a utility function checking the validity of a start/end time pair.
 func checkTimeInterval(start, end *float32, duration float32) error {
 	realStart := float32(0.0)
@@ -232,3 +720,60 @@ func checkTimeInterval(start, end *float32, duration float32) error {
 	}
 	return errors.Join(errs...)
 }
+
+// Check tests semantic constraints on the configuration of a trace traffic pattern,
+// including the content of the referenced file.
+func (t *Trace) Check() error {
+	errs := []error{}
+
+	if t.Scale != nil && *t.Scale < 0 {
+		errs = append(errs, fmt.Errorf("trace scale must be >= 0, got %f", *t.Scale))
+	}
+	if _, err := ReadTrace(t.File); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Check tests semantic constraints on the configuration of a Poisson traffic pattern.
+func (p *Poisson) Check() error {
+	if p.Mean < 0 {
+		return fmt.Errorf("mean transaction rate must be >= 0, got %f", p.Mean)
+	}
+	return nil
+}
+
+// Check tests semantic constraints on the configuration of an on/off traffic pattern.
+func (b *Bursts) Check() error {
+	errs := []error{}
+
+	if b.Rate < 0 {
+		errs = append(errs, fmt.Errorf("burst transaction rate must be >= 0, got %f", b.Rate))
+	}
+	if b.Length <= 0 {
+		errs = append(errs, fmt.Errorf("burst length must be > 0, got %f", b.Length))
+	}
+	if b.Idle < 0 {
+		errs = append(errs, fmt.Errorf("idle length must be >= 0, got %f", b.Idle))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Check tests semantic constraints on the configuration of a random steps traffic pattern.
+func (s *Steps) Check() error {
+	errs := []error{}
+
+	if s.Min < 0 {
+		errs = append(errs, fmt.Errorf("minimum transaction rate must be >= 0, got %f", s.Min))
+	}
+	if s.Min > s.Max {
+		errs = append(errs, fmt.Errorf("minimum transaction rate must be <= maximum rate, got %f > %f", s.Min, s.Max))
+	}
+	if s.Interval <= 0 {
+		errs = append(errs, fmt.Errorf("step interval must be > 0, got %f", s.Interval))
+	}
+
+	return errors.Join(errs...)
+}
@@ -233,6 +233,153 @@ func TestRateCheck_InvalidWaveIsDetected(t *testing.T) {
 	}
 }
 
+func TestRateCheck_InvalidPoissonIsDetected(t *testing.T) {
+	scenario := Scenario{}
+	rate := Rate{Poisson: &Poisson{Mean: 10}}
+	if err := rate.Check(&scenario); err != nil {
+		t.Errorf("valid poisson rate of %v should be fine, but received the error %v", *rate.Poisson, err)
+	}
+	rate.Poisson.Mean = -10
+	if err := rate.Check(&scenario); err == nil {
+		t.Errorf("negative poisson rate specification should be detected")
+	}
+}
+
+func TestRateCheck_InvalidBurstsAreDetected(t *testing.T) {
+	scenario := Scenario{}
+	rate := Rate{Bursts: &Bursts{Rate: 100, Length: 5, Idle: 10}}
+	if err := rate.Check(&scenario); err != nil {
+		t.Errorf("valid bursts of %v should be fine, but received the error %v", *rate.Bursts, err)
+	}
+	for _, bursts := range []Bursts{
+		{Rate: -1, Length: 5, Idle: 10},
+		{Rate: 100, Length: 0, Idle: 10},
+		{Rate: 100, Length: 5, Idle: -1},
+	} {
+		rate.Bursts = &bursts
+		if err := rate.Check(&scenario); err == nil {
+			t.Errorf("invalid bursts specification %v should be detected", bursts)
+		}
+	}
+}
+
+func TestRateCheck_InvalidStepsAreDetected(t *testing.T) {
+	scenario := Scenario{}
+	rate := Rate{Steps: &Steps{Min: 10, Max: 100, Interval: 5}}
+	if err := rate.Check(&scenario); err != nil {
+		t.Errorf("valid steps of %v should be fine, but received the error %v", *rate.Steps, err)
+	}
+	for _, steps := range []Steps{
+		{Min: -1, Max: 100, Interval: 5},
+		{Min: 100, Max: 10, Interval: 5},
+		{Min: 10, Max: 100, Interval: 0},
+	} {
+		rate.Steps = &steps
+		if err := rate.Check(&scenario); err == nil {
+			t.Errorf("invalid steps specification %v should be detected", steps)
+		}
+	}
+}
+
+func TestRateCheck_CompositeRatesAreChecked(t *testing.T) {
+	scenario := Scenario{}
+	valid := func() Rate {
+		rate := Rate{Constant: new(float32)}
+		return rate
+	}
+	invalid := func() Rate {
+		rate := Rate{Constant: new(float32)}
+		*rate.Constant = -10
+		return rate
+	}
+	duration := float32(10)
+	negative := float32(-1)
+	low, high := float32(10), float32(20)
+
+	tests := map[string]struct {
+		rate  Rate
+		issue string
+	}{
+		"valid sequence":        {Rate{Sequence: []Phase{{Duration: &duration, Rate: valid()}, {Rate: valid()}}}, ""},
+		"empty sequence":        {Rate{Sequence: []Phase{}}, "at least one phase"},
+		"open inner phase":      {Rate{Sequence: []Phase{{Rate: valid()}, {Rate: valid()}}}, "only the last phase"},
+		"negative duration":     {Rate{Sequence: []Phase{{Duration: &negative, Rate: valid()}}}, "phase duration must be > 0"},
+		"invalid phase rate":    {Rate{Sequence: []Phase{{Rate: invalid()}}}, "invalid rate of phase 0"},
+		"valid sum":             {Rate{Sum: []Rate{valid(), valid()}}, ""},
+		"empty sum":             {Rate{Sum: []Rate{}}, "at least one rate"},
+		"invalid summand":       {Rate{Sum: []Rate{valid(), invalid()}}, "invalid rate of summand 1"},
+		"nested unit":           {Rate{Sum: []Rate{{Constant: new(float32), Unit: GasUnit}}}, "only be specified for the top-level rate"},
+		"valid clamp":           {Rate{Clamp: &Clamp{Min: &low, Max: &high, Rate: valid()}}, ""},
+		"inverted clamp":        {Rate{Clamp: &Clamp{Min: &high, Max: &low, Rate: valid()}}, "minimum transaction rate must be <= maximum rate"},
+		"invalid clamped rate":  {Rate{Clamp: &Clamp{Rate: invalid()}}, "invalid clamped rate"},
+		"nested auto in gas":    {Rate{Sum: []Rate{{Auto: new(Auto)}}, Unit: GasUnit}, "auto load shape does not support"},
+		"multiple composites":   {Rate{Sum: []Rate{valid()}, Clamp: &Clamp{Rate: valid()}}, "exactly one load shape"},
+		"missing clamped shape": {Rate{Clamp: &Clamp{}}, "exactly one load shape"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.rate.Check(&scenario)
+			if test.issue == "" && err != nil {
+				t.Errorf("valid rate should be fine, but received the error %v", err)
+			}
+			if test.issue != "" && (err == nil || !strings.Contains(err.Error(), test.issue)) {
+				t.Errorf("expected error containing %q, got %v", test.issue, err)
+			}
+		})
+	}
+}
+
+func TestRateCheck_UnitIsChecked(t *testing.T) {
+	scenario := Scenario{}
+	rate := Rate{}
+	rate.Constant = new(float32)
+	for _, unit := range []string{"", TxUnit, GasUnit} {
+		rate.Unit = unit
+		if err := rate.Check(&scenario); err != nil {
+			t.Errorf("valid unit %q should be fine, but received the error %v", unit, err)
+		}
+	}
+	rate.Unit = "wei"
+	if err := rate.Check(&scenario); err == nil || !strings.Contains(err.Error(), "unknown rate unit") {
+		t.Errorf("unknown unit should be detected, got %v", err)
+	}
+}
+
+func TestRateCheck_AutoShapeInGasUnitIsDetected(t *testing.T) {
+	scenario := Scenario{}
+	rate := Rate{Auto: new(Auto), Unit: GasUnit}
+	if err := rate.Check(&scenario); err == nil || !strings.Contains(err.Error(), "auto load shape does not support") {
+		t.Errorf("auto shape in gas unit should be detected, got %v", err)
+	}
+}
+
+func TestRateCheck_InvalidAdaptiveShapeIsDetected(t *testing.T) {
+	scenario := Scenario{}
+	rate := Rate{Adaptive: &Adaptive{Indicator: LatencyP95Indicator, Target: 2000}}
+	if err := rate.Check(&scenario); err != nil {
+		t.Errorf("valid adaptive shape of %v should be fine, but received the error %v", *rate.Adaptive, err)
+	}
+	low, high, negative := float32(10), float32(100), float32(-1)
+	for _, adaptive := range []Adaptive{
+		{Indicator: "throughput", Target: 2000},
+		{Indicator: TxPoolPendingIndicator, Target: 0},
+		{Indicator: LatencyP95Indicator, Target: 2000, Initial: &negative},
+		{Indicator: LatencyP95Indicator, Target: 2000, Max: &negative},
+		{Indicator: LatencyP95Indicator, Target: 2000, Initial: &high, Max: &low},
+		{Indicator: LatencyP95Indicator, Target: 2000, Ki: &negative},
+	} {
+		rate.Adaptive = &adaptive
+		if err := rate.Check(&scenario); err == nil {
+			t.Errorf("invalid adaptive specification %v should be detected", adaptive)
+		}
+	}
+
+	rate = Rate{Adaptive: &Adaptive{Indicator: LatencyP95Indicator, Target: 2000}, Unit: GasUnit}
+	if err := rate.Check(&scenario); err == nil || !strings.Contains(err.Error(), "adaptive load shape does not support") {
+		t.Errorf("adaptive shape in gas unit should be detected, got %v", err)
+	}
+}
+
 func TestApplication_InvalidNameIsDetected(t *testing.T) {
 	scenario := Scenario{}
 	app := Application{}
@@ -319,6 +466,322 @@ func TestApplication_DetectsShapeIssue(t *testing.T) {
 	}
 }
 
+func TestApplication_DetectsTransferIssues(t *testing.T) {
+	scenario := Scenario{}
+	ratio := float32(0.2)
+	app := Application{
+		Name:     "test",
+		Type:     "transfer",
+		Rate:     Rate{Constant: new(float32)},
+		Transfer: &Transfer{NewAccounts: &ratio},
+	}
+	if err := app.Check(&scenario); err != nil {
+		t.Errorf("valid transfer parameters should be fine, but got error: %v", err)
+	}
+	ratio = 1.5
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "between 0 and 1") {
+		t.Errorf("invalid fraction of transfers to new accounts was not detected, got %v", err)
+	}
+	ratio = 0.2
+	app.Type = "counter"
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "only supported by transfer applications") {
+		t.Errorf("transfer parameters of other application types were not detected, got %v", err)
+	}
+}
+
+func TestApplication_DetectsNFTIssues(t *testing.T) {
+	scenario := Scenario{}
+	mint, burn := float32(0.6), float32(0.4)
+	app := Application{
+		Name: "test",
+		Type: "nft",
+		Rate: Rate{Constant: new(float32)},
+		NFT:  &NFT{Mint: &mint, Burn: &burn},
+	}
+	if err := app.Check(&scenario); err != nil {
+		t.Errorf("valid nft parameters should be fine, but got error: %v", err)
+	}
+	burn = -1
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "proportion of burn operations must be >= 0") {
+		t.Errorf("invalid proportion was not detected, got %v", err)
+	}
+	burn = 0.4
+	app.Type = "erc20"
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "only supported by nft applications") {
+		t.Errorf("nft parameters of other application types were not detected, got %v", err)
+	}
+}
+
+func TestApplication_DetectsDeployerIssues(t *testing.T) {
+	scenario := Scenario{}
+	size, factory := 2048, float32(0.3)
+	app := Application{
+		Name:     "test",
+		Type:     "deployer",
+		Rate:     Rate{Constant: new(float32)},
+		Deployer: &Deployer{CodeSize: &size, Factory: &factory},
+	}
+	if err := app.Check(&scenario); err != nil {
+		t.Errorf("valid deployer parameters should be fine, but got error: %v", err)
+	}
+	size = 30000
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "code size must be between") {
+		t.Errorf("too large code size was not detected, got %v", err)
+	}
+	size = 2048
+	factory = 1.5
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "between 0 and 1") {
+		t.Errorf("invalid fraction of factory deployments was not detected, got %v", err)
+	}
+	factory = 0.3
+	app.Type = "counter"
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "only supported by deployer applications") {
+		t.Errorf("deployer parameters of other application types were not detected, got %v", err)
+	}
+}
+
+func TestApplication_DetectsStorageIssues(t *testing.T) {
+	scenario := Scenario{}
+	slots, reads := 1000, float32(0.5)
+	app := Application{
+		Name:    "test",
+		Type:    "storage",
+		Rate:    Rate{Constant: new(float32)},
+		Storage: &Storage{Slots: &slots, Reads: &reads, Distribution: "zipfian"},
+	}
+	if err := app.Check(&scenario); err != nil {
+		t.Errorf("valid storage parameters should be fine, but got error: %v", err)
+	}
+	slots = 0
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "number of slots must be >= 1") {
+		t.Errorf("invalid number of slots was not detected, got %v", err)
+	}
+	slots = 1000
+	reads = 2
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "fraction of reads must be between 0 and 1") {
+		t.Errorf("invalid fraction of reads was not detected, got %v", err)
+	}
+	reads = 0.5
+	app.Storage.Distribution = "normal"
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "unknown distribution") {
+		t.Errorf("unknown distribution was not detected, got %v", err)
+	}
+	app.Storage.Distribution = "uniform"
+	app.Type = "store"
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "only supported by storage applications") {
+		t.Errorf("storage parameters of other application types were not detected, got %v", err)
+	}
+}
+
+func TestApplication_DetectsAdversarialIssues(t *testing.T) {
+	scenario := Scenario{}
+	app := Application{
+		Name:        "test",
+		Type:        "adversarial",
+		Rate:        Rate{Constant: new(float32)},
+		Adversarial: Adversarial{"underpriced": 2, "nonce_gap": 1},
+	}
+	if err := app.Check(&scenario); err != nil {
+		t.Errorf("valid adversarial parameters should be fine, but got error: %v", err)
+	}
+	app.Adversarial["unknown"] = 1
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "unknown category of adversarial transactions: unknown") {
+		t.Errorf("unknown category was not detected, got %v", err)
+	}
+	delete(app.Adversarial, "unknown")
+	app.Adversarial["spam"] = -1
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "weight of category spam must be >= 0") {
+		t.Errorf("negative weight was not detected, got %v", err)
+	}
+	delete(app.Adversarial, "spam")
+	app.Type = "transfer"
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "only supported by adversarial applications") {
+		t.Errorf("adversarial parameters of other application types were not detected, got %v", err)
+	}
+}
+
+func TestApplication_DetectsQueryIssues(t *testing.T) {
+	scenario := Scenario{}
+	logRange := 10
+	app := Application{
+		Name:  "test",
+		Type:  "query",
+		Rate:  Rate{Constant: new(float32)},
+		Query: &Query{Methods: map[string]float32{"eth_call": 2, "eth_getLogs": 1}, LogRange: &logRange},
+	}
+	if err := app.Check(&scenario); err != nil {
+		t.Errorf("valid query parameters should be fine, but got error: %v", err)
+	}
+	app.Query.Methods["eth_sendRawTransaction"] = 1
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "unsupported query method: eth_sendRawTransaction") {
+		t.Errorf("unsupported method was not detected, got %v", err)
+	}
+	delete(app.Query.Methods, "eth_sendRawTransaction")
+	logRange = 0
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "log range must be >= 1") {
+		t.Errorf("invalid log range was not detected, got %v", err)
+	}
+	logRange = 10
+	app.Mode = ClosedLoopMode
+	app.Rate = Rate{}
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "query applications do not support the closed-loop mode") {
+		t.Errorf("closed-loop query application was not detected, got %v", err)
+	}
+	app.Mode = ""
+	app.Rate = Rate{Constant: new(float32)}
+	app.Query.Historical = true
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "historical queries require at least one archive node") {
+		t.Errorf("historical queries without archive nodes were not detected, got %v", err)
+	}
+	scenario.Nodes = []Node{{Name: "archive", Features: []string{"archive"}}}
+	if err := app.Check(&scenario); err != nil {
+		t.Errorf("historical queries with archive nodes should be fine, but got error: %v", err)
+	}
+	app.Query.Historical = false
+	app.Type = "counter"
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "only supported by query applications") {
+		t.Errorf("query parameters of other application types were not detected, got %v", err)
+	}
+}
+
+func TestApplication_DetectsTxTypeIssues(t *testing.T) {
+	scenario := Scenario{}
+	tip := float32(1)
+	maxTip := float32(10)
+	app := Application{
+		Name:   "test",
+		Type:   "counter",
+		Rate:   Rate{Constant: new(float32)},
+		TxType: "dynamic_fee",
+		Fees:   &Fees{Tip: &tip, MaxTip: &maxTip, Distribution: "exponential"},
+	}
+	if err := app.Check(&scenario); err != nil {
+		t.Errorf("valid transaction type should be fine, but got error: %v", err)
+	}
+	app.Fees.MaxTip = nil
+	if err := app.Check(&scenario); err != nil {
+		t.Errorf("missing maximum tip should default to the tip, but got error: %v", err)
+	}
+	app.TxType = "blob"
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "unknown transaction type 'blob'") {
+		t.Errorf("unknown transaction type was not detected, got %v", err)
+	}
+	app.TxType = "access_list"
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "fee cap and tip are only supported by dynamic_fee transactions") {
+		t.Errorf("fees of access-list transactions were not detected, got %v", err)
+	}
+	app.Fees = nil
+	if err := app.Check(&scenario); err != nil {
+		t.Errorf("access-list transactions should be fine, but got error: %v", err)
+	}
+	app.Type = "adversarial"
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "transaction types are not supported by adversarial applications") {
+		t.Errorf("transaction type of adversarial application was not detected, got %v", err)
+	}
+}
+
+func TestApplication_DetectsCustomIssues(t *testing.T) {
+	scenario := Scenario{}
+	weight := float32(2)
+	app := Application{
+		Name: "test",
+		Type: "custom",
+		Rate: Rate{Constant: new(float32)},
+		Custom: &Custom{
+			Abi:      "../../load/contracts/abi/Store.abi",
+			Bin:      "../../load/contracts/abi/Store.bin",
+			Calls:    []Call{{Method: "put", Weight: &weight, Args: []string{"$random(100)", "$counter"}}},
+			Received: "getCount",
+		},
+	}
+	if err := app.Check(&scenario); err != nil {
+		t.Errorf("valid custom parameters should be fine, but got error: %v", err)
+	}
+	weight = 0
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "weight of call put must be > 0") {
+		t.Errorf("invalid call weight was not detected, got %v", err)
+	}
+	weight = 2
+	app.Custom.Calls[0].Method = "unknown"
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "unknown method: unknown") {
+		t.Errorf("unknown method was not detected, got %v", err)
+	}
+	app.Custom.Calls[0].Method = "put"
+	app.Type = "counter"
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "only supported by custom applications") {
+		t.Errorf("custom parameters of other application types were not detected, got %v", err)
+	}
+	app.Type = "custom"
+	app.Custom = nil
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "must define custom parameters") {
+		t.Errorf("missing custom parameters were not detected, got %v", err)
+	}
+}
+
+func TestApplication_DetectsMixIssues(t *testing.T) {
+	scenario := Scenario{}
+	weight := float32(3)
+	ratio := float32(0.5)
+	app := Application{
+		Name:     "test",
+		Type:     "mix",
+		Rate:     Rate{Constant: new(float32)},
+		Mix:      []MixComponent{{Type: "erc20", Weight: &weight}, {Type: "transfer"}},
+		Transfer: &Transfer{NewAccounts: &ratio},
+	}
+	if err := app.Check(&scenario); err != nil {
+		t.Errorf("valid mix components should be fine, but got error: %v", err)
+	}
+	weight = -1
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "weight of component erc20 must be > 0") {
+		t.Errorf("invalid component weight was not detected, got %v", err)
+	}
+	weight = 3
+	app.Mix[1].Type = "counter"
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "only supported by transfer applications") {
+		t.Errorf("transfer parameters without transfer component were not detected, got %v", err)
+	}
+	app.Transfer = nil
+	app.Mix = nil
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "at least one component must be defined") {
+		t.Errorf("missing components were not detected, got %v", err)
+	}
+	app.Type = "counter"
+	app.Mix = []MixComponent{{Type: "erc20"}}
+	if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), "only supported by mix applications") {
+		t.Errorf("mix components of other application types were not detected, got %v", err)
+	}
+}
+
+func TestApplication_ClosedLoopModeIsAccepted(t *testing.T) {
+	scenario := Scenario{}
+	thinkTime, timeout := float32(0.5), float32(10)
+	app := Application{Name: "test", Type: "counter", Mode: ClosedLoopMode, ThinkTime: &thinkTime, ReceiptTimeout: &timeout}
+	if err := app.Check(&scenario); err != nil {
+		t.Errorf("closed-loop application should be valid, but got error: %v", err)
+	}
+}
+
+func TestApplication_DetectsModeIssues(t *testing.T) {
+	scenario := Scenario{}
+	negative, zero := float32(-1), float32(0)
+	tests := map[string]Application{
+		"unknown application mode: half-open":                      {Mode: "half-open"},
+		"must not specify a load shape":                            {Mode: ClosedLoopMode, Rate: Rate{Constant: new(float32)}},
+		"think time must be >= 0":                                  {Mode: ClosedLoopMode, ThinkTime: &negative},
+		"receipt timeout must be > 0":                              {Mode: ClosedLoopMode, ReceiptTimeout: &zero},
+		"think time and receipt timeout are only supported in the": {Mode: OpenLoopMode, ThinkTime: &zero, Rate: Rate{Constant: new(float32)}},
+		"application must specify exactly one load shape, got 0":   {Mode: OpenLoopMode},
+	}
+	for want, app := range tests {
+		app.Name = "test"
+		app.Type = "counter"
+		if err := app.Check(&scenario); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("issue %q was not detected, got %v", want, err)
+		}
+	}
+}
+
 func TestNode_InvalidNameIsDetected(t *testing.T) {
 	scenario := Scenario{}
 	node := Node{}
@@ -441,3 +904,126 @@ func TestScenario_ApplicationIssuesAreDetected(t *testing.T) {
 		t.Errorf("application issue was not detected")
 	}
 }
+
+func TestRpcPool_DefaultConfigurationIsValid(t *testing.T) {
+	pool := RpcPool{}
+	if err := pool.Check(); err != nil {
+		t.Errorf("default RPC pool configuration should be valid, got %v", err)
+	}
+}
+
+func TestRpcPool_SupportedOptionsAreAccepted(t *testing.T) {
+	workers := 10
+	for _, transport := range []string{"ws", "http"} {
+		for _, routing := range []string{"any", "rpc-nodes", "sticky", "round-robin", "weighted"} {
+			pool := RpcPool{Workers: &workers, Transport: transport, Routing: routing}
+			if err := pool.Check(); err != nil {
+				t.Errorf("configuration %v should be valid, got %v", pool, err)
+			}
+		}
+	}
+	pool := RpcPool{Routing: "weighted", Weights: map[string]float32{"validator": 0, "rpc": 2}}
+	if err := pool.Check(); err != nil {
+		t.Errorf("weighted configuration should be valid, got %v", err)
+	}
+}
+
+func TestRpcPool_InvalidOptionsAreDetected(t *testing.T) {
+	workers := 0
+	tests := map[string]RpcPool{
+		"number of RPC workers per node must be >= 1":            {Workers: &workers},
+		"unknown RPC transport: grpc":                            {Transport: "grpc"},
+		"unknown RPC routing: random":                            {Routing: "random"},
+		"weights are only supported by the weighted routing":     {Weights: map[string]float32{"A": 1}},
+		"RPC weight of node group A must be >= 0, got -1.000000": {Routing: "weighted", Weights: map[string]float32{"A": -1}},
+	}
+	for want, pool := range tests {
+		if err := pool.Check(); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("issue %q was not detected, got %v", want, err)
+		}
+	}
+}
+
+func TestScenario_RpcPoolIssuesAreDetected(t *testing.T) {
+	scenario := Scenario{
+		Name:     "Test",
+		Duration: 60,
+		RpcPool:  &RpcPool{Routing: "random"},
+	}
+	if err := scenario.Check(); err == nil || !strings.Contains(err.Error(), "unknown RPC routing") {
+		t.Errorf("RPC pool issue was not detected")
+	}
+}
+
+func TestScenario_RpcRoutingWithoutTargetNodesIsDetected(t *testing.T) {
+	zero := 0
+	tests := map[string]Scenario{
+		"requires at least one non-validator node": {
+			RpcPool: &RpcPool{Routing: "rpc-nodes"},
+			Nodes:   []Node{{Name: "rpc", Instances: &zero}},
+		},
+		"RPC weights of all node groups are 0": {
+			RpcPool: &RpcPool{Routing: "weighted", Weights: map[string]float32{"validator": 0, "rpc": 0}},
+			Nodes:   []Node{{Name: "rpc"}},
+		},
+	}
+	for want, scenario := range tests {
+		scenario.Name = "Test"
+		scenario.Duration = 60
+		if err := scenario.Check(); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("issue %q was not detected, got %v", want, err)
+		}
+	}
+}
+
+func TestScenario_RpcRoutingWithTargetNodesIsAccepted(t *testing.T) {
+	tests := []Scenario{
+		{RpcPool: &RpcPool{Routing: "rpc-nodes"}, Nodes: []Node{{Name: "rpc"}}},
+		{RpcPool: &RpcPool{Routing: "weighted", Weights: map[string]float32{"validator": 0}}, Nodes: []Node{{Name: "rpc"}}},
+		{RpcPool: &RpcPool{Routing: "weighted", Weights: map[string]float32{"rpc": 0}}},
+	}
+	for _, scenario := range tests {
+		scenario.Name = "Test"
+		scenario.Duration = 60
+		if err := scenario.Check(); err != nil {
+			t.Errorf("scenario with routing %s should be valid, got %v", scenario.RpcPool.Routing, err)
+		}
+	}
+}
+
+func TestSubscriptions_SupportedOptionsAreAccepted(t *testing.T) {
+	perNode := 5
+	for _, subscriptions := range []Subscriptions{
+		{},
+		{PerNode: &perNode},
+		{Kinds: []string{"newHeads", "logs", "newPendingTransactions"}},
+	} {
+		if err := subscriptions.Check(); err != nil {
+			t.Errorf("configuration %v should be valid, got %v", subscriptions, err)
+		}
+	}
+}
+
+func TestSubscriptions_InvalidOptionsAreDetected(t *testing.T) {
+	perNode := 0
+	tests := map[string]Subscriptions{
+		"number of subscriptions per node must be >= 1": {PerNode: &perNode},
+		"unknown subscription kind: syncing":            {Kinds: []string{"logs", "syncing"}},
+	}
+	for want, subscriptions := range tests {
+		if err := subscriptions.Check(); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("issue %q was not detected, got %v", want, err)
+		}
+	}
+}
+
+func TestScenario_SubscriptionIssuesAreDetected(t *testing.T) {
+	scenario := Scenario{
+		Name:          "Test",
+		Duration:      60,
+		Subscriptions: &Subscriptions{Kinds: []string{"syncing"}},
+	}
+	if err := scenario.Check(); err == nil || !strings.Contains(err.Error(), "unknown subscription kind") {
+		t.Errorf("subscription issue was not detected")
+	}
+}
@@ -20,7 +20,10 @@ import (
 	"bytes"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/Fantom-foundation/Norma/load/app"
 	"gopkg.in/yaml.v3"
 )
 
@@ -29,9 +32,11 @@ import (
 type Scenario struct {
 	Name          string
 	Duration      float32
-	NumValidators *int          `yaml:"num_validators,omitempty"` // nil == 1
-	Nodes         []Node        `yaml:",omitempty"`
-	Applications  []Application `yaml:",omitempty"`
+	NumValidators *int           `yaml:"num_validators,omitempty"` // nil == 1
+	Nodes         []Node         `yaml:",omitempty"`
+	Applications  []Application  `yaml:",omitempty"`
+	RpcPool       *RpcPool       `yaml:"rpc_pool,omitempty"`      // nil == default pool configuration
+	Subscriptions *Subscriptions `yaml:"subscriptions,omitempty"` // nil == no subscriptions
 }
 
 // Node is a configuration for a group of nodes with similar properties.
@@ -46,34 +51,328 @@ type Node struct {
 	End       *float32 `yaml:",omitempty"` // nil is interpreted as end-of-scenario
 }
 
+// RpcPool configures the way transactions produced by applications are sent to the
+// nodes of the network. The supported routing policies are:
+//   - any         ... transactions are sent by any free worker of any node
+//   - rpc-nodes   ... like any, but validators are not receiving transactions
+//   - sticky      ... all transactions of a sender are sent to the same node
+//   - round-robin ... transactions are assigned to nodes in turn
+//   - weighted    ... transactions are assigned to random nodes according to
+//     the weights of their node groups
+type RpcPool struct {
+	Workers   *int               `yaml:",omitempty"` // number of workers per node, nil = 150
+	Transport string             `yaml:",omitempty"` // "ws" or "http", empty = "ws"
+	Routing   string             `yaml:",omitempty"` // empty = "any"
+	Weights   map[string]float32 `yaml:",omitempty"` // per node group, missing = 1, validators form the group "validator"
+}
+
+// Subscriptions configures WebSocket subscriptions opened on each node of the network
+// to measure the delivery of notifications. The supported kinds are:
+//   - newHeads               ... notifications about new blocks
+//   - logs                   ... notifications about events emitted by the applications
+//   - newPendingTransactions ... notifications about transactions entering the pool
+type Subscriptions struct {
+	PerNode *int     `yaml:"per_node,omitempty"` // number of subscriptions per node and kind, nil = 1
+	Kinds   []string `yaml:",omitempty"`         // empty = all kinds
+}
+
 // Application is a load generator in the simulated network. Each application defines
 // a type application load is generated for, a start and end time, a traffic
 // shape (see Rate below), and a number of instances.
+//
+// By default, applications are run in the open-loop mode, where transactions are sent
+// following the traffic shape regardless of their confirmation. In the closed-loop mode,
+// each user sends a single transaction, waits for its inclusion in a block or a timeout,
+// pauses for a think time, and continues with the next transaction. Closed-loop
+// applications do not define a traffic shape.
 type Application struct {
-	Name      string
-	Type      string   `yaml:",omitempty"` // empty is interpreted as the default app type
-	Instances *int     `yaml:",omitempty"` // nil is interpreted as 1
-	Users     *int     `yaml:",omitempty"` // nil is interpreted as 1
-	Start     *float32 `yaml:",omitempty"` // nil is interpreted as 0
-	End       *float32 `yaml:",omitempty"` // nil is interpreted as end-of-scenario
-	Rate      Rate
+	Name           string
+	Type           string   `yaml:",omitempty"`                // empty is interpreted as the default app type
+	Instances      *int     `yaml:",omitempty"`                // nil is interpreted as 1
+	Users          *int     `yaml:",omitempty"`                // nil is interpreted as 1
+	Start          *float32 `yaml:",omitempty"`                // nil is interpreted as 0
+	End            *float32 `yaml:",omitempty"`                // nil is interpreted as end-of-scenario
+	Mode           string   `yaml:",omitempty"`                // "open-loop" or "closed-loop", empty is interpreted as open-loop
+	ThinkTime      *float32 `yaml:"think_time,omitempty"`      // seconds, nil is interpreted as 0
+	ReceiptTimeout *float32 `yaml:"receipt_timeout,omitempty"` // seconds, nil is interpreted as 30
+	Rate           Rate
+	TxType         string `yaml:"tx_type,omitempty"` // "legacy", "access_list", or "dynamic_fee", empty = legacy
+	Fees           *Fees  `yaml:",omitempty"`        // fee cap and tip of dynamic-fee transactions
+
+	// Type-specific parameters, only allowed for applications of the respective type.
+	Transfer    *Transfer      `yaml:",omitempty"`
+	Custom      *Custom        `yaml:",omitempty"`
+	Mix         []MixComponent `yaml:",omitempty"`
+	NFT         *NFT           `yaml:"nft,omitempty"`
+	Deployer    *Deployer      `yaml:",omitempty"`
+	Storage     *Storage       `yaml:",omitempty"`
+	Adversarial Adversarial    `yaml:",omitempty"`
+	Query       *Query         `yaml:",omitempty"`
+}
+
+// Fees defines the fee cap and the tip of dynamic-fee transactions. Tips are either
+// fixed or drawn from a distribution between the minimum and the maximum tip, letting
+// transactions compete for inclusion. The supported distributions are:
+//   - fixed       ... all transactions use the minimum tip
+//   - uniform     ... tips are uniformly distributed
+//   - exponential ... most tips are close to the minimum, few are close to the maximum
+type Fees struct {
+	FeeCap       *float32 `yaml:"fee_cap,omitempty"` // relative to the regular gas price, nil = 1
+	Tip          *float32 `yaml:",omitempty"`        // minimum tip in Gwei, nil = 0
+	MaxTip       *float32 `yaml:"max_tip,omitempty"` // maximum tip in Gwei, nil = tip
+	Distribution string   `yaml:",omitempty"`        // empty = fixed
+}
+
+// AppConfig converts the parameters to the fees of transactions of an application.
+// The type of the transactions is defined by the application.
+func (f *Fees) AppConfig() app.TxConfig {
+	res := app.TxConfig{TipDistribution: f.Distribution}
+	if f.FeeCap != nil {
+		res.FeeCapRatio = float64(*f.FeeCap)
+	}
+	if f.Tip != nil {
+		res.MinTip = float64(*f.Tip)
+	}
+	if f.MaxTip != nil {
+		res.MaxTip = float64(*f.MaxTip)
+	} else if f.Distribution != "" && !strings.EqualFold(f.Distribution, app.TipFixed) {
+		res.MaxTip = res.MinTip
+	}
+	return res
+}
+
+// Query defines the read-only requests sent by applications of the query type.
+type Query struct {
+	Methods    map[string]float32 `yaml:",omitempty"`          // relative frequencies indexed by RPC method, empty = all equally often
+	LogRange   *int               `yaml:"log_range,omitempty"` // blocks covered by eth_getLogs requests, nil = 100
+	Historical bool               `yaml:",omitempty"`          // query the state of past blocks on archive nodes
+}
+
+// AppConfig converts the parameters to the configuration of a query application.
+func (q *Query) AppConfig() app.QueryConfig {
+	res := app.QueryConfig{Weights: map[string]float64{}}
+	for method, weight := range q.Methods {
+		res.Weights[method] = float64(weight)
+	}
+	if q.LogRange != nil {
+		res.LogRange = *q.LogRange
+	}
+	res.Historical = q.Historical
+	return res
+}
+
+// Adversarial defines the relative frequencies of the categories of transactions sent by
+// applications of the adversarial type, indexed by category, e.g. underpriced or
+// gas_limit. If no frequency is set, all categories are sent equally often.
+type Adversarial map[string]float32
+
+// AppConfig converts the parameters to the configuration of an adversarial application.
+func (a Adversarial) AppConfig() app.AdversarialConfig {
+	res := app.AdversarialConfig{Weights: map[string]float64{}}
+	for category, weight := range a {
+		res.Weights[category] = float64(weight)
+	}
+	return res
+}
+
+// Storage defines the state accessed by applications of the storage type.
+type Storage struct {
+	Slots        *int     `yaml:",omitempty"` // distinct slots per contract, nil = 10000
+	Contracts    *int     `yaml:",omitempty"` // number of contracts, nil = 1
+	Reads        *float32 `yaml:",omitempty"` // fraction of operations reading a slot, nil = 0
+	Deletes      *float32 `yaml:",omitempty"` // fraction of writes clearing a slot, nil = 0
+	Distribution string   `yaml:",omitempty"` // "uniform" or "zipfian", empty = uniform
 }
 
-// Rate defines the shape of traffic to be generated. There are three types
+// AppConfig converts the parameters to the configuration of a storage application.
+func (s *Storage) AppConfig() app.StorageConfig {
+	res := app.StorageConfig{Distribution: s.Distribution}
+	if s.Slots != nil {
+		res.Slots = *s.Slots
+	}
+	if s.Contracts != nil {
+		res.Contracts = *s.Contracts
+	}
+	if s.Reads != nil {
+		res.ReadRatio = float64(*s.Reads)
+	}
+	if s.Deletes != nil {
+		res.DeleteRatio = float64(*s.Deletes)
+	}
+	return res
+}
+
+// Deployer defines the contracts deployed by applications of the deployer type.
+type Deployer struct {
+	CodeSize *int     `yaml:"code_size,omitempty"` // bytes of code of each contract, nil = 1024
+	Factory  *float32 `yaml:",omitempty"`          // fraction of contracts deployed through the factory, nil = 0.5
+}
+
+// AppConfig converts the parameters to the configuration of a deployer application.
+func (d *Deployer) AppConfig() app.DeployerConfig {
+	res := app.DeployerConfig{CodeSize: 1024, FactoryRatio: 0.5}
+	if d.CodeSize != nil {
+		res.CodeSize = *d.CodeSize
+	}
+	if d.Factory != nil {
+		res.FactoryRatio = float64(*d.Factory)
+	}
+	return res
+}
+
+// NFT defines the proportions of the operations performed by users of applications
+// of the nft type. Proportions are relative to each other, if none is set, mints,
+// transfers, and burns make up 50%, 30%, and 20% of the transactions.
+type NFT struct {
+	Mint     *float32 `yaml:",omitempty"` // nil = 0
+	Transfer *float32 `yaml:",omitempty"` // nil = 0
+	Burn     *float32 `yaml:",omitempty"` // nil = 0
+}
+
+// AppConfig converts the parameters to the configuration of an NFT application.
+func (n *NFT) AppConfig() app.NFTConfig {
+	get := func(value *float32) float64 {
+		if value == nil {
+			return 0
+		}
+		return float64(*value)
+	}
+	return app.NFTConfig{
+		MintRatio:     get(n.Mint),
+		TransferRatio: get(n.Transfer),
+		BurnRatio:     get(n.Burn),
+	}
+}
+
+// MixComponent defines an application type contributing to applications of the mix
+// type. Type-specific parameters of components, e.g. of transfer or custom applications,
+// are taken from the mix application.
+type MixComponent struct {
+	Type   string
+	Weight *float32 `yaml:",omitempty"` // relative frequency of the component's transactions, nil = 1
+}
+
+// AppConfig converts the component to the configuration of a component of a mix application.
+func (m *MixComponent) AppConfig() app.MixComponent {
+	res := app.MixComponent{Type: m.Type, Weight: 1}
+	if m.Weight != nil {
+		res.Weight = float64(*m.Weight)
+	}
+	return res
+}
+
+// Transfer defines the parameters of applications of the transfer type.
+type Transfer struct {
+	NewAccounts *float32 `yaml:"new_accounts,omitempty"` // fraction of transfers to new accounts, nil = 0
+}
+
+// AppConfig converts the parameters to the configuration of a transfer application.
+func (t *Transfer) AppConfig() app.TransferConfig {
+	res := app.TransferConfig{}
+	if t.NewAccounts != nil {
+		res.NewAccountRatio = float64(*t.NewAccounts)
+	}
+	return res
+}
+
+// Custom defines the contract and the method calls of applications of the custom type.
+// Call arguments are literal values or one of the generators $random, $random(N),
+// $user, and $counter, producing a random value, the address of the sending user,
+// and the number of calls sent by the user so far, respectively.
+type Custom struct {
+	Abi         string   // path of the JSON ABI file of the contract
+	Bin         string   // path of the file containing the hex-encoded contract bytecode
+	Constructor []string `yaml:",omitempty"` // constructor arguments
+	Calls       []Call
+	Received    string `yaml:",omitempty"` // view function counting received transactions, empty = count receipts
+}
+
+// Call defines a method call issued by users of a custom application.
+type Call struct {
+	Method   string
+	Weight   *float32 `yaml:",omitempty"`          // relative frequency, nil = 1
+	Args     []string `yaml:",omitempty"`          // arguments of the method
+	GasLimit *uint64  `yaml:"gas_limit,omitempty"` // nil = estimated
+}
+
+// AppConfig converts the parameters to the configuration of a custom application.
+func (c *Custom) AppConfig() app.CustomConfig {
+	res := app.CustomConfig{
+		AbiFile:         c.Abi,
+		BinFile:         c.Bin,
+		Constructor:     c.Constructor,
+		ReceivedCounter: c.Received,
+	}
+	for _, call := range c.Calls {
+		cur := app.CustomCall{
+			Method: call.Method,
+			Weight: 1,
+			Args:   call.Args,
+		}
+		if call.Weight != nil {
+			cur.Weight = float64(*call.Weight)
+		}
+		if call.GasLimit != nil {
+			cur.GasLimit = *call.GasLimit
+		}
+		res.Calls = append(res.Calls, cur)
+	}
+	return res
+}
+
+const (
+	// OpenLoopMode is the mode of applications sending transactions following a traffic shape.
+	OpenLoopMode = "open-loop"
+	// ClosedLoopMode is the mode of applications whose users wait for their transactions to be confirmed.
+	ClosedLoopMode = "closed-loop"
+)
+
+// Rate defines the shape of traffic to be generated. There are the following types
 // currently supported:
 //   - constant ... traffic is created at a constant rate
 //   - slope    ... traffic rate starts at 0 and is linearly increased
 //   - wave     ... traffic rate follows a sin-wave pattern
+//   - auto     ... traffic rate is adjusted to max out the throughput
+//   - trace    ... traffic rate follows a time series loaded from a file
+//   - poisson  ... traffic arrives following a Poisson process
+//   - bursts   ... traffic alternates between bursts and idle periods
+//   - steps    ... traffic rate changes randomly in regular intervals
+//   - adaptive ... traffic rate is adjusted to meet a service level objective
+//
+// Furthermore, rates can be composed of other rates:
+//   - sequence ... a list of rates, each used for a given duration
+//   - sum      ... the sum of a list of rates
+//   - clamp    ... a rate limited to a minimum and maximum rate
 //
-// Only one of those options can be set for a single source.
+// Only one of those options can be set for a single source. By default,
+// rates are expressed in Tx/s. With the unit set to gas, rates are expressed
+// in gas/s and converted to Tx/s based on the gas used by the application's
+// transactions.
 type Rate struct {
 	// Only one of the next fields may be set.
-	Constant *float32 `yaml:",omitempty"`
-	Slope    *Slope   `yaml:",omitempty"`
-	Wave     *Wave    `yaml:",omitempty"`
-	Auto     *Auto    `yaml:",omitempty"`
+	Constant *float32  `yaml:",omitempty"`
+	Slope    *Slope    `yaml:",omitempty"`
+	Wave     *Wave     `yaml:",omitempty"`
+	Auto     *Auto     `yaml:",omitempty"`
+	Trace    *Trace    `yaml:",omitempty"`
+	Poisson  *Poisson  `yaml:",omitempty"`
+	Bursts   *Bursts   `yaml:",omitempty"`
+	Steps    *Steps    `yaml:",omitempty"`
+	Sequence []Phase   `yaml:",omitempty"`
+	Sum      []Rate    `yaml:",omitempty"`
+	Clamp    *Clamp    `yaml:",omitempty"`
+	Adaptive *Adaptive `yaml:",omitempty"`
+
+	Unit string `yaml:",omitempty"` // "tx" or "gas", empty is interpreted as "tx", only allowed for top-level rates
 }
 
+const (
+	// TxUnit is the unit of rates expressed in transactions per second.
+	TxUnit = "tx"
+	// GasUnit is the unit of rates expressed in gas per second.
+	GasUnit = "gas"
+)
+
 // Slope defines the parameters of a linearly increasing traffic pattern.
 // The pattern is defined by a starting Tx/s rate and an increment per second.
 type Slope struct {
@@ -94,6 +393,77 @@ type Auto struct {
 	Decrease *float32 `yaml:",omitempty"` // decrease in overload case in percent, nil = 0.2 (=20%)
 }
 
+// Adaptive defines a load pattern adjusting the traffic rate using a PID controller
+// such that a service level indicator of the network meets the given target, e.g.
+// the 95th percentile of the transaction latency stays below a given number of
+// milliseconds. The rate the controller converges to is reported as the sustainable
+// throughput of the network.
+type Adaptive struct {
+	Indicator string   // "latency_p95" (in ms) or "txpool_pending"
+	Target    float32  // the targeted value of the indicator
+	Initial   *float32 `yaml:",omitempty"` // initial Tx/s, nil = 10
+	Max       *float32 `yaml:",omitempty"` // maximum Tx/s, nil = unlimited
+	Kp        *float32 `yaml:",omitempty"` // proportional gain in Tx/s, nil = 100
+	Ki        *float32 `yaml:",omitempty"` // integral gain in Tx/s per second, nil = 20
+	Kd        *float32 `yaml:",omitempty"` // derivative gain in Tx/s times seconds, nil = 0
+}
+
+const (
+	// LatencyP95Indicator is the 95th percentile of the transaction latency in ms.
+	LatencyP95Indicator = "latency_p95"
+	// TxPoolPendingIndicator is the maximum number of pending transactions in a node's pool.
+	TxPoolPendingIndicator = "txpool_pending"
+)
+
+// Trace defines a traffic pattern replaying a time series of rates loaded from a
+// CSV or JSON file. Rates between the points of the series are interpolated linearly.
+// Relative file paths are resolved relative to the scenario file.
+type Trace struct {
+	File  string
+	Loop  bool     `yaml:",omitempty"` // restart the series after its last point, false = keep the last rate
+	Scale *float32 `yaml:",omitempty"` // factor applied to all rates, nil = 1
+}
+
+// Poisson defines a traffic pattern where transactions arrive following a Poisson
+// process, i.e. with exponentially distributed times between transactions.
+type Poisson struct {
+	Mean float32 // Tx/s
+	Seed *int64  `yaml:",omitempty"` // seed of the random source, nil = time-based, logged
+}
+
+// Bursts defines an on/off traffic pattern alternating between bursts, in which
+// transactions are sent at a fixed rate, and idle periods without transactions.
+// The lengths of the periods are exponentially distributed with the given means.
+type Bursts struct {
+	Rate   float32 // Tx/s during bursts
+	Length float32 // mean length of bursts in seconds
+	Idle   float32 // mean length of idle periods in seconds
+	Seed   *int64  `yaml:",omitempty"` // seed of the random source, nil = time-based, logged
+}
+
+// Steps defines a traffic pattern where the rate is changed in regular intervals
+// to a rate drawn uniformly at random from the given range.
+type Steps struct {
+	Min      float32 // Tx/s
+	Max      float32 // Tx/s
+	Interval float32 // seconds between rate changes
+	Seed     *int64  `yaml:",omitempty"` // seed of the random source, nil = time-based, logged
+}
+
+// Phase is a single phase of a sequence traffic pattern. The rate of a phase
+// starts with the phase, e.g. a slope starts at its initial rate.
+type Phase struct {
+	Duration *float32 `yaml:",omitempty"` // seconds, nil = until the end, only allowed for the last phase
+	Rate     Rate
+}
+
+// Clamp defines a traffic pattern limiting another pattern to a range of rates.
+type Clamp struct {
+	Min  *float32 `yaml:",omitempty"` // Tx/s, nil = 0
+	Max  *float32 `yaml:",omitempty"` // Tx/s, nil = unlimited
+	Rate Rate
+}
+
 // Parse parses a YAML based scenario description from the given reader.
 // The parsing will fail if there are syntactic issues in the YAML file
 // or if there are unknown keys. However, no semantic checks on the resulting
@@ -114,8 +484,44 @@ func ParseBytes(data []byte) (Scenario, error) {
 // ParseFile parses the YAML encoded scenario in the given file.
 func ParseFile(path string) (Scenario, error) {
 	if reader, err := os.Open(path); err == nil {
-		return Parse(reader)
+		scenario, err := Parse(reader)
+		scenario.resolvePaths(filepath.Dir(path))
+		return scenario, err
 	} else {
 		return Scenario{}, err
 	}
 }
+
+// resolvePaths makes relative file paths referenced by the scenario relative to
+// the given directory.
+func (s *Scenario) resolvePaths(dir string) {
+	for i := range s.Applications {
+		s.Applications[i].Rate.resolvePaths(dir)
+		if custom := s.Applications[i].Custom; custom != nil {
+			custom.Abi = resolvePath(dir, custom.Abi)
+			custom.Bin = resolvePath(dir, custom.Bin)
+		}
+	}
+}
+
+func resolvePath(dir, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+func (r *Rate) resolvePaths(dir string) {
+	if r.Trace != nil && r.Trace.File != "" && !filepath.IsAbs(r.Trace.File) {
+		r.Trace.File = filepath.Join(dir, r.Trace.File)
+	}
+	for i := range r.Sequence {
+		r.Sequence[i].Rate.resolvePaths(dir)
+	}
+	for i := range r.Sum {
+		r.Sum[i].resolvePaths(dir)
+	}
+	if r.Clamp != nil {
+		r.Clamp.Rate.resolvePaths(dir)
+	}
+}
@@ -87,11 +87,31 @@ applications:
         min: 10
         max: 20
         period: 120
+
+rpc_pool:
+  workers: 20
+  transport: http
+  routing: weighted
+  weights:
+    validator: 0
+    A: 2
+
+subscriptions:
+  per_node: 3
+  kinds:
+    - newHeads
+    - logs
 `
 
 func TestParseSmallExampleWorks(t *testing.T) {
-	_, err := ParseBytes([]byte(smallExample))
+	scenario, err := ParseBytes([]byte(smallExample))
 	if err != nil {
 		t.Fatalf("parsing of input failed: %v", err)
 	}
+	if scenario.RpcPool == nil || scenario.RpcPool.Routing != "weighted" || scenario.RpcPool.Weights["A"] != 2 {
+		t.Errorf("RPC pool configuration not parsed correctly: %v", scenario.RpcPool)
+	}
+	if scenario.Subscriptions == nil || scenario.Subscriptions.PerNode == nil || *scenario.Subscriptions.PerNode != 3 || len(scenario.Subscriptions.Kinds) != 2 {
+		t.Errorf("subscriptions configuration not parsed correctly: %v", scenario.Subscriptions)
+	}
 }
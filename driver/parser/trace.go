@@ -0,0 +1,124 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package parser
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// TracePoint is a single point of a rate trace.
+type TracePoint struct {
+	Time float64 `json:"time"` // seconds since the start of the trace
+	Rate float64 `json:"rate"` // Tx/s
+}
+
+// ReadTrace reads a rate trace from the given file. Files with a .json extension
+// are expected to contain a list of objects with a time and a rate field, e.g.
+//
+//	[{"time": 0, "rate": 10}, {"time": 60, "rate": 20}]
+//
+// All other files are read as CSV files with a time and a rate column, optionally
+// preceded by a header line. Times must be non-negative and strictly increasing,
+// rates must be non-negative.
+func ReadTrace(path string) ([]TracePoint, error) {
+	if path == "" {
+		return nil, fmt.Errorf("trace file must be specified")
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open trace file; %v", err)
+	}
+	defer file.Close()
+
+	var points []TracePoint
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		points, err = readJsonTrace(file)
+	} else {
+		points, err = readCsvTrace(file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trace file %s; %v", path, err)
+	}
+	if err := checkTrace(points); err != nil {
+		return nil, fmt.Errorf("invalid trace in file %s; %v", path, err)
+	}
+	return points, nil
+}
+
+func readJsonTrace(reader io.Reader) ([]TracePoint, error) {
+	decoder := json.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+	var points []TracePoint
+	if err := decoder.Decode(&points); err != nil {
+		return nil, err
+	}
+	return points, nil
+}
+
+func readCsvTrace(reader io.Reader) ([]TracePoint, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = 2
+	csvReader.TrimLeadingSpace = true
+	csvReader.Comment = '#'
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]TracePoint, 0, len(records))
+	for i, record := range records {
+		time, timeErr := strconv.ParseFloat(record[0], 64)
+		rate, rateErr := strconv.ParseFloat(record[1], 64)
+		if i == 0 && timeErr != nil && rateErr != nil {
+			continue // a header line
+		}
+		if timeErr != nil {
+			return nil, fmt.Errorf("invalid time in line %d; %v", i+1, timeErr)
+		}
+		if rateErr != nil {
+			return nil, fmt.Errorf("invalid rate in line %d; %v", i+1, rateErr)
+		}
+		points = append(points, TracePoint{Time: time, Rate: rate})
+	}
+	return points, nil
+}
+
+func checkTrace(points []TracePoint) error {
+	if len(points) == 0 {
+		return fmt.Errorf("trace must contain at least one point")
+	}
+	for i, point := range points {
+		if math.IsNaN(point.Time) || math.IsInf(point.Time, 0) || point.Time < 0 {
+			return fmt.Errorf("time of point %d must be a non-negative number, got %v", i, point.Time)
+		}
+		if math.IsNaN(point.Rate) || math.IsInf(point.Rate, 0) || point.Rate < 0 {
+			return fmt.Errorf("rate of point %d must be a non-negative number, got %v", i, point.Rate)
+		}
+		if i > 0 && point.Time <= points[i-1].Time {
+			return fmt.Errorf("times must be strictly increasing, got %v after %v", point.Time, points[i-1].Time)
+		}
+	}
+	return nil
+}
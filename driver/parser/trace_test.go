@@ -0,0 +1,136 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeTrace(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write trace file: %v", err)
+	}
+	return path
+}
+
+func TestReadTrace_ReadsCsvFiles(t *testing.T) {
+	want := []TracePoint{{Time: 0, Rate: 10}, {Time: 60, Rate: 20.5}}
+	for name, content := range map[string]string{
+		"plain":       "0,10\n60,20.5\n",
+		"with header": "time,rate\n0, 10\n60, 20.5\n",
+		"comments":    "# exported rates\n0,10\n60,20.5\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := ReadTrace(writeTrace(t, "trace.csv", content))
+			if err != nil {
+				t.Fatalf("failed to read trace: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("unexpected trace, wanted %v, got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestReadTrace_ReadsJsonFiles(t *testing.T) {
+	path := writeTrace(t, "trace.json", `[{"time": 0, "rate": 10}, {"time": 60, "rate": 20.5}]`)
+	got, err := ReadTrace(path)
+	if err != nil {
+		t.Fatalf("failed to read trace: %v", err)
+	}
+	if want := []TracePoint{{Time: 0, Rate: 10}, {Time: 60, Rate: 20.5}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected trace, wanted %v, got %v", want, got)
+	}
+}
+
+func TestReadTrace_DetectsIssues(t *testing.T) {
+	tests := map[string]struct {
+		name    string
+		content string
+		issue   string
+	}{
+		"empty":          {"trace.csv", "", "at least one point"},
+		"missing column": {"trace.csv", "0,10\n60\n", "wrong number of fields"},
+		"invalid rate":   {"trace.csv", "0,10\n60,abc\n", "invalid rate in line 2"},
+		"negative rate":  {"trace.csv", "0,-10\n", "rate of point 0 must be a non-negative number"},
+		"negative time":  {"trace.csv", "-1,10\n", "time of point 0 must be a non-negative number"},
+		"unordered":      {"trace.csv", "0,10\n60,10\n30,10\n", "strictly increasing"},
+		"invalid json":   {"trace.json", `{"time": 0}`, "cannot unmarshal"},
+		"unknown field":  {"trace.json", `[{"time": 0, "tps": 10}]`, "unknown field"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ReadTrace(writeTrace(t, test.name, test.content))
+			if err == nil || !strings.Contains(err.Error(), test.issue) {
+				t.Errorf("expected error containing %q, got %v", test.issue, err)
+			}
+		})
+	}
+}
+
+func TestReadTrace_DetectsMissingFile(t *testing.T) {
+	if _, err := ReadTrace(filepath.Join(t.TempDir(), "missing.csv")); err == nil {
+		t.Errorf("missing trace file should be detected")
+	}
+	if _, err := ReadTrace(""); err == nil {
+		t.Errorf("missing trace file name should be detected")
+	}
+}
+
+func TestTraceCheck_DetectsInvalidScale(t *testing.T) {
+	scale := float32(-1)
+	trace := Trace{File: writeTrace(t, "trace.csv", "0,10\n"), Scale: &scale}
+	if err := trace.Check(); err == nil || !strings.Contains(err.Error(), "trace scale must be >= 0") {
+		t.Errorf("negative scale should be detected, got %v", err)
+	}
+	scale = 2
+	if err := trace.Check(); err != nil {
+		t.Errorf("valid trace should be fine, but received the error %v", err)
+	}
+}
+
+func TestParseFile_ResolvesTraceFilesRelativeToScenario(t *testing.T) {
+	dir := t.TempDir()
+	scenario := `
+name: Trace
+duration: 60
+applications:
+  - name: load
+    type: counter
+    rate:
+      trace:
+        file: rates.csv
+        loop: true
+`
+	path := filepath.Join(dir, "scenario.yml")
+	if err := os.WriteFile(path, []byte(scenario), 0600); err != nil {
+		t.Fatalf("failed to write scenario: %v", err)
+	}
+	got, err := ParseFile(path)
+	if err != nil {
+		t.Fatalf("failed to parse scenario: %v", err)
+	}
+	if want := filepath.Join(dir, "rates.csv"); got.Applications[0].Rate.Trace.File != want {
+		t.Errorf("unexpected trace file, wanted %s, got %s", want, got.Applications[0].Rate.Trace.File)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+package rpc
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// sfcAddress is the address of the SFC system contract, which is present in every network.
+var sfcAddress = common.HexToAddress("0xFC00FACE00000000000000000000000000000000")
+
+// currentSealedEpochCall is the input of a call of the currentSealedEpoch() function of the SFC.
+var currentSealedEpochCall = hexutil.Bytes(crypto.Keccak256([]byte("currentSealedEpoch()"))[:4])
+
+// HistoricalStateQuery is a query on the state of a past block. Such queries can only
+// be answered by archive nodes. The result is the raw value reported by the node.
+type HistoricalStateQuery struct {
+	Method string
+	Query  func(rpcClient RpcClient, blockNumber uint64) (string, error)
+}
+
+// HistoricalStateQueries lists queries covering the balance, storage and
+// call APIs on the state of past blocks.
+var HistoricalStateQueries = []HistoricalStateQuery{
+	{
+		Method: "eth_getBalance",
+		Query: func(rpcClient RpcClient, blockNumber uint64) (string, error) {
+			var res string
+			err := rpcClient.Call(&res, "eth_getBalance", sfcAddress, hexutil.EncodeUint64(blockNumber))
+			return res, err
+		},
+	},
+	{
+		Method: "eth_getStorageAt",
+		Query: func(rpcClient RpcClient, blockNumber uint64) (string, error) {
+			var res string
+			err := rpcClient.Call(&res, "eth_getStorageAt", sfcAddress, "0x0", hexutil.EncodeUint64(blockNumber))
+			return res, err
+		},
+	},
+	{
+		Method: "eth_call",
+		Query: func(rpcClient RpcClient, blockNumber uint64) (string, error) {
+			var res string
+			call := map[string]interface{}{
+				"to":   sfcAddress,
+				"data": currentSealedEpochCall,
+			}
+			err := rpcClient.Call(&res, "eth_call", call, hexutil.EncodeUint64(blockNumber))
+			return res, err
+		},
+	},
+}
@@ -0,0 +1,37 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+// Read-only RPC methods issued by query applications.
+const (
+	CallMethod             = "eth_call"
+	GetBalanceMethod       = "eth_getBalance"
+	GetStorageAtMethod     = "eth_getStorageAt"
+	GetLogsMethod          = "eth_getLogs"
+	GetBlockByNumberMethod = "eth_getBlockByNumber"
+	EstimateGasMethod      = "eth_estimateGas"
+)
+
+// QueryMethods lists all read-only RPC methods issued by query applications.
+var QueryMethods = []string{
+	CallMethod,
+	GetBalanceMethod,
+	GetStorageAtMethod,
+	GetLogsMethod,
+	GetBlockByNumberMethod,
+	EstimateGasMethod,
+}
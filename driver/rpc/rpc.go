@@ -30,6 +30,7 @@ import (
 type RpcClient interface {
 	bind.ContractBackend
 	Call(result interface{}, method string, args ...interface{}) error
+	BatchCall(b []rpc.BatchElem) error
 	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
 	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
 	Close()
@@ -50,3 +51,7 @@ type RpcClientImpl struct {
 func (r RpcClientImpl) Call(result interface{}, method string, args ...interface{}) error {
 	return r.RpcClient.Call(result, method, args...)
 }
+
+func (r RpcClientImpl) BatchCall(b []rpc.BatchElem) error {
+	return r.RpcClient.BatchCall(b)
+}
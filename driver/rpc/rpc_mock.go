@@ -28,6 +28,7 @@ import (
 	ethereum "github.com/ethereum/go-ethereum"
 	common "github.com/ethereum/go-ethereum/common"
 	types "github.com/ethereum/go-ethereum/core/types"
+	rpc0 "github.com/ethereum/go-ethereum/rpc"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -69,6 +70,20 @@ func (mr *MockRpcClientMockRecorder) BalanceAt(ctx, account, blockNumber interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BalanceAt", reflect.TypeOf((*MockRpcClient)(nil).BalanceAt), ctx, account, blockNumber)
 }
 
+// BatchCall mocks base method.
+func (m *MockRpcClient) BatchCall(b []rpc0.BatchElem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchCall", b)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchCall indicates an expected call of BatchCall.
+func (mr *MockRpcClientMockRecorder) BatchCall(b interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchCall", reflect.TypeOf((*MockRpcClient)(nil).BatchCall), b)
+}
+
 // Call mocks base method.
 func (m *MockRpcClient) Call(result interface{}, method string, args ...interface{}) error {
 	m.ctrl.T.Helper()
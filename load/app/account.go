@@ -19,6 +19,7 @@ package app
 import (
 	"context"
 	"crypto/ecdsa"
+	"encoding/binary"
 	"fmt"
 	"github.com/Fantom-foundation/Norma/driver/rpc"
 	"math/big"
@@ -35,6 +36,7 @@ type AccountFactory struct {
 	keyGenerator *KeyGenerator
 	chainID      *big.Int
 	numAccounts  int64
+	txConfig     TxConfig // type of the transactions sent by created accounts
 }
 
 // NewAccountFactory creates a new AccountFactory, generating accounts for given feeder and app.
@@ -70,9 +72,16 @@ func (f *AccountFactory) CreateAccount(rpcClient rpc.RpcClient) (*Account, error
 		address:    address,
 		chainID:    f.chainID,
 		nonce:      nonce,
+		txs:        newTxBuilder(f.txConfig, int64(binary.BigEndian.Uint64(address[:8]))),
 	}, nil
 }
 
+// setTxConfig defines the type of the transactions sent by accounts created from now on.
+// It must not be called concurrently with CreateAccount.
+func (f *AccountFactory) setTxConfig(config TxConfig) {
+	f.txConfig = config
+}
+
 // Account represents an account from which we can send transactions.
 // It sustains the nonce value - it allows multiple generators which use one Account
 // to produce multiple txs in one block.
@@ -82,6 +91,7 @@ type Account struct {
 	address    common.Address
 	chainID    *big.Int
 	nonce      uint64
+	txs        *txBuilder // nil for accounts sending legacy transactions
 }
 
 // NewAccount creates an Account instance from the provided private key
@@ -129,3 +139,30 @@ func (a *Account) getNextNonce() uint64 {
 func (a *Account) getCurrentNonce() uint64 {
 	return atomic.LoadUint64(&a.nonce)
 }
+
+// syncNonce resets the nonce used for the next transaction to the pending nonce of the
+// account reported by the network, if lower. This way, transactions dropped by the
+// network do not leave a nonce gap blocking all later transactions of the account.
+// It returns the number of nonces given up, i.e. the number of lost transactions.
+func (a *Account) syncNonce(rpcClient rpc.RpcClient) (uint64, error) {
+	confirmed, err := rpcClient.NonceAt(context.Background(), a.address, nil) // nonce at latest block
+	if err != nil {
+		return 0, fmt.Errorf("failed to get address nonce; %v", err)
+	}
+	pending, err := rpcClient.PendingNonceAt(context.Background(), a.address)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pending address nonce; %v", err)
+	}
+	if pending < confirmed {
+		pending = confirmed // the pool of the node may lag behind its latest block
+	}
+	for {
+		current := atomic.LoadUint64(&a.nonce)
+		if pending >= current {
+			return 0, nil
+		}
+		if atomic.CompareAndSwapUint64(&a.nonce, current, pending) {
+			return current - pending, nil
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/golang/mock/gomock"
+)
+
+func TestAccount_SyncNonceSkipsNoncesOfLostTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+	rpcClient.EXPECT().NonceAt(gomock.Any(), gomock.Any(), nil).Return(uint64(5), nil)
+	rpcClient.EXPECT().PendingNonceAt(gomock.Any(), gomock.Any()).Return(uint64(7), nil)
+
+	account := &Account{nonce: 10}
+	lost, err := account.syncNonce(rpcClient)
+	if err != nil || lost != 3 {
+		t.Errorf("unexpected number of lost transactions, wanted 3, got %d, err %v", lost, err)
+	}
+	if got := account.getCurrentNonce(); got != 7 {
+		t.Errorf("unexpected nonce, wanted 7, got %d", got)
+	}
+}
+
+func TestAccount_SyncNonceKeepsNonceOfPendingTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+	rpcClient.EXPECT().NonceAt(gomock.Any(), gomock.Any(), nil).Return(uint64(5), nil)
+	rpcClient.EXPECT().PendingNonceAt(gomock.Any(), gomock.Any()).Return(uint64(10), nil)
+
+	account := &Account{nonce: 10}
+	lost, err := account.syncNonce(rpcClient)
+	if err != nil || lost != 0 {
+		t.Errorf("no transaction should be lost, got %d, err %v", lost, err)
+	}
+	if got := account.getCurrentNonce(); got != 10 {
+		t.Errorf("unexpected nonce, wanted 10, got %d", got)
+	}
+}
+
+func TestAccount_SyncNonceIsNotLoweredBelowConfirmedNonce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+	rpcClient.EXPECT().NonceAt(gomock.Any(), gomock.Any(), nil).Return(uint64(8), nil)
+	rpcClient.EXPECT().PendingNonceAt(gomock.Any(), gomock.Any()).Return(uint64(6), nil)
+
+	account := &Account{nonce: 10}
+	if lost, err := account.syncNonce(rpcClient); err != nil || lost != 2 {
+		t.Errorf("unexpected number of lost transactions, wanted 2, got %d, err %v", lost, err)
+	}
+	if got := account.getCurrentNonce(); got != 8 {
+		t.Errorf("unexpected nonce, wanted 8, got %d", got)
+	}
+}
@@ -0,0 +1,539 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// Categories of transactions sent by adversarial applications.
+const (
+	// AdversarialNonceGap transactions use a nonce far ahead of the sender's nonce,
+	// they are queued by the transaction pool but never become executable.
+	AdversarialNonceGap = "nonce_gap"
+	// AdversarialReplaceBumped transactions are replaced by a transaction with the
+	// same nonce and a gas price bumped by 25%, which the pool accepts.
+	AdversarialReplaceBumped = "replace_bumped"
+	// AdversarialReplaceUnderpriced transactions are followed by a transaction with
+	// the same nonce and a gas price bumped by 5%, which is insufficient to replace them.
+	AdversarialReplaceUnderpriced = "replace_underpriced"
+	// AdversarialUnderpriced transactions offer a gas price below the minimum gas price.
+	AdversarialUnderpriced = "underpriced"
+	// AdversarialGasLimit transactions request more gas than available in a block.
+	AdversarialGasLimit = "gas_limit"
+	// AdversarialInvalidSignature transactions are signed for a different chain.
+	AdversarialInvalidSignature = "invalid_signature"
+	// AdversarialRevert transactions call a contract which always reverts.
+	AdversarialRevert = "revert"
+	// AdversarialSpam transactions are sent by many unfunded accounts.
+	AdversarialSpam = "spam"
+)
+
+// AdversarialCategories lists all categories of transactions of adversarial applications.
+var AdversarialCategories = []string{
+	AdversarialNonceGap,
+	AdversarialReplaceBumped,
+	AdversarialReplaceUnderpriced,
+	AdversarialUnderpriced,
+	AdversarialGasLimit,
+	AdversarialInvalidSignature,
+	AdversarialRevert,
+	AdversarialSpam,
+}
+
+// adversarialRejection describes how the rejection of a category of transactions
+// is reported by the nodes.
+type adversarialRejection struct {
+	// metric is the Prometheus metric of the transaction pool counting the rejection.
+	metric string
+	// class is the class of the error returned when submitting the transaction.
+	class string
+}
+
+// adversarialRejections maps the categories of transactions the transaction pool has to
+// reject to the way their rejection is reported. The pool counts all of them as invalid
+// transactions failing its basic validation; txpool_underpriced is only counted for
+// transactions not fitting into a full pool. The class of the submission error thus is
+// the only way to tell the categories apart.
+//
+// Replacements are not listed: the second transaction of a pair races with the inclusion
+// of the first one. If the first one got included already, the second one is rejected
+// for its outdated nonce, otherwise it replaces the first one or is rejected as
+// underpriced, so the outcome cannot be predicted.
+var adversarialRejections = map[string]adversarialRejection{
+	AdversarialUnderpriced:      {metric: "txpool_invalid", class: "Underpriced"},
+	AdversarialGasLimit:         {metric: "txpool_invalid", class: "GasLimit"},
+	AdversarialInvalidSignature: {metric: "txpool_invalid", class: "InvalidSender"},
+	AdversarialSpam:             {metric: "txpool_invalid", class: "InsufficientFunds"},
+}
+
+const (
+	// adversarialGapNonce is the first nonce used for transactions with a nonce gap.
+	adversarialGapNonce = 1 << 40
+	// adversarialExcessiveGasLimit exceeds the gas available in a block, while keeping
+	// the fee below the fee cap enforced by RPC nodes before passing transactions to the pool.
+	adversarialExcessiveGasLimit = 50_000_000
+	// adversarialRevertGasLimit covers calling the reverting contract.
+	adversarialRevertGasLimit = 30_000
+	// adversarialTrackedRejections is the number of rejected transactions of each user
+	// checked for not being executed by Verify.
+	adversarialTrackedRejections = 100
+)
+
+// adversarialRevertingCode is the code of a contract reverting any call: PUSH1 0, DUP1, REVERT.
+var adversarialRevertingCode = []byte{0x60, 0x00, 0x80, 0xfd}
+
+// AdversarialConfig defines the mix of transactions sent by users of an adversarial application.
+type AdversarialConfig struct {
+	// Weights are the relative frequencies of the categories of transactions, indexed
+	// by category. Categories without a weight are not sent, if no weight is set at
+	// all, all categories are sent with the same frequency.
+	Weights map[string]float64
+}
+
+// withDefaults provides a copy of the configuration with defaults for unset parameters.
+func (c AdversarialConfig) withDefaults() AdversarialConfig {
+	if len(c.Weights) > 0 {
+		return c
+	}
+	res := AdversarialConfig{Weights: map[string]float64{}}
+	for _, category := range AdversarialCategories {
+		res.Weights[category] = 1
+	}
+	return res
+}
+
+// CheckAdversarialConfig tests that the given configuration only refers to known
+// categories of transactions with non-negative weights.
+func CheckAdversarialConfig(config AdversarialConfig) error {
+	errs := []error{}
+	total := 0.0
+	for _, category := range getSortedKeys(config.Weights) {
+		weight := config.Weights[category]
+		if !isAdversarialCategory(category) {
+			errs = append(errs, fmt.Errorf("unknown category of adversarial transactions: %s, supported are %s", category, strings.Join(AdversarialCategories, ", ")))
+		}
+		if weight < 0 {
+			errs = append(errs, fmt.Errorf("weight of category %s must be >= 0, got %f", category, weight))
+		}
+		total += weight
+	}
+	if len(config.Weights) > 0 && total <= 0 {
+		errs = append(errs, fmt.Errorf("at least one category of adversarial transactions must have a weight > 0"))
+	}
+	return errors.Join(errs...)
+}
+
+func isAdversarialCategory(category string) bool {
+	for _, cur := range AdversarialCategories {
+		if cur == category {
+			return true
+		}
+	}
+	return false
+}
+
+func getSortedKeys(weights map[string]float64) []string {
+	res := make([]string, 0, len(weights))
+	for key := range weights {
+		res = append(res, key)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// newAdversarialFactory creates a factory for adversarial applications using the given configuration.
+func newAdversarialFactory(config AdversarialConfig) appFactoryFunc {
+	return func(rpcClient rpc.RpcClient, primaryAccount *Account, numUsers int, feederId, appId uint32) (Application, error) {
+		return NewAdversarialApplication(rpcClient, primaryAccount, numUsers, feederId, appId, config)
+	}
+}
+
+// NewAdversarialApplication deploys a contract reverting any call to the chain. Users
+// of the application send transactions testing the robustness of the transaction pool,
+// most of which the pool has to reject or must never execute.
+func NewAdversarialApplication(rpcClient rpc.RpcClient, primaryAccount *Account, numUsers int, feederId, appId uint32, config AdversarialConfig) (Application, error) {
+	config = config.withDefaults()
+	if err := CheckAdversarialConfig(config); err != nil {
+		return nil, err
+	}
+
+	// get price of gas from the network
+	regularGasPrice, err := getGasPrice(rpcClient)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deploy the reverting contract called by users
+	nonce := primaryAccount.getNextNonce()
+	initCode := getInitCode(adversarialRevertingCode)
+	gasLimit := getDeploymentGasLimit(initCode, initCode, len(adversarialRevertingCode), false)
+	tx, err := createContractCreationTx(primaryAccount, nonce, initCode, getPriorityGasPrice(regularGasPrice), gasLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reverting contract deployment; %v", err)
+	}
+	if err := rpcClient.SendTransaction(context.Background(), tx); err != nil {
+		return nil, fmt.Errorf("failed to deploy reverting contract; %v", err)
+	}
+
+	accountFactory, err := NewAccountFactory(primaryAccount.chainID, feederId, appId)
+	if err != nil {
+		return nil, err
+	}
+
+	// deploying too many generators from one account leads to excessive gasPrice growth - we
+	// need to spread the initialization in between multiple startingAccounts
+	startingAccounts, err := generateStartingAccounts(rpcClient, primaryAccount, accountFactory, numUsers, regularGasPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	// wait until the contract will be available on the chain
+	err = waitUntilAccountNonceIs(primaryAccount.address, primaryAccount.getCurrentNonce(), rpcClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait until the reverting contract is deployed; %v", err)
+	}
+
+	categories := make([]string, 0, len(config.Weights))
+	weights := make([]float64, 0, len(config.Weights))
+	total := 0.0
+	for _, category := range getSortedKeys(config.Weights) {
+		if config.Weights[category] <= 0 {
+			continue
+		}
+		total += config.Weights[category]
+		categories = append(categories, category)
+		weights = append(weights, total)
+	}
+
+	return &AdversarialApplication{
+		startingAccounts: startingAccounts,
+		contractAddress:  crypto.CreateAddress(primaryAccount.address, nonce),
+		accountFactory:   accountFactory,
+		categories:       categories,
+		weights:          weights,
+	}, nil
+}
+
+// AdversarialApplication sends transactions testing the robustness of the transaction pool.
+// While the application is thread-safe, each created user should be used in a single thread only.
+type AdversarialApplication struct {
+	startingAccounts []*Account
+	contractAddress  common.Address
+	accountFactory   *AccountFactory
+	users            userAccounts
+	categories       []string
+	weights          []float64 // cumulative weights of the categories
+
+	adversarialUsers []*AdversarialUser
+	usersMutex       sync.Mutex
+}
+
+// CreateUser creates a new user for the app.
+func (f *AdversarialApplication) CreateUser(rpcClient rpc.RpcClient) (User, error) {
+	// get price of gas from the network
+	regularGasPrice, err := getGasPrice(rpcClient)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate a new account for each worker - avoid account nonces related bottlenecks
+	workerAccount, err := f.accountFactory.CreateAccount(rpcClient)
+	if err != nil {
+		return nil, err
+	}
+	startingAccount := f.startingAccounts[workerAccount.id%len(f.startingAccounts)]
+	err = workerAccount.Fund(startingAccount, rpcClient, regularGasPrice, 1000)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fund worker account %d; %v", workerAccount.id, err)
+	}
+	f.users.add(workerAccount)
+
+	user := &AdversarialUser{
+		sender:     workerAccount,
+		gasPrice:   regularGasPrice,
+		contract:   f.contractAddress,
+		categories: f.categories,
+		weights:    f.weights,
+		random:     rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(workerAccount.address[:8])))),
+		counts:     make([]atomic.Uint64, len(f.categories)),
+	}
+	f.usersMutex.Lock()
+	f.adversarialUsers = append(f.adversarialUsers, user)
+	f.usersMutex.Unlock()
+	return user, nil
+}
+
+func (f *AdversarialApplication) WaitUntilApplicationIsDeployed(rpcClient rpc.RpcClient) error {
+	return waitUntilAllSentTxsAreOnChain(f.startingAccounts, rpcClient)
+}
+
+// GetReceivedTransactions obtains the number of transactions of users included in the
+// chain, derived from their nonces. Of each replaced transaction, only one gets included.
+func (f *AdversarialApplication) GetReceivedTransactions(rpcClient rpc.RpcClient) (uint64, error) {
+	included, err := f.users.getIncludedTransactions(rpcClient, nil)
+	if err != nil {
+		return 0, err
+	}
+	return sum(included), nil
+}
+
+// GetExpectedRejections returns the number of sent transactions the transaction pool
+// has to reject, indexed by the Prometheus metric counting the rejections.
+func (f *AdversarialApplication) GetExpectedRejections() map[string]uint64 {
+	res := map[string]uint64{}
+	for category, count := range f.getSentTransactionsPerCategory() {
+		if rejection, found := adversarialRejections[category]; found {
+			res[rejection.metric] += count
+		}
+	}
+	return res
+}
+
+// GetExpectedSubmissionErrors returns the number of sent transactions the transaction
+// pool has to reject, indexed by the class of the error reported on their submission.
+func (f *AdversarialApplication) GetExpectedSubmissionErrors() map[string]uint64 {
+	res := map[string]uint64{}
+	for category, count := range f.getSentTransactionsPerCategory() {
+		if rejection, found := adversarialRejections[category]; found {
+			res[rejection.class] += count
+		}
+	}
+	return res
+}
+
+// IsExpectedToFail reports whether the given transaction sent by a user of the
+// application is not expected to be executed successfully. This holds for all
+// categories of transactions: they are rejected by the pool, never become executable,
+// revert, or are one of the two transactions of a replacement, of which only one can
+// be included. Thus, no hashes of sent transactions need to be retained.
+func (f *AdversarialApplication) IsExpectedToFail(common.Hash) bool {
+	return true
+}
+
+// getSentTransactionsPerCategory sums up the transactions sent by all users per category.
+func (f *AdversarialApplication) getSentTransactionsPerCategory() map[string]uint64 {
+	f.usersMutex.Lock()
+	users := f.adversarialUsers
+	f.usersMutex.Unlock()
+
+	res := map[string]uint64{}
+	for _, user := range users {
+		for i, category := range user.categories {
+			res[category] += user.counts[i].Load()
+		}
+	}
+	return res
+}
+
+// Verify checks that none of the latest transactions the transaction pool has to reject
+// got executed.
+func (f *AdversarialApplication) Verify(rpcClient rpc.RpcClient, blockNumber *big.Int) error {
+	blockNumber, err := resolveBlockNumber(rpcClient, blockNumber)
+	if err != nil {
+		return err
+	}
+	f.usersMutex.Lock()
+	users := f.adversarialUsers
+	f.usersMutex.Unlock()
+
+	for _, user := range users {
+		rejected := user.getRejectedTransactions()
+		receipts := make([]*struct{ BlockNumber *hexutil.Big }, len(rejected))
+		batch := make([]gethrpc.BatchElem, len(rejected))
+		for i, hash := range rejected {
+			batch[i] = gethrpc.BatchElem{
+				Method: "eth_getTransactionReceipt",
+				Args:   []interface{}{hash},
+				Result: &receipts[i],
+			}
+		}
+		if len(batch) == 0 {
+			continue
+		}
+		if err := rpcClient.BatchCall(batch); err != nil {
+			return fmt.Errorf("failed to get receipts; %v", err)
+		}
+		for i, receipt := range receipts {
+			if batch[i].Error != nil {
+				return fmt.Errorf("failed to get receipt of tx %v; %v", rejected[i], batch[i].Error)
+			}
+			if receipt != nil && receipt.BlockNumber != nil && receipt.BlockNumber.ToInt().Cmp(blockNumber) <= 0 {
+				return fmt.Errorf("transaction %v should have been rejected, but was executed in block %v", rejected[i], receipt.BlockNumber)
+			}
+		}
+	}
+	return nil
+}
+
+// AdversarialUser sends transactions of a weighted mix of categories.
+// A generator is supposed to be used in a single thread.
+type AdversarialUser struct {
+	sender     *Account
+	gasPrice   *big.Int
+	contract   common.Address // < a contract reverting all calls
+	categories []string
+	weights    []float64 // cumulative weights of the categories
+	random     *rand.Rand
+	gaps       uint64 // < number of transactions sent with a nonce gap
+
+	next         *types.Transaction // < the second transaction of a replacement, if pending
+	nextCategory int
+
+	counts  []atomic.Uint64 // < sent transactions per category
+	sentTxs atomic.Uint64
+
+	// rejected are the hashes of the latest transactions the pool has to reject.
+	rejected      []common.Hash
+	rejectedMutex sync.Mutex
+}
+
+func (g *AdversarialUser) GenerateTx() (*types.Transaction, error) {
+	var tx *types.Transaction
+	var category int
+	var err error
+	if g.next != nil {
+		tx, category = g.next, g.nextCategory
+		g.next = nil
+	} else {
+		category = g.pickCategory()
+		tx, err = g.generateTxOf(g.categories[category])
+		if err != nil {
+			return nil, err
+		}
+		if _, found := adversarialRejections[g.categories[category]]; found {
+			g.addRejectedTransaction(tx.Hash())
+		}
+	}
+	g.counts[category].Add(1)
+	g.sentTxs.Add(1)
+	return tx, nil
+}
+
+func (g *AdversarialUser) GetSentTransactions() uint64 {
+	return g.sentTxs.Load()
+}
+
+// pickCategory selects the category of the next transaction following the configured weights.
+func (g *AdversarialUser) pickCategory() int {
+	pos := g.random.Float64() * g.weights[len(g.weights)-1]
+	res := sort.SearchFloat64s(g.weights, pos)
+	if res >= len(g.weights) {
+		res = len(g.weights) - 1
+	}
+	return res
+}
+
+// generateTxOf creates a transaction of the given category. For replacements, the second
+// transaction is kept to be returned by the next call of GenerateTx. Transactions to be
+// rejected use the next nonce of the sender without consuming it.
+func (g *AdversarialUser) generateTxOf(category string) (*types.Transaction, error) {
+	zero := big.NewInt(0)
+	self := g.sender.address
+	switch category {
+	case AdversarialNonceGap:
+		nonce := adversarialGapNonce + g.gaps
+		g.gaps++
+		return createTxWithNonce(g.sender, nonce, self, zero, nil, g.gasPrice, 21000)
+	case AdversarialReplaceBumped, AdversarialReplaceUnderpriced:
+		bump := int64(125)
+		if category == AdversarialReplaceUnderpriced {
+			bump = 105
+		}
+		nonce := g.sender.getNextNonce()
+		tx, err := createTxWithNonce(g.sender, nonce, self, zero, nil, g.gasPrice, 21000)
+		if err != nil {
+			return nil, err
+		}
+		bumpedPrice := new(big.Int).Div(new(big.Int).Mul(g.gasPrice, big.NewInt(bump)), big.NewInt(100))
+		next, err := createTxWithNonce(g.sender, nonce, self, zero, nil, bumpedPrice, 21000)
+		if err != nil {
+			return nil, err
+		}
+		g.next, g.nextCategory = next, g.getCategoryIndex(category)
+		return tx, nil
+	case AdversarialUnderpriced:
+		return createTxWithNonce(g.sender, g.sender.getCurrentNonce(), self, zero, nil, big.NewInt(1), 21000)
+	case AdversarialGasLimit:
+		return createTxWithNonce(g.sender, g.sender.getCurrentNonce(), self, zero, nil, g.gasPrice, adversarialExcessiveGasLimit)
+	case AdversarialInvalidSignature:
+		tx := types.NewTx(&types.LegacyTx{
+			Nonce:    g.sender.getCurrentNonce(),
+			GasPrice: g.gasPrice,
+			Gas:      21000,
+			To:       &self,
+			Value:    zero,
+		})
+		wrongChainId := new(big.Int).Add(g.sender.chainID, big.NewInt(1))
+		return types.SignTx(tx, types.NewEIP155Signer(wrongChainId), g.sender.privateKey)
+	case AdversarialRevert:
+		return createTxWithNonce(g.sender, g.sender.getNextNonce(), g.contract, zero, nil, g.gasPrice, adversarialRevertGasLimit)
+	case AdversarialSpam:
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate spam account; %v", err)
+		}
+		spammer := &Account{
+			privateKey: key,
+			address:    crypto.PubkeyToAddress(key.PublicKey),
+			chainID:    g.sender.chainID,
+		}
+		return createTxWithNonce(spammer, 0, self, big.NewInt(1), nil, g.gasPrice, 21000)
+	}
+	return nil, fmt.Errorf("unknown category of adversarial transactions: %s", category)
+}
+
+func (g *AdversarialUser) getCategoryIndex(category string) int {
+	for i, cur := range g.categories {
+		if cur == category {
+			return i
+		}
+	}
+	return 0
+}
+
+// addRejectedTransaction records a transaction the pool has to reject, retaining the latest ones.
+func (g *AdversarialUser) addRejectedTransaction(hash common.Hash) {
+	g.rejectedMutex.Lock()
+	defer g.rejectedMutex.Unlock()
+	g.rejected = append(g.rejected, hash)
+	if len(g.rejected) > adversarialTrackedRejections {
+		g.rejected = g.rejected[len(g.rejected)-adversarialTrackedRejections:]
+	}
+}
+
+func (g *AdversarialUser) getRejectedTransactions() []common.Hash {
+	g.rejectedMutex.Lock()
+	defer g.rejectedMutex.Unlock()
+	return append([]common.Hash(nil), g.rejected...)
+}
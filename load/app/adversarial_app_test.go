@@ -0,0 +1,213 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"math/big"
+	"math/rand"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestAdversarialConfig_AllCategoriesAreSentByDefault(t *testing.T) {
+	config := (AdversarialConfig{}).withDefaults()
+	if len(config.Weights) != len(AdversarialCategories) {
+		t.Fatalf("unexpected default weights: %v", config.Weights)
+	}
+	for _, category := range AdversarialCategories {
+		if config.Weights[category] != 1 {
+			t.Errorf("unexpected default weight of category %s: %f", category, config.Weights[category])
+		}
+	}
+	if err := CheckAdversarialConfig(config); err != nil {
+		t.Errorf("default configuration should be valid, got %v", err)
+	}
+}
+
+func TestAdversarialConfig_InvalidConfigurationsAreDetected(t *testing.T) {
+	tests := map[string]map[string]float64{
+		"unknown category":        {"unknown": 1},
+		"weight of category spam": {"spam": -1, "revert": 1},
+		"at least one category":   {"spam": 0},
+	}
+	for want, weights := range tests {
+		if err := CheckAdversarialConfig(AdversarialConfig{Weights: weights}); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error containing %q for %v, got %v", want, weights, err)
+		}
+	}
+}
+
+func newTestAdversarialUser(t *testing.T, categories ...string) *AdversarialUser {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	weights := make([]float64, len(categories))
+	for i := range categories {
+		weights[i] = float64(i + 1)
+	}
+	return &AdversarialUser{
+		sender: &Account{
+			privateKey: key,
+			address:    crypto.PubkeyToAddress(key.PublicKey),
+			chainID:    big.NewInt(250),
+			nonce:      5,
+		},
+		gasPrice:   big.NewInt(1000),
+		categories: categories,
+		weights:    weights,
+		random:     rand.New(rand.NewSource(1)),
+		counts:     make([]atomic.Uint64, len(categories)),
+	}
+}
+
+func TestAdversarialUser_ReplacementsUseSameNonceWithBumpedPrice(t *testing.T) {
+	for category, bump := range map[string]int64{AdversarialReplaceBumped: 1250, AdversarialReplaceUnderpriced: 1050} {
+		user := newTestAdversarialUser(t, category)
+		first, err := user.GenerateTx()
+		if err != nil {
+			t.Fatal(err)
+		}
+		second, err := user.GenerateTx()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if first.Nonce() != 5 || second.Nonce() != 5 {
+			t.Errorf("replacement should use the same nonce, got %d and %d", first.Nonce(), second.Nonce())
+		}
+		if first.GasPrice().Int64() != 1000 || second.GasPrice().Int64() != bump {
+			t.Errorf("unexpected gas prices of %s, wanted 1000 and %d, got %v and %v", category, bump, first.GasPrice(), second.GasPrice())
+		}
+		if got := user.sender.getCurrentNonce(); got != 6 {
+			t.Errorf("replacement should consume a single nonce, next nonce is %d", got)
+		}
+		if got := user.counts[0].Load(); got != 2 {
+			t.Errorf("both transactions should be counted, got %d", got)
+		}
+	}
+}
+
+func TestAdversarialUser_RejectedTransactionsDoNotConsumeNonces(t *testing.T) {
+	for _, category := range []string{AdversarialUnderpriced, AdversarialGasLimit, AdversarialInvalidSignature, AdversarialNonceGap} {
+		user := newTestAdversarialUser(t, category)
+		tx, err := user.GenerateTx()
+		if err != nil {
+			t.Fatalf("failed to generate %s transaction: %v", category, err)
+		}
+		if got := user.sender.getCurrentNonce(); got != 5 {
+			t.Errorf("%s transaction should not consume a nonce, next nonce is %d", category, got)
+		}
+		switch category {
+		case AdversarialUnderpriced:
+			if tx.GasPrice().Cmp(user.gasPrice) >= 0 {
+				t.Errorf("gas price of underpriced transaction is not reduced: %v", tx.GasPrice())
+			}
+		case AdversarialGasLimit:
+			if tx.Gas() != adversarialExcessiveGasLimit {
+				t.Errorf("unexpected gas limit: %d", tx.Gas())
+			}
+		case AdversarialInvalidSignature:
+			if _, err := types.Sender(types.NewEIP155Signer(user.sender.chainID), tx); err == nil {
+				t.Errorf("signature of transaction should be invalid for the chain")
+			}
+		case AdversarialNonceGap:
+			if tx.Nonce() < adversarialGapNonce {
+				t.Errorf("nonce gap transaction uses regular nonce %d", tx.Nonce())
+			}
+		}
+	}
+}
+
+func TestAdversarialUser_SpamIsSentByDifferentAccounts(t *testing.T) {
+	user := newTestAdversarialUser(t, AdversarialSpam)
+	signer := types.NewEIP155Signer(user.sender.chainID)
+	senders := map[string]bool{}
+	for i := 0; i < 10; i++ {
+		tx, err := user.GenerateTx()
+		if err != nil {
+			t.Fatal(err)
+		}
+		sender, err := types.Sender(signer, tx)
+		if err != nil {
+			t.Fatalf("spam transaction should be validly signed: %v", err)
+		}
+		if sender == user.sender.address {
+			t.Errorf("spam should not be sent by the user's account")
+		}
+		senders[sender.Hex()] = true
+	}
+	if len(senders) != 10 {
+		t.Errorf("spam should be sent by different accounts, got %d", len(senders))
+	}
+}
+
+func TestAdversarialApplication_ExpectedRejectionsCountRejectedCategoriesOnly(t *testing.T) {
+	user := newTestAdversarialUser(t, AdversarialUnderpriced, AdversarialSpam, AdversarialRevert, AdversarialNonceGap)
+	user.counts[0].Add(3)
+	user.counts[1].Add(4)
+	user.counts[2].Add(5)
+	user.counts[3].Add(6)
+	app := &AdversarialApplication{adversarialUsers: []*AdversarialUser{user}}
+	got := app.GetExpectedRejections()
+	if len(got) != 1 || got["txpool_invalid"] != 7 {
+		t.Errorf("unexpected expected rejections, wanted 7 in txpool_invalid, got %v", got)
+	}
+}
+
+func TestAdversarialApplication_ExpectedSubmissionErrorsAreReportedPerCategory(t *testing.T) {
+	user := newTestAdversarialUser(t, AdversarialUnderpriced, AdversarialGasLimit, AdversarialInvalidSignature, AdversarialSpam, AdversarialReplaceUnderpriced, AdversarialReplaceBumped)
+	for i := range user.counts {
+		user.counts[i].Add(uint64(i + 1))
+	}
+	app := &AdversarialApplication{adversarialUsers: []*AdversarialUser{user}}
+	want := map[string]uint64{"Underpriced": 1, "GasLimit": 2, "InvalidSender": 3, "InsufficientFunds": 4}
+	if got := app.GetExpectedSubmissionErrors(); !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected expected submission errors, wanted %v, got %v", want, got)
+	}
+}
+
+func TestAdversarialUser_RejectedTransactionsAreTracked(t *testing.T) {
+	user := newTestAdversarialUser(t, AdversarialUnderpriced)
+	for i := 0; i < adversarialTrackedRejections+10; i++ {
+		if _, err := user.GenerateTx(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := len(user.getRejectedTransactions()); got != adversarialTrackedRejections {
+		t.Errorf("unexpected number of tracked rejected transactions, wanted %d, got %d", adversarialTrackedRejections, got)
+	}
+}
+
+func TestAdversarialApplication_AllSentTransactionsAreExpectedToFail(t *testing.T) {
+	user := newTestAdversarialUser(t, AdversarialNonceGap, AdversarialReplaceBumped)
+	app := &AdversarialApplication{adversarialUsers: []*AdversarialUser{user}}
+	for i := 0; i < 10; i++ {
+		tx, err := user.GenerateTx()
+		if err != nil {
+			t.Fatalf("failed to generate transaction: %v", err)
+		}
+		if !app.IsExpectedToFail(tx.Hash()) {
+			t.Errorf("transaction %v is not expected to fail", tx.Hash())
+		}
+	}
+}
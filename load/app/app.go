@@ -17,7 +17,10 @@
 package app
 
 import (
+	"math/big"
+
 	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
@@ -30,6 +33,85 @@ type Application interface {
 	WaitUntilApplicationIsDeployed(rpcClient rpc.RpcClient) error
 
 	GetReceivedTransactions(rpcClient rpc.RpcClient) (uint64, error)
+
+	// Verify checks application specific invariants on the on-chain state of
+	// the application at the given block, nil referring to the latest block.
+	// Applications without such invariants may return nil.
+	Verify(rpcClient rpc.RpcClient, blockNumber *big.Int) error
+}
+
+// CompositeApplication is implemented by applications combining several application
+// types, reporting the transactions of each of their components.
+type CompositeApplication interface {
+	Application
+
+	// GetComponentStats returns the number of sent and received transactions of each
+	// component of the application.
+	GetComponentStats(rpcClient rpc.RpcClient) ([]ComponentStats, error)
+}
+
+// RejectingApplication is implemented by applications deliberately sending transactions
+// the transaction pool of nodes has to reject, reporting the rejections to expect.
+type RejectingApplication interface {
+	Application
+
+	// GetExpectedRejections returns the minimum number of rejections expected to be
+	// counted by the nodes, indexed by the name of the Prometheus metric of the
+	// transaction pool counting them, e.g. txpool_invalid.
+	GetExpectedRejections() map[string]uint64
+
+	// GetExpectedSubmissionErrors returns the minimum number of transactions expected
+	// to be rejected on submission, indexed by the class of the reported error, e.g.
+	// Underpriced.
+	GetExpectedSubmissionErrors() map[string]uint64
+
+	// IsExpectedToFail reports whether the given transaction sent by a user of the
+	// application is not expected to be executed successfully, e.g. since the
+	// transaction pool has to reject it or it reverts.
+	IsExpectedToFail(hash common.Hash) bool
+}
+
+// QueryingApplication is implemented by applications producing read-only RPC requests
+// instead of transactions. Such applications do not create transaction users.
+type QueryingApplication interface {
+	Application
+
+	// CreateQuerier creates a new user generating requests for this application.
+	CreateQuerier(rpcClient rpc.RpcClient) (Querier, error)
+}
+
+// Querier produces a stream of read-only RPC requests to generate load on RPC nodes.
+// Implementations are not required to be thread-safe.
+type Querier interface {
+	// GenerateQuery produces the next request, the given head being the number of
+	// the latest block known to be available in the network.
+	GenerateQuery(head uint64) (Query, error)
+	GetSentQueries() uint64
+}
+
+// NonceSyncingUser is implemented by users able to re-synchronize the nonce of their
+// sending account with the network, e.g. after one of their transactions got lost.
+type NonceSyncingUser interface {
+	User
+
+	// SyncNonce resets the nonce used for the next transaction to the pending nonce
+	// of the sending account reported by the network, if lower, and returns the
+	// number of nonces given up. It must not be called concurrently with GenerateTx.
+	SyncNonce(rpcClient rpc.RpcClient) (uint64, error)
+}
+
+// Query is a read-only RPC request.
+type Query struct {
+	Method  string
+	Args    []interface{}
+	Archive bool // < whether the request targets the state of a past block, requiring an archive node
+}
+
+// ComponentStats summarizes the transactions of a single component of a composite application.
+type ComponentStats struct {
+	Name     string
+	Sent     uint64
+	Received uint64
 }
 
 // User produces a stream of transactions to Generate traffic on the chain.
@@ -21,9 +21,11 @@
 package app
 
 import (
+	big "math/big"
 	reflect "reflect"
 
 	rpc "github.com/Fantom-foundation/Norma/driver/rpc"
+	common "github.com/ethereum/go-ethereum/common"
 	types "github.com/ethereum/go-ethereum/core/types"
 	gomock "github.com/golang/mock/gomock"
 )
@@ -81,6 +83,20 @@ func (mr *MockApplicationMockRecorder) GetReceivedTransactions(rpcClient interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceivedTransactions", reflect.TypeOf((*MockApplication)(nil).GetReceivedTransactions), rpcClient)
 }
 
+// Verify mocks base method.
+func (m *MockApplication) Verify(rpcClient rpc.RpcClient, blockNumber *big.Int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", rpcClient, blockNumber)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockApplicationMockRecorder) Verify(rpcClient, blockNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockApplication)(nil).Verify), rpcClient, blockNumber)
+}
+
 // WaitUntilApplicationIsDeployed mocks base method.
 func (m *MockApplication) WaitUntilApplicationIsDeployed(rpcClient rpc.RpcClient) error {
 	m.ctrl.T.Helper()
@@ -95,6 +111,440 @@ func (mr *MockApplicationMockRecorder) WaitUntilApplicationIsDeployed(rpcClient
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitUntilApplicationIsDeployed", reflect.TypeOf((*MockApplication)(nil).WaitUntilApplicationIsDeployed), rpcClient)
 }
 
+// MockCompositeApplication is a mock of CompositeApplication interface.
+type MockCompositeApplication struct {
+	ctrl     *gomock.Controller
+	recorder *MockCompositeApplicationMockRecorder
+}
+
+// MockCompositeApplicationMockRecorder is the mock recorder for MockCompositeApplication.
+type MockCompositeApplicationMockRecorder struct {
+	mock *MockCompositeApplication
+}
+
+// NewMockCompositeApplication creates a new mock instance.
+func NewMockCompositeApplication(ctrl *gomock.Controller) *MockCompositeApplication {
+	mock := &MockCompositeApplication{ctrl: ctrl}
+	mock.recorder = &MockCompositeApplicationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCompositeApplication) EXPECT() *MockCompositeApplicationMockRecorder {
+	return m.recorder
+}
+
+// CreateUser mocks base method.
+func (m *MockCompositeApplication) CreateUser(rpcClient rpc.RpcClient) (User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", rpcClient)
+	ret0, _ := ret[0].(User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockCompositeApplicationMockRecorder) CreateUser(rpcClient interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockCompositeApplication)(nil).CreateUser), rpcClient)
+}
+
+// GetComponentStats mocks base method.
+func (m *MockCompositeApplication) GetComponentStats(rpcClient rpc.RpcClient) ([]ComponentStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComponentStats", rpcClient)
+	ret0, _ := ret[0].([]ComponentStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComponentStats indicates an expected call of GetComponentStats.
+func (mr *MockCompositeApplicationMockRecorder) GetComponentStats(rpcClient interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComponentStats", reflect.TypeOf((*MockCompositeApplication)(nil).GetComponentStats), rpcClient)
+}
+
+// GetReceivedTransactions mocks base method.
+func (m *MockCompositeApplication) GetReceivedTransactions(rpcClient rpc.RpcClient) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceivedTransactions", rpcClient)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceivedTransactions indicates an expected call of GetReceivedTransactions.
+func (mr *MockCompositeApplicationMockRecorder) GetReceivedTransactions(rpcClient interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceivedTransactions", reflect.TypeOf((*MockCompositeApplication)(nil).GetReceivedTransactions), rpcClient)
+}
+
+// Verify mocks base method.
+func (m *MockCompositeApplication) Verify(rpcClient rpc.RpcClient, blockNumber *big.Int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", rpcClient, blockNumber)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockCompositeApplicationMockRecorder) Verify(rpcClient, blockNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockCompositeApplication)(nil).Verify), rpcClient, blockNumber)
+}
+
+// WaitUntilApplicationIsDeployed mocks base method.
+func (m *MockCompositeApplication) WaitUntilApplicationIsDeployed(rpcClient rpc.RpcClient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitUntilApplicationIsDeployed", rpcClient)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitUntilApplicationIsDeployed indicates an expected call of WaitUntilApplicationIsDeployed.
+func (mr *MockCompositeApplicationMockRecorder) WaitUntilApplicationIsDeployed(rpcClient interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitUntilApplicationIsDeployed", reflect.TypeOf((*MockCompositeApplication)(nil).WaitUntilApplicationIsDeployed), rpcClient)
+}
+
+// MockRejectingApplication is a mock of RejectingApplication interface.
+type MockRejectingApplication struct {
+	ctrl     *gomock.Controller
+	recorder *MockRejectingApplicationMockRecorder
+}
+
+// MockRejectingApplicationMockRecorder is the mock recorder for MockRejectingApplication.
+type MockRejectingApplicationMockRecorder struct {
+	mock *MockRejectingApplication
+}
+
+// NewMockRejectingApplication creates a new mock instance.
+func NewMockRejectingApplication(ctrl *gomock.Controller) *MockRejectingApplication {
+	mock := &MockRejectingApplication{ctrl: ctrl}
+	mock.recorder = &MockRejectingApplicationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRejectingApplication) EXPECT() *MockRejectingApplicationMockRecorder {
+	return m.recorder
+}
+
+// CreateUser mocks base method.
+func (m *MockRejectingApplication) CreateUser(rpcClient rpc.RpcClient) (User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", rpcClient)
+	ret0, _ := ret[0].(User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockRejectingApplicationMockRecorder) CreateUser(rpcClient interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockRejectingApplication)(nil).CreateUser), rpcClient)
+}
+
+// GetExpectedRejections mocks base method.
+func (m *MockRejectingApplication) GetExpectedRejections() map[string]uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpectedRejections")
+	ret0, _ := ret[0].(map[string]uint64)
+	return ret0
+}
+
+// GetExpectedRejections indicates an expected call of GetExpectedRejections.
+func (mr *MockRejectingApplicationMockRecorder) GetExpectedRejections() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpectedRejections", reflect.TypeOf((*MockRejectingApplication)(nil).GetExpectedRejections))
+}
+
+// GetExpectedSubmissionErrors mocks base method.
+func (m *MockRejectingApplication) GetExpectedSubmissionErrors() map[string]uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpectedSubmissionErrors")
+	ret0, _ := ret[0].(map[string]uint64)
+	return ret0
+}
+
+// GetExpectedSubmissionErrors indicates an expected call of GetExpectedSubmissionErrors.
+func (mr *MockRejectingApplicationMockRecorder) GetExpectedSubmissionErrors() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpectedSubmissionErrors", reflect.TypeOf((*MockRejectingApplication)(nil).GetExpectedSubmissionErrors))
+}
+
+// GetReceivedTransactions mocks base method.
+func (m *MockRejectingApplication) GetReceivedTransactions(rpcClient rpc.RpcClient) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceivedTransactions", rpcClient)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceivedTransactions indicates an expected call of GetReceivedTransactions.
+func (mr *MockRejectingApplicationMockRecorder) GetReceivedTransactions(rpcClient interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceivedTransactions", reflect.TypeOf((*MockRejectingApplication)(nil).GetReceivedTransactions), rpcClient)
+}
+
+// IsExpectedToFail mocks base method.
+func (m *MockRejectingApplication) IsExpectedToFail(hash common.Hash) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsExpectedToFail", hash)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsExpectedToFail indicates an expected call of IsExpectedToFail.
+func (mr *MockRejectingApplicationMockRecorder) IsExpectedToFail(hash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsExpectedToFail", reflect.TypeOf((*MockRejectingApplication)(nil).IsExpectedToFail), hash)
+}
+
+// Verify mocks base method.
+func (m *MockRejectingApplication) Verify(rpcClient rpc.RpcClient, blockNumber *big.Int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", rpcClient, blockNumber)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockRejectingApplicationMockRecorder) Verify(rpcClient, blockNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockRejectingApplication)(nil).Verify), rpcClient, blockNumber)
+}
+
+// WaitUntilApplicationIsDeployed mocks base method.
+func (m *MockRejectingApplication) WaitUntilApplicationIsDeployed(rpcClient rpc.RpcClient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitUntilApplicationIsDeployed", rpcClient)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitUntilApplicationIsDeployed indicates an expected call of WaitUntilApplicationIsDeployed.
+func (mr *MockRejectingApplicationMockRecorder) WaitUntilApplicationIsDeployed(rpcClient interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitUntilApplicationIsDeployed", reflect.TypeOf((*MockRejectingApplication)(nil).WaitUntilApplicationIsDeployed), rpcClient)
+}
+
+// MockQueryingApplication is a mock of QueryingApplication interface.
+type MockQueryingApplication struct {
+	ctrl     *gomock.Controller
+	recorder *MockQueryingApplicationMockRecorder
+}
+
+// MockQueryingApplicationMockRecorder is the mock recorder for MockQueryingApplication.
+type MockQueryingApplicationMockRecorder struct {
+	mock *MockQueryingApplication
+}
+
+// NewMockQueryingApplication creates a new mock instance.
+func NewMockQueryingApplication(ctrl *gomock.Controller) *MockQueryingApplication {
+	mock := &MockQueryingApplication{ctrl: ctrl}
+	mock.recorder = &MockQueryingApplicationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQueryingApplication) EXPECT() *MockQueryingApplicationMockRecorder {
+	return m.recorder
+}
+
+// CreateQuerier mocks base method.
+func (m *MockQueryingApplication) CreateQuerier(rpcClient rpc.RpcClient) (Querier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateQuerier", rpcClient)
+	ret0, _ := ret[0].(Querier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateQuerier indicates an expected call of CreateQuerier.
+func (mr *MockQueryingApplicationMockRecorder) CreateQuerier(rpcClient interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQuerier", reflect.TypeOf((*MockQueryingApplication)(nil).CreateQuerier), rpcClient)
+}
+
+// CreateUser mocks base method.
+func (m *MockQueryingApplication) CreateUser(rpcClient rpc.RpcClient) (User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", rpcClient)
+	ret0, _ := ret[0].(User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockQueryingApplicationMockRecorder) CreateUser(rpcClient interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockQueryingApplication)(nil).CreateUser), rpcClient)
+}
+
+// GetReceivedTransactions mocks base method.
+func (m *MockQueryingApplication) GetReceivedTransactions(rpcClient rpc.RpcClient) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReceivedTransactions", rpcClient)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReceivedTransactions indicates an expected call of GetReceivedTransactions.
+func (mr *MockQueryingApplicationMockRecorder) GetReceivedTransactions(rpcClient interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceivedTransactions", reflect.TypeOf((*MockQueryingApplication)(nil).GetReceivedTransactions), rpcClient)
+}
+
+// Verify mocks base method.
+func (m *MockQueryingApplication) Verify(rpcClient rpc.RpcClient, blockNumber *big.Int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", rpcClient, blockNumber)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockQueryingApplicationMockRecorder) Verify(rpcClient, blockNumber interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockQueryingApplication)(nil).Verify), rpcClient, blockNumber)
+}
+
+// WaitUntilApplicationIsDeployed mocks base method.
+func (m *MockQueryingApplication) WaitUntilApplicationIsDeployed(rpcClient rpc.RpcClient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WaitUntilApplicationIsDeployed", rpcClient)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WaitUntilApplicationIsDeployed indicates an expected call of WaitUntilApplicationIsDeployed.
+func (mr *MockQueryingApplicationMockRecorder) WaitUntilApplicationIsDeployed(rpcClient interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WaitUntilApplicationIsDeployed", reflect.TypeOf((*MockQueryingApplication)(nil).WaitUntilApplicationIsDeployed), rpcClient)
+}
+
+// MockQuerier is a mock of Querier interface.
+type MockQuerier struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuerierMockRecorder
+}
+
+// MockQuerierMockRecorder is the mock recorder for MockQuerier.
+type MockQuerierMockRecorder struct {
+	mock *MockQuerier
+}
+
+// NewMockQuerier creates a new mock instance.
+func NewMockQuerier(ctrl *gomock.Controller) *MockQuerier {
+	mock := &MockQuerier{ctrl: ctrl}
+	mock.recorder = &MockQuerierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuerier) EXPECT() *MockQuerierMockRecorder {
+	return m.recorder
+}
+
+// GenerateQuery mocks base method.
+func (m *MockQuerier) GenerateQuery(head uint64) (Query, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateQuery", head)
+	ret0, _ := ret[0].(Query)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateQuery indicates an expected call of GenerateQuery.
+func (mr *MockQuerierMockRecorder) GenerateQuery(head interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateQuery", reflect.TypeOf((*MockQuerier)(nil).GenerateQuery), head)
+}
+
+// GetSentQueries mocks base method.
+func (m *MockQuerier) GetSentQueries() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSentQueries")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// GetSentQueries indicates an expected call of GetSentQueries.
+func (mr *MockQuerierMockRecorder) GetSentQueries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSentQueries", reflect.TypeOf((*MockQuerier)(nil).GetSentQueries))
+}
+
+// MockNonceSyncingUser is a mock of NonceSyncingUser interface.
+type MockNonceSyncingUser struct {
+	ctrl     *gomock.Controller
+	recorder *MockNonceSyncingUserMockRecorder
+}
+
+// MockNonceSyncingUserMockRecorder is the mock recorder for MockNonceSyncingUser.
+type MockNonceSyncingUserMockRecorder struct {
+	mock *MockNonceSyncingUser
+}
+
+// NewMockNonceSyncingUser creates a new mock instance.
+func NewMockNonceSyncingUser(ctrl *gomock.Controller) *MockNonceSyncingUser {
+	mock := &MockNonceSyncingUser{ctrl: ctrl}
+	mock.recorder = &MockNonceSyncingUserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNonceSyncingUser) EXPECT() *MockNonceSyncingUserMockRecorder {
+	return m.recorder
+}
+
+// GenerateTx mocks base method.
+func (m *MockNonceSyncingUser) GenerateTx() (*types.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateTx")
+	ret0, _ := ret[0].(*types.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateTx indicates an expected call of GenerateTx.
+func (mr *MockNonceSyncingUserMockRecorder) GenerateTx() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateTx", reflect.TypeOf((*MockNonceSyncingUser)(nil).GenerateTx))
+}
+
+// GetSentTransactions mocks base method.
+func (m *MockNonceSyncingUser) GetSentTransactions() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSentTransactions")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// GetSentTransactions indicates an expected call of GetSentTransactions.
+func (mr *MockNonceSyncingUserMockRecorder) GetSentTransactions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSentTransactions", reflect.TypeOf((*MockNonceSyncingUser)(nil).GetSentTransactions))
+}
+
+// SyncNonce mocks base method.
+func (m *MockNonceSyncingUser) SyncNonce(rpcClient rpc.RpcClient) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncNonce", rpcClient)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncNonce indicates an expected call of SyncNonce.
+func (mr *MockNonceSyncingUserMockRecorder) SyncNonce(rpcClient interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncNonce", reflect.TypeOf((*MockNonceSyncingUser)(nil).SyncNonce), rpcClient)
+}
+
 // MockUser is a mock of User interface.
 type MockUser struct {
 	ctrl     *gomock.Controller
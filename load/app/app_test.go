@@ -18,6 +18,7 @@ package app_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/Fantom-foundation/Norma/driver/network"
 	"github.com/Fantom-foundation/Norma/driver/rpc"
@@ -27,6 +28,7 @@ import (
 	"github.com/Fantom-foundation/Norma/driver"
 	"github.com/Fantom-foundation/Norma/driver/network/local"
 	"github.com/Fantom-foundation/Norma/load/app"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 const PrivateKey = "163f5f0f9a621d72fedd85ffca3d08d131ab4e812181e0d30ffd1c885d20aac7" // Fakenet validator 1
@@ -78,6 +80,105 @@ func TestGenerators(t *testing.T) {
 		}
 		testGenerator(t, uniswapApp, rpcClient)
 	})
+	t.Run("Transfer", func(t *testing.T) {
+		transferApp, err := app.NewTransferApplication(rpcClient, primaryAccount, 1, 0, 0, app.TransferConfig{NewAccountRatio: 0.5})
+		if err != nil {
+			t.Fatal(err)
+		}
+		testGenerator(t, transferApp, rpcClient)
+	})
+	t.Run("NFT", func(t *testing.T) {
+		nftApp, err := app.NewNFTApplication(rpcClient, primaryAccount, 1, 0, 0, app.NFTConfig{MintRatio: 2, TransferRatio: 1, BurnRatio: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		testGenerator(t, nftApp, rpcClient)
+	})
+	t.Run("Storage", func(t *testing.T) {
+		storageApp, err := app.NewStorageApplication(rpcClient, primaryAccount, 1, 0, 0, app.StorageConfig{
+			Slots:        100,
+			Contracts:    2,
+			ReadRatio:    0.3,
+			DeleteRatio:  0.2,
+			Distribution: app.DistributionZipfian,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		testGenerator(t, storageApp, rpcClient)
+	})
+	t.Run("Adversarial", func(t *testing.T) {
+		adversarialApp, err := app.NewAdversarialApplication(rpcClient, primaryAccount, 1, 0, 0, app.AdversarialConfig{
+			Weights: map[string]float64{app.AdversarialRevert: 1},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		testGenerator(t, adversarialApp, rpcClient)
+	})
+	t.Run("AdversarialRejections", func(t *testing.T) {
+		adversarialApp, err := app.NewAdversarialApplication(rpcClient, primaryAccount, 1, 0, 0, app.AdversarialConfig{
+			Weights: map[string]float64{
+				app.AdversarialUnderpriced:      1,
+				app.AdversarialGasLimit:         1,
+				app.AdversarialInvalidSignature: 1,
+				app.AdversarialSpam:             1,
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		testRejectedGenerator(t, adversarialApp, rpcClient)
+	})
+	t.Run("Deployer", func(t *testing.T) {
+		deployerApp, err := app.NewDeployerApplication(rpcClient, primaryAccount, 1, 0, 0, app.DeployerConfig{CodeSize: 512, FactoryRatio: 0.5})
+		if err != nil {
+			t.Fatal(err)
+		}
+		testGenerator(t, deployerApp, rpcClient)
+	})
+	t.Run("Query", func(t *testing.T) {
+		queryApp, err := app.NewQueryApplication(rpcClient, primaryAccount, 1, 0, 0, app.QueryConfig{LogRange: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		testQueryGenerator(t, queryApp, rpcClient)
+	})
+	t.Run("Custom", func(t *testing.T) {
+		customApp, err := app.NewCustomApplication(rpcClient, primaryAccount, 1, 0, 0, app.CustomConfig{
+			AbiFile:         "../contracts/abi/Counter.abi",
+			BinFile:         "../contracts/abi/Counter.bin",
+			Calls:           []app.CustomCall{{Method: "incrementCounter", Weight: 1}},
+			ReceivedCounter: "getCount",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		testGenerator(t, customApp, rpcClient)
+	})
+	t.Run("Mix", func(t *testing.T) {
+		mixApp, err := app.NewMixApplication(rpcClient, primaryAccount, 1, 0, 0, app.Config{
+			Mix: []app.MixComponent{{Type: "erc20", Weight: 5}, {Type: "store", Weight: 2}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		testGenerator(t, mixApp, rpcClient)
+	})
+	t.Run("CustomReceipts", func(t *testing.T) {
+		customApp, err := app.NewCustomApplication(rpcClient, primaryAccount, 1, 0, 0, app.CustomConfig{
+			AbiFile: "../contracts/abi/Store.abi",
+			BinFile: "../contracts/abi/Store.bin",
+			Calls: []app.CustomCall{
+				{Method: "put", Weight: 3, Args: []string{"$random(1000)", "$counter"}},
+				{Method: "fill", Weight: 1, Args: []string{"0", "10", "$random(1000)"}},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		testGenerator(t, customApp, rpcClient)
+	})
 }
 
 func testGenerator(t *testing.T, app app.Application, rpcClient rpc.RpcClient) {
@@ -120,4 +221,82 @@ func testGenerator(t *testing.T, app app.Application, rpcClient rpc.RpcClient) {
 	if err != nil {
 		t.Error(err)
 	}
+
+	if err := app.Verify(rpcClient, nil); err != nil {
+		t.Errorf("verification of the application state failed: %v", err)
+	}
+}
+
+// testRejectedGenerator checks that all transactions generated by a user of the given
+// application are rejected by the network.
+func testRejectedGenerator(t *testing.T, application app.Application, rpcClient rpc.RpcClient) {
+	gen, err := application.CreateUser(rpcClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := application.WaitUntilApplicationIsDeployed(rpcClient); err != nil {
+		t.Fatal(err)
+	}
+
+	numTransactions := 10
+	for i := 0; i < numTransactions; i++ {
+		tx, err := gen.GenerateTx()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := rpcClient.SendTransaction(context.Background(), tx); err == nil {
+			t.Errorf("transaction %d should have been rejected", i)
+		}
+	}
+
+	rejecting, ok := application.(app.RejectingApplication)
+	if !ok {
+		t.Fatalf("application does not report expected rejections")
+	}
+	if got := rejecting.GetExpectedRejections()["txpool_invalid"]; got != uint64(numTransactions) {
+		t.Errorf("unexpected number of expected rejections, wanted %d, got %d", numTransactions, got)
+	}
+	if err := application.Verify(rpcClient, nil); err != nil {
+		t.Errorf("verification of the application state failed: %v", err)
+	}
+}
+
+// testQueryGenerator checks that all requests generated by a user of the given
+// application are served by the network without modifying the state.
+func testQueryGenerator(t *testing.T, application app.Application, rpcClient rpc.RpcClient) {
+	querying, ok := application.(app.QueryingApplication)
+	if !ok {
+		t.Fatalf("application does not create queriers")
+	}
+	gen, err := querying.CreateQuerier(rpcClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := application.WaitUntilApplicationIsDeployed(rpcClient); err != nil {
+		t.Fatal(err)
+	}
+
+	var head hexutil.Uint64
+	if err := rpcClient.Call(&head, "eth_blockNumber"); err != nil {
+		t.Fatal(err)
+	}
+
+	numQueries := 50
+	for i := 0; i < numQueries; i++ {
+		query, err := gen.GenerateQuery(uint64(head))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var result json.RawMessage
+		if err := rpcClient.Call(&result, query.Method, query.Args...); err != nil {
+			t.Errorf("failed to send %s query: %v", query.Method, err)
+		}
+	}
+
+	if got, want := gen.GetSentQueries(), numQueries; got != uint64(want) {
+		t.Errorf("invalid number of sent queries reported, wanted %d, got %d", want, got)
+	}
+	if err := application.Verify(rpcClient, nil); err != nil {
+		t.Errorf("verification of the application state failed: %v", err)
+	}
 }
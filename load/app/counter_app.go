@@ -91,6 +91,11 @@ type CounterApplication struct {
 	startingAccounts []*Account
 	contractAddress  common.Address
 	accountFactory   *AccountFactory
+	users            userAccounts
+}
+
+func (f *CounterApplication) setTxConfig(config TxConfig) {
+	f.accountFactory.setTxConfig(config)
 }
 
 // CreateUser creates a new user for the app.
@@ -112,6 +117,7 @@ func (f *CounterApplication) CreateUser(rpcClient rpc.RpcClient) (User, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to fund worker account %d; %v", workerAccount.id, err)
 	}
+	f.users.add(workerAccount)
 
 	gen := &CounterUser{
 		abi:      f.abi,
@@ -139,6 +145,33 @@ func (f *CounterApplication) GetReceivedTransactions(rpcClient rpc.RpcClient) (u
 	return count.Uint64(), nil
 }
 
+// Verify checks that the counter value equals the number of increments included in the chain.
+func (f *CounterApplication) Verify(rpcClient rpc.RpcClient, blockNumber *big.Int) error {
+	blockNumber, err := resolveBlockNumber(rpcClient, blockNumber)
+	if err != nil {
+		return err
+	}
+	included, err := f.users.getIncludedTransactions(rpcClient, blockNumber)
+	if err != nil {
+		return err
+	}
+	counterContract, err := contract.NewCounter(f.contractAddress, rpcClient)
+	if err != nil {
+		return fmt.Errorf("failed to get Counter contract representation; %v", err)
+	}
+	count, err := counterContract.GetCount(&bind.CallOpts{BlockNumber: blockNumber})
+	if err != nil {
+		return err
+	}
+	if want := new(big.Int).SetUint64(sum(included)); count.Cmp(want) != 0 {
+		return fmt.Errorf("counter value %v does not match the number of included increments %v at block %v", count, want, blockNumber)
+	}
+	return nil
+}
+
+// counterCountSlot is the storage slot of the value of the Counter contract.
+var counterCountSlot = common.Hash{}
+
 // CounterUser represents a user sending txs to increment a trivial Counter contract value.
 // A generator is supposed to be used in a single thread.
 type CounterUser struct {
@@ -158,7 +191,7 @@ func (g *CounterUser) GenerateTx() (*types.Transaction, error) {
 
 	// prepare tx
 	const gasLimit = 50000 // IncrementCounter method call takes 43426 of gas
-	tx, err := createTx(g.sender, g.contract, big.NewInt(0), data, g.gasPrice, gasLimit)
+	tx, err := createTxAccessing(g.sender, g.contract, []common.Hash{counterCountSlot}, data, g.gasPrice, gasLimit)
 	if err == nil {
 		atomic.AddUint64(&g.sentTxs, 1)
 	}
@@ -168,3 +201,7 @@ func (g *CounterUser) GenerateTx() (*types.Transaction, error) {
 func (g *CounterUser) GetSentTransactions() uint64 {
 	return atomic.LoadUint64(&g.sentTxs)
 }
+
+func (g *CounterUser) SyncNonce(rpcClient rpc.RpcClient) (uint64, error) {
+	return g.sender.syncNonce(rpcClient)
+}
@@ -0,0 +1,482 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// customGasLimitMargin is the factor applied to estimated gas limits of calls, covering
+// calls consuming more gas than the call used for the estimation.
+const customGasLimitMargin = 1.5
+
+// CustomConfig defines the contract deployed by a custom application and the
+// calls issued by its users.
+type CustomConfig struct {
+	// AbiFile is the path of the JSON ABI definition of the contract.
+	AbiFile string
+	// BinFile is the path of the file containing the hex-encoded bytecode of the contract.
+	BinFile string
+	// Constructor are the arguments of the constructor of the contract.
+	Constructor []string
+	// Calls are the method calls issued by the users.
+	Calls []CustomCall
+	// ReceivedCounter is the name of a view function without arguments returning the
+	// number of received transactions. If empty, successful receipts are counted.
+	ReceivedCounter string
+}
+
+// CustomCall defines a method call issued by users of a custom application. The
+// arguments are either literal values or names of argument generators.
+type CustomCall struct {
+	Method   string
+	Weight   float64 // relative frequency of the call
+	Args     []string
+	GasLimit uint64 // 0 if the gas limit is to be estimated
+}
+
+// customContract is the parsed definition of a custom application's contract and calls.
+type customContract struct {
+	abi         *abi.ABI
+	bytecode    []byte
+	constructor []argGenerator
+	calls       []customCall
+	weights     []float64 // cumulative weights of the calls
+	counter     string
+}
+
+type customCall struct {
+	method string
+	args   []argGenerator
+	gas    uint64
+}
+
+// CheckCustomConfig tests that the contract and calls of the given configuration
+// can be loaded and are consistent with the contract's ABI.
+func CheckCustomConfig(config CustomConfig) error {
+	_, err := loadCustomContract(config)
+	return err
+}
+
+// loadCustomContract reads the contract definition files of the given configuration
+// and compiles generators for the arguments of all calls.
+func loadCustomContract(config CustomConfig) (*customContract, error) {
+	abiFile, err := os.Open(config.AbiFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ABI file; %v", err)
+	}
+	defer abiFile.Close()
+	parsedAbi, err := abi.JSON(abiFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI file %s; %v", config.AbiFile, err)
+	}
+
+	bin, err := os.ReadFile(config.BinFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bytecode file; %v", err)
+	}
+	code := strings.TrimSpace(string(bin))
+	if !strings.HasPrefix(code, "0x") {
+		code = "0x" + code
+	}
+	bytecode, err := hexutil.Decode(code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode bytecode in %s; %v", config.BinFile, err)
+	}
+	if len(bytecode) == 0 {
+		return nil, fmt.Errorf("bytecode in %s is empty", config.BinFile)
+	}
+
+	constructor, err := parseArgs(parsedAbi.Constructor.Inputs, config.Constructor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid constructor arguments; %v", err)
+	}
+
+	if len(config.Calls) == 0 {
+		return nil, fmt.Errorf("at least one call must be defined")
+	}
+	res := &customContract{
+		abi:         &parsedAbi,
+		bytecode:    bytecode,
+		constructor: constructor,
+		counter:     config.ReceivedCounter,
+	}
+	sum := 0.0
+	for _, call := range config.Calls {
+		method, found := parsedAbi.Methods[call.Method]
+		if !found {
+			return nil, fmt.Errorf("unknown method: %s", call.Method)
+		}
+		if call.Weight <= 0 {
+			return nil, fmt.Errorf("weight of method %s must be > 0, got %f", call.Method, call.Weight)
+		}
+		args, err := parseArgs(method.Inputs, call.Args)
+		if err != nil {
+			return nil, fmt.Errorf("invalid arguments of method %s; %v", call.Method, err)
+		}
+		sum += call.Weight
+		res.calls = append(res.calls, customCall{method: call.Method, args: args, gas: call.GasLimit})
+		res.weights = append(res.weights, sum)
+	}
+
+	if counter := config.ReceivedCounter; counter != "" {
+		method, found := parsedAbi.Methods[counter]
+		if !found {
+			return nil, fmt.Errorf("unknown received counter method: %s", counter)
+		}
+		if !method.IsConstant() || len(method.Inputs) != 0 || len(method.Outputs) != 1 ||
+			(method.Outputs[0].Type.T != abi.IntTy && method.Outputs[0].Type.T != abi.UintTy) {
+			return nil, fmt.Errorf("received counter %s must be a view function without arguments returning an integer", counter)
+		}
+	}
+	return res, nil
+}
+
+// pickCall selects a call randomly according to the call weights.
+func (c *customContract) pickCall(random *rand.Rand) *customCall {
+	target := random.Float64() * c.weights[len(c.weights)-1]
+	pos := sort.SearchFloat64s(c.weights, target)
+	if pos >= len(c.calls) {
+		pos = len(c.calls) - 1
+	}
+	return &c.calls[pos]
+}
+
+// newCustomFactory creates a factory for custom applications using the given configuration.
+func newCustomFactory(config CustomConfig) appFactoryFunc {
+	return func(rpcClient rpc.RpcClient, primaryAccount *Account, numUsers int, feederId, appId uint32) (Application, error) {
+		return NewCustomApplication(rpcClient, primaryAccount, numUsers, feederId, appId, config)
+	}
+}
+
+// NewCustomApplication deploys the contract defined by the given configuration to
+// the chain. Users of the application issue the configured calls to the contract.
+func NewCustomApplication(rpcClient rpc.RpcClient, primaryAccount *Account, numUsers int, feederId, appId uint32, config CustomConfig) (Application, error) {
+	contract, err := loadCustomContract(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// get price of gas from the network
+	regularGasPrice, err := getGasPrice(rpcClient)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deploy the contract to be used by tx generators
+	txOpts, err := bind.NewKeyedTransactorWithChainID(primaryAccount.privateKey, primaryAccount.chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create txOpts for contract deploy; %v", err)
+	}
+	txOpts.GasPrice = getPriorityGasPrice(regularGasPrice)
+	txOpts.Nonce = big.NewInt(int64(primaryAccount.getNextNonce()))
+	deployer := &argContext{sender: primaryAccount.address, random: rand.New(rand.NewSource(int64(appId)))}
+	constructorArgs, err := generateArgs(deployer, contract.constructor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate constructor arguments; %v", err)
+	}
+	contractAddress, _, _, err := bind.DeployContract(txOpts, *contract.abi, contract.bytecode, rpcClient, constructorArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deploy custom contract; %v", err)
+	}
+
+	accountFactory, err := NewAccountFactory(primaryAccount.chainID, feederId, appId)
+	if err != nil {
+		return nil, err
+	}
+
+	// deploying too many generators from one account leads to excessive gasPrice growth - we
+	// need to spread the initialization in between multiple startingAccounts
+	startingAccounts, err := generateStartingAccounts(rpcClient, primaryAccount, accountFactory, numUsers, regularGasPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	// wait until the contract will be available on the chain
+	err = waitUntilAccountNonceIs(primaryAccount.address, primaryAccount.getCurrentNonce(), rpcClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait until the custom contract is deployed; %v", err)
+	}
+
+	// estimate gas limits of calls not specifying one, using the deployer as the sender
+	for i := range contract.calls {
+		call := &contract.calls[i]
+		if call.gas != 0 {
+			continue
+		}
+		data, err := contract.pack(call, deployer)
+		if err != nil {
+			return nil, err
+		}
+		gas, err := rpcClient.EstimateGas(context.Background(), ethereum.CallMsg{From: primaryAccount.address, To: &contractAddress, Data: data})
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate gas of method %s; %v", call.method, err)
+		}
+		call.gas = uint64(float64(gas) * customGasLimitMargin)
+	}
+
+	return &CustomApplication{
+		contract:         contract,
+		startingAccounts: startingAccounts,
+		contractAddress:  contractAddress,
+		accountFactory:   accountFactory,
+	}, nil
+}
+
+// pack encodes the given call with arguments generated for the given user.
+func (c *customContract) pack(call *customCall, ctx *argContext) ([]byte, error) {
+	args, err := generateArgs(ctx, call.args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate arguments of method %s; %v", call.method, err)
+	}
+	data, err := c.abi.Pack(call.method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack arguments of method %s; %v", call.method, err)
+	}
+	return data, nil
+}
+
+// CustomApplication represents a user-defined contract receiving a configurable mix of
+// method calls. While the application is thread-safe, each created user should be used
+// in a single thread only.
+type CustomApplication struct {
+	contract         *customContract
+	startingAccounts []*Account
+	contractAddress  common.Address
+	accountFactory   *AccountFactory
+
+	customUsers []*CustomUser
+	usersMutex  sync.Mutex
+}
+
+func (f *CustomApplication) setTxConfig(config TxConfig) {
+	f.accountFactory.setTxConfig(config)
+}
+
+// CreateUser creates a new user for the app.
+func (f *CustomApplication) CreateUser(rpcClient rpc.RpcClient) (User, error) {
+
+	// get price of gas from the network
+	regularGasPrice, err := getGasPrice(rpcClient)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate a new account for each worker - avoid account nonces related bottlenecks
+	workerAccount, err := f.accountFactory.CreateAccount(rpcClient)
+	if err != nil {
+		return nil, err
+	}
+	startingAccount := f.startingAccounts[workerAccount.id%len(f.startingAccounts)]
+	err = workerAccount.Fund(startingAccount, rpcClient, regularGasPrice, 1000)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fund worker account %d; %v", workerAccount.id, err)
+	}
+
+	gen := &CustomUser{
+		contract:        f.contract,
+		contractAddress: f.contractAddress,
+		sender:          workerAccount,
+		gasPrice:        regularGasPrice,
+		ctx: argContext{
+			sender: workerAccount.address,
+			random: rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(workerAccount.address[:8])))),
+		},
+	}
+	f.usersMutex.Lock()
+	f.customUsers = append(f.customUsers, gen)
+	f.usersMutex.Unlock()
+	return gen, nil
+}
+
+func (f *CustomApplication) WaitUntilApplicationIsDeployed(rpcClient rpc.RpcClient) error {
+	return waitUntilAllSentTxsAreOnChain(f.startingAccounts, rpcClient)
+}
+
+// GetReceivedTransactions obtains the number of received transactions from the
+// configured view function of the contract or, if there is none, by counting the
+// successful receipts of the transactions sent by the users.
+func (f *CustomApplication) GetReceivedTransactions(rpcClient rpc.RpcClient) (uint64, error) {
+	if f.contract.counter != "" {
+		return f.getReceivedFromCounter(rpcClient)
+	}
+	f.usersMutex.Lock()
+	users := f.customUsers
+	f.usersMutex.Unlock()
+
+	sum := uint64(0)
+	for _, user := range users {
+		count, err := user.updateReceived(rpcClient)
+		if err != nil {
+			return 0, err
+		}
+		sum += count
+	}
+	return sum, nil
+}
+
+func (f *CustomApplication) getReceivedFromCounter(rpcClient rpc.RpcClient) (uint64, error) {
+	contract := bind.NewBoundContract(f.contractAddress, *f.contract.abi, rpcClient, rpcClient, rpcClient)
+	var out []interface{}
+	if err := contract.Call(nil, &out, f.contract.counter); err != nil {
+		return 0, fmt.Errorf("failed to call %s; %v", f.contract.counter, err)
+	}
+	if len(out) != 1 {
+		return 0, fmt.Errorf("unexpected result of %s: %v", f.contract.counter, out)
+	}
+	count := new(big.Int)
+	switch value := out[0].(type) {
+	case *big.Int:
+		count.Set(value)
+	case uint8, uint16, uint32, uint64, int8, int16, int32, int64:
+		count.SetString(fmt.Sprintf("%d", value), 10)
+	default:
+		return 0, fmt.Errorf("unexpected result type of %s: %T", f.contract.counter, value)
+	}
+	if count.Sign() < 0 || !count.IsUint64() {
+		return 0, fmt.Errorf("invalid number of received transactions: %v", count)
+	}
+	return count.Uint64(), nil
+}
+
+// Verify is a no-op for custom applications, since the invariants of user-defined
+// contracts are unknown.
+func (f *CustomApplication) Verify(rpc.RpcClient, *big.Int) error {
+	return nil
+}
+
+// CustomUser represents a user sending calls to a custom contract.
+// A generator is supposed to be used in a single thread.
+type CustomUser struct {
+	contract        *customContract
+	contractAddress common.Address
+	sender          *Account
+	gasPrice        *big.Int
+	ctx             argContext
+	sentTxs         uint64
+
+	// pending are the hashes of sent transactions without a known receipt, in the
+	// order they have been sent, succeeded the number of successful receipts.
+	pending      []common.Hash
+	succeeded    uint64
+	pendingMutex sync.Mutex
+}
+
+func (g *CustomUser) GenerateTx() (*types.Transaction, error) {
+	call := g.contract.pickCall(g.ctx.random)
+	data, err := g.contract.pack(call, &g.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := createTx(g.sender, g.contractAddress, big.NewInt(0), data, g.gasPrice, call.gas)
+	if err == nil {
+		g.ctx.counter++
+		atomic.AddUint64(&g.sentTxs, 1)
+		g.pendingMutex.Lock()
+		g.pending = append(g.pending, tx.Hash())
+		g.pendingMutex.Unlock()
+	}
+	return tx, err
+}
+
+func (g *CustomUser) GetSentTransactions() uint64 {
+	return atomic.LoadUint64(&g.sentTxs)
+}
+
+func (g *CustomUser) SyncNonce(rpcClient rpc.RpcClient) (uint64, error) {
+	lost, err := g.sender.syncNonce(rpcClient)
+	if err != nil || lost == 0 {
+		return lost, err
+	}
+	// the lost transactions are the latest ones sent, they never get a receipt;
+	// the list is copied since concurrent updates may still read the dropped hashes
+	g.pendingMutex.Lock()
+	defer g.pendingMutex.Unlock()
+	keep := len(g.pending) - int(lost)
+	if keep < 0 {
+		keep = 0
+	}
+	g.pending = append([]common.Hash(nil), g.pending[:keep]...)
+	return lost, nil
+}
+
+// maxReceiptBatchSize is the maximum number of receipts fetched at once per user.
+const maxReceiptBatchSize = 1000
+
+// updateReceived fetches the receipts of pending transactions and returns the total
+// number of successful transactions. Since transactions of a user are included in
+// the order they have been sent, fetching stops at the first missing receipt.
+func (g *CustomUser) updateReceived(rpcClient rpc.RpcClient) (uint64, error) {
+	g.pendingMutex.Lock()
+	pending := g.pending
+	if len(pending) > maxReceiptBatchSize {
+		pending = pending[:maxReceiptBatchSize]
+	}
+	g.pendingMutex.Unlock()
+
+	receipts := make([]*struct{ Status hexutil.Uint64 }, len(pending))
+	batch := make([]gethrpc.BatchElem, len(pending))
+	for i, hash := range pending {
+		batch[i] = gethrpc.BatchElem{
+			Method: "eth_getTransactionReceipt",
+			Args:   []interface{}{hash},
+			Result: &receipts[i],
+		}
+	}
+	if len(batch) > 0 {
+		if err := rpcClient.BatchCall(batch); err != nil {
+			return 0, fmt.Errorf("failed to get receipts; %v", err)
+		}
+	}
+
+	// concurrent updates may have processed some of the fetched receipts already,
+	// only those of hashes still at the front of the pending list are counted
+	g.pendingMutex.Lock()
+	defer g.pendingMutex.Unlock()
+	for i, receipt := range receipts {
+		if batch[i].Error != nil || receipt == nil {
+			break
+		}
+		if len(g.pending) == 0 || g.pending[0] != pending[i] {
+			continue
+		}
+		if receipt.Status == hexutil.Uint64(types.ReceiptStatusSuccessful) {
+			g.succeeded++
+		}
+		g.pending = g.pending[1:]
+	}
+	return g.succeeded, nil
+}
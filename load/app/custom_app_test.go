@@ -0,0 +1,148 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/golang/mock/gomock"
+)
+
+func TestLoadCustomContract_ValidConfigIsAccepted(t *testing.T) {
+	contract, err := loadCustomContract(CustomConfig{
+		AbiFile: "../contracts/abi/Store.abi",
+		BinFile: "../contracts/abi/Store.bin",
+		Calls: []CustomCall{
+			{Method: "put", Weight: 3, Args: []string{"$random(1000)", "$counter"}},
+			{Method: "get", Weight: 1, Args: []string{"$random(1000)"}},
+		},
+		ReceivedCounter: "getCount",
+	})
+	if err != nil {
+		t.Fatalf("failed to load contract: %v", err)
+	}
+	if len(contract.bytecode) == 0 {
+		t.Errorf("bytecode was not loaded")
+	}
+	if want, got := []float64{3, 4}, contract.weights; len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("unexpected cumulative weights, wanted %v, got %v", want, got)
+	}
+}
+
+func TestLoadCustomContract_InvalidConfigIsRejected(t *testing.T) {
+	valid := func() CustomConfig {
+		return CustomConfig{
+			AbiFile: "../contracts/abi/Counter.abi",
+			BinFile: "../contracts/abi/Counter.bin",
+			Calls:   []CustomCall{{Method: "incrementCounter", Weight: 1}},
+		}
+	}
+	tests := map[string]func(*CustomConfig){
+		"missing ABI":        func(c *CustomConfig) { c.AbiFile = "missing.abi" },
+		"missing bytecode":   func(c *CustomConfig) { c.BinFile = "missing.bin" },
+		"invalid bytecode":   func(c *CustomConfig) { c.BinFile = "../contracts/abi/Counter.abi" },
+		"no calls":           func(c *CustomConfig) { c.Calls = nil },
+		"unknown method":     func(c *CustomConfig) { c.Calls[0].Method = "unknown" },
+		"zero weight":        func(c *CustomConfig) { c.Calls[0].Weight = 0 },
+		"too many arguments": func(c *CustomConfig) { c.Calls[0].Args = []string{"1"} },
+		"constructor args":   func(c *CustomConfig) { c.Constructor = []string{"1"} },
+		"unknown counter":    func(c *CustomConfig) { c.ReceivedCounter = "unknown" },
+		"non-view counter":   func(c *CustomConfig) { c.ReceivedCounter = "incrementCounter" },
+	}
+	for name, modify := range tests {
+		config := valid()
+		modify(&config)
+		if err := CheckCustomConfig(config); err == nil {
+			t.Errorf("%s: expected configuration to be rejected", name)
+		}
+	}
+	if err := CheckCustomConfig(valid()); err != nil {
+		t.Errorf("unexpected error for valid configuration: %v", err)
+	}
+}
+
+func TestCustomContract_CallsArePickedByWeight(t *testing.T) {
+	contract := &customContract{
+		calls:   []customCall{{method: "a"}, {method: "b"}},
+		weights: []float64{1, 4},
+	}
+	random := rand.New(rand.NewSource(1))
+	counts := map[string]int{}
+	for i := 0; i < 10000; i++ {
+		counts[contract.pickCall(random).method]++
+	}
+	if ratio := float64(counts["b"]) / float64(counts["a"]); ratio < 2.5 || ratio > 3.5 {
+		t.Errorf("unexpected ratio of picked calls, wanted ~3, got %f (%v)", ratio, counts)
+	}
+}
+
+func TestCustomUser_OverlappingUpdatesCountReceiptsOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+	user := &CustomUser{pending: []common.Hash{{0x01}, {0x02}, {0x03}}}
+
+	// receipts of the first two transactions are available, the nested call
+	// completes while the outer call is waiting for its receipts
+	nested := true
+	rpcClient.EXPECT().BatchCall(gomock.Any()).Times(2).DoAndReturn(func(batch []gethrpc.BatchElem) error {
+		if nested {
+			nested = false
+			if got, err := user.updateReceived(rpcClient); err != nil || got != 2 {
+				t.Errorf("unexpected result of nested update, wanted 2, got %d, err %v", got, err)
+			}
+		}
+		for _, elem := range batch {
+			if hash := elem.Args[0].(common.Hash); hash != (common.Hash{0x03}) {
+				result := elem.Result.(**struct{ Status hexutil.Uint64 })
+				*result = &struct{ Status hexutil.Uint64 }{Status: 1}
+			}
+		}
+		return nil
+	})
+
+	got, err := user.updateReceived(rpcClient)
+	if err != nil || got != 2 {
+		t.Errorf("receipts should be counted once, wanted 2, got %d, err %v", got, err)
+	}
+	if len(user.pending) != 1 || user.pending[0] != (common.Hash{0x03}) {
+		t.Errorf("transaction without receipt should remain pending, got %v", user.pending)
+	}
+}
+
+func TestCustomUser_SyncNonceDropsLostTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+	rpcClient.EXPECT().NonceAt(gomock.Any(), gomock.Any(), nil).Return(uint64(1), nil)
+	rpcClient.EXPECT().PendingNonceAt(gomock.Any(), gomock.Any()).Return(uint64(2), nil)
+
+	user := &CustomUser{
+		sender:  &Account{nonce: 4},
+		pending: []common.Hash{{0x01}, {0x02}, {0x03}},
+	}
+	lost, err := user.SyncNonce(rpcClient)
+	if err != nil || lost != 2 {
+		t.Errorf("unexpected number of lost transactions, wanted 2, got %d, err %v", lost, err)
+	}
+	if len(user.pending) != 1 || user.pending[0] != (common.Hash{0x01}) {
+		t.Errorf("only transactions still known to the network should remain pending, got %v", user.pending)
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Argument generators supported in the arguments of calls of custom applications.
+const (
+	// RandomArg produces a random value of the argument's type.
+	RandomArg = "$random"
+	// UserArg produces the address of the user sending the call.
+	UserArg = "$user"
+	// CounterArg produces the number of calls sent by the user before.
+	CounterArg = "$counter"
+)
+
+// argContext provides the state of the user an argument is generated for.
+type argContext struct {
+	sender  common.Address
+	counter uint64
+	random  *rand.Rand
+}
+
+// argGenerator produces the value of a single call argument.
+type argGenerator func(ctx *argContext) (interface{}, error)
+
+// parseArgs compiles generators for the given arguments of a method with the given inputs.
+func parseArgs(inputs abi.Arguments, args []string) ([]argGenerator, error) {
+	if len(inputs) != len(args) {
+		return nil, fmt.Errorf("expected %d arguments, got %d", len(inputs), len(args))
+	}
+	res := make([]argGenerator, 0, len(args))
+	for i, arg := range args {
+		generator, err := parseArg(inputs[i].Type, arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument %d (%s); %v", i, inputs[i].Type, err)
+		}
+		res = append(res, generator)
+	}
+	return res, nil
+}
+
+// generateArgs produces the values of the given arguments.
+func generateArgs(ctx *argContext, generators []argGenerator) ([]interface{}, error) {
+	res := make([]interface{}, 0, len(generators))
+	for _, generator := range generators {
+		value, err := generator(ctx)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, value)
+	}
+	return res, nil
+}
+
+// parseArg compiles a generator for an argument of the given type. The argument is
+// either the name of a generator or a literal value.
+func parseArg(typ abi.Type, arg string) (argGenerator, error) {
+	switch {
+	case arg == RandomArg:
+		return getRandomGenerator(typ)
+	case strings.HasPrefix(arg, RandomArg+"(") && strings.HasSuffix(arg, ")"):
+		if typ.T != abi.IntTy && typ.T != abi.UintTy {
+			return nil, fmt.Errorf("bounded random values are only supported for integers")
+		}
+		limit, ok := new(big.Int).SetString(arg[len(RandomArg)+1:len(arg)-1], 0)
+		if !ok || limit.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid limit of random values: %s", arg)
+		}
+		return func(ctx *argContext) (interface{}, error) {
+			return toAbiInteger(typ, new(big.Int).Rand(ctx.random, limit))
+		}, nil
+	case arg == UserArg:
+		if typ.T != abi.AddressTy {
+			return nil, fmt.Errorf("%s is only supported for addresses", UserArg)
+		}
+		return func(ctx *argContext) (interface{}, error) {
+			return ctx.sender, nil
+		}, nil
+	case arg == CounterArg:
+		if typ.T != abi.IntTy && typ.T != abi.UintTy {
+			return nil, fmt.Errorf("%s is only supported for integers", CounterArg)
+		}
+		return func(ctx *argContext) (interface{}, error) {
+			return toAbiInteger(typ, new(big.Int).SetUint64(ctx.counter))
+		}, nil
+	case strings.HasPrefix(arg, "$"):
+		return nil, fmt.Errorf("unknown argument generator: %s", arg)
+	}
+
+	value, err := parseLiteral(typ, arg)
+	if err != nil {
+		return nil, err
+	}
+	return func(*argContext) (interface{}, error) {
+		return value, nil
+	}, nil
+}
+
+// parseLiteral converts the given literal into a value of the given type.
+func parseLiteral(typ abi.Type, literal string) (interface{}, error) {
+	switch typ.T {
+	case abi.IntTy, abi.UintTy:
+		value, ok := new(big.Int).SetString(literal, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer: %s", literal)
+		}
+		return toAbiInteger(typ, value)
+	case abi.BoolTy:
+		return strconv.ParseBool(literal)
+	case abi.AddressTy:
+		if !common.IsHexAddress(literal) {
+			return nil, fmt.Errorf("invalid address: %s", literal)
+		}
+		return common.HexToAddress(literal), nil
+	case abi.StringTy:
+		return literal, nil
+	case abi.BytesTy:
+		return hexutil.Decode(literal)
+	case abi.FixedBytesTy:
+		data, err := hexutil.Decode(literal)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > typ.Size {
+			return nil, fmt.Errorf("value exceeds %d bytes: %s", typ.Size, literal)
+		}
+		return toFixedBytes(typ, data), nil
+	}
+	return nil, fmt.Errorf("unsupported argument type: %s", typ)
+}
+
+// getRandomGenerator creates a generator of random values of the given type.
+func getRandomGenerator(typ abi.Type) (argGenerator, error) {
+	switch typ.T {
+	case abi.IntTy, abi.UintTy:
+		bits := uint(typ.Size)
+		if typ.T == abi.IntTy {
+			bits-- // only non-negative values are produced
+		}
+		limit := new(big.Int).Lsh(big.NewInt(1), bits)
+		return func(ctx *argContext) (interface{}, error) {
+			return toAbiInteger(typ, new(big.Int).Rand(ctx.random, limit))
+		}, nil
+	case abi.BoolTy:
+		return func(ctx *argContext) (interface{}, error) {
+			return ctx.random.Intn(2) == 1, nil
+		}, nil
+	case abi.AddressTy:
+		return func(ctx *argContext) (interface{}, error) {
+			var address common.Address
+			ctx.random.Read(address[:])
+			return address, nil
+		}, nil
+	case abi.StringTy:
+		return func(ctx *argContext) (interface{}, error) {
+			data := make([]byte, 16)
+			ctx.random.Read(data)
+			return hexutil.Encode(data), nil
+		}, nil
+	case abi.BytesTy:
+		return func(ctx *argContext) (interface{}, error) {
+			data := make([]byte, 32)
+			ctx.random.Read(data)
+			return data, nil
+		}, nil
+	case abi.FixedBytesTy:
+		return func(ctx *argContext) (interface{}, error) {
+			data := make([]byte, typ.Size)
+			ctx.random.Read(data)
+			return toFixedBytes(typ, data), nil
+		}, nil
+	}
+	return nil, fmt.Errorf("random values are not supported for type %s", typ)
+}
+
+// toAbiInteger converts the given value into the Go type the ABI encoder expects
+// for the given integer type.
+func toAbiInteger(typ abi.Type, value *big.Int) (interface{}, error) {
+	if typ.T == abi.UintTy && value.Sign() < 0 {
+		return nil, fmt.Errorf("negative value %v for unsigned type %s", value, typ)
+	}
+	bits := value.BitLen()
+	if typ.T == abi.IntTy {
+		bits++ // sign bit
+	}
+	if bits > typ.Size {
+		return nil, fmt.Errorf("value %v exceeds type %s", value, typ)
+	}
+	kind := typ.GetType().Kind()
+	switch kind {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return reflect.ValueOf(value.Uint64()).Convert(typ.GetType()).Interface(), nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.ValueOf(value.Int64()).Convert(typ.GetType()).Interface(), nil
+	}
+	return value, nil
+}
+
+// toFixedBytes converts the given data into a fixed-size byte array of the given type.
+func toFixedBytes(typ abi.Type, data []byte) interface{} {
+	res := reflect.New(typ.GetType()).Elem()
+	reflect.Copy(res, reflect.ValueOf(data))
+	return res.Interface()
+}
@@ -0,0 +1,142 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestParseArgs_LiteralsAreConverted(t *testing.T) {
+	tests := []struct {
+		typ  string
+		arg  string
+		want interface{}
+	}{
+		{"uint8", "200", uint8(200)},
+		{"int64", "-5", int64(-5)},
+		{"uint256", "0x10", big.NewInt(16)},
+		{"bool", "true", true},
+		{"address", "0x0000000000000000000000000000000000000001", common.Address{19: 1}},
+		{"string", "hello", "hello"},
+		{"bytes", "0x0102", []byte{1, 2}},
+		{"bytes4", "0x0102", [4]byte{1, 2}},
+	}
+	for _, test := range tests {
+		value := generateSingleArg(t, test.typ, test.arg, &argContext{})
+		if !equalValues(value, test.want) {
+			t.Errorf("unexpected value for %s %s, wanted %v (%T), got %v (%T)", test.typ, test.arg, test.want, test.want, value, value)
+		}
+	}
+}
+
+func TestParseArgs_InvalidArgumentsAreDetected(t *testing.T) {
+	tests := []struct {
+		typ string
+		arg string
+	}{
+		{"uint8", "256"},
+		{"uint256", "-1"},
+		{"int8", "128"},
+		{"uint256", "abc"},
+		{"bool", "maybe"},
+		{"address", "0x01"},
+		{"bytes2", "0x010203"},
+		{"uint256", "$user"},
+		{"address", "$counter"},
+		{"address", "$random(10)"},
+		{"uint256", "$random(0)"},
+		{"uint256", "$unknown"},
+	}
+	for _, test := range tests {
+		typ, err := abi.NewType(test.typ, "", nil)
+		if err != nil {
+			t.Fatalf("failed to create type %s: %v", test.typ, err)
+		}
+		if _, err := parseArgs(abi.Arguments{{Type: typ}}, []string{test.arg}); err == nil {
+			t.Errorf("expected argument %s of type %s to be rejected", test.arg, test.typ)
+		}
+	}
+}
+
+func TestParseArgs_NumberOfArgumentsIsChecked(t *testing.T) {
+	typ, _ := abi.NewType("uint256", "", nil)
+	_, err := parseArgs(abi.Arguments{{Type: typ}, {Type: typ}}, []string{"1"})
+	if err == nil || !strings.Contains(err.Error(), "expected 2 arguments") {
+		t.Errorf("expected error on missing argument, got %v", err)
+	}
+}
+
+func TestParseArgs_GeneratorsUseUserState(t *testing.T) {
+	sender := common.Address{1, 2, 3}
+	ctx := &argContext{sender: sender, counter: 12, random: rand.New(rand.NewSource(1))}
+
+	if got := generateSingleArg(t, "address", UserArg, ctx); got != sender {
+		t.Errorf("unexpected user address, wanted %v, got %v", sender, got)
+	}
+	if got := generateSingleArg(t, "uint32", CounterArg, ctx); got != uint32(12) {
+		t.Errorf("unexpected counter, wanted 12, got %v", got)
+	}
+	for i := 0; i < 100; i++ {
+		got := generateSingleArg(t, "uint256", "$random(10)", ctx).(*big.Int)
+		if got.Sign() < 0 || got.Cmp(big.NewInt(10)) >= 0 {
+			t.Fatalf("bounded random value out of range: %v", got)
+		}
+		signed := generateSingleArg(t, "int16", RandomArg, ctx).(int16)
+		if signed < 0 {
+			t.Fatalf("random value should not be negative: %v", signed)
+		}
+	}
+}
+
+func generateSingleArg(t *testing.T, typeName, arg string, ctx *argContext) interface{} {
+	t.Helper()
+	typ, err := abi.NewType(typeName, "", nil)
+	if err != nil {
+		t.Fatalf("failed to create type %s: %v", typeName, err)
+	}
+	generators, err := parseArgs(abi.Arguments{{Type: typ}}, []string{arg})
+	if err != nil {
+		t.Fatalf("failed to parse argument %s of type %s: %v", arg, typeName, err)
+	}
+	values, err := generateArgs(ctx, generators)
+	if err != nil {
+		t.Fatalf("failed to generate argument %s of type %s: %v", arg, typeName, err)
+	}
+	// make sure the value is accepted by the ABI encoder
+	if _, err := (abi.Arguments{{Type: typ}}).Pack(values...); err != nil {
+		t.Fatalf("generated value %v is not accepted for type %s: %v", values[0], typeName, err)
+	}
+	return values[0]
+}
+
+func equalValues(a, b interface{}) bool {
+	if x, ok := a.(*big.Int); ok {
+		y, ok := b.(*big.Int)
+		return ok && x.Cmp(y) == 0
+	}
+	if x, ok := a.([]byte); ok {
+		y, ok := b.([]byte)
+		return ok && string(x) == string(y)
+	}
+	return a == b
+}
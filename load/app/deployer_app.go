@@ -0,0 +1,354 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	contract "github.com/Fantom-foundation/Norma/load/contracts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// MaxCodeSize is the maximum size of the code of a contract in bytes (EIP-170).
+	MaxCodeSize = 24576
+	// defaultCodeSize is the size of the code of deployed contracts if none is configured.
+	defaultCodeSize = 1024
+)
+
+// DeployerConfig defines the contracts deployed by users of a deployer application.
+type DeployerConfig struct {
+	CodeSize     int     // size of the code of deployed contracts in bytes, 0 = 1024
+	FactoryRatio float64 // fraction of contracts deployed through the factory contract
+}
+
+// newDeployerFactory creates a factory for deployer applications using the given configuration.
+func newDeployerFactory(config DeployerConfig) appFactoryFunc {
+	return func(rpcClient rpc.RpcClient, primaryAccount *Account, numUsers int, feederId, appId uint32) (Application, error) {
+		return NewDeployerApplication(rpcClient, primaryAccount, numUsers, feederId, appId, config)
+	}
+}
+
+// NewDeployerApplication deploys a new contract factory to the chain. Users of the
+// application continuously deploy new contracts, either using contract creation
+// transactions or through the factory contract using CREATE2.
+func NewDeployerApplication(rpcClient rpc.RpcClient, primaryAccount *Account, numUsers int, feederId, appId uint32, config DeployerConfig) (Application, error) {
+	if config.CodeSize == 0 {
+		config.CodeSize = defaultCodeSize
+	}
+	if config.CodeSize < 1 || config.CodeSize > MaxCodeSize {
+		return nil, fmt.Errorf("code size must be between 1 and %d, got %d", MaxCodeSize, config.CodeSize)
+	}
+
+	// get price of gas from the network
+	regularGasPrice, err := getGasPrice(rpcClient)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deploy the factory contract to be used by generators created using the factory
+	txOpts, err := bind.NewKeyedTransactorWithChainID(primaryAccount.privateKey, primaryAccount.chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create txOpts for contract deploy; %v", err)
+	}
+	txOpts.GasPrice = getPriorityGasPrice(regularGasPrice)
+	txOpts.Nonce = big.NewInt(int64(primaryAccount.getNextNonce()))
+	contractAddress, _, _, err := contract.DeployContractFactory(txOpts, rpcClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deploy contract factory; %v", err)
+	}
+
+	accountFactory, err := NewAccountFactory(primaryAccount.chainID, feederId, appId)
+	if err != nil {
+		return nil, err
+	}
+
+	// deploying too many generators from one account leads to excessive gasPrice growth - we
+	// need to spread the initialization in between multiple startingAccounts
+	startingAccounts, err := generateStartingAccounts(rpcClient, primaryAccount, accountFactory, numUsers, regularGasPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	// parse ABI for generating txs data
+	parsedAbi, err := contract.ContractFactoryMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	// wait until the contract will be available on the chain
+	err = waitUntilAccountNonceIs(primaryAccount.address, primaryAccount.getCurrentNonce(), rpcClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait until the contract factory is deployed; %v", err)
+	}
+
+	return &DeployerApplication{
+		abi:              parsedAbi,
+		config:           config,
+		startingAccounts: startingAccounts,
+		contractAddress:  contractAddress,
+		accountFactory:   accountFactory,
+	}, nil
+}
+
+// DeployerApplication represents one application deployed to the network - a contract factory.
+// While the application is thread-safe, each created user should be used in a single thread only.
+type DeployerApplication struct {
+	abi              *abi.ABI
+	config           DeployerConfig
+	startingAccounts []*Account
+	contractAddress  common.Address
+	accountFactory   *AccountFactory
+	users            userAccounts
+
+	deployerUsers []*DeployerUser
+	usersMutex    sync.Mutex
+}
+
+func (f *DeployerApplication) setTxConfig(config TxConfig) {
+	f.accountFactory.setTxConfig(config)
+}
+
+// CreateUser creates a new user for the app.
+func (f *DeployerApplication) CreateUser(rpcClient rpc.RpcClient) (User, error) {
+	// get price of gas from the network
+	regularGasPrice, err := getGasPrice(rpcClient)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate a new account for each worker - avoid account nonces related bottlenecks
+	workerAccount, err := f.accountFactory.CreateAccount(rpcClient)
+	if err != nil {
+		return nil, err
+	}
+	startingAccount := f.startingAccounts[workerAccount.id%len(f.startingAccounts)]
+	err = workerAccount.Fund(startingAccount, rpcClient, regularGasPrice, 1000)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fund worker account %d; %v", workerAccount.id, err)
+	}
+	f.users.add(workerAccount)
+
+	user := &DeployerUser{
+		abi:      f.abi,
+		config:   f.config,
+		sender:   workerAccount,
+		gasPrice: regularGasPrice,
+		factory:  f.contractAddress,
+		random:   rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(workerAccount.address[:8])))),
+	}
+	f.usersMutex.Lock()
+	f.deployerUsers = append(f.deployerUsers, user)
+	f.usersMutex.Unlock()
+	return user, nil
+}
+
+func (f *DeployerApplication) WaitUntilApplicationIsDeployed(rpcClient rpc.RpcClient) error {
+	return waitUntilAllSentTxsAreOnChain(f.startingAccounts, rpcClient)
+}
+
+// GetReceivedTransactions obtains the number of deployments included in the chain,
+// derived from the nonces of the users.
+func (f *DeployerApplication) GetReceivedTransactions(rpcClient rpc.RpcClient) (uint64, error) {
+	included, err := f.users.getIncludedTransactions(rpcClient, nil)
+	if err != nil {
+		return 0, err
+	}
+	return sum(included), nil
+}
+
+// Verify checks that the contract deployed by the latest included deployment of each
+// user has the expected code hash, and that the contract of the first deployment not
+// included yet does not exist.
+func (f *DeployerApplication) Verify(rpcClient rpc.RpcClient, blockNumber *big.Int) error {
+	blockNumber, err := resolveBlockNumber(rpcClient, blockNumber)
+	if err != nil {
+		return err
+	}
+	f.usersMutex.Lock()
+	users := f.deployerUsers
+	f.usersMutex.Unlock()
+
+	for _, user := range users {
+		nonce, err := rpcClient.NonceAt(context.Background(), user.sender.address, blockNumber)
+		if err != nil {
+			return fmt.Errorf("failed to get nonce of account %v; %v", user.sender.address, err)
+		}
+		for address, want := range user.getCodeChecks(nonce) {
+			code, err := rpcClient.CodeAt(context.Background(), address, blockNumber)
+			if err != nil {
+				return fmt.Errorf("failed to get code of contract %v; %v", address, err)
+			}
+			if got := getCodeHash(code); got != want {
+				return fmt.Errorf("code hash of contract %v is %v, expected %v at block %v", address, got, want, blockNumber)
+			}
+		}
+	}
+	return nil
+}
+
+// getCodeHash computes the hash of the given code, the zero hash for no code.
+func getCodeHash(code []byte) common.Hash {
+	if len(code) == 0 {
+		return common.Hash{}
+	}
+	return crypto.Keccak256Hash(code)
+}
+
+// deployment is a contract deployed by a user of a deployer application.
+type deployment struct {
+	nonce    uint64 // nonce of the transaction deploying the contract
+	address  common.Address
+	codeHash common.Hash
+}
+
+// DeployerUser represents a user deploying contracts.
+// A generator is supposed to be used in a single thread.
+type DeployerUser struct {
+	abi      *abi.ABI
+	config   DeployerConfig
+	sender   *Account
+	gasPrice *big.Int
+	factory  common.Address
+	random   *rand.Rand
+	sentTxs  uint64
+
+	// deployments are the contracts deployed by the user, in ascending order of nonces.
+	deployments      []deployment
+	deploymentsMutex sync.Mutex
+}
+
+func (g *DeployerUser) GenerateTx() (*types.Transaction, error) {
+	code := g.generateCode()
+	initCode := getInitCode(code)
+	nonce := g.sender.getNextNonce()
+
+	var tx *types.Transaction
+	var address common.Address
+	var err error
+	if g.random.Float64() < g.config.FactoryRatio {
+		// the salt is unique for each deployment of the user
+		var salt [32]byte
+		copy(salt[:], g.sender.address[:])
+		binary.BigEndian.PutUint64(salt[24:], nonce)
+		var data []byte
+		data, err = g.abi.Pack("deploy", salt, initCode)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prepare tx data; %v", err)
+		}
+		gasLimit := getDeploymentGasLimit(data, initCode, len(code), true)
+		tx, err = createTxWithNonce(g.sender, nonce, g.factory, big.NewInt(0), data, g.gasPrice, gasLimit)
+		address = crypto.CreateAddress2(g.factory, salt, crypto.Keccak256(initCode))
+	} else {
+		gasLimit := getDeploymentGasLimit(initCode, initCode, len(code), false)
+		tx, err = createContractCreationTx(g.sender, nonce, initCode, g.gasPrice, gasLimit)
+		address = crypto.CreateAddress(g.sender.address, nonce)
+	}
+	if err == nil {
+		g.deploymentsMutex.Lock()
+		g.deployments = append(g.deployments, deployment{nonce: nonce, address: address, codeHash: crypto.Keccak256Hash(code)})
+		g.deploymentsMutex.Unlock()
+		atomic.AddUint64(&g.sentTxs, 1)
+	}
+	return tx, err
+}
+
+func (g *DeployerUser) GetSentTransactions() uint64 {
+	return atomic.LoadUint64(&g.sentTxs)
+}
+
+func (g *DeployerUser) SyncNonce(rpcClient rpc.RpcClient) (uint64, error) {
+	return g.sender.syncNonce(rpcClient)
+}
+
+// generateCode produces the code of a new contract of the configured size. The code
+// starts with a STOP instruction followed by random data, making each contract unique.
+func (g *DeployerUser) generateCode() []byte {
+	code := make([]byte, g.config.CodeSize)
+	g.random.Read(code[1:])
+	code[0] = 0x00 // STOP
+	return code
+}
+
+// getCodeChecks provides the expected code hashes of the contracts deployed next to
+// the boundary of the included transactions given by the nonce of the sender: the
+// latest included deployment must have created its contract, the first one not
+// included yet must not.
+func (g *DeployerUser) getCodeChecks(nonce uint64) map[common.Address]common.Hash {
+	g.deploymentsMutex.Lock()
+	defer g.deploymentsMutex.Unlock()
+
+	res := map[common.Address]common.Hash{}
+	deployments := g.deployments
+	pos := sort.Search(len(deployments), func(i int) bool { return deployments[i].nonce >= nonce })
+	if pos > 0 {
+		res[deployments[pos-1].address] = deployments[pos-1].codeHash
+	}
+	if pos < len(deployments) {
+		res[deployments[pos].address] = common.Hash{}
+	}
+	return res
+}
+
+// getInitCode creates init code returning the given code as the code of the contract.
+func getInitCode(code []byte) []byte {
+	size := len(code)
+	prefix := []byte{
+		0x61, byte(size >> 8), byte(size), // PUSH2 size
+		0x80,       // DUP1
+		0x60, 0x0c, // PUSH1 12, the size of this prefix
+		0x60, 0x00, // PUSH1 0
+		0x39,       // CODECOPY
+		0x60, 0x00, // PUSH1 0
+		0xf3, // RETURN
+	}
+	return append(prefix, code...)
+}
+
+// getDeploymentGasLimit estimates the gas needed by a transaction with the given data
+// deploying the given init code, producing code of the given size.
+func getDeploymentGasLimit(data, initCode []byte, codeSize int, viaFactory bool) uint64 {
+	words := uint64(len(initCode)+31) / 32
+	memory := 3*words + words*words/512
+	gas := uint64(21000 + 32000) // transaction and contract creation
+	for _, cur := range data {
+		if cur == 0 {
+			gas += 4
+		} else {
+			gas += 16
+		}
+	}
+	gas += 2 * words              // init code (EIP-3860)
+	gas += 3*words + memory + 100 // running the init code
+	gas += 200 * uint64(codeSize) // code deposit
+	if viaFactory {
+		gas += 9*words + memory + 30000 // copying and hashing the code, updating the counter
+	}
+	return gas + gas/10
+}
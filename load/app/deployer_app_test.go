@@ -0,0 +1,95 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestDeployer_InitCodeReturnsCode(t *testing.T) {
+	code := []byte{0x00, 0x01, 0x02}
+	initCode := getInitCode(code)
+	want := []byte{0x61, 0x00, 0x03, 0x80, 0x60, 0x0c, 0x60, 0x00, 0x39, 0x60, 0x00, 0xf3, 0x00, 0x01, 0x02}
+	if !bytes.Equal(initCode, want) {
+		t.Errorf("unexpected init code, wanted %x, got %x", want, initCode)
+	}
+	if got := getInitCode(make([]byte, MaxCodeSize)); got[1] != 0x60 || got[2] != 0x00 {
+		t.Errorf("unexpected size encoding in init code, got %x", got[:3])
+	}
+}
+
+func TestDeployerUser_GeneratedCodeIsUniqueAndOfConfiguredSize(t *testing.T) {
+	user := &DeployerUser{
+		config: DeployerConfig{CodeSize: 100},
+		random: rand.New(rand.NewSource(1)),
+	}
+	a, b := user.generateCode(), user.generateCode()
+	if len(a) != 100 || len(b) != 100 {
+		t.Fatalf("unexpected code sizes, wanted 100, got %d and %d", len(a), len(b))
+	}
+	if a[0] != 0x00 || b[0] != 0x00 {
+		t.Errorf("code should start with a STOP instruction")
+	}
+	if bytes.Equal(a, b) {
+		t.Errorf("generated codes should differ")
+	}
+}
+
+func TestDeployer_GasLimitCoversCodeSizeAndFactory(t *testing.T) {
+	small := getInitCode(make([]byte, 100))
+	large := getInitCode(make([]byte, 10000))
+	if getDeploymentGasLimit(small, small, 100, false) >= getDeploymentGasLimit(large, large, 10000, false) {
+		t.Errorf("larger contracts should get a higher gas limit")
+	}
+	if getDeploymentGasLimit(small, small, 100, false) >= getDeploymentGasLimit(small, small, 100, true) {
+		t.Errorf("deployments through the factory should get a higher gas limit")
+	}
+	if limit := getDeploymentGasLimit(large, large, 10000, false); limit < 53000+200*10000 {
+		t.Errorf("gas limit does not cover the code deposit, got %d", limit)
+	}
+}
+
+func TestDeployerUser_CodeChecksAreAtBoundaryOfIncludedDeployments(t *testing.T) {
+	user := &DeployerUser{deployments: []deployment{
+		{nonce: 1, address: common.Address{1}, codeHash: common.Hash{1}},
+		{nonce: 2, address: common.Address{2}, codeHash: common.Hash{2}},
+		{nonce: 4, address: common.Address{4}, codeHash: common.Hash{4}},
+	}}
+
+	tests := map[uint64]map[common.Address]common.Hash{
+		0: {{1}: {}},
+		2: {{1}: {1}, {2}: {}},
+		3: {{2}: {2}, {4}: {}},
+		5: {{4}: {4}},
+	}
+	for nonce, want := range tests {
+		got := user.getCodeChecks(nonce)
+		if len(got) != len(want) {
+			t.Errorf("unexpected checks for nonce %d, wanted %v, got %v", nonce, want, got)
+			continue
+		}
+		for address, hash := range want {
+			if got[address] != hash {
+				t.Errorf("unexpected check for nonce %d, wanted %v, got %v", nonce, want, got)
+			}
+		}
+	}
+}
@@ -22,13 +22,16 @@ import (
 	"github.com/Fantom-foundation/Norma/driver/rpc"
 	"math/big"
 	"math/rand"
+	"sync"
 	"sync/atomic"
 
 	contract "github.com/Fantom-foundation/Norma/load/contracts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 )
 
 // NewERC20Application deploys a new ERC-20 dapp to the chain.
@@ -100,6 +103,12 @@ func generateRecipientsAddresses() ([]common.Address, error) {
 	return recipients, nil
 }
 
+// erc20UserInitialBalance is the amount of tokens minted for each user.
+var erc20UserInitialBalance = big.NewInt(1_000000000000000000)
+
+// erc20BalancesSlot is the storage slot of the balanceOf mapping of the ERC20 contract.
+var erc20BalancesSlot = common.BigToHash(big.NewInt(1))
+
 // ERC20Application represents one application deployed to the network - an ERC-20 contract.
 // Each created app should be used in a single thread only.
 type ERC20Application struct {
@@ -108,6 +117,12 @@ type ERC20Application struct {
 	contractAddress  common.Address
 	recipients       []common.Address
 	accountFactory   *AccountFactory
+	users            userAccounts
+	mints            erc20Mints
+}
+
+func (f *ERC20Application) setTxConfig(config TxConfig) {
+	f.accountFactory.setTxConfig(config)
 }
 
 // CreateUser creates a new user for the app.
@@ -140,10 +155,12 @@ func (f *ERC20Application) CreateUser(rpcClient rpc.RpcClient) (User, error) {
 	}
 	txOpts.GasPrice = getPriorityGasPrice(regularGasPrice)
 	txOpts.Nonce = big.NewInt(int64(startingAccount.getNextNonce()))
-	_, err = erc20Contract.Mint(txOpts, workerAccount.address, big.NewInt(1_000000000000000000))
+	mint, err := erc20Contract.Mint(txOpts, workerAccount.address, erc20UserInitialBalance)
 	if err != nil {
 		return nil, fmt.Errorf("failed to mint ERC-20; %v", err)
 	}
+	f.users.add(workerAccount)
+	f.mints.add(mint.Hash())
 
 	return &ERC20User{
 		abi:        f.abi,
@@ -175,6 +192,113 @@ func (f *ERC20Application) GetReceivedTransactions(rpcClient rpc.RpcClient) (uin
 	return totalReceived, nil
 }
 
+// Verify checks that the total supply of the token equals the amount minted for the users
+// until the given block and that it is conserved by the transfers, i.e. the balances of all
+// holders sum up to it.
+func (f *ERC20Application) Verify(rpcClient rpc.RpcClient, blockNumber *big.Int) error {
+	blockNumber, err := resolveBlockNumber(rpcClient, blockNumber)
+	if err != nil {
+		return err
+	}
+	ERC20Contract, err := contract.NewERC20(f.contractAddress, rpcClient)
+	if err != nil {
+		return fmt.Errorf("failed to get ERC20 contract representation; %v", err)
+	}
+	opts := &bind.CallOpts{BlockNumber: blockNumber}
+	totalSupply, err := ERC20Contract.TotalSupply(opts)
+	if err != nil {
+		return err
+	}
+
+	// Users may have been created after the given block, so only mints included until then count.
+	users := f.users.getAccounts()
+	numMinted, err := f.mints.countIncludedUntil(rpcClient, blockNumber.Uint64())
+	if err != nil {
+		return err
+	}
+	minted := new(big.Int).Mul(erc20UserInitialBalance, big.NewInt(int64(numMinted)))
+	if totalSupply.Cmp(minted) != 0 {
+		return fmt.Errorf("total supply %v does not match the minted amount %v at block %v", totalSupply, minted, blockNumber)
+	}
+
+	holders := make([]common.Address, 0, len(users)+len(f.recipients))
+	for _, user := range users {
+		holders = append(holders, user.address)
+	}
+	holders = append(holders, f.recipients...)
+	balances := new(big.Int)
+	for _, holder := range holders {
+		balance, err := ERC20Contract.BalanceOf(opts, holder)
+		if err != nil {
+			return err
+		}
+		balances.Add(balances, balance)
+	}
+	if totalSupply.Cmp(balances) != 0 {
+		return fmt.Errorf("total supply %v does not match the sum of all balances %v at block %v", totalSupply, balances, blockNumber)
+	}
+	return nil
+}
+
+// erc20Mints tracks the transactions minting the initial balances of the users together
+// with the blocks they got included in. Instances are thread-safe.
+type erc20Mints struct {
+	hashes []common.Hash
+	blocks []uint64 // < block the mint got included in, 0 if not known yet
+	mutex  sync.Mutex
+}
+
+func (m *erc20Mints) add(hash common.Hash) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.hashes = append(m.hashes, hash)
+	m.blocks = append(m.blocks, 0)
+}
+
+// countIncludedUntil returns the number of mints included in the chain until the given block.
+// Blocks of mints not known yet are looked up using the given RPC client.
+func (m *erc20Mints) countIncludedUntil(rpcClient rpc.RpcClient, blockNumber uint64) (int, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	unknown := []int{}
+	for i, block := range m.blocks {
+		if block == 0 {
+			unknown = append(unknown, i)
+		}
+	}
+	if len(unknown) > 0 {
+		receipts := make([]*struct{ BlockNumber *hexutil.Big }, len(unknown))
+		batch := make([]gethrpc.BatchElem, len(unknown))
+		for i, pos := range unknown {
+			batch[i] = gethrpc.BatchElem{
+				Method: "eth_getTransactionReceipt",
+				Args:   []interface{}{m.hashes[pos]},
+				Result: &receipts[i],
+			}
+		}
+		if err := rpcClient.BatchCall(batch); err != nil {
+			return 0, fmt.Errorf("failed to get receipts of mints; %v", err)
+		}
+		for i, receipt := range receipts {
+			if batch[i].Error != nil {
+				return 0, fmt.Errorf("failed to get receipt of mint %v; %v", m.hashes[unknown[i]], batch[i].Error)
+			}
+			if receipt != nil && receipt.BlockNumber != nil {
+				m.blocks[unknown[i]] = receipt.BlockNumber.ToInt().Uint64()
+			}
+		}
+	}
+
+	count := 0
+	for _, block := range m.blocks {
+		if block != 0 && block <= blockNumber {
+			count++
+		}
+	}
+	return count, nil
+}
+
 // ERC20User represents a user sending txs to transfer ERC20 tokens.
 // A generator is supposed to be used in a single thread.
 type ERC20User struct {
@@ -198,7 +322,11 @@ func (g *ERC20User) GenerateTx() (*types.Transaction, error) {
 
 	// prepare tx
 	const gasLimit = 52000 // Transfer method call takes 51349 of gas
-	tx, err := createTx(g.sender, g.contract, big.NewInt(0), data, g.gasPrice, gasLimit)
+	balances := []common.Hash{
+		getMappingSlot(common.BytesToHash(g.sender.address[:]), erc20BalancesSlot),
+		getMappingSlot(common.BytesToHash(recipient[:]), erc20BalancesSlot),
+	}
+	tx, err := createTxAccessing(g.sender, g.contract, balances, data, g.gasPrice, gasLimit)
 	if err == nil {
 		atomic.AddUint64(&g.sentTxs, 1)
 	}
@@ -208,3 +336,7 @@ func (g *ERC20User) GenerateTx() (*types.Transaction, error) {
 func (g *ERC20User) GetSentTransactions() uint64 {
 	return atomic.LoadUint64(&g.sentTxs)
 }
+
+func (g *ERC20User) SyncNonce(rpcClient rpc.RpcClient) (uint64, error) {
+	return g.sender.syncNonce(rpcClient)
+}
@@ -0,0 +1,65 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/golang/mock/gomock"
+)
+
+func TestErc20Mints_OnlyMintsIncludedUntilBlockAreCounted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+
+	included := map[common.Hash]uint64{{0x01}: 5, {0x02}: 7}
+	lookups := 0
+	rpcClient.EXPECT().BatchCall(gomock.Any()).AnyTimes().DoAndReturn(func(batch []gethrpc.BatchElem) error {
+		lookups += len(batch)
+		for _, elem := range batch {
+			if block, found := included[elem.Args[0].(common.Hash)]; found {
+				result := elem.Result.(**struct{ BlockNumber *hexutil.Big })
+				*result = &struct{ BlockNumber *hexutil.Big }{(*hexutil.Big)(hexutil.MustDecodeBig(hexutil.EncodeUint64(block)))}
+			}
+		}
+		return nil
+	})
+
+	mints := erc20Mints{}
+	mints.add(common.Hash{0x01})
+	mints.add(common.Hash{0x02})
+	mints.add(common.Hash{0x03}) // < not included yet
+
+	for block, want := range map[uint64]int{4: 0, 5: 1, 6: 1, 7: 2, 100: 2} {
+		got, err := mints.countIncludedUntil(rpcClient, block)
+		if err != nil {
+			t.Fatalf("failed to count mints: %v", err)
+		}
+		if got != want {
+			t.Errorf("unexpected number of mints until block %d, wanted %d, got %d", block, want, got)
+		}
+	}
+
+	// blocks of included mints are looked up only once, the pending mint in every call
+	if want := 2 + 5; lookups != want {
+		t.Errorf("unexpected number of receipt lookups, wanted %d, got %d", want, lookups)
+	}
+}
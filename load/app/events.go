@@ -0,0 +1,45 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"fmt"
+
+	contract "github.com/Fantom-foundation/Norma/load/contracts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// GetEventTopics obtains the topics of the events emitted by the contracts of the
+// applications, e.g. to subscribe to the logs produced by the load.
+func GetEventTopics() ([]common.Hash, error) {
+	seen := map[common.Hash]bool{}
+	res := []common.Hash{}
+	for _, metaData := range []*bind.MetaData{contract.ERC20MetaData, contract.NFTMetaData, contract.UniswapV2PairMetaData} {
+		parsed, err := metaData.GetAbi()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse contract ABI; %v", err)
+		}
+		for _, event := range parsed.Events {
+			if !seen[event.ID] {
+				seen[event.ID] = true
+				res = append(res, event.ID)
+			}
+		}
+	}
+	return res, nil
+}
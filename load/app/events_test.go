@@ -0,0 +1,47 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestGetEventTopics_ContainsEventsOfApplications(t *testing.T) {
+	topics, err := GetEventTopics()
+	if err != nil {
+		t.Fatalf("failed to get event topics: %v", err)
+	}
+	contained := map[string]bool{}
+	for _, topic := range topics {
+		if contained[topic.Hex()] {
+			t.Errorf("duplicate topic %v", topic)
+		}
+		contained[topic.Hex()] = true
+	}
+	for _, event := range []string{
+		"Transfer(address,address,uint256)",
+		"Approval(address,address,uint256)",
+		"Swap(address,uint256,uint256,uint256,uint256,address)",
+		"Sync(uint112,uint112)",
+	} {
+		if !contained[crypto.Keccak256Hash([]byte(event)).Hex()] {
+			t.Errorf("topic of event %s is missing", event)
+		}
+	}
+}
@@ -24,18 +24,42 @@ import (
 
 type appFactoryFunc func(rpcClient rpc.RpcClient, primaryAccount *Account, numUsers int, feederId, appId uint32) (Application, error)
 
-func NewApplication(appType string, rpcClient rpc.RpcClient, primaryAccount *Account, numUsers int, feederId, appId uint32) (Application, error) {
-	if factory := getFactory(appType); factory != nil {
-		return factory(rpcClient, primaryAccount, numUsers, feederId, appId)
+// Config defines type-specific parameters of applications. Each application
+// only uses the parameters of its own type.
+type Config struct {
+	Transfer    TransferConfig
+	Custom      CustomConfig
+	Mix         []MixComponent
+	NFT         NFTConfig
+	Deployer    DeployerConfig
+	Storage     StorageConfig
+	Adversarial AdversarialConfig
+	Query       QueryConfig
+	Tx          TxConfig // type of the transactions sent by users, supported by all types sending transactions
+}
+
+func NewApplication(appType string, rpcClient rpc.RpcClient, primaryAccount *Account, numUsers int, feederId, appId uint32, config Config) (Application, error) {
+	if factory := getFactory(appType, config); factory != nil {
+		if err := CheckTxConfig(config.Tx); err != nil {
+			return nil, err
+		}
+		application, err := factory(rpcClient, primaryAccount, numUsers, feederId, appId)
+		if err != nil {
+			return nil, err
+		}
+		if err := configureTxs(application, config.Tx); err != nil {
+			return nil, fmt.Errorf("invalid transaction type of %s application; %v", appType, err)
+		}
+		return application, nil
 	}
 	return nil, fmt.Errorf("unknown application type '%s'", appType)
 }
 
 func IsSupportedApplicationType(appType string) bool {
-	return getFactory(appType) != nil
+	return getFactory(appType, Config{}) != nil
 }
 
-func getFactory(appType string) appFactoryFunc {
+func getFactory(appType string, config Config) appFactoryFunc {
 	switch strings.ToLower(appType) {
 	case "erc20":
 		return NewERC20Application
@@ -45,6 +69,22 @@ func getFactory(appType string) appFactoryFunc {
 		return NewStoreApplication
 	case "uniswap":
 		return NewUniswapApplication
+	case "transfer":
+		return newTransferFactory(config.Transfer)
+	case "custom":
+		return newCustomFactory(config.Custom)
+	case "nft":
+		return newNFTFactory(config.NFT)
+	case "deployer":
+		return newDeployerFactory(config.Deployer)
+	case "storage":
+		return newStorageFactory(config.Storage)
+	case "adversarial":
+		return newAdversarialFactory(config.Adversarial)
+	case "query":
+		return newQueryFactory(config.Query)
+	case "mix":
+		return newMixFactory(config)
 	}
 	return nil
 }
@@ -21,8 +21,11 @@ import (
 	"fmt"
 	"github.com/Fantom-foundation/Norma/driver/rpc"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"math/big"
+	"sync"
 	"time"
 )
 
@@ -37,15 +40,36 @@ func transferValue(rpcClient rpc.RpcClient, from *Account, toAddress common.Addr
 }
 
 func createTx(from *Account, toAddress common.Address, value *big.Int, data []byte, gasPrice *big.Int, gasLimit uint64) (*types.Transaction, error) {
-	tx := types.NewTx(&types.LegacyTx{
-		Nonce:    from.getNextNonce(),
-		GasPrice: gasPrice,
-		Gas:      gasLimit,
-		To:       &toAddress,
-		Value:    value,
-		Data:     data,
-	})
-	return types.SignTx(tx, types.NewEIP155Signer(from.chainID), from.privateKey)
+	return createTxWithNonce(from, from.getNextNonce(), toAddress, value, data, gasPrice, gasLimit)
+}
+
+// createTxWithNonce creates a transaction of the given account using a nonce
+// previously obtained from the account. The transaction is of the type configured
+// for the account, the given gas price is the one of legacy transactions.
+func createTxWithNonce(from *Account, nonce uint64, toAddress common.Address, value *big.Int, data []byte, gasPrice *big.Int, gasLimit uint64) (*types.Transaction, error) {
+	tx := types.NewTx(from.txs.newTxData(from.chainID, nonce, &toAddress, value, data, gasPrice, gasLimit, nil))
+	return types.SignTx(tx, types.LatestSignerForChainID(from.chainID), from.privateKey)
+}
+
+// createTxAccessing creates a transaction of the given account calling a contract,
+// which accesses the given storage keys of the contract. The keys are listed in the
+// access list of transactions of accounts configured to send access-list transactions.
+func createTxAccessing(from *Account, toAddress common.Address, storageKeys []common.Hash, data []byte, gasPrice *big.Int, gasLimit uint64) (*types.Transaction, error) {
+	tx := types.NewTx(from.txs.newTxData(from.chainID, from.getNextNonce(), &toAddress, big.NewInt(0), data, gasPrice, gasLimit, storageKeys))
+	return types.SignTx(tx, types.LatestSignerForChainID(from.chainID), from.privateKey)
+}
+
+// getMappingSlot computes the storage key of the entry with the given key of a
+// Solidity mapping stored at the given slot.
+func getMappingSlot(key common.Hash, slot common.Hash) common.Hash {
+	return crypto.Keccak256Hash(key[:], slot[:])
+}
+
+// createContractCreationTx creates a transaction of the given account deploying a
+// contract using the given init code.
+func createContractCreationTx(from *Account, nonce uint64, initCode []byte, gasPrice *big.Int, gasLimit uint64) (*types.Transaction, error) {
+	tx := types.NewTx(from.txs.newTxData(from.chainID, nonce, nil, big.NewInt(0), initCode, gasPrice, gasLimit, nil))
+	return types.SignTx(tx, types.LatestSignerForChainID(from.chainID), from.privateKey)
 }
 
 // waitUntilAccountNonceIs blocks until the account nonce at the latest block on the chain is given value
@@ -116,3 +140,76 @@ func reverseAddresses(in []common.Address) []common.Address {
 	}
 	return out
 }
+
+// getBlockNumber obtains the number of the latest block known to the node behind the given client.
+// It is used to read a consistent snapshot of the on-chain state.
+func getBlockNumber(rpcClient rpc.RpcClient) (*big.Int, error) {
+	var blockNumber hexutil.Big
+	if err := rpcClient.Call(&blockNumber, "eth_blockNumber"); err != nil {
+		return nil, fmt.Errorf("failed to get block number; %v", err)
+	}
+	return blockNumber.ToInt(), nil
+}
+
+// resolveBlockNumber returns the given block number, or the number of the latest block
+// known to the node behind the given client if no block number is given.
+func resolveBlockNumber(rpcClient rpc.RpcClient, blockNumber *big.Int) (*big.Int, error) {
+	if blockNumber != nil {
+		return blockNumber, nil
+	}
+	return getBlockNumber(rpcClient)
+}
+
+// userAccounts tracks the accounts of all users created for an application together with
+// their nonces at creation time. This allows to derive the number of transactions of each
+// user included in the chain. Instances are thread-safe.
+type userAccounts struct {
+	accounts    []*Account
+	startNonces []uint64
+	mutex       sync.Mutex
+}
+
+func (u *userAccounts) add(account *Account) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	u.accounts = append(u.accounts, account)
+	u.startNonces = append(u.startNonces, account.getCurrentNonce())
+}
+
+func (u *userAccounts) getAccounts() []*Account {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	res := make([]*Account, len(u.accounts))
+	copy(res, u.accounts)
+	return res
+}
+
+// getIncludedTransactions provides the number of transactions of each user included
+// in the chain up to the given block, in the order the users have been created.
+func (u *userAccounts) getIncludedTransactions(rpcClient rpc.RpcClient, blockNumber *big.Int) ([]uint64, error) {
+	u.mutex.Lock()
+	accounts := u.accounts
+	startNonces := u.startNonces
+	u.mutex.Unlock()
+
+	res := make([]uint64, len(accounts))
+	for i, account := range accounts {
+		nonce, err := rpcClient.NonceAt(context.Background(), account.address, blockNumber)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get nonce of account %v; %v", account.address, err)
+		}
+		if nonce < startNonces[i] {
+			return nil, fmt.Errorf("nonce of account %v decreased from %d to %d", account.address, startNonces[i], nonce)
+		}
+		res[i] = nonce - startNonces[i]
+	}
+	return res, nil
+}
+
+func sum(values []uint64) uint64 {
+	res := uint64(0)
+	for _, value := range values {
+		res += value
+	}
+	return res
+}
@@ -0,0 +1,260 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// mixComponentIdOffset separates the application IDs used for deriving the accounts of
+// the components of a mix application from the IDs of regular applications.
+const mixComponentIdOffset = 1 << 20
+
+// MixComponent defines an application type contributing to a mix application.
+type MixComponent struct {
+	Type   string
+	Weight float64 // relative frequency of the component's transactions
+}
+
+// newMixFactory creates a factory for mix applications using the given configuration.
+// Components are configured using the type-specific parameters of the configuration.
+func newMixFactory(config Config) appFactoryFunc {
+	return func(rpcClient rpc.RpcClient, primaryAccount *Account, numUsers int, feederId, appId uint32) (Application, error) {
+		return NewMixApplication(rpcClient, primaryAccount, numUsers, feederId, appId, config)
+	}
+}
+
+// NewMixApplication deploys an application for each component defined by the given
+// configuration. Each user of the mix application picks the component of its next
+// transaction randomly according to the component weights.
+func NewMixApplication(rpcClient rpc.RpcClient, primaryAccount *Account, numUsers int, feederId, appId uint32, config Config) (Application, error) {
+	if err := CheckMixComponents(config.Mix); err != nil {
+		return nil, err
+	}
+
+	components := make([]*mixComponent, 0, len(config.Mix))
+	weights := make([]float64, 0, len(config.Mix))
+	sum := 0.0
+	for i, component := range config.Mix {
+		factory := getFactory(component.Type, config)
+		componentId := appId + uint32(i+1)*mixComponentIdOffset
+		application, err := factory(rpcClient, primaryAccount, numUsers, feederId, componentId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s component; %v", component.Type, err)
+		}
+		components = append(components, &mixComponent{
+			name:        strings.ToLower(component.Type),
+			application: application,
+		})
+		sum += component.Weight
+		weights = append(weights, sum)
+	}
+
+	return &MixApplication{
+		components: components,
+		weights:    weights,
+	}, nil
+}
+
+// CheckMixComponents tests that the given components can be combined in a mix application.
+func CheckMixComponents(components []MixComponent) error {
+	if len(components) == 0 {
+		return fmt.Errorf("at least one component must be defined")
+	}
+	errs := []error{}
+	seen := map[string]bool{}
+	for _, component := range components {
+		name := strings.ToLower(component.Type)
+		switch {
+		case name == "mix":
+			errs = append(errs, fmt.Errorf("mix applications can not be nested"))
+		case name == "adversarial":
+			errs = append(errs, fmt.Errorf("adversarial applications can not be mixed"))
+		case name == "query":
+			errs = append(errs, fmt.Errorf("query applications can not be mixed"))
+		case getFactory(name, Config{}) == nil:
+			errs = append(errs, fmt.Errorf("unknown component type: %s", component.Type))
+		case seen[name]:
+			errs = append(errs, fmt.Errorf("duplicate component type: %s", component.Type))
+		}
+		seen[name] = true
+		if component.Weight <= 0 {
+			errs = append(errs, fmt.Errorf("weight of component %s must be > 0, got %f", component.Type, component.Weight))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// MixApplication combines several applications of different types, sending a weighted
+// mix of their transactions. While the application is thread-safe, each created user
+// should be used in a single thread only.
+type MixApplication struct {
+	components []*mixComponent
+	weights    []float64 // cumulative weights of the components
+}
+
+// mixComponent is an application contributing to a mix application.
+type mixComponent struct {
+	name        string
+	application Application
+	users       []User
+	usersMutex  sync.Mutex
+}
+
+func (c *mixComponent) getSentTransactions() uint64 {
+	c.usersMutex.Lock()
+	defer c.usersMutex.Unlock()
+	sum := uint64(0)
+	for _, user := range c.users {
+		sum += user.GetSentTransactions()
+	}
+	return sum
+}
+
+// setTxConfig applies the given configuration to all components supporting it.
+func (f *MixApplication) setTxConfig(config TxConfig) {
+	for _, component := range f.components {
+		if configurable, ok := component.application.(txConfigurable); ok {
+			configurable.setTxConfig(config)
+		}
+	}
+}
+
+// CreateUser creates a new user for the app, using a user of each component.
+func (f *MixApplication) CreateUser(rpcClient rpc.RpcClient) (User, error) {
+	users := make([]User, 0, len(f.components))
+	for _, component := range f.components {
+		user, err := component.application.CreateUser(rpcClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create user of %s component; %v", component.name, err)
+		}
+		users = append(users, user)
+	}
+	seed := int64(0)
+	for i, component := range f.components {
+		component.usersMutex.Lock()
+		component.users = append(component.users, users[i])
+		if i == 0 {
+			seed = int64(len(component.users))
+		}
+		component.usersMutex.Unlock()
+	}
+	return &MixUser{
+		users:   users,
+		weights: f.weights,
+		random:  rand.New(rand.NewSource(seed)),
+	}, nil
+}
+
+func (f *MixApplication) WaitUntilApplicationIsDeployed(rpcClient rpc.RpcClient) error {
+	for _, component := range f.components {
+		if err := component.application.WaitUntilApplicationIsDeployed(rpcClient); err != nil {
+			return fmt.Errorf("failed to wait for %s component; %v", component.name, err)
+		}
+	}
+	return nil
+}
+
+// GetReceivedTransactions returns the total number of transactions received by all components.
+func (f *MixApplication) GetReceivedTransactions(rpcClient rpc.RpcClient) (uint64, error) {
+	stats, err := f.GetComponentStats(rpcClient)
+	if err != nil {
+		return 0, err
+	}
+	sum := uint64(0)
+	for _, cur := range stats {
+		sum += cur.Received
+	}
+	return sum, nil
+}
+
+// GetComponentStats returns the number of sent and received transactions of each component.
+func (f *MixApplication) GetComponentStats(rpcClient rpc.RpcClient) ([]ComponentStats, error) {
+	res := make([]ComponentStats, 0, len(f.components))
+	for _, component := range f.components {
+		received, err := component.application.GetReceivedTransactions(rpcClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get received transactions of %s component; %v", component.name, err)
+		}
+		res = append(res, ComponentStats{
+			Name:     component.name,
+			Sent:     component.getSentTransactions(),
+			Received: received,
+		})
+	}
+	return res, nil
+}
+
+// Verify checks the invariants of all components.
+func (f *MixApplication) Verify(rpcClient rpc.RpcClient, blockNumber *big.Int) error {
+	errs := []error{}
+	for _, component := range f.components {
+		if err := component.application.Verify(rpcClient, blockNumber); err != nil {
+			errs = append(errs, fmt.Errorf("%s component: %v", component.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// MixUser represents a user sending transactions of all components of a mix application.
+// A generator is supposed to be used in a single thread.
+type MixUser struct {
+	users   []User
+	weights []float64
+	random  *rand.Rand
+}
+
+func (g *MixUser) GenerateTx() (*types.Transaction, error) {
+	target := g.random.Float64() * g.weights[len(g.weights)-1]
+	pos := sort.SearchFloat64s(g.weights, target)
+	if pos >= len(g.users) {
+		pos = len(g.users) - 1
+	}
+	return g.users[pos].GenerateTx()
+}
+
+func (g *MixUser) GetSentTransactions() uint64 {
+	sum := uint64(0)
+	for _, user := range g.users {
+		sum += user.GetSentTransactions()
+	}
+	return sum
+}
+
+// SyncNonce re-synchronizes the nonces of all components able to do so.
+func (g *MixUser) SyncNonce(rpcClient rpc.RpcClient) (uint64, error) {
+	sum := uint64(0)
+	for _, user := range g.users {
+		if syncing, ok := user.(NonceSyncingUser); ok {
+			lost, err := syncing.SyncNonce(rpcClient)
+			if err != nil {
+				return sum, err
+			}
+			sum += lost
+		}
+	}
+	return sum, nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/golang/mock/gomock"
+)
+
+func TestCheckMixComponents_DetectsIssues(t *testing.T) {
+	tests := map[string][]MixComponent{
+		"at least one component":     nil,
+		"unknown component type":     {{Type: "unknown", Weight: 1}},
+		"can not be nested":          {{Type: "mix", Weight: 1}},
+		"can not be mixed":           {{Type: "adversarial", Weight: 1}},
+		"query applications can not": {{Type: "query", Weight: 1}},
+		"duplicate component type":   {{Type: "erc20", Weight: 1}, {Type: "ERC20", Weight: 2}},
+		"weight of component store":  {{Type: "store", Weight: 0}},
+	}
+	for issue, components := range tests {
+		if err := CheckMixComponents(components); err == nil || !strings.Contains(err.Error(), issue) {
+			t.Errorf("expected error containing '%s', got %v", issue, err)
+		}
+	}
+	valid := []MixComponent{{Type: "erc20", Weight: 5}, {Type: "uniswap", Weight: 3}, {Type: "store", Weight: 2}}
+	if err := CheckMixComponents(valid); err != nil {
+		t.Errorf("unexpected error for valid components: %v", err)
+	}
+}
+
+func TestMixUser_TransactionsFollowWeights(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	tx := types.NewTx(&types.LegacyTx{})
+
+	counts := []int{0, 0}
+	users := []User{NewMockUser(ctrl), NewMockUser(ctrl)}
+	for i, user := range users {
+		i := i
+		user.(*MockUser).EXPECT().GenerateTx().DoAndReturn(func() (*types.Transaction, error) {
+			counts[i]++
+			return tx, nil
+		}).AnyTimes()
+		user.(*MockUser).EXPECT().GetSentTransactions().Return(uint64(10 * (i + 1)))
+	}
+
+	user := &MixUser{users: users, weights: []float64{1, 5}, random: rand.New(rand.NewSource(1))}
+	for i := 0; i < 10000; i++ {
+		if _, err := user.GenerateTx(); err != nil {
+			t.Fatalf("failed to generate transaction: %v", err)
+		}
+	}
+	if ratio := float64(counts[1]) / float64(counts[0]); ratio < 3.5 || ratio > 4.5 {
+		t.Errorf("unexpected ratio of component transactions, wanted ~4, got %f (%v)", ratio, counts)
+	}
+	if got := user.GetSentTransactions(); got != 30 {
+		t.Errorf("unexpected number of sent transactions, wanted 30, got %d", got)
+	}
+}
+
+func TestMixApplication_ComponentStatsAreReported(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	erc20 := NewMockApplication(ctrl)
+	store := NewMockApplication(ctrl)
+	erc20.EXPECT().GetReceivedTransactions(nil).Return(uint64(7), nil).Times(2)
+	store.EXPECT().GetReceivedTransactions(nil).Return(uint64(3), nil).Times(2)
+	erc20User := NewMockUser(ctrl)
+	erc20User.EXPECT().GetSentTransactions().Return(uint64(8))
+
+	mix := &MixApplication{components: []*mixComponent{
+		{name: "erc20", application: erc20, users: []User{erc20User}},
+		{name: "store", application: store},
+	}}
+	stats, err := mix.GetComponentStats(nil)
+	if err != nil {
+		t.Fatalf("failed to get component stats: %v", err)
+	}
+	want := []ComponentStats{{Name: "erc20", Sent: 8, Received: 7}, {Name: "store", Sent: 0, Received: 3}}
+	if len(stats) != len(want) || stats[0] != want[0] || stats[1] != want[1] {
+		t.Errorf("unexpected component stats, wanted %v, got %v", want, stats)
+	}
+	if got, err := mix.GetReceivedTransactions(nil); err != nil || got != 10 {
+		t.Errorf("unexpected number of received transactions, wanted 10, got %d, err %v", got, err)
+	}
+}
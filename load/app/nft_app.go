@@ -0,0 +1,332 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sync/atomic"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	contract "github.com/Fantom-foundation/Norma/load/contracts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// NFTConfig defines the proportions of the operations performed by users of an
+// NFT application. If all proportions are zero, defaults are used.
+type NFTConfig struct {
+	MintRatio     float64
+	TransferRatio float64
+	BurnRatio     float64
+}
+
+// withDefaults returns the configuration with default proportions if none are set.
+func (c NFTConfig) withDefaults() NFTConfig {
+	if c.MintRatio <= 0 && c.TransferRatio <= 0 && c.BurnRatio <= 0 {
+		return NFTConfig{MintRatio: 0.5, TransferRatio: 0.3, BurnRatio: 0.2}
+	}
+	return c
+}
+
+// newNFTFactory creates a factory for NFT applications using the given configuration.
+func newNFTFactory(config NFTConfig) appFactoryFunc {
+	return func(rpcClient rpc.RpcClient, primaryAccount *Account, numUsers int, feederId, appId uint32) (Application, error) {
+		return NewNFTApplication(rpcClient, primaryAccount, numUsers, feederId, appId, config)
+	}
+}
+
+// NewNFTApplication deploys a new NFT dapp to the chain. Users of the application
+// mint, transfer, and burn ERC-721 tokens in the given proportions.
+func NewNFTApplication(rpcClient rpc.RpcClient, primaryAccount *Account, numUsers int, feederId, appId uint32, config NFTConfig) (Application, error) {
+	// get price of gas from the network
+	regularGasPrice, err := getGasPrice(rpcClient)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deploy the NFT contract to be used by generators created using the factory
+	txOpts, err := bind.NewKeyedTransactorWithChainID(primaryAccount.privateKey, primaryAccount.chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create txOpts for contract deploy; %v", err)
+	}
+	txOpts.GasPrice = getPriorityGasPrice(regularGasPrice)
+	txOpts.Nonce = big.NewInt(int64(primaryAccount.getNextNonce()))
+	contractAddress, _, _, err := contract.DeployNFT(txOpts, rpcClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deploy NFT contract; %v", err)
+	}
+	recipients, err := generateRecipientsAddresses()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate recipients addresses; %v", err)
+	}
+
+	accountFactory, err := NewAccountFactory(primaryAccount.chainID, feederId, appId)
+	if err != nil {
+		return nil, err
+	}
+
+	// deploying too many generators from one account leads to excessive gasPrice growth - we
+	// need to spread the initialization in between multiple startingAccounts
+	startingAccounts, err := generateStartingAccounts(rpcClient, primaryAccount, accountFactory, numUsers, regularGasPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	// parse ABI for generating txs data
+	parsedAbi, err := contract.NFTMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	// wait until the contract will be available on the chain
+	err = waitUntilAccountNonceIs(primaryAccount.address, primaryAccount.getCurrentNonce(), rpcClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait until the NFT contract is deployed; %v", err)
+	}
+
+	return &NFTApplication{
+		abi:              parsedAbi,
+		config:           config.withDefaults(),
+		startingAccounts: startingAccounts,
+		contractAddress:  contractAddress,
+		recipients:       recipients,
+		accountFactory:   accountFactory,
+	}, nil
+}
+
+// NFTApplication represents one application deployed to the network - an ERC-721 contract.
+// Each created app should be used in a single thread only.
+type NFTApplication struct {
+	abi              *abi.ABI
+	config           NFTConfig
+	startingAccounts []*Account
+	contractAddress  common.Address
+	recipients       []common.Address
+	accountFactory   *AccountFactory
+	users            userAccounts
+}
+
+func (f *NFTApplication) setTxConfig(config TxConfig) {
+	f.accountFactory.setTxConfig(config)
+}
+
+// CreateUser creates a new user for the app.
+func (f *NFTApplication) CreateUser(rpcClient rpc.RpcClient) (User, error) {
+	// get price of gas from the network
+	regularGasPrice, err := getGasPrice(rpcClient)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate a new account for each worker - avoid account nonces related bottlenecks
+	workerAccount, err := f.accountFactory.CreateAccount(rpcClient)
+	if err != nil {
+		return nil, err
+	}
+	startingAccount := f.startingAccounts[workerAccount.id%len(f.startingAccounts)]
+	err = workerAccount.Fund(startingAccount, rpcClient, regularGasPrice, 1000)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fund worker account %d; %v", workerAccount.id, err)
+	}
+	f.users.add(workerAccount)
+
+	return &NFTUser{
+		abi:        f.abi,
+		config:     f.config,
+		sender:     workerAccount,
+		gasPrice:   regularGasPrice,
+		contract:   f.contractAddress,
+		recipients: f.recipients,
+		random:     rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(workerAccount.address[:8])))),
+	}, nil
+}
+
+func (f *NFTApplication) WaitUntilApplicationIsDeployed(rpcClient rpc.RpcClient) error {
+	return waitUntilAllSentTxsAreOnChain(f.startingAccounts, rpcClient)
+}
+
+// GetReceivedTransactions obtains the number of successful mints, transfers, and burns
+// from the counter of the contract.
+func (f *NFTApplication) GetReceivedTransactions(rpcClient rpc.RpcClient) (uint64, error) {
+	nftContract, err := contract.NewNFT(f.contractAddress, rpcClient)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get NFT contract representation; %v", err)
+	}
+	count, err := nftContract.GetCount(nil)
+	if err != nil {
+		return 0, err
+	}
+	return count.Uint64(), nil
+}
+
+// Verify checks that the total supply of tokens is covered by the operations performed
+// on the contract and that the balances of all holders sum up to it.
+func (f *NFTApplication) Verify(rpcClient rpc.RpcClient, blockNumber *big.Int) error {
+	blockNumber, err := resolveBlockNumber(rpcClient, blockNumber)
+	if err != nil {
+		return err
+	}
+	nftContract, err := contract.NewNFT(f.contractAddress, rpcClient)
+	if err != nil {
+		return fmt.Errorf("failed to get NFT contract representation; %v", err)
+	}
+	opts := &bind.CallOpts{BlockNumber: blockNumber}
+	totalSupply, err := nftContract.TotalSupply(opts)
+	if err != nil {
+		return err
+	}
+	count, err := nftContract.GetCount(opts)
+	if err != nil {
+		return err
+	}
+	if totalSupply.Cmp(count) > 0 {
+		return fmt.Errorf("total supply %v exceeds the number of operations %v at block %v", totalSupply, count, blockNumber)
+	}
+
+	holders := make([]common.Address, 0)
+	for _, user := range f.users.getAccounts() {
+		holders = append(holders, user.address)
+	}
+	holders = append(holders, f.recipients...)
+	balances := new(big.Int)
+	for _, holder := range holders {
+		balance, err := nftContract.BalanceOf(opts, holder)
+		if err != nil {
+			return err
+		}
+		balances.Add(balances, balance)
+	}
+	if totalSupply.Cmp(balances) != 0 {
+		return fmt.Errorf("total supply %v does not match the sum of all balances %v at block %v", totalSupply, balances, blockNumber)
+	}
+	return nil
+}
+
+// Gas limits of the NFT operations, covering the creation of new storage slots.
+const (
+	nftMintGasLimit     = 120000 // Mint method call takes up to 112000 of gas, if the supply was 0
+	nftTransferGasLimit = 90000  // TransferFrom method call takes up to 62000 of gas
+	nftBurnGasLimit     = 70000  // Burn method call takes up to 45000 of gas
+)
+
+// NFTUser represents a user minting, transferring, and burning tokens. Since the
+// transactions of a user are included in the order they have been sent, tokens
+// minted by the user can be transferred or burned right away.
+// A generator is supposed to be used in a single thread.
+type NFTUser struct {
+	abi        *abi.ABI
+	config     NFTConfig
+	sender     *Account
+	gasPrice   *big.Int
+	contract   common.Address
+	recipients []common.Address
+	random     *rand.Rand
+	minted     uint64     // number of tokens minted by this user
+	owned      []*big.Int // tokens owned by this user
+	sentTxs    uint64
+}
+
+func (g *NFTUser) GenerateTx() (*types.Transaction, error) {
+	var data []byte
+	var err error
+	var token *big.Int
+	var gasLimit uint64
+	pos := 0
+
+	operation := g.pickOperation()
+	switch operation {
+	case nftMint:
+		token = g.getTokenId(g.minted)
+		data, err = g.abi.Pack("mint", token)
+		gasLimit = nftMintGasLimit
+	case nftTransfer:
+		pos = g.random.Intn(len(g.owned))
+		token = g.owned[pos]
+		recipient := g.recipients[g.random.Intn(len(g.recipients))]
+		data, err = g.abi.Pack("transferFrom", g.sender.address, recipient, token)
+		gasLimit = nftTransferGasLimit
+	case nftBurn:
+		pos = g.random.Intn(len(g.owned))
+		token = g.owned[pos]
+		data, err = g.abi.Pack("burn", token)
+		gasLimit = nftBurnGasLimit
+	}
+	if err != nil || data == nil {
+		return nil, fmt.Errorf("failed to prepare tx data; %v", err)
+	}
+
+	tx, err := createTx(g.sender, g.contract, big.NewInt(0), data, g.gasPrice, gasLimit)
+	if err == nil {
+		if operation == nftMint {
+			g.minted++
+			g.owned = append(g.owned, token)
+		} else {
+			// the token is no longer owned by the user, replace it by the last one
+			last := len(g.owned) - 1
+			g.owned[pos] = g.owned[last]
+			g.owned = g.owned[:last]
+		}
+		atomic.AddUint64(&g.sentTxs, 1)
+	}
+	return tx, err
+}
+
+func (g *NFTUser) GetSentTransactions() uint64 {
+	return atomic.LoadUint64(&g.sentTxs)
+}
+
+func (g *NFTUser) SyncNonce(rpcClient rpc.RpcClient) (uint64, error) {
+	return g.sender.syncNonce(rpcClient)
+}
+
+type nftOperation int
+
+const (
+	nftMint nftOperation = iota
+	nftTransfer
+	nftBurn
+)
+
+// pickOperation selects the next operation according to the configured proportions.
+// Users not owning any tokens always mint.
+func (g *NFTUser) pickOperation() nftOperation {
+	if len(g.owned) == 0 {
+		return nftMint
+	}
+	target := g.random.Float64() * (g.config.MintRatio + g.config.TransferRatio + g.config.BurnRatio)
+	switch {
+	case target < g.config.MintRatio:
+		return nftMint
+	case target < g.config.MintRatio+g.config.TransferRatio:
+		return nftTransfer
+	default:
+		return nftBurn
+	}
+}
+
+// getTokenId derives the ID of the i-th token minted by the user, which is unique
+// among all users of the application.
+func (g *NFTUser) getTokenId(i uint64) *big.Int {
+	id := new(big.Int).SetBytes(g.sender.address[:])
+	id.Lsh(id, 64)
+	return id.Or(id, new(big.Int).SetUint64(i))
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestNFTConfig_DefaultsAreUsedIfNoProportionIsSet(t *testing.T) {
+	if got := (NFTConfig{}).withDefaults(); got.MintRatio != 0.5 || got.TransferRatio != 0.3 || got.BurnRatio != 0.2 {
+		t.Errorf("unexpected default proportions: %v", got)
+	}
+	config := NFTConfig{MintRatio: 1}
+	if got := config.withDefaults(); got != config {
+		t.Errorf("configured proportions were not kept, wanted %v, got %v", config, got)
+	}
+}
+
+func TestNFTUser_OperationsFollowProportions(t *testing.T) {
+	user := &NFTUser{
+		config: NFTConfig{MintRatio: 2, TransferRatio: 1, BurnRatio: 1},
+		random: rand.New(rand.NewSource(1)),
+	}
+	if got := user.pickOperation(); got != nftMint {
+		t.Errorf("users without tokens should mint, got %v", got)
+	}
+
+	user.owned = []*big.Int{big.NewInt(1)}
+	counts := map[nftOperation]int{}
+	for i := 0; i < 10000; i++ {
+		counts[user.pickOperation()]++
+	}
+	if ratio := float64(counts[nftMint]) / float64(counts[nftTransfer]); ratio < 1.8 || ratio > 2.2 {
+		t.Errorf("unexpected ratio of mints to transfers, wanted ~2, got %f (%v)", ratio, counts)
+	}
+	if ratio := float64(counts[nftTransfer]) / float64(counts[nftBurn]); ratio < 0.9 || ratio > 1.1 {
+		t.Errorf("unexpected ratio of transfers to burns, wanted ~1, got %f (%v)", ratio, counts)
+	}
+}
+
+func TestNFTUser_TokenIdsAreUnique(t *testing.T) {
+	userA := &NFTUser{sender: &Account{address: common.Address{1}}}
+	userB := &NFTUser{sender: &Account{address: common.Address{2}}}
+	seen := map[string]bool{}
+	for i := uint64(0); i < 100; i++ {
+		for _, user := range []*NFTUser{userA, userB} {
+			id := user.getTokenId(i).String()
+			if seen[id] {
+				t.Fatalf("duplicate token id %s", id)
+			}
+			seen[id] = true
+		}
+	}
+}
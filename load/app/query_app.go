@@ -0,0 +1,319 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	contract "github.com/Fantom-foundation/Norma/load/contracts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// defaultQueryLogRange is the number of blocks covered by eth_getLogs requests if not configured.
+const defaultQueryLogRange = 100
+
+// QueryConfig defines the mix of read-only requests sent by users of a query application.
+type QueryConfig struct {
+	// Weights are the relative frequencies of the RPC methods, indexed by method.
+	// Methods without a weight are not used, if no weight is set at all, all
+	// methods are used with the same frequency.
+	Weights map[string]float64
+	// LogRange is the number of blocks covered by eth_getLogs requests, 0 = 100.
+	LogRange int
+	// Historical enables querying the state of random past blocks instead of the
+	// latest block by eth_call, eth_getBalance, and eth_getStorageAt requests. Such
+	// requests are only sent to archive nodes.
+	Historical bool
+}
+
+// withDefaults provides a copy of the configuration with defaults for unset parameters.
+func (c QueryConfig) withDefaults() QueryConfig {
+	if c.LogRange == 0 {
+		c.LogRange = defaultQueryLogRange
+	}
+	if len(c.Weights) > 0 {
+		return c
+	}
+	c.Weights = map[string]float64{}
+	for _, method := range rpc.QueryMethods {
+		c.Weights[method] = 1
+	}
+	return c
+}
+
+// CheckQueryConfig tests that the given configuration only refers to supported RPC
+// methods with non-negative weights and covers a positive range of blocks.
+func CheckQueryConfig(config QueryConfig) error {
+	errs := []error{}
+	total := 0.0
+	for _, method := range getSortedKeys(config.Weights) {
+		weight := config.Weights[method]
+		if !isQueryMethod(method) {
+			errs = append(errs, fmt.Errorf("unsupported query method: %s, supported are %s", method, strings.Join(rpc.QueryMethods, ", ")))
+		}
+		if weight < 0 {
+			errs = append(errs, fmt.Errorf("weight of method %s must be >= 0, got %f", method, weight))
+		}
+		total += weight
+	}
+	if len(config.Weights) > 0 && total <= 0 {
+		errs = append(errs, fmt.Errorf("at least one query method must have a weight > 0"))
+	}
+	if config.LogRange < 0 {
+		errs = append(errs, fmt.Errorf("log range must be >= 1, got %d", config.LogRange))
+	}
+	return errors.Join(errs...)
+}
+
+func isQueryMethod(method string) bool {
+	for _, cur := range rpc.QueryMethods {
+		if cur == method {
+			return true
+		}
+	}
+	return false
+}
+
+// newQueryFactory creates a factory for query applications using the given configuration.
+func newQueryFactory(config QueryConfig) appFactoryFunc {
+	return func(rpcClient rpc.RpcClient, primaryAccount *Account, numUsers int, feederId, appId uint32) (Application, error) {
+		return NewQueryApplication(rpcClient, primaryAccount, numUsers, feederId, appId, config)
+	}
+}
+
+// NewQueryApplication deploys a Counter contract to the chain, which is the target of
+// eth_call and eth_estimateGas requests of the users. Users of the application do not
+// send transactions but read-only requests, producing load on the RPC nodes serving them.
+func NewQueryApplication(rpcClient rpc.RpcClient, primaryAccount *Account, numUsers int, feederId, appId uint32, config QueryConfig) (Application, error) {
+	config = config.withDefaults()
+	if err := CheckQueryConfig(config); err != nil {
+		return nil, err
+	}
+
+	// get price of gas from the network
+	regularGasPrice, err := getGasPrice(rpcClient)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deploy the Counter contract to be queried by users
+	txOpts, err := bind.NewKeyedTransactorWithChainID(primaryAccount.privateKey, primaryAccount.chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create txOpts for contract deploy; %v", err)
+	}
+	txOpts.GasPrice = getPriorityGasPrice(regularGasPrice)
+	txOpts.Nonce = big.NewInt(int64(primaryAccount.getNextNonce()))
+	contractAddress, _, _, err := contract.DeployCounter(txOpts, rpcClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deploy Counter contract; %v", err)
+	}
+
+	// prepare the inputs of the requests
+	parsedAbi, err := contract.CounterMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	getCount, err := parsedAbi.Pack("getCount")
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare call data; %v", err)
+	}
+	increment, err := parsedAbi.Pack("incrementCounter")
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare call data; %v", err)
+	}
+
+	// wait until the contract will be available on the chain
+	err = waitUntilAccountNonceIs(primaryAccount.address, primaryAccount.getCurrentNonce(), rpcClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait until the Counter contract is deployed; %v", err)
+	}
+
+	methods := make([]string, 0, len(config.Weights))
+	weights := make([]float64, 0, len(config.Weights))
+	total := 0.0
+	for _, method := range getSortedKeys(config.Weights) {
+		if config.Weights[method] <= 0 {
+			continue
+		}
+		total += config.Weights[method]
+		methods = append(methods, method)
+		weights = append(weights, total)
+	}
+
+	return &QueryApplication{
+		contractAddress: contractAddress,
+		account:         primaryAccount.address,
+		getCount:        getCount,
+		increment:       increment,
+		logRange:        uint64(config.LogRange),
+		historical:      config.Historical,
+		methods:         methods,
+		weights:         weights,
+		appId:           appId,
+	}, nil
+}
+
+// QueryApplication produces read-only requests on a Counter contract, accounts, blocks,
+// and logs of the chain, either on the latest state or the state of past blocks. The
+// application does not send any transactions.
+// While the application is thread-safe, each created user should be used in a single thread only.
+type QueryApplication struct {
+	contractAddress common.Address
+	account         common.Address // < a funded account, used as sender of estimated transactions
+	getCount        hexutil.Bytes
+	increment       hexutil.Bytes
+	logRange        uint64
+	historical      bool // < whether state requests target past blocks
+	methods         []string
+	weights         []float64 // cumulative weights of the methods
+	appId           uint32
+	numUsers        atomic.Uint32
+}
+
+// CreateUser fails since query applications do not send transactions, users are
+// to be created by CreateQuerier instead.
+func (f *QueryApplication) CreateUser(rpc.RpcClient) (User, error) {
+	return nil, fmt.Errorf("query applications do not send transactions")
+}
+
+// CreateQuerier creates a new user for the app.
+func (f *QueryApplication) CreateQuerier(rpcClient rpc.RpcClient) (Querier, error) {
+	id := f.numUsers.Add(1)
+	return &QueryUser{
+		application: f,
+		random:      rand.New(rand.NewSource(int64(f.appId)<<32 | int64(id))),
+	}, nil
+}
+
+func (f *QueryApplication) WaitUntilApplicationIsDeployed(rpcClient rpc.RpcClient) error {
+	// the contract is deployed when the application is created
+	return nil
+}
+
+// GetReceivedTransactions returns 0 since query applications do not send transactions.
+func (f *QueryApplication) GetReceivedTransactions(rpcClient rpc.RpcClient) (uint64, error) {
+	return 0, nil
+}
+
+// Verify checks that the counter queried by users has never been incremented, i.e. that
+// estimating the gas of increments did not modify the state.
+func (f *QueryApplication) Verify(rpcClient rpc.RpcClient, blockNumber *big.Int) error {
+	counterContract, err := contract.NewCounter(f.contractAddress, rpcClient)
+	if err != nil {
+		return fmt.Errorf("failed to get Counter contract representation; %v", err)
+	}
+	count, err := counterContract.GetCount(&bind.CallOpts{BlockNumber: blockNumber})
+	if err != nil {
+		return err
+	}
+	if count.Sign() != 0 {
+		return fmt.Errorf("counter value %v of queried contract has been modified at block %v", count, blockNumber)
+	}
+	return nil
+}
+
+// QueryUser sends read-only requests of a weighted mix of RPC methods.
+// A generator is supposed to be used in a single thread.
+type QueryUser struct {
+	application *QueryApplication
+	random      *rand.Rand
+	sentQueries atomic.Uint64
+}
+
+func (g *QueryUser) GenerateQuery(head uint64) (Query, error) {
+	f := g.application
+	method := f.methods[g.pickMethod()]
+	var args []interface{}
+	archive := false
+	block := "latest"
+	if f.historical {
+		block = hexutil.EncodeUint64(g.pickBlock(head))
+	}
+	switch method {
+	case rpc.CallMethod:
+		call := map[string]interface{}{
+			"to":   f.contractAddress,
+			"data": f.getCount,
+		}
+		args = []interface{}{call, block}
+		archive = f.historical
+	case rpc.GetBalanceMethod:
+		target := f.account
+		if g.random.Intn(2) == 0 {
+			target = f.contractAddress
+		}
+		args = []interface{}{target, block}
+		archive = f.historical
+	case rpc.GetStorageAtMethod:
+		// slot 0 of the Counter contract holds the counter value
+		args = []interface{}{f.contractAddress, "0x0", block}
+		archive = f.historical
+	case rpc.GetLogsMethod:
+		to := g.pickBlock(head)
+		from := uint64(0)
+		if to >= f.logRange {
+			from = to - f.logRange + 1
+		}
+		filter := map[string]interface{}{
+			"fromBlock": hexutil.EncodeUint64(from),
+			"toBlock":   hexutil.EncodeUint64(to),
+		}
+		args = []interface{}{filter}
+	case rpc.GetBlockByNumberMethod:
+		args = []interface{}{hexutil.EncodeUint64(g.pickBlock(head)), true}
+	case rpc.EstimateGasMethod:
+		call := map[string]interface{}{
+			"from": f.account,
+			"to":   f.contractAddress,
+			"data": f.increment,
+		}
+		args = []interface{}{call}
+	default:
+		return Query{}, fmt.Errorf("unsupported query method: %s", method)
+	}
+	g.sentQueries.Add(1)
+	return Query{Method: method, Args: args, Archive: archive}, nil
+}
+
+func (g *QueryUser) GetSentQueries() uint64 {
+	return g.sentQueries.Load()
+}
+
+// pickMethod selects the method of the next request following the configured weights.
+func (g *QueryUser) pickMethod() int {
+	weights := g.application.weights
+	pos := g.random.Float64() * weights[len(weights)-1]
+	res := sort.SearchFloat64s(weights, pos)
+	if res >= len(weights) {
+		res = len(weights) - 1
+	}
+	return res
+}
+
+// pickBlock selects a random block up to the given head.
+func (g *QueryUser) pickBlock(head uint64) uint64 {
+	return uint64(g.random.Int63n(int64(head) + 1))
+}
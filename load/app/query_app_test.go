@@ -0,0 +1,176 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestQueryConfig_AllMethodsAreUsedByDefault(t *testing.T) {
+	config := (QueryConfig{}).withDefaults()
+	if len(config.Weights) != len(rpc.QueryMethods) {
+		t.Fatalf("unexpected default weights: %v", config.Weights)
+	}
+	for _, method := range rpc.QueryMethods {
+		if config.Weights[method] != 1 {
+			t.Errorf("unexpected default weight of method %s: %f", method, config.Weights[method])
+		}
+	}
+	if config.LogRange != defaultQueryLogRange {
+		t.Errorf("unexpected default log range: %d", config.LogRange)
+	}
+	if err := CheckQueryConfig(config); err != nil {
+		t.Errorf("default configuration should be valid, got %v", err)
+	}
+}
+
+func TestQueryConfig_InvalidConfigurationsAreDetected(t *testing.T) {
+	tests := map[string]QueryConfig{
+		"unsupported query method":  {Weights: map[string]float64{"eth_sendRawTransaction": 1}},
+		"weight of method eth_call": {Weights: map[string]float64{"eth_call": -1, "eth_getLogs": 1}},
+		"at least one query method": {Weights: map[string]float64{"eth_call": 0}},
+		"log range must be >= 1":    {LogRange: -5},
+	}
+	for want, config := range tests {
+		if err := CheckQueryConfig(config); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error containing %q for %v, got %v", want, config, err)
+		}
+	}
+}
+
+func newTestQueryUser(methods ...string) *QueryUser {
+	weights := make([]float64, len(methods))
+	for i := range methods {
+		weights[i] = float64(i + 1)
+	}
+	return &QueryUser{
+		application: &QueryApplication{
+			contractAddress: common.Address{1},
+			account:         common.Address{2},
+			getCount:        hexutil.Bytes{0x01},
+			increment:       hexutil.Bytes{0x02},
+			logRange:        10,
+			methods:         methods,
+			weights:         weights,
+		},
+		random: rand.New(rand.NewSource(1)),
+	}
+}
+
+func TestQueryUser_QueriesFollowWeights(t *testing.T) {
+	user := newTestQueryUser(rpc.CallMethod, rpc.GetBalanceMethod)
+	counts := map[string]int{}
+	const total = 3000
+	for i := 0; i < total; i++ {
+		query, err := user.GenerateQuery(100)
+		if err != nil {
+			t.Fatalf("failed to generate query: %v", err)
+		}
+		counts[query.Method]++
+	}
+	if user.GetSentQueries() != total {
+		t.Errorf("unexpected number of sent queries, wanted %d, got %d", total, user.GetSentQueries())
+	}
+	// cumulative weights 1 and 2 select each method with the same probability
+	for _, method := range []string{rpc.CallMethod, rpc.GetBalanceMethod} {
+		if counts[method] < total/3 {
+			t.Errorf("method %s used too rarely: %d of %d", method, counts[method], total)
+		}
+	}
+}
+
+func TestQueryUser_BlocksAreWithinHead(t *testing.T) {
+	user := newTestQueryUser(rpc.GetLogsMethod, rpc.GetBlockByNumberMethod)
+	const head = 25
+	for i := 0; i < 100; i++ {
+		query, err := user.GenerateQuery(head)
+		if err != nil {
+			t.Fatalf("failed to generate query: %v", err)
+		}
+		switch query.Method {
+		case rpc.GetLogsMethod:
+			filter := query.Args[0].(map[string]interface{})
+			from, _ := hexutil.DecodeUint64(filter["fromBlock"].(string))
+			to, _ := hexutil.DecodeUint64(filter["toBlock"].(string))
+			if from > to || to > head || to-from+1 > 10 {
+				t.Errorf("invalid block range of logs query: %d-%d", from, to)
+			}
+		case rpc.GetBlockByNumberMethod:
+			number, _ := hexutil.DecodeUint64(query.Args[0].(string))
+			if number > head || query.Args[1] != true {
+				t.Errorf("invalid block query: %v", query.Args)
+			}
+		default:
+			t.Errorf("unexpected method %s", query.Method)
+		}
+	}
+}
+
+func TestQueryUser_HistoricalStateQueriesTargetPastBlocksOnArchiveNodes(t *testing.T) {
+	user := newTestQueryUser(rpc.CallMethod, rpc.GetBalanceMethod, rpc.GetStorageAtMethod, rpc.GetBlockByNumberMethod)
+	user.application.historical = true
+	const head = 25
+	for i := 0; i < 100; i++ {
+		query, err := user.GenerateQuery(head)
+		if err != nil {
+			t.Fatalf("failed to generate query: %v", err)
+		}
+		if query.Method == rpc.GetBlockByNumberMethod {
+			if query.Archive {
+				t.Errorf("block queries should not require an archive node")
+			}
+			continue
+		}
+		if !query.Archive {
+			t.Errorf("%s query on a past state should require an archive node", query.Method)
+		}
+		number, err := hexutil.DecodeUint64(query.Args[len(query.Args)-1].(string))
+		if err != nil || number > head {
+			t.Errorf("invalid block of %s query: %v", query.Method, query.Args)
+		}
+	}
+}
+
+func TestQueryUser_StateQueriesTargetLatestBlockByDefault(t *testing.T) {
+	user := newTestQueryUser(rpc.CallMethod, rpc.GetBalanceMethod, rpc.GetStorageAtMethod)
+	for i := 0; i < 30; i++ {
+		query, err := user.GenerateQuery(25)
+		if err != nil {
+			t.Fatalf("failed to generate query: %v", err)
+		}
+		if query.Archive || query.Args[len(query.Args)-1] != "latest" {
+			t.Errorf("unexpected %s query: %v, archive %t", query.Method, query.Args, query.Archive)
+		}
+	}
+}
+
+func TestQueryApplication_DoesNotCreateTransactionUsers(t *testing.T) {
+	application := &QueryApplication{}
+	if _, err := application.CreateUser(nil); err == nil {
+		t.Errorf("query application should not create transaction users")
+	}
+	querier, err := application.CreateQuerier(nil)
+	if err != nil || querier == nil {
+		t.Errorf("failed to create querier: %v", err)
+	}
+}
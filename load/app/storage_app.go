@@ -0,0 +1,364 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strings"
+	"sync/atomic"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	contract "github.com/Fantom-foundation/Norma/load/contracts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// DistributionUniform accesses all slots with the same probability.
+	DistributionUniform = "uniform"
+	// DistributionZipfian accesses slots following a Zipf distribution, where
+	// slots with lower keys are hot and slots with higher keys are cold.
+	DistributionZipfian = "zipfian"
+
+	defaultStorageSlots     = 10000
+	defaultStorageContracts = 1
+	// storageZipfExponent is the skew of the Zipf distribution, must be > 1.
+	storageZipfExponent = 1.1
+	// storageVerifiedSlots is the number of slots checked in each contract by Verify.
+	storageVerifiedSlots = 16
+)
+
+// StorageConfig defines the state accessed by users of a storage application.
+type StorageConfig struct {
+	Slots        int     // number of distinct slots per contract, 0 = 10000
+	Contracts    int     // number of contracts, 0 = 1
+	ReadRatio    float64 // fraction of operations reading a slot
+	DeleteRatio  float64 // fraction of write operations clearing a slot instead of setting it
+	Distribution string  // distribution of accessed slots, "uniform" (default) or "zipfian"
+}
+
+// withDefaults provides a copy of the configuration with defaults for unset parameters.
+func (c StorageConfig) withDefaults() StorageConfig {
+	if c.Slots == 0 {
+		c.Slots = defaultStorageSlots
+	}
+	if c.Contracts == 0 {
+		c.Contracts = defaultStorageContracts
+	}
+	if c.Distribution == "" {
+		c.Distribution = DistributionUniform
+	}
+	return c
+}
+
+// CheckStorageConfig tests that the given configuration, with defaults applied
+// for unset parameters, describes a valid storage application.
+func CheckStorageConfig(config StorageConfig) error {
+	config = config.withDefaults()
+	if config.Slots < 1 {
+		return fmt.Errorf("number of slots must be >= 1, got %d", config.Slots)
+	}
+	if config.Contracts < 1 {
+		return fmt.Errorf("number of contracts must be >= 1, got %d", config.Contracts)
+	}
+	if config.ReadRatio < 0 || config.ReadRatio > 1 {
+		return fmt.Errorf("fraction of reads must be between 0 and 1, got %f", config.ReadRatio)
+	}
+	if config.DeleteRatio < 0 || config.DeleteRatio > 1 {
+		return fmt.Errorf("fraction of deletes must be between 0 and 1, got %f", config.DeleteRatio)
+	}
+	switch strings.ToLower(config.Distribution) {
+	case DistributionUniform, DistributionZipfian:
+	default:
+		return fmt.Errorf("unknown distribution '%s', supported are %s and %s", config.Distribution, DistributionUniform, DistributionZipfian)
+	}
+	return nil
+}
+
+// newStorageFactory creates a factory for storage applications using the given configuration.
+func newStorageFactory(config StorageConfig) appFactoryFunc {
+	return func(rpcClient rpc.RpcClient, primaryAccount *Account, numUsers int, feederId, appId uint32) (Application, error) {
+		return NewStorageApplication(rpcClient, primaryAccount, numUsers, feederId, appId, config)
+	}
+}
+
+// NewStorageApplication deploys the configured number of Storage contracts to the chain.
+// Each Storage contract is a key/value store shared by all users, which read, write, and
+// delete slots of a configurable key space following a configurable access distribution.
+// It is intended to control the size and the locality of the state seen by the state database.
+func NewStorageApplication(rpcClient rpc.RpcClient, primaryAccount *Account, numUsers int, feederId, appId uint32, config StorageConfig) (Application, error) {
+	config = config.withDefaults()
+	if err := CheckStorageConfig(config); err != nil {
+		return nil, err
+	}
+
+	// get price of gas from the network
+	regularGasPrice, err := getGasPrice(rpcClient)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deploy the Storage contracts to be used by tx generators
+	txOpts, err := bind.NewKeyedTransactorWithChainID(primaryAccount.privateKey, primaryAccount.chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create txOpts for contract deploy; %v", err)
+	}
+	txOpts.GasPrice = getPriorityGasPrice(regularGasPrice)
+	contracts := make([]common.Address, 0, config.Contracts)
+	for i := 0; i < config.Contracts; i++ {
+		txOpts.Nonce = big.NewInt(int64(primaryAccount.getNextNonce()))
+		contractAddress, _, _, err := contract.DeployStorage(txOpts, rpcClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deploy Storage contract; %v", err)
+		}
+		contracts = append(contracts, contractAddress)
+	}
+
+	accountFactory, err := NewAccountFactory(primaryAccount.chainID, feederId, appId)
+	if err != nil {
+		return nil, err
+	}
+
+	// deploying too many generators from one account leads to excessive gasPrice growth - we
+	// need to spread the initialization in between multiple startingAccounts
+	startingAccounts, err := generateStartingAccounts(rpcClient, primaryAccount, accountFactory, numUsers, regularGasPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	// parse ABI for generating txs data
+	parsedAbi, err := contract.StorageMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	// wait until the contracts will be available on the chain (and will be possible to call CreateGenerator)
+	err = waitUntilAccountNonceIs(primaryAccount.address, primaryAccount.getCurrentNonce(), rpcClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait until the Storage contracts are deployed; %v", err)
+	}
+
+	return &StorageApplication{
+		abi:              parsedAbi,
+		config:           config,
+		startingAccounts: startingAccounts,
+		contracts:        contracts,
+		accountFactory:   accountFactory,
+	}, nil
+}
+
+// StorageApplication represents a set of on-chain key/value stores shared by a set of users.
+type StorageApplication struct {
+	abi              *abi.ABI
+	config           StorageConfig
+	startingAccounts []*Account
+	contracts        []common.Address
+	accountFactory   *AccountFactory
+	users            userAccounts
+}
+
+func (f *StorageApplication) setTxConfig(config TxConfig) {
+	f.accountFactory.setTxConfig(config)
+}
+
+// CreateUser creates a new user for the app.
+func (f *StorageApplication) CreateUser(rpcClient rpc.RpcClient) (User, error) {
+	// get price of gas from the network
+	regularGasPrice, err := getGasPrice(rpcClient)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate a new account for each worker - avoid account nonces related bottlenecks
+	workerAccount, err := f.accountFactory.CreateAccount(rpcClient)
+	if err != nil {
+		return nil, err
+	}
+	startingAccount := f.startingAccounts[workerAccount.id%len(f.startingAccounts)]
+	err = workerAccount.Fund(startingAccount, rpcClient, regularGasPrice, 1000)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fund worker account %d; %v", workerAccount.id, err)
+	}
+	f.users.add(workerAccount)
+
+	random := rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(workerAccount.address[:8]))))
+	return &StorageUser{
+		abi:       f.abi,
+		config:    f.config,
+		sender:    workerAccount,
+		gasPrice:  regularGasPrice,
+		contracts: f.contracts,
+		random:    random,
+		keys:      newKeyGenerator(f.config, random),
+	}, nil
+}
+
+func (f *StorageApplication) WaitUntilApplicationIsDeployed(rpcClient rpc.RpcClient) error {
+	return waitUntilAllSentTxsAreOnChain(f.startingAccounts, rpcClient)
+}
+
+// GetReceivedTransactions obtains the number of operations included in the chain,
+// derived from the nonces of the users since reads do not modify the state.
+func (f *StorageApplication) GetReceivedTransactions(rpcClient rpc.RpcClient) (uint64, error) {
+	included, err := f.users.getIncludedTransactions(rpcClient, nil)
+	if err != nil {
+		return 0, err
+	}
+	return sum(included), nil
+}
+
+// Verify checks that the hottest slots of each contract are either empty or hold a
+// value written for their key. Since slots are shared by all users, the exact value
+// depends on the order in which the writes of different users got included.
+func (f *StorageApplication) Verify(rpcClient rpc.RpcClient, blockNumber *big.Int) error {
+	blockNumber, err := resolveBlockNumber(rpcClient, blockNumber)
+	if err != nil {
+		return err
+	}
+	slots := storageVerifiedSlots
+	if f.config.Slots < slots {
+		slots = f.config.Slots
+	}
+	for _, address := range f.contracts {
+		storage, err := contract.NewStorage(address, rpcClient)
+		if err != nil {
+			return fmt.Errorf("failed to get Storage contract representation; %v", err)
+		}
+		for key := uint64(0); key < uint64(slots); key++ {
+			value, err := storage.Get(&bind.CallOpts{BlockNumber: blockNumber}, new(big.Int).SetUint64(key))
+			if err != nil {
+				return err
+			}
+			if value.Sign() != 0 && getKeyOfValue(value) != key {
+				return fmt.Errorf("slot %d of contract %v holds %v, which was not written for this slot", key, address, value)
+			}
+		}
+	}
+	return nil
+}
+
+// getStorageValue creates the value written into the slot of the given key. Values
+// record the key of their slot in the lower 64 bits for verification, and differ
+// between writes in their upper bits.
+func getStorageValue(key uint64, write uint64) *big.Int {
+	value := new(big.Int).SetUint64(write + 1)
+	value.Lsh(value, 128)
+	return value.Or(value, new(big.Int).SetUint64(key))
+}
+
+// getKeyOfValue recovers the key of the slot a value was written for.
+func getKeyOfValue(value *big.Int) uint64 {
+	return new(big.Int).And(value, new(big.Int).SetUint64(^uint64(0))).Uint64()
+}
+
+// keyGenerator produces the keys of accessed slots.
+type keyGenerator func() uint64
+
+// newKeyGenerator creates a key generator following the configured distribution.
+func newKeyGenerator(config StorageConfig, random *rand.Rand) keyGenerator {
+	if strings.EqualFold(config.Distribution, DistributionZipfian) {
+		zipf := rand.NewZipf(random, storageZipfExponent, 1, uint64(config.Slots-1))
+		return zipf.Uint64
+	}
+	return func() uint64 {
+		return uint64(random.Intn(config.Slots))
+	}
+}
+
+// storageOperation is an operation performed by a user of a storage application.
+type storageOperation int
+
+const (
+	storageRead storageOperation = iota
+	storageWrite
+	storageDelete
+)
+
+const (
+	// storageWriteGasLimit covers writing a new slot, deletes get a refund on top.
+	storageWriteGasLimit = 60000
+	storageReadGasLimit  = 40000
+)
+
+// storageDataSlot is the storage slot of the data mapping of the Storage contract.
+var storageDataSlot = common.Hash{}
+
+// StorageUser represents a user accessing slots of Storage contracts.
+// Instances are not thread safe.
+type StorageUser struct {
+	abi       *abi.ABI
+	config    StorageConfig
+	sender    *Account
+	gasPrice  *big.Int
+	contracts []common.Address
+	random    *rand.Rand
+	keys      keyGenerator
+	sentTxs   atomic.Uint64
+}
+
+func (g *StorageUser) GenerateTx() (*types.Transaction, error) {
+	target := g.contracts[g.random.Intn(len(g.contracts))]
+	key := g.keys()
+
+	var data []byte
+	var err error
+	gasLimit := uint64(storageWriteGasLimit)
+	switch g.pickOperation() {
+	case storageRead:
+		// reading through a transaction makes validators perform the SLOAD
+		data, err = g.abi.Pack("get", new(big.Int).SetUint64(key))
+		gasLimit = storageReadGasLimit
+	case storageWrite:
+		data, err = g.abi.Pack("put", new(big.Int).SetUint64(key), getStorageValue(key, g.sentTxs.Load()))
+	case storageDelete:
+		data, err = g.abi.Pack("remove", new(big.Int).SetUint64(key))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare tx data; %v", err)
+	}
+
+	slot := getMappingSlot(common.BigToHash(new(big.Int).SetUint64(key)), storageDataSlot)
+	tx, err := createTxAccessing(g.sender, target, []common.Hash{slot}, data, g.gasPrice, gasLimit)
+	if err == nil {
+		g.sentTxs.Add(1)
+	}
+	return tx, err
+}
+
+// pickOperation selects the next operation following the configured ratios.
+func (g *StorageUser) pickOperation() storageOperation {
+	if g.random.Float64() < g.config.ReadRatio {
+		return storageRead
+	}
+	if g.random.Float64() < g.config.DeleteRatio {
+		return storageDelete
+	}
+	return storageWrite
+}
+
+func (g *StorageUser) GetSentTransactions() uint64 {
+	return g.sentTxs.Load()
+}
+
+func (g *StorageUser) SyncNonce(rpcClient rpc.RpcClient) (uint64, error) {
+	return g.sender.syncNonce(rpcClient)
+}
@@ -0,0 +1,111 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestStorageConfig_DefaultsAreUsedForUnsetParameters(t *testing.T) {
+	got := (StorageConfig{}).withDefaults()
+	want := StorageConfig{Slots: 10000, Contracts: 1, Distribution: DistributionUniform}
+	if got != want {
+		t.Errorf("unexpected defaults, wanted %v, got %v", want, got)
+	}
+	if err := CheckStorageConfig(StorageConfig{}); err != nil {
+		t.Errorf("default configuration should be valid, got %v", err)
+	}
+}
+
+func TestStorageConfig_InvalidConfigurationsAreDetected(t *testing.T) {
+	tests := map[string]StorageConfig{
+		"number of slots must be >= 1":     {Slots: -1},
+		"number of contracts must be >= 1": {Contracts: -1},
+		"fraction of reads":                {ReadRatio: 1.5},
+		"fraction of deletes":              {DeleteRatio: -0.5},
+		"unknown distribution":             {Distribution: "normal"},
+	}
+	for want, config := range tests {
+		if err := CheckStorageConfig(config); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error containing %q for %v, got %v", want, config, err)
+		}
+	}
+}
+
+func TestStorage_ValuesRecordTheirKey(t *testing.T) {
+	for _, key := range []uint64{0, 1, 12345, ^uint64(0)} {
+		for _, write := range []uint64{0, 1, 1 << 40} {
+			value := getStorageValue(key, write)
+			if value.Sign() == 0 {
+				t.Errorf("written values must not be zero")
+			}
+			if got := getKeyOfValue(value); got != key {
+				t.Errorf("unexpected key of value %v, wanted %d, got %d", value, key, got)
+			}
+		}
+	}
+	if getStorageValue(1, 1).Cmp(getStorageValue(1, 2)) == 0 {
+		t.Errorf("values of different writes should differ")
+	}
+}
+
+func TestStorage_KeysFollowDistribution(t *testing.T) {
+	const slots = 1000
+	const samples = 100000
+	for _, distribution := range []string{DistributionUniform, DistributionZipfian} {
+		keys := newKeyGenerator(StorageConfig{Slots: slots, Distribution: distribution}, rand.New(rand.NewSource(1)))
+		hot := 0
+		for i := 0; i < samples; i++ {
+			key := keys()
+			if key >= slots {
+				t.Fatalf("key %d out of key space of %d slots", key, slots)
+			}
+			if key < slots/100 {
+				hot++
+			}
+		}
+		// the hottest 1% of slots get ~1% of the accesses under a uniform distribution,
+		// and a large share of the accesses under the zipfian distribution
+		ratio := float64(hot) / samples
+		if distribution == DistributionUniform && (ratio < 0.005 || ratio > 0.015) {
+			t.Errorf("unexpected share of accesses to hot slots for uniform distribution: %f", ratio)
+		}
+		if distribution == DistributionZipfian && ratio < 0.3 {
+			t.Errorf("unexpected share of accesses to hot slots for zipfian distribution: %f", ratio)
+		}
+	}
+}
+
+func TestStorageUser_OperationsFollowRatios(t *testing.T) {
+	user := &StorageUser{
+		config: StorageConfig{ReadRatio: 0.5, DeleteRatio: 0.2},
+		random: rand.New(rand.NewSource(1)),
+	}
+	counts := map[storageOperation]int{}
+	for i := 0; i < 100000; i++ {
+		counts[user.pickOperation()]++
+	}
+	// reads are 50% of all operations, deletes 20% of the remaining writes
+	want := map[storageOperation]float64{storageRead: 0.5, storageWrite: 0.4, storageDelete: 0.1}
+	for op, share := range want {
+		if got := float64(counts[op]) / 100000; got < share-0.01 || got > share+0.01 {
+			t.Errorf("unexpected share of operation %d, wanted %f, got %f", op, share, got)
+		}
+	}
+}
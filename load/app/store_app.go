@@ -90,6 +90,11 @@ type StoreApplication struct {
 	startingAccounts []*Account
 	contractAddress  common.Address
 	accountFactory   *AccountFactory
+	users            userAccounts
+}
+
+func (f *StoreApplication) setTxConfig(config TxConfig) {
+	f.accountFactory.setTxConfig(config)
 }
 
 // CreateUser creates a new user for the app.
@@ -111,6 +116,7 @@ func (f *StoreApplication) CreateUser(rpcClient rpc.RpcClient) (User, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to fund worker account %d; %v", workerAccount.id, err)
 	}
+	f.users.add(workerAccount)
 
 	gen := &StoreUser{
 		abi:      f.abi,
@@ -138,6 +144,64 @@ func (f *StoreApplication) GetReceivedTransactions(rpcClient rpc.RpcClient) (uin
 	return count.Uint64(), nil
 }
 
+// Verify checks that the number of fill operations recorded by the contract matches the number
+// of included transactions and that the slots written by the latest fill operation of each user
+// hold the value the user's generator has written into them.
+func (f *StoreApplication) Verify(rpcClient rpc.RpcClient, blockNumber *big.Int) error {
+	blockNumber, err := resolveBlockNumber(rpcClient, blockNumber)
+	if err != nil {
+		return err
+	}
+	included, err := f.users.getIncludedTransactions(rpcClient, blockNumber)
+	if err != nil {
+		return err
+	}
+	storeContract, err := contract.NewStore(f.contractAddress, rpcClient)
+	if err != nil {
+		return fmt.Errorf("failed to get Store contract representation; %v", err)
+	}
+	count, err := storeContract.GetCount(&bind.CallOpts{BlockNumber: blockNumber})
+	if err != nil {
+		return err
+	}
+	if want := new(big.Int).SetUint64(sum(included)); count.Cmp(want) != 0 {
+		return fmt.Errorf("store count %v does not match the number of included transactions %v at block %v", count, want, blockNumber)
+	}
+
+	// The i-th transaction of a user fills the slots [i*storeUpdateSize, (i+1)*storeUpdateSize) with value i.
+	for i, account := range f.users.getAccounts() {
+		last := int64(included[i])
+		if last == 0 {
+			continue
+		}
+		opts := &bind.CallOpts{From: account.address, BlockNumber: blockNumber}
+		checks := map[int64]int64{
+			last * storeUpdateSize:       last, // first slot of the latest fill
+			(last+1)*storeUpdateSize - 1: last, // last slot of the latest fill
+			(last + 1) * storeUpdateSize: 0,    // not yet filled
+		}
+		for key, want := range checks {
+			got, err := storeContract.Get(opts, big.NewInt(key))
+			if err != nil {
+				return err
+			}
+			if got.Cmp(big.NewInt(want)) != 0 {
+				return fmt.Errorf("slot %d of user %v holds %v, expected %d", key, account.address, got, want)
+			}
+		}
+	}
+	return nil
+}
+
+// storeUpdateSize is the number of slots written by a single Store transaction.
+const storeUpdateSize = 260 // ~ 1 GB/minute new netto data at 1000 Tx/s
+
+// Storage slots of the count and the data mapping of the Store contract.
+var (
+	storeCountSlot = common.Hash{}
+	storeDataSlot  = common.BigToHash(big.NewInt(1))
+)
+
 // StoreUser represents a user sending txs to manipulate a user-private key/value store.
 // Instances are not thread safe.
 type StoreUser struct {
@@ -149,28 +213,49 @@ type StoreUser struct {
 }
 
 func (g *StoreUser) GenerateTx() (*types.Transaction, error) {
-	const updateSize = 260 // ~ 1 GB/minute new netto data at 1000 Tx/s
-
 	// prepare tx data -- since as single put is rather cheap, we use the 'fill' operation
-	// to perform a number of updates at once. Each transaction is allocating updateSize
+	// to perform a number of updates at once. Each transaction is allocating storeUpdateSize
 	// extra slots, which correspond to ~(32 byte key + 32 byte value) extra storage.
 	val := int64(g.sentTxs.Load()) + 1
-	from := val * updateSize
-	to := from + updateSize
+	from := val * storeUpdateSize
+	to := from + storeUpdateSize
 	data, err := g.abi.Pack("fill", big.NewInt(from), big.NewInt(to), big.NewInt(val))
 	if err != nil || data == nil {
 		return nil, fmt.Errorf("failed to prepare tx data; %v", err)
 	}
 
 	// prepare tx
-	const gasLimit = 52000 + 25000*updateSize // wild guess ...
-	tx, err := createTx(g.sender, g.contract, big.NewInt(0), data, g.gasPrice, gasLimit)
+	const gasLimit = 52000 + 25000*storeUpdateSize // wild guess ...
+	tx, err := createTxAccessing(g.sender, g.contract, g.getAccessedSlots(from, to), data, g.gasPrice, gasLimit)
 	if err == nil {
 		g.sentTxs.Add(1)
 	}
 	return tx, err
 }
 
+// getAccessedSlots lists the storage keys of the Store contract accessed by filling
+// the given range of keys: the counter and the entries of the sender's data.
+func (g *StoreUser) getAccessedSlots(from, to int64) []common.Hash {
+	data := getMappingSlot(common.BytesToHash(g.sender.address[:]), storeDataSlot)
+	res := make([]common.Hash, 0, to-from+1)
+	res = append(res, storeCountSlot)
+	for key := from; key < to; key++ {
+		res = append(res, getMappingSlot(common.BigToHash(big.NewInt(key)), data))
+	}
+	return res
+}
+
 func (g *StoreUser) GetSentTransactions() uint64 {
 	return g.sentTxs.Load()
 }
+
+func (g *StoreUser) SyncNonce(rpcClient rpc.RpcClient) (uint64, error) {
+	lost, err := g.sender.syncNonce(rpcClient)
+	if err != nil || lost == 0 {
+		return lost, err
+	}
+	// the values of fills are derived from the number of sent transactions, such
+	// that lost fills are sent again with the same values, counted only once
+	g.sentTxs.Add(^(lost - 1))
+	return lost, nil
+}
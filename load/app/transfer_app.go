@@ -0,0 +1,265 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// transferAmount is the amount of wei sent by each transfer. It is kept small
+// such that users can sustain a long run, but non-zero such that transfers to
+// new addresses create new accounts.
+var transferAmount = big.NewInt(1_000)
+
+// TransferConfig defines the parameters of a transfer application.
+type TransferConfig struct {
+	// NewAccountRatio is the fraction of transfers sent to freshly derived
+	// addresses instead of the accounts of other users.
+	NewAccountRatio float64
+}
+
+// newTransferFactory creates a factory for transfer applications using the given configuration.
+func newTransferFactory(config TransferConfig) appFactoryFunc {
+	return func(rpcClient rpc.RpcClient, primaryAccount *Account, numUsers int, feederId, appId uint32) (Application, error) {
+		return NewTransferApplication(rpcClient, primaryAccount, numUsers, feederId, appId, config)
+	}
+}
+
+// NewTransferApplication creates an application sending plain native value transfers.
+// Transfers are sent to randomly selected users of the application, or, with the
+// configured ratio, to freshly derived addresses, growing the number of accounts.
+// No contract is deployed for this application.
+func NewTransferApplication(rpcClient rpc.RpcClient, primaryAccount *Account, numUsers int, feederId, appId uint32, config TransferConfig) (Application, error) {
+	if config.NewAccountRatio < 0 || config.NewAccountRatio > 1 {
+		return nil, fmt.Errorf("ratio of transfers to new accounts must be between 0 and 1, got %f", config.NewAccountRatio)
+	}
+
+	// get price of gas from the network
+	regularGasPrice, err := getGasPrice(rpcClient)
+	if err != nil {
+		return nil, err
+	}
+
+	accountFactory, err := NewAccountFactory(primaryAccount.chainID, feederId, appId)
+	if err != nil {
+		return nil, err
+	}
+
+	// deploying too many generators from one account leads to excessive gasPrice growth - we
+	// need to spread the initialization in between multiple startingAccounts
+	startingAccounts, err := generateStartingAccounts(rpcClient, primaryAccount, accountFactory, numUsers, regularGasPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransferApplication{
+		startingAccounts: startingAccounts,
+		accountFactory:   accountFactory,
+		newAccountRatio:  config.NewAccountRatio,
+	}, nil
+}
+
+// TransferApplication represents a set of users sending native value to each other
+// and to new accounts. While the application is thread-safe, each created user should
+// be used in a single thread only.
+type TransferApplication struct {
+	startingAccounts []*Account
+	accountFactory   *AccountFactory
+	newAccountRatio  float64
+	users            userAccounts
+
+	// recipients are the addresses of all users, used as targets of transfers.
+	recipients      []common.Address
+	transferUsers   []*TransferUser
+	recipientsMutex sync.RWMutex
+}
+
+func (f *TransferApplication) setTxConfig(config TxConfig) {
+	f.accountFactory.setTxConfig(config)
+}
+
+// CreateUser creates a new user for the app.
+func (f *TransferApplication) CreateUser(rpcClient rpc.RpcClient) (User, error) {
+
+	// get price of gas from the network
+	regularGasPrice, err := getGasPrice(rpcClient)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate a new account for each worker - avoid account nonces related bottlenecks
+	workerAccount, err := f.accountFactory.CreateAccount(rpcClient)
+	if err != nil {
+		return nil, err
+	}
+	startingAccount := f.startingAccounts[workerAccount.id%len(f.startingAccounts)]
+	err = workerAccount.Fund(startingAccount, rpcClient, regularGasPrice, 1000)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fund worker account %d; %v", workerAccount.id, err)
+	}
+	f.users.add(workerAccount)
+
+	gen := &TransferUser{
+		app:      f,
+		sender:   workerAccount,
+		gasPrice: regularGasPrice,
+		random:   rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(workerAccount.address[:8])))),
+	}
+
+	f.recipientsMutex.Lock()
+	f.recipients = append(f.recipients, workerAccount.address)
+	f.transferUsers = append(f.transferUsers, gen)
+	f.recipientsMutex.Unlock()
+	return gen, nil
+}
+
+// getRecipient selects a random user address other than the given sender, if
+// there is any other user.
+func (f *TransferApplication) getRecipient(random *rand.Rand, sender common.Address) common.Address {
+	f.recipientsMutex.RLock()
+	defer f.recipientsMutex.RUnlock()
+	res := f.recipients[random.Intn(len(f.recipients))]
+	if res == sender && len(f.recipients) > 1 {
+		res = f.recipients[random.Intn(len(f.recipients))]
+	}
+	return res
+}
+
+func (f *TransferApplication) WaitUntilApplicationIsDeployed(rpcClient rpc.RpcClient) error {
+	return waitUntilAllSentTxsAreOnChain(f.startingAccounts, rpcClient)
+}
+
+// GetReceivedTransactions derives the number of transfers included in the chain
+// from the nonces of the user accounts.
+func (f *TransferApplication) GetReceivedTransactions(rpcClient rpc.RpcClient) (uint64, error) {
+	included, err := f.users.getIncludedTransactions(rpcClient, nil)
+	if err != nil {
+		return 0, err
+	}
+	return sum(included), nil
+}
+
+// Verify checks that the latest included transfer of each user to a new account
+// created this account with the transferred value, and that the first transfer to
+// a new account not included yet did not.
+func (f *TransferApplication) Verify(rpcClient rpc.RpcClient, blockNumber *big.Int) error {
+	blockNumber, err := resolveBlockNumber(rpcClient, blockNumber)
+	if err != nil {
+		return err
+	}
+	f.recipientsMutex.RLock()
+	users := f.transferUsers
+	f.recipientsMutex.RUnlock()
+
+	for _, user := range users {
+		nonce, err := rpcClient.NonceAt(context.Background(), user.sender.address, blockNumber)
+		if err != nil {
+			return fmt.Errorf("failed to get nonce of account %v; %v", user.sender.address, err)
+		}
+		for address, want := range user.getNewAccountChecks(nonce) {
+			balance, err := rpcClient.BalanceAt(context.Background(), address, blockNumber)
+			if err != nil {
+				return fmt.Errorf("failed to get balance of account %v; %v", address, err)
+			}
+			if balance.Cmp(want) != 0 {
+				return fmt.Errorf("balance of new account %v is %v, expected %v at block %v", address, balance, want, blockNumber)
+			}
+		}
+	}
+	return nil
+}
+
+// getNewAccountAddress derives the address of the new account targeted by the
+// transfer of the given sender with the given nonce.
+func getNewAccountAddress(sender common.Address, nonce uint64) common.Address {
+	var buffer [8]byte
+	binary.BigEndian.PutUint64(buffer[:], nonce)
+	return common.BytesToAddress(crypto.Keccak256([]byte("transfer"), sender.Bytes(), buffer[:]))
+}
+
+// TransferUser represents a user sending native value to other users or new accounts.
+// A generator is supposed to be used in a single thread.
+type TransferUser struct {
+	app      *TransferApplication
+	sender   *Account
+	gasPrice *big.Int
+	random   *rand.Rand
+	sentTxs  uint64
+
+	// newAccountNonces are the nonces of the transfers to new accounts, in ascending order.
+	newAccountNonces []uint64
+	newAccountsMutex sync.Mutex
+}
+
+func (g *TransferUser) GenerateTx() (*types.Transaction, error) {
+	nonce := g.sender.getNextNonce()
+	var recipient common.Address
+	if g.random.Float64() < g.app.newAccountRatio {
+		recipient = getNewAccountAddress(g.sender.address, nonce)
+		g.newAccountsMutex.Lock()
+		g.newAccountNonces = append(g.newAccountNonces, nonce)
+		g.newAccountsMutex.Unlock()
+	} else {
+		recipient = g.app.getRecipient(g.random, g.sender.address)
+	}
+
+	tx, err := createTxWithNonce(g.sender, nonce, recipient, transferAmount, nil, g.gasPrice, 21000)
+	if err == nil {
+		atomic.AddUint64(&g.sentTxs, 1)
+	}
+	return tx, err
+}
+
+func (g *TransferUser) GetSentTransactions() uint64 {
+	return atomic.LoadUint64(&g.sentTxs)
+}
+
+func (g *TransferUser) SyncNonce(rpcClient rpc.RpcClient) (uint64, error) {
+	return g.sender.syncNonce(rpcClient)
+}
+
+// getNewAccountChecks provides the expected balances of the new accounts targeted
+// by the transfers next to the boundary of the included transfers given by the
+// nonce of the sender: the latest included transfer to a new account must have
+// created it, the first one not included yet must not.
+func (g *TransferUser) getNewAccountChecks(nonce uint64) map[common.Address]*big.Int {
+	g.newAccountsMutex.Lock()
+	defer g.newAccountsMutex.Unlock()
+
+	res := map[common.Address]*big.Int{}
+	nonces := g.newAccountNonces
+	pos := sort.Search(len(nonces), func(i int) bool { return nonces[i] >= nonce })
+	if pos > 0 {
+		res[getNewAccountAddress(g.sender.address, nonces[pos-1])] = transferAmount
+	}
+	if pos < len(nonces) {
+		res[getNewAccountAddress(g.sender.address, nonces[pos])] = big.NewInt(0)
+	}
+	return res
+}
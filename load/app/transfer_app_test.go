@@ -0,0 +1,61 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestTransferUser_NewAccountsAreChecked(t *testing.T) {
+	sender := common.Address{1}
+	user := &TransferUser{sender: &Account{address: sender}, newAccountNonces: []uint64{3, 5, 8}}
+
+	tests := map[uint64]map[common.Address]*big.Int{
+		0: {getNewAccountAddress(sender, 3): big.NewInt(0)},
+		4: {getNewAccountAddress(sender, 3): transferAmount, getNewAccountAddress(sender, 5): big.NewInt(0)},
+		6: {getNewAccountAddress(sender, 5): transferAmount, getNewAccountAddress(sender, 8): big.NewInt(0)},
+		9: {getNewAccountAddress(sender, 8): transferAmount},
+	}
+	for nonce, want := range tests {
+		got := user.getNewAccountChecks(nonce)
+		if len(got) != len(want) {
+			t.Errorf("unexpected checks for nonce %d, wanted %v, got %v", nonce, want, got)
+			continue
+		}
+		for address, balance := range want {
+			if got[address] == nil || got[address].Cmp(balance) != 0 {
+				t.Errorf("unexpected balance check of %v for nonce %d, wanted %v, got %v", address, nonce, balance, got[address])
+			}
+		}
+	}
+}
+
+func TestTransferApplication_NewAccountAddressesAreUnique(t *testing.T) {
+	seen := map[common.Address]bool{}
+	for _, sender := range []common.Address{{1}, {2}} {
+		for nonce := uint64(0); nonce < 100; nonce++ {
+			address := getNewAccountAddress(sender, nonce)
+			if seen[address] || address == sender {
+				t.Fatalf("address %v derived multiple times", address)
+			}
+			seen[address] = true
+		}
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	// LegacyTxType sends transactions with a fixed gas price, signed following EIP-155.
+	LegacyTxType = "legacy"
+	// AccessListTxType sends EIP-2930 transactions listing the storage slots of the
+	// called contract accessed by the transaction, as far as known by the application.
+	AccessListTxType = "access_list"
+	// DynamicFeeTxType sends EIP-1559 transactions defining a fee cap and a priority fee.
+	DynamicFeeTxType = "dynamic_fee"
+
+	// TipFixed uses the minimum tip for all transactions.
+	TipFixed = "fixed"
+	// TipUniform draws tips uniformly between the minimum and the maximum tip.
+	TipUniform = "uniform"
+	// TipExponential draws tips from an exponential distribution starting at the
+	// minimum tip, capped at the maximum tip, such that few transactions compete
+	// with high tips.
+	TipExponential = "exponential"
+
+	// tipExponentialMeanRatio is the mean of exponentially distributed tips above
+	// the minimum tip, relative to the difference between the maximum and minimum tip.
+	tipExponentialMeanRatio = 0.25
+)
+
+// TxConfig defines the type of the transactions sent by the users of an application
+// and, for dynamic-fee transactions, the way their fee cap and tip are chosen.
+// The fee cap is derived from the regular gas price the application would use for
+// legacy transactions.
+type TxConfig struct {
+	Type            string  // "legacy", "access_list", or "dynamic_fee", empty = legacy
+	FeeCapRatio     float64 // fee cap relative to the regular gas price, 0 = 1
+	MinTip          float64 // priority fee in Gwei
+	MaxTip          float64 // upper limit of randomized priority fees in Gwei
+	TipDistribution string  // "fixed", "uniform", or "exponential", empty = fixed
+}
+
+// withDefaults provides a copy of the configuration with defaults for unset parameters
+// and the names of the transaction type and tip distribution converted to lower case.
+func (c TxConfig) withDefaults() TxConfig {
+	c.Type = strings.ToLower(c.Type)
+	c.TipDistribution = strings.ToLower(c.TipDistribution)
+	if c.Type == "" {
+		c.Type = LegacyTxType
+	}
+	if c.FeeCapRatio == 0 {
+		c.FeeCapRatio = 1
+	}
+	if c.TipDistribution == "" {
+		c.TipDistribution = TipFixed
+	}
+	return c
+}
+
+// CheckTxConfig tests that the given configuration, with defaults applied for
+// unset parameters, describes valid transactions.
+func CheckTxConfig(config TxConfig) error {
+	config = config.withDefaults()
+	switch config.Type {
+	case LegacyTxType, AccessListTxType:
+		if config.FeeCapRatio != 1 || config.MinTip != 0 || config.MaxTip != 0 || config.TipDistribution != TipFixed {
+			return fmt.Errorf("fee cap and tip are only supported by %s transactions", DynamicFeeTxType)
+		}
+		return nil
+	case DynamicFeeTxType:
+	default:
+		return fmt.Errorf("unknown transaction type '%s', supported are %s, %s, and %s", config.Type, LegacyTxType, AccessListTxType, DynamicFeeTxType)
+	}
+	if config.FeeCapRatio <= 0 {
+		return fmt.Errorf("fee cap ratio must be > 0, got %f", config.FeeCapRatio)
+	}
+	if config.MinTip < 0 {
+		return fmt.Errorf("minimum tip must be >= 0, got %f", config.MinTip)
+	}
+	switch config.TipDistribution {
+	case TipFixed:
+		if config.MaxTip != 0 {
+			return fmt.Errorf("maximum tip is only supported by randomized tip distributions")
+		}
+	case TipUniform, TipExponential:
+		if config.MaxTip < config.MinTip {
+			return fmt.Errorf("maximum tip must be >= minimum tip %f, got %f", config.MinTip, config.MaxTip)
+		}
+	default:
+		return fmt.Errorf("unknown tip distribution '%s', supported are %s, %s, and %s", config.TipDistribution, TipFixed, TipUniform, TipExponential)
+	}
+	return nil
+}
+
+// txConfigurable is implemented by applications whose users can send transactions
+// of the type defined by a TxConfig. The configuration applies to all users created
+// after it has been set.
+type txConfigurable interface {
+	setTxConfig(config TxConfig)
+}
+
+// configureTxs applies the given configuration to the users of the given application.
+// Applications not supporting the configuration only accept legacy transactions.
+func configureTxs(application Application, config TxConfig) error {
+	if configurable, ok := application.(txConfigurable); ok {
+		configurable.setTxConfig(config)
+		return nil
+	}
+	if config.withDefaults().Type != LegacyTxType {
+		return fmt.Errorf("application does not support %s transactions", config.Type)
+	}
+	return nil
+}
+
+// txBuilder creates the transactions of one account following a TxConfig. It is
+// thread-safe, randomized tips are drawn from a source seeded by the account.
+type txBuilder struct {
+	config TxConfig
+	random *rand.Rand
+	mutex  sync.Mutex
+}
+
+// newTxBuilder creates a builder for the given configuration, or nil if the
+// configuration describes legacy transactions.
+func newTxBuilder(config TxConfig, seed int64) *txBuilder {
+	config = config.withDefaults()
+	if config.Type == LegacyTxType {
+		return nil
+	}
+	return &txBuilder{
+		config: config,
+		random: rand.New(rand.NewSource(seed)),
+	}
+}
+
+// newTxData creates the payload of a transaction of the configured type. The given
+// gas price is the one of the equivalent legacy transaction, the gas limit is raised
+// to cover the access list if needed. Access lists contain the given storage keys of
+// the called contract; the called address itself is warm anyway, so it is not listed
+// on its own. A nil builder creates legacy transactions.
+func (b *txBuilder) newTxData(chainID *big.Int, nonce uint64, to *common.Address, value *big.Int, data []byte, gasPrice *big.Int, gasLimit uint64, storageKeys []common.Hash) types.TxData {
+	if b == nil {
+		return &types.LegacyTx{
+			Nonce:    nonce,
+			GasPrice: gasPrice,
+			Gas:      gasLimit,
+			To:       to,
+			Value:    value,
+			Data:     data,
+		}
+	}
+
+	if b.config.Type == AccessListTxType {
+		accessList := types.AccessList{}
+		if to != nil && len(storageKeys) > 0 {
+			accessList = append(accessList, types.AccessTuple{Address: *to, StorageKeys: storageKeys})
+			gasLimit += params.TxAccessListAddressGas + uint64(len(storageKeys))*params.TxAccessListStorageKeyGas
+		}
+		return &types.AccessListTx{
+			ChainID:    chainID,
+			Nonce:      nonce,
+			GasPrice:   gasPrice,
+			Gas:        gasLimit,
+			To:         to,
+			Value:      value,
+			Data:       data,
+			AccessList: accessList,
+		}
+	}
+
+	tip := b.getTip()
+	feeCap := new(big.Float).Mul(new(big.Float).SetInt(gasPrice), big.NewFloat(b.config.FeeCapRatio))
+	feeCapWei, _ := feeCap.Int(nil)
+	if feeCapWei.Cmp(tip) < 0 {
+		feeCapWei = tip // the fee cap must cover the tip
+	}
+	return &types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: tip,
+		GasFeeCap: feeCapWei,
+		Gas:       gasLimit,
+		To:        to,
+		Value:     value,
+		Data:      data,
+	}
+}
+
+// getTip draws the tip of the next transaction, in wei.
+func (b *txBuilder) getTip() *big.Int {
+	tip := b.config.MinTip
+	switch b.config.TipDistribution {
+	case TipUniform:
+		b.mutex.Lock()
+		tip += b.random.Float64() * (b.config.MaxTip - b.config.MinTip)
+		b.mutex.Unlock()
+	case TipExponential:
+		b.mutex.Lock()
+		tip += b.random.ExpFloat64() * tipExponentialMeanRatio * (b.config.MaxTip - b.config.MinTip)
+		b.mutex.Unlock()
+		tip = math.Min(tip, b.config.MaxTip)
+	}
+	res, _ := new(big.Float).Mul(big.NewFloat(tip), big.NewFloat(params.GWei)).Int(nil)
+	return res
+}
@@ -0,0 +1,229 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/golang/mock/gomock"
+)
+
+func TestCheckTxConfig_ValidConfigurationsAreAccepted(t *testing.T) {
+	tests := []TxConfig{
+		{},
+		{Type: LegacyTxType},
+		{Type: AccessListTxType},
+		{Type: DynamicFeeTxType},
+		{Type: DynamicFeeTxType, FeeCapRatio: 2, MinTip: 1},
+		{Type: DynamicFeeTxType, MinTip: 1, MaxTip: 10, TipDistribution: TipUniform},
+		{Type: DynamicFeeTxType, MaxTip: 10, TipDistribution: TipExponential},
+		{Type: "Legacy", TipDistribution: "Fixed"},
+		{Type: "Dynamic_Fee", MinTip: 1, MaxTip: 10, TipDistribution: "Uniform"},
+	}
+	for _, config := range tests {
+		if err := CheckTxConfig(config); err != nil {
+			t.Errorf("configuration %v should be valid, got %v", config, err)
+		}
+	}
+}
+
+func TestCheckTxConfig_InvalidConfigurationsAreDetected(t *testing.T) {
+	tests := map[string]TxConfig{
+		"unknown transaction type 'blob'":                          {Type: "blob"},
+		"fee cap and tip are only supported by dynamic_fee":        {Type: AccessListTxType, MinTip: 1},
+		"fee cap ratio must be > 0":                                {Type: DynamicFeeTxType, FeeCapRatio: -1},
+		"minimum tip must be >= 0":                                 {Type: DynamicFeeTxType, MinTip: -1},
+		"maximum tip is only supported by randomized tip":          {Type: DynamicFeeTxType, MaxTip: 5},
+		"maximum tip must be >= minimum tip":                       {Type: DynamicFeeTxType, MinTip: 5, MaxTip: 1, TipDistribution: TipUniform},
+		"unknown tip distribution 'normal'":                        {Type: DynamicFeeTxType, TipDistribution: "normal"},
+		"fee cap and tip are only supported by dynamic_fee trans":  {FeeCapRatio: 2},
+		"maximum tip must be >= minimum tip 5.000000, got 0.00000": {Type: DynamicFeeTxType, MinTip: 5, TipDistribution: TipExponential},
+	}
+	for want, config := range tests {
+		if err := CheckTxConfig(config); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("issue %q was not detected, got %v", want, err)
+		}
+	}
+}
+
+func TestTxBuilder_LegacyTransactionsAreCreatedByDefault(t *testing.T) {
+	if builder := newTxBuilder(TxConfig{}, 1); builder != nil {
+		t.Errorf("no builder should be needed for legacy transactions")
+	}
+	tx, err := createTxWithNonce(newTestAccount(t, TxConfig{}), 3, common.Address{1}, big.NewInt(0), nil, big.NewInt(100), 21000)
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+	if got, want := tx.Type(), uint8(types.LegacyTxType); got != want {
+		t.Errorf("unexpected transaction type, wanted %d, got %d", want, got)
+	}
+	if got, want := tx.GasPrice(), big.NewInt(100); got.Cmp(want) != 0 {
+		t.Errorf("unexpected gas price, wanted %v, got %v", want, got)
+	}
+}
+
+func TestTxBuilder_AccessListTransactionsListTheAccessedStorageKeys(t *testing.T) {
+	account := newTestAccount(t, TxConfig{Type: AccessListTxType})
+	keys := []common.Hash{{1}, {2}}
+	tx, err := createTxAccessing(account, common.Address{1}, keys, nil, big.NewInt(100), 21000)
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+	if got, want := tx.Type(), uint8(types.AccessListTxType); got != want {
+		t.Errorf("unexpected transaction type, wanted %d, got %d", want, got)
+	}
+	if list := tx.AccessList(); len(list) != 1 || list[0].Address != (common.Address{1}) || !reflect.DeepEqual(list[0].StorageKeys, keys) {
+		t.Errorf("unexpected access list: %v", list)
+	}
+	if got, want := tx.Gas(), 21000+params.TxAccessListAddressGas+2*params.TxAccessListStorageKeyGas; got != want {
+		t.Errorf("gas limit does not cover the access list, wanted %d, got %d", want, got)
+	}
+	if sender, err := types.Sender(types.LatestSignerForChainID(account.chainID), tx); err != nil || sender != account.address {
+		t.Errorf("unexpected sender %v, err %v", sender, err)
+	}
+
+	// the called address is warm anyway, it is not listed without storage keys
+	transfer, err := createTxWithNonce(account, 4, common.Address{1}, big.NewInt(0), nil, big.NewInt(100), 21000)
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+	if transfer.Type() != types.AccessListTxType || len(transfer.AccessList()) != 0 || transfer.Gas() != 21000 {
+		t.Errorf("unexpected transfer: type %d, access list %v, gas %d", transfer.Type(), transfer.AccessList(), transfer.Gas())
+	}
+
+	deployment, err := createContractCreationTx(account, 5, []byte{1, 2, 3}, big.NewInt(100), 50000)
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+	if len(deployment.AccessList()) != 0 || deployment.Gas() != 50000 || deployment.To() != nil {
+		t.Errorf("unexpected contract creation: access list %v, gas %d", deployment.AccessList(), deployment.Gas())
+	}
+}
+
+func TestTxBuilder_AccessedStorageKeysAreIgnoredByOtherTypes(t *testing.T) {
+	for _, config := range []TxConfig{{}, {Type: DynamicFeeTxType}} {
+		tx, err := createTxAccessing(newTestAccount(t, config), common.Address{1}, []common.Hash{{1}}, nil, big.NewInt(100), 21000)
+		if err != nil {
+			t.Fatalf("failed to create transaction: %v", err)
+		}
+		if len(tx.AccessList()) != 0 || tx.Gas() != 21000 {
+			t.Errorf("unexpected %s transaction: access list %v, gas %d", config.Type, tx.AccessList(), tx.Gas())
+		}
+	}
+}
+
+func TestGetMappingSlot_MatchesSolidityLayout(t *testing.T) {
+	// keccak256(abi.encode(uint256(0), uint256(0)))
+	want := common.HexToHash("0xad3228b676f7d3cd4284a5443f17f1962b36e491b30a40b2405849e597ba5fb5")
+	if got := getMappingSlot(common.Hash{}, common.Hash{}); got != want {
+		t.Errorf("unexpected slot, wanted %v, got %v", want, got)
+	}
+}
+
+func TestTxBuilder_DynamicFeeTransactionsUseConfiguredFees(t *testing.T) {
+	account := newTestAccount(t, TxConfig{Type: DynamicFeeTxType, FeeCapRatio: 1.5, MinTip: 2})
+	tx, err := createTxWithNonce(account, 3, common.Address{1}, big.NewInt(0), nil, big.NewInt(100*params.GWei), 21000)
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+	if got, want := tx.Type(), uint8(types.DynamicFeeTxType); got != want {
+		t.Errorf("unexpected transaction type, wanted %d, got %d", want, got)
+	}
+	if got, want := tx.GasFeeCap(), big.NewInt(150*params.GWei); got.Cmp(want) != 0 {
+		t.Errorf("unexpected fee cap, wanted %v, got %v", want, got)
+	}
+	if got, want := tx.GasTipCap(), big.NewInt(2*params.GWei); got.Cmp(want) != 0 {
+		t.Errorf("unexpected tip, wanted %v, got %v", want, got)
+	}
+	if sender, err := types.Sender(types.LatestSignerForChainID(account.chainID), tx); err != nil || sender != account.address {
+		t.Errorf("unexpected sender %v, err %v", sender, err)
+	}
+}
+
+func TestTxBuilder_FeeCapCoversTheTip(t *testing.T) {
+	builder := newTxBuilder(TxConfig{Type: DynamicFeeTxType, MinTip: 10}, 1)
+	data := builder.newTxData(big.NewInt(250), 0, &common.Address{}, big.NewInt(0), nil, big.NewInt(params.GWei), 21000, nil)
+	tx := types.NewTx(data)
+	if tx.GasFeeCap().Cmp(tx.GasTipCap()) < 0 {
+		t.Errorf("fee cap %v is lower than tip %v", tx.GasFeeCap(), tx.GasTipCap())
+	}
+}
+
+func TestTxBuilder_RandomizedTipsStayInRange(t *testing.T) {
+	for _, distribution := range []string{TipUniform, TipExponential} {
+		builder := newTxBuilder(TxConfig{Type: DynamicFeeTxType, MinTip: 1, MaxTip: 5, TipDistribution: distribution}, 1)
+		low := big.NewInt(1 * params.GWei)
+		high := big.NewInt(5 * params.GWei)
+		distinct := map[string]bool{}
+		for i := 0; i < 1000; i++ {
+			tip := builder.getTip()
+			if tip.Cmp(low) < 0 || tip.Cmp(high) > 0 {
+				t.Fatalf("%s tip %v out of range [%v,%v]", distribution, tip, low, high)
+			}
+			distinct[tip.String()] = true
+		}
+		if len(distinct) < 100 {
+			t.Errorf("%s tips are not randomized, got %d distinct values", distribution, len(distinct))
+		}
+	}
+}
+
+func TestTxBuilder_TypeAndDistributionAreCaseInsensitive(t *testing.T) {
+	if builder := newTxBuilder(TxConfig{Type: "LEGACY"}, 1); builder != nil {
+		t.Errorf("no builder should be needed for legacy transactions")
+	}
+	builder := newTxBuilder(TxConfig{Type: "Dynamic_Fee", MinTip: 1, MaxTip: 5, TipDistribution: "Uniform"}, 1)
+	distinct := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		distinct[builder.getTip().String()] = true
+	}
+	if len(distinct) < 10 {
+		t.Errorf("tips are not randomized, got %d distinct values", len(distinct))
+	}
+}
+
+func TestConfigureTxs_UnsupportedApplicationsOnlyAcceptLegacyTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	application := NewMockApplication(ctrl)
+	if err := configureTxs(application, TxConfig{}); err != nil {
+		t.Errorf("legacy transactions should be accepted, got %v", err)
+	}
+	if err := configureTxs(application, TxConfig{Type: DynamicFeeTxType}); err == nil || !strings.Contains(err.Error(), "does not support dynamic_fee transactions") {
+		t.Errorf("unsupported transaction type was not detected, got %v", err)
+	}
+}
+
+func newTestAccount(t *testing.T, config TxConfig) *Account {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Account{
+		privateKey: key,
+		address:    crypto.PubkeyToAddress(key.PublicKey),
+		chainID:    big.NewInt(250),
+		txs:        newTxBuilder(config, 1),
+	}
+}
@@ -172,6 +172,10 @@ type UniswapApplication struct {
 	accountFactory   *AccountFactory
 }
 
+func (f *UniswapApplication) setTxConfig(config TxConfig) {
+	f.accountFactory.setTxConfig(config)
+}
+
 // CreateUser creates a new user for the app.
 func (f *UniswapApplication) CreateUser(rpcClient rpc.RpcClient) (User, error) {
 	// get price of gas from the network
@@ -245,6 +249,61 @@ func (f *UniswapApplication) GetReceivedTransactions(rpcClient rpc.RpcClient) (u
 	return count.Uint64(), nil
 }
 
+// Verify checks that the reserves of all pairs match their token balances and that the
+// constant-product invariant holds, i.e. swaps never decreased the product of the reserves
+// below the product of the initial liquidity.
+func (f *UniswapApplication) Verify(rpcClient rpc.RpcClient, blockNumber *big.Int) error {
+	blockNumber, err := resolveBlockNumber(rpcClient, blockNumber)
+	if err != nil {
+		return err
+	}
+	opts := &bind.CallOpts{BlockNumber: blockNumber}
+	initialK := new(big.Int).Mul(PairLiquidity, PairLiquidity)
+	for _, pairAddress := range f.pairsAddresses {
+		pair, err := contract.NewUniswapV2Pair(pairAddress, rpcClient)
+		if err != nil {
+			return fmt.Errorf("failed to get Uniswap pair representation; %v", err)
+		}
+		reserves, err := pair.GetReserves(opts)
+		if err != nil {
+			return err
+		}
+
+		k := new(big.Int).Mul(reserves.Reserve0, reserves.Reserve1)
+		if k.Cmp(initialK) < 0 {
+			return fmt.Errorf("constant-product invariant of pair %v violated at block %v: %v * %v < %v",
+				pairAddress, blockNumber, reserves.Reserve0, reserves.Reserve1, initialK)
+		}
+
+		token0, err := pair.Token0(opts)
+		if err != nil {
+			return err
+		}
+		token1, err := pair.Token1(opts)
+		if err != nil {
+			return err
+		}
+		for _, cur := range []struct {
+			token   common.Address
+			reserve *big.Int
+		}{{token0, reserves.Reserve0}, {token1, reserves.Reserve1}} {
+			tokenContract, err := contract.NewERC20(cur.token, rpcClient)
+			if err != nil {
+				return fmt.Errorf("failed to get token representation; %v", err)
+			}
+			balance, err := tokenContract.BalanceOf(opts, pairAddress)
+			if err != nil {
+				return err
+			}
+			if balance.Cmp(cur.reserve) != 0 {
+				return fmt.Errorf("reserve %v of token %v in pair %v does not match its balance %v at block %v",
+					cur.reserve, cur.token, pairAddress, balance, blockNumber)
+			}
+		}
+	}
+	return nil
+}
+
 // UniswapUser represents a user sending txs to swap ERC-20 tokens using Uniswap.
 // A generator is supposed to be used in a single thread.
 type UniswapUser struct {
@@ -288,3 +347,7 @@ func (g *UniswapUser) GenerateTx() (*types.Transaction, error) {
 func (g *UniswapUser) GetSentTransactions() uint64 {
 	return atomic.LoadUint64(&g.sentTxs)
 }
+
+func (g *UniswapUser) SyncNonce(rpcClient rpc.RpcClient) (uint64, error) {
+	return g.sender.syncNonce(rpcClient)
+}
@@ -0,0 +1,20 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.4;
+
+// ContractFactory deploys contracts of arbitrary code using CREATE2.
+contract ContractFactory {
+    uint256 private count = 0;
+
+    function deploy(bytes32 salt, bytes memory code) public returns (address addr) {
+        assembly {
+            addr := create2(0, add(code, 0x20), mload(code), salt)
+        }
+        require(addr != address(0));
+        count += 1;
+    }
+
+    // getCount returns the number of deployed contracts.
+    function getCount() public view returns (uint256) {
+        return count;
+    }
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.4;
+
+interface IERC165 {
+    function supportsInterface(bytes4 interfaceId) external view returns (bool);
+}
+
+interface IERC721 is IERC165 {
+    event Transfer(address indexed from, address indexed to, uint256 indexed tokenId);
+    event Approval(address indexed owner, address indexed approved, uint256 indexed tokenId);
+    event ApprovalForAll(address indexed owner, address indexed operator, bool approved);
+
+    function balanceOf(address owner) external view returns (uint256);
+    function ownerOf(uint256 tokenId) external view returns (address);
+    function safeTransferFrom(address from, address to, uint256 tokenId, bytes calldata data) external;
+    function safeTransferFrom(address from, address to, uint256 tokenId) external;
+    function transferFrom(address from, address to, uint256 tokenId) external;
+    function approve(address to, uint256 tokenId) external;
+    function setApprovalForAll(address operator, bool approved) external;
+    function getApproved(uint256 tokenId) external view returns (address);
+    function isApprovedForAll(address owner, address operator) external view returns (bool);
+}
+
+interface IERC721Receiver {
+    function onERC721Received(address operator, address from, uint256 tokenId, bytes calldata data) external returns (bytes4);
+}
+
+// NFT is an ERC-721 token used for generating load. Tokens are minted by users
+// under IDs of their choice and may be transferred and burned by their owners
+// or approved operators.
+contract NFT is IERC721 {
+    mapping(uint256 => address) private owners;
+    mapping(address => uint256) private balances;
+    mapping(uint256 => address) private tokenApprovals;
+    mapping(address => mapping(address => bool)) private operatorApprovals;
+    uint256 private supply = 0;
+    uint256 private count = 0;
+
+    function supportsInterface(bytes4 interfaceId) public pure returns (bool) {
+        return interfaceId == type(IERC721).interfaceId || interfaceId == type(IERC165).interfaceId;
+    }
+
+    function mint(uint256 tokenId) public {
+        require(owners[tokenId] == address(0), "token already minted");
+        owners[tokenId] = msg.sender;
+        balances[msg.sender] += 1;
+        supply += 1;
+        count += 1;
+        emit Transfer(address(0), msg.sender, tokenId);
+    }
+
+    function transferFrom(address from, address to, uint256 tokenId) public {
+        require(to != address(0), "transfer to the zero address");
+        require(ownerOf(tokenId) == from, "transfer from incorrect owner");
+        require(isApprovedOrOwner(msg.sender, from, tokenId), "caller is not owner nor approved");
+        delete tokenApprovals[tokenId];
+        owners[tokenId] = to;
+        balances[from] -= 1;
+        balances[to] += 1;
+        count += 1;
+        emit Transfer(from, to, tokenId);
+    }
+
+    function safeTransferFrom(address from, address to, uint256 tokenId) public {
+        safeTransferFrom(from, to, tokenId, "");
+    }
+
+    function safeTransferFrom(address from, address to, uint256 tokenId, bytes memory data) public {
+        transferFrom(from, to, tokenId);
+        if (to.code.length > 0) {
+            require(
+                IERC721Receiver(to).onERC721Received(msg.sender, from, tokenId, data) == IERC721Receiver.onERC721Received.selector,
+                "transfer to non ERC721Receiver implementer"
+            );
+        }
+    }
+
+    function burn(uint256 tokenId) public {
+        address owner = ownerOf(tokenId);
+        require(isApprovedOrOwner(msg.sender, owner, tokenId), "caller is not owner nor approved");
+        delete tokenApprovals[tokenId];
+        delete owners[tokenId];
+        balances[owner] -= 1;
+        supply -= 1;
+        count += 1;
+        emit Transfer(owner, address(0), tokenId);
+    }
+
+    function approve(address to, uint256 tokenId) public {
+        address owner = ownerOf(tokenId);
+        require(to != owner, "approval to current owner");
+        require(msg.sender == owner || operatorApprovals[owner][msg.sender], "caller is not owner nor approved for all");
+        tokenApprovals[tokenId] = to;
+        emit Approval(owner, to, tokenId);
+    }
+
+    function getApproved(uint256 tokenId) public view returns (address) {
+        ownerOf(tokenId);
+        return tokenApprovals[tokenId];
+    }
+
+    function setApprovalForAll(address operator, bool approved) public {
+        require(operator != msg.sender, "approve to caller");
+        operatorApprovals[msg.sender][operator] = approved;
+        emit ApprovalForAll(msg.sender, operator, approved);
+    }
+
+    function isApprovedForAll(address owner, address operator) public view returns (bool) {
+        return operatorApprovals[owner][operator];
+    }
+
+    function balanceOf(address owner) public view returns (uint256) {
+        require(owner != address(0), "balance query for the zero address");
+        return balances[owner];
+    }
+
+    function ownerOf(uint256 tokenId) public view returns (address) {
+        address owner = owners[tokenId];
+        require(owner != address(0), "invalid token ID");
+        return owner;
+    }
+
+    function totalSupply() public view returns (uint256) {
+        return supply;
+    }
+
+    // getCount returns the number of successful mints, transfers and burns.
+    function getCount() public view returns (uint256) {
+        return count;
+    }
+
+    function isApprovedOrOwner(address spender, address owner, uint256 tokenId) private view returns (bool) {
+        return spender == owner || operatorApprovals[owner][spender] || tokenApprovals[tokenId] == spender;
+    }
+}
@@ -0,0 +1,19 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.4;
+
+// Storage is a key/value store shared by all of its users.
+contract Storage {
+    mapping(uint256 => uint256) private data;
+
+    function put(uint256 key, uint256 value) public {
+        data[key] = value;
+    }
+
+    function remove(uint256 key) public {
+        delete data[key];
+    }
+
+    function get(uint256 key) public view returns (uint256) {
+        return data[key];
+    }
+}
@@ -0,0 +1,271 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+// This binding and the bytecode in ContractFactory.bin were written by hand, as no Solidity
+// compiler was available when they were added. They are to be replaced by the
+// output of solc and abigen for ../ContractFactory.sol by running `make -B generate-abi`.
+
+package abi
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// ContractFactoryMetaData contains all meta data concerning the ContractFactory contract.
+var ContractFactoryMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"salt\",\"type\":\"bytes32\"},{\"internalType\":\"bytes\",\"name\":\"code\",\"type\":\"bytes\"}],\"name\":\"deploy\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"addr\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+	Bin: "0x341561000a57600080fd5b6100b9806100186000396000f3341561000a57600080fd5b6004361061002f5760003560e01c8063a87d942c146100ad578063cdcb760a14610034575b600080fd5b604436101561004257600080fd5b602435806401000000001161005657600080fd5b6004018035806401000000001161006c57600080fd5b80826020010136101561007e57600080fd5b80826020016000376004358160006000f580151561009b57600080fd5b60005460010160005560005260206000f35b60005460005260206000f3",
+}
+
+// ContractFactoryABI is the input ABI used to generate the binding from.
+// Deprecated: Use ContractFactoryMetaData.ABI instead.
+var ContractFactoryABI = ContractFactoryMetaData.ABI
+
+// ContractFactoryBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use ContractFactoryMetaData.Bin instead.
+var ContractFactoryBin = ContractFactoryMetaData.Bin
+
+// DeployContractFactory deploys a new Ethereum contract, binding an instance of ContractFactory to it.
+func DeployContractFactory(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *ContractFactory, error) {
+	parsed, err := ContractFactoryMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(ContractFactoryBin), backend)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &ContractFactory{ContractFactoryCaller: ContractFactoryCaller{contract: contract}, ContractFactoryTransactor: ContractFactoryTransactor{contract: contract}, ContractFactoryFilterer: ContractFactoryFilterer{contract: contract}}, nil
+}
+
+// ContractFactory is an auto generated Go binding around an Ethereum contract.
+type ContractFactory struct {
+	ContractFactoryCaller     // Read-only binding to the contract
+	ContractFactoryTransactor // Write-only binding to the contract
+	ContractFactoryFilterer   // Log filterer for contract events
+}
+
+// ContractFactoryCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ContractFactoryCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ContractFactoryTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ContractFactoryTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ContractFactoryFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ContractFactoryFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ContractFactorySession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ContractFactorySession struct {
+	Contract     *ContractFactory  // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// ContractFactoryCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ContractFactoryCallerSession struct {
+	Contract *ContractFactoryCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts          // Call options to use throughout this session
+}
+
+// ContractFactoryTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ContractFactoryTransactorSession struct {
+	Contract     *ContractFactoryTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts          // Transaction auth options to use throughout this session
+}
+
+// ContractFactoryRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ContractFactoryRaw struct {
+	Contract *ContractFactory // Generic contract binding to access the raw methods on
+}
+
+// ContractFactoryCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ContractFactoryCallerRaw struct {
+	Contract *ContractFactoryCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ContractFactoryTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ContractFactoryTransactorRaw struct {
+	Contract *ContractFactoryTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewContractFactory creates a new instance of ContractFactory, bound to a specific deployed contract.
+func NewContractFactory(address common.Address, backend bind.ContractBackend) (*ContractFactory, error) {
+	contract, err := bindContractFactory(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &ContractFactory{ContractFactoryCaller: ContractFactoryCaller{contract: contract}, ContractFactoryTransactor: ContractFactoryTransactor{contract: contract}, ContractFactoryFilterer: ContractFactoryFilterer{contract: contract}}, nil
+}
+
+// NewContractFactoryCaller creates a new read-only instance of ContractFactory, bound to a specific deployed contract.
+func NewContractFactoryCaller(address common.Address, caller bind.ContractCaller) (*ContractFactoryCaller, error) {
+	contract, err := bindContractFactory(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ContractFactoryCaller{contract: contract}, nil
+}
+
+// NewContractFactoryTransactor creates a new write-only instance of ContractFactory, bound to a specific deployed contract.
+func NewContractFactoryTransactor(address common.Address, transactor bind.ContractTransactor) (*ContractFactoryTransactor, error) {
+	contract, err := bindContractFactory(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ContractFactoryTransactor{contract: contract}, nil
+}
+
+// NewContractFactoryFilterer creates a new log filterer instance of ContractFactory, bound to a specific deployed contract.
+func NewContractFactoryFilterer(address common.Address, filterer bind.ContractFilterer) (*ContractFactoryFilterer, error) {
+	contract, err := bindContractFactory(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ContractFactoryFilterer{contract: contract}, nil
+}
+
+// bindContractFactory binds a generic wrapper to an already deployed contract.
+func bindContractFactory(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(ContractFactoryABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ContractFactory *ContractFactoryRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ContractFactory.Contract.ContractFactoryCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ContractFactory *ContractFactoryRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ContractFactory.Contract.ContractFactoryTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ContractFactory *ContractFactoryRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ContractFactory.Contract.ContractFactoryTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_ContractFactory *ContractFactoryCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _ContractFactory.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_ContractFactory *ContractFactoryTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ContractFactory.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_ContractFactory *ContractFactoryTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _ContractFactory.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetCount is a free data retrieval call binding the contract method 0xa87d942c.
+//
+// Solidity: function getCount() view returns(uint256)
+func (_ContractFactory *ContractFactoryCaller) GetCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _ContractFactory.contract.Call(opts, &out, "getCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetCount is a free data retrieval call binding the contract method 0xa87d942c.
+//
+// Solidity: function getCount() view returns(uint256)
+func (_ContractFactory *ContractFactorySession) GetCount() (*big.Int, error) {
+	return _ContractFactory.Contract.GetCount(&_ContractFactory.CallOpts)
+}
+
+// GetCount is a free data retrieval call binding the contract method 0xa87d942c.
+//
+// Solidity: function getCount() view returns(uint256)
+func (_ContractFactory *ContractFactoryCallerSession) GetCount() (*big.Int, error) {
+	return _ContractFactory.Contract.GetCount(&_ContractFactory.CallOpts)
+}
+
+// Deploy is a paid mutator transaction binding the contract method 0xcdcb760a.
+//
+// Solidity: function deploy(bytes32 salt, bytes code) returns(address addr)
+func (_ContractFactory *ContractFactoryTransactor) Deploy(opts *bind.TransactOpts, salt [32]byte, code []byte) (*types.Transaction, error) {
+	return _ContractFactory.contract.Transact(opts, "deploy", salt, code)
+}
+
+// Deploy is a paid mutator transaction binding the contract method 0xcdcb760a.
+//
+// Solidity: function deploy(bytes32 salt, bytes code) returns(address addr)
+func (_ContractFactory *ContractFactorySession) Deploy(salt [32]byte, code []byte) (*types.Transaction, error) {
+	return _ContractFactory.Contract.Deploy(&_ContractFactory.TransactOpts, salt, code)
+}
+
+// Deploy is a paid mutator transaction binding the contract method 0xcdcb760a.
+//
+// Solidity: function deploy(bytes32 salt, bytes code) returns(address addr)
+func (_ContractFactory *ContractFactoryTransactorSession) Deploy(salt [32]byte, code []byte) (*types.Transaction, error) {
+	return _ContractFactory.Contract.Deploy(&_ContractFactory.TransactOpts, salt, code)
+}
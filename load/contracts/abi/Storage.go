@@ -0,0 +1,292 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+// This binding and the bytecode in Storage.bin were written by hand, as no Solidity
+// compiler was available when they were added. They are to be replaced by the
+// output of solc and abigen for ../Storage.sol by running `make -B generate-abi`.
+
+package abi
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// StorageMetaData contains all meta data concerning the Storage contract.
+var StorageMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"key\",\"type\":\"uint256\"}],\"name\":\"get\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"key\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"put\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"key\",\"type\":\"uint256\"}],\"name\":\"remove\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	Bin: "0x341561000a57600080fd5b6100ae806100186000396000f3341561000a57600080fd5b6004361061003a5760003560e01c80634cc8221514610063578063541aea0f1461003f5780639507d39a14610086575b600080fd5b604436101561004d57600080fd5b6024356004356000526000602052604060002055005b602436101561007157600080fd5b60006004356000526000602052604060002055005b602436101561009457600080fd5b600435600052600060205260406000205460005260206000f3",
+}
+
+// StorageABI is the input ABI used to generate the binding from.
+// Deprecated: Use StorageMetaData.ABI instead.
+var StorageABI = StorageMetaData.ABI
+
+// StorageBin is the compiled bytecode used for deploying new contracts.
+// Deprecated: Use StorageMetaData.Bin instead.
+var StorageBin = StorageMetaData.Bin
+
+// DeployStorage deploys a new Ethereum contract, binding an instance of Storage to it.
+func DeployStorage(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *Storage, error) {
+	parsed, err := StorageMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
+
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(StorageBin), backend)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return address, tx, &Storage{StorageCaller: StorageCaller{contract: contract}, StorageTransactor: StorageTransactor{contract: contract}, StorageFilterer: StorageFilterer{contract: contract}}, nil
+}
+
+// Storage is an auto generated Go binding around an Ethereum contract.
+type Storage struct {
+	StorageCaller     // Read-only binding to the contract
+	StorageTransactor // Write-only binding to the contract
+	StorageFilterer   // Log filterer for contract events
+}
+
+// StorageCaller is an auto generated read-only Go binding around an Ethereum contract.
+type StorageCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// StorageTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type StorageTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// StorageFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type StorageFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// StorageSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type StorageSession struct {
+	Contract     *Storage          // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// StorageCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type StorageCallerSession struct {
+	Contract *StorageCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts  // Call options to use throughout this session
+}
+
+// StorageTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type StorageTransactorSession struct {
+	Contract     *StorageTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts  // Transaction auth options to use throughout this session
+}
+
+// StorageRaw is an auto generated low-level Go binding around an Ethereum contract.
+type StorageRaw struct {
+	Contract *Storage // Generic contract binding to access the raw methods on
+}
+
+// StorageCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type StorageCallerRaw struct {
+	Contract *StorageCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// StorageTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type StorageTransactorRaw struct {
+	Contract *StorageTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewStorage creates a new instance of Storage, bound to a specific deployed contract.
+func NewStorage(address common.Address, backend bind.ContractBackend) (*Storage, error) {
+	contract, err := bindStorage(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Storage{StorageCaller: StorageCaller{contract: contract}, StorageTransactor: StorageTransactor{contract: contract}, StorageFilterer: StorageFilterer{contract: contract}}, nil
+}
+
+// NewStorageCaller creates a new read-only instance of Storage, bound to a specific deployed contract.
+func NewStorageCaller(address common.Address, caller bind.ContractCaller) (*StorageCaller, error) {
+	contract, err := bindStorage(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageCaller{contract: contract}, nil
+}
+
+// NewStorageTransactor creates a new write-only instance of Storage, bound to a specific deployed contract.
+func NewStorageTransactor(address common.Address, transactor bind.ContractTransactor) (*StorageTransactor, error) {
+	contract, err := bindStorage(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageTransactor{contract: contract}, nil
+}
+
+// NewStorageFilterer creates a new log filterer instance of Storage, bound to a specific deployed contract.
+func NewStorageFilterer(address common.Address, filterer bind.ContractFilterer) (*StorageFilterer, error) {
+	contract, err := bindStorage(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageFilterer{contract: contract}, nil
+}
+
+// bindStorage binds a generic wrapper to an already deployed contract.
+func bindStorage(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(StorageABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Storage *StorageRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Storage.Contract.StorageCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Storage *StorageRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Storage.Contract.StorageTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Storage *StorageRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Storage.Contract.StorageTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Storage *StorageCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Storage.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Storage *StorageTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Storage.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Storage *StorageTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Storage.Contract.contract.Transact(opts, method, params...)
+}
+
+// Get is a free data retrieval call binding the contract method 0x9507d39a.
+//
+// Solidity: function get(uint256 key) view returns(uint256)
+func (_Storage *StorageCaller) Get(opts *bind.CallOpts, key *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _Storage.contract.Call(opts, &out, "get", key)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Get is a free data retrieval call binding the contract method 0x9507d39a.
+//
+// Solidity: function get(uint256 key) view returns(uint256)
+func (_Storage *StorageSession) Get(key *big.Int) (*big.Int, error) {
+	return _Storage.Contract.Get(&_Storage.CallOpts, key)
+}
+
+// Get is a free data retrieval call binding the contract method 0x9507d39a.
+//
+// Solidity: function get(uint256 key) view returns(uint256)
+func (_Storage *StorageCallerSession) Get(key *big.Int) (*big.Int, error) {
+	return _Storage.Contract.Get(&_Storage.CallOpts, key)
+}
+
+// Put is a paid mutator transaction binding the contract method 0x541aea0f.
+//
+// Solidity: function put(uint256 key, uint256 value) returns()
+func (_Storage *StorageTransactor) Put(opts *bind.TransactOpts, key *big.Int, value *big.Int) (*types.Transaction, error) {
+	return _Storage.contract.Transact(opts, "put", key, value)
+}
+
+// Put is a paid mutator transaction binding the contract method 0x541aea0f.
+//
+// Solidity: function put(uint256 key, uint256 value) returns()
+func (_Storage *StorageSession) Put(key *big.Int, value *big.Int) (*types.Transaction, error) {
+	return _Storage.Contract.Put(&_Storage.TransactOpts, key, value)
+}
+
+// Put is a paid mutator transaction binding the contract method 0x541aea0f.
+//
+// Solidity: function put(uint256 key, uint256 value) returns()
+func (_Storage *StorageTransactorSession) Put(key *big.Int, value *big.Int) (*types.Transaction, error) {
+	return _Storage.Contract.Put(&_Storage.TransactOpts, key, value)
+}
+
+// Remove is a paid mutator transaction binding the contract method 0x4cc82215.
+//
+// Solidity: function remove(uint256 key) returns()
+func (_Storage *StorageTransactor) Remove(opts *bind.TransactOpts, key *big.Int) (*types.Transaction, error) {
+	return _Storage.contract.Transact(opts, "remove", key)
+}
+
+// Remove is a paid mutator transaction binding the contract method 0x4cc82215.
+//
+// Solidity: function remove(uint256 key) returns()
+func (_Storage *StorageSession) Remove(key *big.Int) (*types.Transaction, error) {
+	return _Storage.Contract.Remove(&_Storage.TransactOpts, key)
+}
+
+// Remove is a paid mutator transaction binding the contract method 0x4cc82215.
+//
+// Solidity: function remove(uint256 key) returns()
+func (_Storage *StorageTransactorSession) Remove(key *big.Int) (*types.Transaction, error) {
+	return _Storage.Contract.Remove(&_Storage.TransactOpts, key)
+}
@@ -0,0 +1,202 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/Fantom-foundation/Norma/load/app"
+)
+
+// DefaultReceiptTimeout is the time users in closed-loop mode wait for the
+// inclusion of a transaction if no timeout is configured.
+const DefaultReceiptTimeout = 30 * time.Second
+
+// ClosedLoopConfig configures an AppController running in closed-loop mode,
+// in which each user sends a transaction, waits for its inclusion in a block
+// or a timeout, and pauses for the think time before sending the next one.
+// After a timeout, the nonce of the user is re-synchronized with the network.
+type ClosedLoopConfig struct {
+	ThinkTime      time.Duration
+	ReceiptTimeout time.Duration // < zero is interpreted as DefaultReceiptTimeout
+}
+
+// NewClosedLoopAppController creates an AppController producing load in
+// closed-loop mode. Unlike in the open-loop mode, the load is not controlled
+// by a shaper but by the speed the network confirms transactions at.
+func NewClosedLoopAppController(application app.Application, numUsers int, network driver.Network, config ClosedLoopConfig) (*AppController, error) {
+	if _, isQuerying := application.(app.QueryingApplication); isQuerying {
+		return nil, fmt.Errorf("query applications do not support the closed-loop mode")
+	}
+	ac, err := NewAppController(application, nil, numUsers, network)
+	if err != nil {
+		return nil, err
+	}
+	if config.ReceiptTimeout <= 0 {
+		config.ReceiptTimeout = DefaultReceiptTimeout
+	}
+	ac.closedLoop = &config
+	ac.roundTrips = make([]*roundTripRegistry, numUsers)
+	for i := range ac.roundTrips {
+		ac.roundTrips[i] = &roundTripRegistry{}
+	}
+	return ac, nil
+}
+
+// runClosedLoop runs all users in closed-loop mode until the context is done.
+func (ac *AppController) runClosedLoop(ctx context.Context) error {
+	watcher, err := newInclusionWatcher(ac.network)
+	if err != nil {
+		return err
+	}
+	defer watcher.close()
+
+	var done sync.WaitGroup
+	for i, user := range ac.users {
+		user, sent, stats := user, ac.sentTxs[i], ac.roundTrips[i]
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			resync := func() { ac.resyncNonce(user) }
+			runClosedLoopUser(ctx, user, sent, stats, ac.load, ac.network, watcher, resync, *ac.closedLoop)
+		}()
+	}
+	done.Wait()
+
+	err = ctx.Err()
+	if err == context.DeadlineExceeded || err == context.Canceled {
+		return nil // terminated gracefully
+	}
+	return err
+}
+
+func runClosedLoopUser(
+	ctx context.Context,
+	user app.User,
+	sent *txRegistry,
+	stats *roundTripRegistry,
+	load *loadStats,
+	network driver.Network,
+	watcher *inclusionWatcher,
+	resync func(),
+	config ClosedLoopConfig,
+) {
+	for {
+		// in the closed-loop mode, each user requests its next transaction
+		// on its own as soon as the previous round trip is completed
+		load.request(1)
+		load.issue()
+		tx, err := user.GenerateTx()
+		if err != nil {
+			log.Printf("failed to generate tx; %v", err)
+			if !sleep(ctx, time.Second) {
+				return
+			}
+			continue
+		}
+
+		hash := tx.Hash()
+		included := watcher.watch(hash)
+		sent.add(tx)
+		start := time.Now()
+		network.SendTransaction(tx)
+		load.submit()
+
+		timeout := time.NewTimer(config.ReceiptTimeout)
+		select {
+		case <-included:
+			stats.completed(time.Since(start))
+		case <-timeout.C:
+			watcher.forget(hash)
+			stats.timedOut()
+			// the transaction may have been dropped, leaving a nonce gap
+			// blocking all later transactions of the user
+			resync()
+		case <-ctx.Done():
+			watcher.forget(hash)
+		}
+		timeout.Stop()
+
+		if !sleep(ctx, config.ThinkTime) {
+			return
+		}
+	}
+}
+
+// resyncNonce re-synchronizes the nonce of the given user with the network, such
+// that transactions lost by the network do not block later transactions of the user.
+func (ac *AppController) resyncNonce(user app.User) {
+	syncing, ok := user.(app.NonceSyncingUser)
+	if !ok {
+		return
+	}
+	err := ac.retryRpc(func(rpcClient rpc.RpcClient) error {
+		_, err := syncing.SyncNonce(rpcClient)
+		return err
+	})
+	if err != nil {
+		log.Printf("failed to re-synchronize nonce of user; %v", err)
+	}
+}
+
+// sleep waits for the given duration and reports whether the context is
+// still active afterwards.
+func sleep(ctx context.Context, duration time.Duration) bool {
+	if duration <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// roundTripRegistry accumulates the round trip statistics of a single user.
+// Instances are thread-safe.
+type roundTripRegistry struct {
+	stats driver.RoundTripStats
+	mutex sync.Mutex
+}
+
+func (r *roundTripRegistry) completed(latency time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.stats.Completed++
+	r.stats.Latency += latency
+}
+
+func (r *roundTripRegistry) timedOut() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.stats.TimedOut++
+}
+
+func (r *roundTripRegistry) get() driver.RoundTripStats {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.stats
+}
@@ -0,0 +1,90 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/Fantom-foundation/Norma/load/app"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/golang/mock/gomock"
+)
+
+func TestRoundTripRegistry_AccumulatesStats(t *testing.T) {
+	registry := &roundTripRegistry{}
+	registry.completed(2 * time.Second)
+	registry.timedOut()
+	registry.completed(3 * time.Second)
+
+	want := driver.RoundTripStats{Completed: 2, TimedOut: 1, Latency: 5 * time.Second}
+	if got := registry.get(); got != want {
+		t.Errorf("unexpected stats, wanted %v, got %v", want, got)
+	}
+}
+
+func TestAppController_OpenLoopControllerHasNoRoundTripStats(t *testing.T) {
+	ac := &AppController{}
+	if got, err := ac.GetRoundTripStats(0); err != nil || got != (driver.RoundTripStats{}) {
+		t.Errorf("unexpected stats, got %v, err %v", got, err)
+	}
+}
+
+func TestInclusionWatcher_NotifiesWatchedTransactions(t *testing.T) {
+	watcher := &inclusionWatcher{pending: map[common.Hash]chan struct{}{}}
+	included := watcher.watch(common.Hash{1})
+	forgotten := watcher.watch(common.Hash{2})
+	watcher.forget(common.Hash{2})
+
+	watcher.notify([]common.Hash{{2}, {3}})
+	select {
+	case <-included:
+		t.Errorf("transaction reported as included before being in a block")
+	default:
+	}
+
+	watcher.notify([]common.Hash{{1}})
+	select {
+	case <-included:
+	default:
+		t.Errorf("transaction not reported as included")
+	}
+
+	select {
+	case <-forgotten:
+		t.Errorf("forgotten transaction reported as included")
+	default:
+	}
+	if len(watcher.pending) != 0 {
+		t.Errorf("watcher still tracks %d transactions", len(watcher.pending))
+	}
+}
+
+func TestAppController_ResyncNonceSyncsUsersSupportingIt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+	ac := &AppController{rpcClient: rpcClient}
+
+	syncing := app.NewMockNonceSyncingUser(ctrl)
+	syncing.EXPECT().SyncNonce(rpcClient).Return(uint64(1), nil)
+	ac.resyncNonce(syncing)
+
+	// users not supporting it are ignored
+	ac.resyncNonce(app.NewMockUser(ctrl))
+}
@@ -21,12 +21,15 @@ import (
 	"fmt"
 	"github.com/Fantom-foundation/Norma/driver/rpc"
 	"log"
+	"math/big"
 	"sync"
 	"time"
 
 	"github.com/Fantom-foundation/Norma/driver"
 	"github.com/Fantom-foundation/Norma/load/app"
 	"github.com/Fantom-foundation/Norma/load/shaper"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // AppController emits transactions to the testing network into a blockchain app to generate a load.
@@ -39,7 +42,16 @@ type AppController struct {
 	network     driver.Network
 	trigger     chan struct{}
 	users       []app.User
-	rpcClient   rpc.RpcClient
+	queriers    []app.Querier // < users of query applications, sending read-only requests
+	sentTxs     []*txRegistry
+	rpcClient   rpc.RpcClient // < shared by sensors querying the network, guarded by rpcMutex
+	rpcMutex    sync.Mutex
+	deployedAt  uint64               // < the first block the application was fully deployed at
+	closedLoop  *ClosedLoopConfig    // < nil for the open-loop mode
+	roundTrips  []*roundTripRegistry // < per-user round trips, closed-loop mode only
+	load        *loadStats
+	gas         *gasEstimator
+	head        *headTracker
 }
 
 func NewAppController(application app.Application, shaper shaper.Shaper, numUsers int, network driver.Network) (*AppController, error) {
@@ -52,12 +64,24 @@ func NewAppController(application app.Application, shaper shaper.Shaper, numUser
 
 	// initialize workers for individual generators
 	users := make([]app.User, 0, numUsers)
+	sentTxs := make([]*txRegistry, 0, numUsers)
+	var queriers []app.Querier
+	querying, isQuerying := application.(app.QueryingApplication)
 	for i := 0; i < numUsers; i++ {
+		if isQuerying {
+			querier, err := querying.CreateQuerier(rpcClient)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create load app; %s", err)
+			}
+			queriers = append(queriers, querier)
+			continue
+		}
 		gen, err := application.CreateUser(rpcClient)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create load app; %s", err)
 		}
 		users = append(users, gen)
+		sentTxs = append(sentTxs, newTxRegistry(txRegistryCapacity))
 		if i%100 == 0 {
 			log.Printf("initialized %d of %d users ...\n", i+1, numUsers)
 		}
@@ -68,32 +92,77 @@ func NewAppController(application app.Application, shaper shaper.Shaper, numUser
 	if err := application.WaitUntilApplicationIsDeployed(rpcClient); err != nil {
 		return nil, fmt.Errorf("failed to wait for app on-chain init; %s", err)
 	}
+	var deployedAt hexutil.Uint64
+	if err := rpcClient.Call(&deployedAt, "eth_blockNumber"); err != nil {
+		return nil, fmt.Errorf("failed to get block number of app deployment; %v", err)
+	}
 	log.Printf("the app is deployed\n")
 
+	head := &headTracker{}
+	head.head.Store(uint64(deployedAt))
+
 	return &AppController{
 		shaper:      shaper,
 		application: application,
 		network:     network,
 		trigger:     trigger,
 		users:       users,
+		queriers:    queriers,
+		sentTxs:     sentTxs,
 		rpcClient:   rpcClient,
+		deployedAt:  uint64(deployedAt),
+		load:        &loadStats{},
+		gas:         &gasEstimator{},
+		head:        head,
 	}, nil
 }
 
 func (ac *AppController) Run(ctx context.Context) error {
-	defer ac.rpcClient.Close()
+	defer func() {
+		ac.rpcMutex.Lock()
+		defer ac.rpcMutex.Unlock()
+		ac.rpcClient.Close()
+	}()
+
+	if ac.closedLoop != nil {
+		return ac.runClosedLoop(ctx)
+	}
 
 	// start generators for each user
 	var done sync.WaitGroup
-	for _, user := range ac.users {
-		user := user
+	for i, user := range ac.users {
+		user, sent := user, ac.sentTxs[i]
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			runGeneratorLoop(user, sent, ac.load, ac.gas, ac.trigger, ac.network)
+		}()
+	}
+	for _, querier := range ac.queriers {
+		querier := querier
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			runQueryLoop(querier, ac.head, ac.load, ac.trigger, ac.network)
+		}()
+	}
+
+	// keep the head limiting the blocks covered by requests of query users up to date
+	if len(ac.queriers) > 0 {
 		done.Add(1)
 		go func() {
 			defer done.Done()
-			runGeneratorLoop(user, ac.trigger, ac.network)
+			ac.head.run(ctx, ac.network)
 		}()
 	}
 
+	// keep the gas estimate used by gas-based shapers up to date
+	done.Add(1)
+	go func() {
+		defer done.Done()
+		ac.gas.run(ctx, ac.network)
+	}()
+
 	var pending float64
 	lastUpdate := time.Now()
 	ac.shaper.Start(lastUpdate, ac)
@@ -101,13 +170,13 @@ func (ac *AppController) Run(ctx context.Context) error {
 	for {
 		// re-plenish the number of pending messages
 		now := time.Now()
-		pending += ac.shaper.GetNumMessagesInInterval(lastUpdate, now.Sub(lastUpdate))
+		requested := ac.shaper.GetNumMessagesInInterval(lastUpdate, now.Sub(lastUpdate))
+		ac.load.request(requested)
+		pending += requested
 		lastUpdate = now
 
-		for pending > 0 {
-			ac.trigger <- struct{}{}
-			pending -= 1
-		}
+		pending = ac.releasePending(ctx, pending)
+		ac.load.check(now)
 
 		select {
 		case <-time.After(time.Millisecond):
@@ -124,8 +193,26 @@ func (ac *AppController) Run(ctx context.Context) error {
 	}
 }
 
+// releasePending hands out pending messages, waiting for generators to pick them up,
+// and returns the number of messages remaining pending once the context is done. No
+// requested messages are dropped; if generators can not keep up, the backlog is
+// reflected by the requested but not yet submitted transactions of the load stats.
+func (ac *AppController) releasePending(ctx context.Context, pending float64) float64 {
+	for pending > 0 {
+		select {
+		case ac.trigger <- struct{}{}:
+			pending -= 1
+		case <-ctx.Done():
+			return pending
+		}
+	}
+	return pending
+}
+
+// GetNumberOfUsers returns the number of users of the application, including users
+// sending read-only requests instead of transactions.
 func (ac *AppController) GetNumberOfUsers() int {
-	return len(ac.users)
+	return len(ac.users) + len(ac.queriers)
 }
 
 func (ac *AppController) GetTransactionsSentBy(user int) (uint64, error) {
@@ -135,6 +222,33 @@ func (ac *AppController) GetTransactionsSentBy(user int) (uint64, error) {
 	return ac.users[user].GetSentTransactions(), nil
 }
 
+// GetTransactionLogOf returns the latest transactions the given user has passed
+// to the network for sending, in sending order.
+func (ac *AppController) GetTransactionLogOf(user int) (driver.SentTransactionLog, error) {
+	if user < 0 || user >= len(ac.sentTxs) {
+		return driver.SentTransactionLog{}, nil
+	}
+	return ac.sentTxs[user].get(), nil
+}
+
+// GetRoundTripStats returns the round trip statistics of the given user. For
+// controllers running in the open-loop mode, no round trips are tracked.
+func (ac *AppController) GetRoundTripStats(user int) (driver.RoundTripStats, error) {
+	if user < 0 || user >= len(ac.roundTrips) {
+		return driver.RoundTripStats{}, nil
+	}
+	return ac.roundTrips[user].get(), nil
+}
+
+// GetLoadStats returns statistics on the load produced by this controller.
+func (ac *AppController) GetLoadStats() driver.LoadStats {
+	res := ac.load.get()
+	if estimator, ok := ac.shaper.(shaper.ThroughputEstimator); ok {
+		res.SustainableRate, _ = estimator.GetSustainableRate()
+	}
+	return res
+}
+
 func (ac *AppController) GetSentTransactions() (uint64, error) {
 	sum := uint64(0)
 	for i := 0; i < ac.GetNumberOfUsers(); i++ {
@@ -145,21 +259,139 @@ func (ac *AppController) GetSentTransactions() (uint64, error) {
 }
 
 func (ac *AppController) GetReceivedTransactions() (uint64, error) {
+	var res uint64
+	err := ac.retryRpc(func(rpcClient rpc.RpcClient) (err error) {
+		res, err = ac.application.GetReceivedTransactions(rpcClient)
+		return err
+	})
+	return res, err
+}
+
+// GetComponentStats obtains the number of sent and received transactions of each
+// component of composite applications, nil for other applications.
+func (ac *AppController) GetComponentStats() ([]driver.ComponentStats, error) {
+	composite, ok := ac.application.(app.CompositeApplication)
+	if !ok {
+		return nil, nil
+	}
+	var stats []app.ComponentStats
+	err := ac.retryRpc(func(rpcClient rpc.RpcClient) (err error) {
+		stats, err = composite.GetComponentStats(rpcClient)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]driver.ComponentStats, 0, len(stats))
+	for _, cur := range stats {
+		res = append(res, driver.ComponentStats{Name: cur.Name, Sent: cur.Sent, Received: cur.Received})
+	}
+	return res, nil
+}
+
+// GetExpectedRejections obtains the number of transactions the transaction pools of
+// nodes are expected to reject, nil for applications not sending such transactions.
+func (ac *AppController) GetExpectedRejections() map[string]uint64 {
+	rejecting, ok := ac.application.(app.RejectingApplication)
+	if !ok {
+		return nil
+	}
+	return rejecting.GetExpectedRejections()
+}
+
+// GetExpectedSubmissionErrors obtains the number of transactions expected to be rejected
+// on submission per error class, nil for applications not sending such transactions.
+func (ac *AppController) GetExpectedSubmissionErrors() map[string]uint64 {
+	rejecting, ok := ac.application.(app.RejectingApplication)
+	if !ok {
+		return nil
+	}
+	return rejecting.GetExpectedSubmissionErrors()
+}
+
+// IsExpectedToFail reports whether the given transaction is deliberately not expected
+// to be executed successfully, false for applications not sending such transactions.
+func (ac *AppController) IsExpectedToFail(hash common.Hash) bool {
+	rejecting, ok := ac.application.(app.RejectingApplication)
+	return ok && rejecting.IsExpectedToFail(hash)
+}
+
+// retryRpc runs the given query fetching data from the network, re-connecting
+// to a random RPC node on failures. Queries are serialized since the client
+// shared by all of them may get replaced.
+func (ac *AppController) retryRpc(query func(rpc.RpcClient) error) error {
+	ac.rpcMutex.Lock()
+	defer ac.rpcMutex.Unlock()
 	for retry := 0; ; retry++ {
 		// fetch transaction data from the network
-		res, err := ac.application.GetReceivedTransactions(ac.rpcClient)
+		err := query(ac.rpcClient)
 		if err == nil {
-			return res, nil
+			return nil
 		}
 		if retry >= 5 {
-			return 0, err
+			return err
 		}
 
-		// attempt a re-connect
-		ac.rpcClient.Close()
-		ac.rpcClient, err = ac.network.DialRandomRpc()
+		// attempt a re-connect, keeping the old client if no new one is available
+		rpcClient, err := ac.network.DialRandomRpc()
 		if err != nil {
-			return 0, fmt.Errorf("failed to dial random RPC; %v", err)
+			return fmt.Errorf("failed to dial random RPC; %v", err)
 		}
+		ac.rpcClient.Close()
+		ac.rpcClient = rpcClient
+	}
+}
+
+// GetGasPerTransaction obtains an estimate of the gas used by a single transaction
+// sent by this controller, 0 if no transaction has been sent yet.
+func (ac *AppController) GetGasPerTransaction() (float64, error) {
+	return ac.gas.get(), nil
+}
+
+// GetServiceLevel obtains the latest value of the given service level indicator
+// of the network the load is sent to.
+func (ac *AppController) GetServiceLevel(indicator driver.ServiceLevelIndicator) (driver.ServiceLevel, bool) {
+	return ac.network.GetServiceLevel(indicator)
+}
+
+// GetRejectedTransactions obtains the number of transactions sent by this controller
+// the network failed to accept.
+func (ac *AppController) GetRejectedTransactions() (uint64, error) {
+	sum := uint64(0)
+	for _, count := range ac.network.GetTransactionSubmissionErrors() {
+		sum += count
+	}
+	return sum, nil
+}
+
+// GetOverloadRejections obtains the number of transactions sent by this controller
+// the network failed to accept due to being overloaded.
+func (ac *AppController) GetOverloadRejections() (uint64, error) {
+	sum := uint64(0)
+	for key, count := range ac.network.GetTransactionSubmissionErrors() {
+		if key.Class.IndicatesOverload() {
+			sum += count
+		}
+	}
+	return sum, nil
+}
+
+// Verify checks the application specific invariants on the current on-chain state.
+func (ac *AppController) Verify() error {
+	rpcClient, err := ac.network.DialRandomRpc()
+	if err != nil {
+		return fmt.Errorf("failed to dial random RPC; %v", err)
+	}
+	defer rpcClient.Close()
+	return ac.application.Verify(rpcClient, nil)
+}
+
+// VerifyAt checks the application specific invariants on the on-chain state of the
+// given block using the given RPC client. Blocks before the application has been
+// deployed are not checked.
+func (ac *AppController) VerifyAt(rpcClient rpc.RpcClient, blockNumber uint64) error {
+	if blockNumber < ac.deployedAt {
+		return nil
 	}
+	return ac.application.Verify(rpcClient, new(big.Int).SetUint64(blockNumber))
 }
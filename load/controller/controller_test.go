@@ -46,7 +46,7 @@ func TestLoadGeneration_CanRealizeConstantTrafficShape(t *testing.T) {
 			rpcClient := rpc.NewMockRpcClient(ctrl)
 			application := app.NewMockApplication(ctrl)
 			user := app.NewMockUser(ctrl)
-			transaction := types.Transaction{}
+			transaction := types.NewTx(&types.LegacyTx{})
 
 			check := NewRateCheck(float64(rate))
 			var count atomic.Int32
@@ -57,11 +57,12 @@ func TestLoadGeneration_CanRealizeConstantTrafficShape(t *testing.T) {
 			})
 
 			rpcClient.EXPECT().Close().AnyTimes().Return()
+			rpcClient.EXPECT().Call(gomock.Any(), "eth_blockNumber").Return(nil)
 
 			application.EXPECT().CreateUser(gomock.Any()).AnyTimes().Return(user, nil)
 			application.EXPECT().WaitUntilApplicationIsDeployed(gomock.Any()).Return(nil)
 
-			user.EXPECT().GenerateTx().AnyTimes().Return(&transaction, nil)
+			user.EXPECT().GenerateTx().AnyTimes().Return(transaction, nil)
 
 			shaper := shaper.NewConstantShaper(float64(rate))
 			controller, err := NewAppController(application, shaper, 100, net)
@@ -0,0 +1,187 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// gasEstimationPeriod is the period in which receipts of sent transactions
+	// are sampled to update the gas estimate.
+	gasEstimationPeriod = 2 * time.Second
+	// gasSampleSize is the maximum number of transactions awaiting sampling.
+	gasSampleSize = 16
+	// gasSampleMinAge is the minimum time since sending a transaction before its
+	// receipt is requested, roughly the time it takes to include it in a block.
+	gasSampleMinAge = time.Second
+	// gasSampleMaxAge is the time after which a sampled transaction without a
+	// receipt is discarded to make room for new samples.
+	gasSampleMaxAge = time.Minute
+	// gasEstimateSmoothing is the weight of new observations in the estimate.
+	gasEstimateSmoothing = 0.2
+)
+
+// gasEstimator estimates the gas used by a single transaction of an application.
+// The estimate starts with the gas limit of the generated transactions and is
+// refined by the gas used by sampled transactions as reported by their receipts.
+// Sampled transactions are kept until their receipt is available, new transactions
+// are only sampled if there is space left.
+// Instances are thread-safe.
+type gasEstimator struct {
+	estimate float64
+	observed bool        // < true once the estimate is based on receipts
+	samples  []gasSample // < sent transactions not sampled yet, in sending order
+	mutex    sync.Mutex
+}
+
+// gasSample is a transaction awaiting the sampling of its receipt.
+type gasSample struct {
+	hash common.Hash
+	sent time.Time
+}
+
+// observe registers a transaction sent by the application.
+func (e *gasEstimator) observe(tx *types.Transaction) {
+	e.observeAt(tx, time.Now())
+}
+
+func (e *gasEstimator) observeAt(tx *types.Transaction, now time.Time) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if e.estimate == 0 {
+		e.estimate = float64(tx.Gas())
+	}
+	if len(e.samples) < gasSampleSize {
+		e.samples = append(e.samples, gasSample{hash: tx.Hash(), sent: now})
+	}
+}
+
+// get returns the current estimate, 0 if no transaction has been observed yet.
+func (e *gasEstimator) get() float64 {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.estimate
+}
+
+// run periodically updates the estimate until the context is done. The estimator
+// uses its own connection to a random RPC node of the network, which is re-dialed
+// after failed updates.
+func (e *gasEstimator) run(ctx context.Context, network driver.Network) {
+	var rpcClient rpc.RpcClient
+	defer func() {
+		if rpcClient != nil {
+			rpcClient.Close()
+		}
+	}()
+	ticker := time.NewTicker(gasEstimationPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if rpcClient == nil {
+				client, err := network.DialRandomRpc()
+				if err != nil {
+					log.Printf("failed to dial random RPC for gas estimation; %v", err)
+					continue
+				}
+				rpcClient = client
+			}
+			if err := e.update(rpcClient, time.Now()); err != nil {
+				log.Printf("failed to update gas estimate; %v", err)
+				rpcClient.Close()
+				rpcClient = nil
+			}
+		}
+	}
+}
+
+// update fetches the receipts of sampled transactions sent long enough ago to be
+// included in a block and incorporates the gas used by those already included into
+// the estimate. Samples stay registered until their receipt is available, or they
+// got too old, so no samples are lost if fetching the receipts fails.
+func (e *gasEstimator) update(rpcClient rpc.RpcClient, now time.Time) error {
+	e.mutex.Lock()
+	ready := []common.Hash{}
+	for _, sample := range e.samples {
+		if now.Sub(sample.sent) >= gasSampleMinAge {
+			ready = append(ready, sample.hash)
+		}
+	}
+	e.mutex.Unlock()
+
+	receipts := make([]*struct{ GasUsed hexutil.Uint64 }, len(ready))
+	batch := make([]gethrpc.BatchElem, len(ready))
+	for i, hash := range ready {
+		batch[i] = gethrpc.BatchElem{
+			Method: "eth_getTransactionReceipt",
+			Args:   []interface{}{hash},
+			Result: &receipts[i],
+		}
+	}
+	if len(batch) > 0 {
+		if err := rpcClient.BatchCall(batch); err != nil {
+			return fmt.Errorf("failed to get receipts; %v", err)
+		}
+	}
+
+	sum, count := 0.0, 0
+	sampled := map[common.Hash]bool{}
+	for i, receipt := range receipts {
+		if batch[i].Error != nil || receipt == nil {
+			continue
+		}
+		sum += float64(receipt.GasUsed)
+		count++
+		sampled[ready[i]] = true
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	// transactions not included yet are sampled again, unless they got too old
+	remaining := e.samples[:0]
+	for _, sample := range e.samples {
+		if !sampled[sample.hash] && now.Sub(sample.sent) < gasSampleMaxAge {
+			remaining = append(remaining, sample)
+		}
+	}
+	e.samples = remaining
+	if count == 0 {
+		return nil
+	}
+	mean := sum / float64(count)
+	if e.observed {
+		e.estimate = (1-gasEstimateSmoothing)*e.estimate + gasEstimateSmoothing*mean
+	} else {
+		e.estimate = mean
+		e.observed = true
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/golang/mock/gomock"
+)
+
+func TestGasEstimator_StartsWithGasLimit(t *testing.T) {
+	estimator := &gasEstimator{}
+	if got := estimator.get(); got != 0 {
+		t.Errorf("unexpected initial estimate: %v", got)
+	}
+	estimator.observe(types.NewTx(&types.LegacyTx{Gas: 50_000}))
+	estimator.observe(types.NewTx(&types.LegacyTx{Gas: 70_000}))
+	if got, want := estimator.get(), 50_000.0; got != want {
+		t.Errorf("unexpected estimate, wanted %v, got %v", want, got)
+	}
+}
+
+func TestGasEstimator_IsUpdatedFromReceipts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+
+	start := time.Unix(100, 0)
+	estimator := &gasEstimator{}
+	included := types.NewTx(&types.LegacyTx{Nonce: 1, Gas: 50_000})
+	pending := types.NewTx(&types.LegacyTx{Nonce: 2, Gas: 50_000})
+	estimator.observeAt(included, start)
+	estimator.observeAt(pending, start)
+
+	now := start.Add(gasSampleMinAge)
+	rpcClient.EXPECT().BatchCall(gomock.Any()).DoAndReturn(func(batch []gethrpc.BatchElem) error {
+		if len(batch) != 2 {
+			t.Fatalf("unexpected batch size: %d", len(batch))
+		}
+		setGasUsed(t, batch[0], "0xa410")
+		return nil
+	})
+	if err := estimator.update(rpcClient, now); err != nil {
+		t.Fatalf("failed to update estimate: %v", err)
+	}
+	if got, want := estimator.get(), 42_000.0; got != want {
+		t.Errorf("unexpected estimate, wanted %v, got %v", want, got)
+	}
+
+	// the pending transaction is sampled again
+	rpcClient.EXPECT().BatchCall(gomock.Any()).DoAndReturn(func(batch []gethrpc.BatchElem) error {
+		if len(batch) != 1 || batch[0].Args[0] != pending.Hash() {
+			t.Fatalf("pending transaction was not sampled again")
+		}
+		setGasUsed(t, batch[0], "0xc350")
+		return nil
+	})
+	if err := estimator.update(rpcClient, now); err != nil {
+		t.Fatalf("failed to update estimate: %v", err)
+	}
+	if got, want := estimator.get(), 0.8*42_000+0.2*50_000; math.Abs(got-want) > 1e-6 {
+		t.Errorf("unexpected estimate, wanted %v, got %v", want, got)
+	}
+}
+
+func TestGasEstimator_OlderSamplesAreKeptWhileNewestHaveNoReceipts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+
+	// the sample buffer is filled by the oldest transactions, later ones are not sampled
+	start := time.Unix(100, 0)
+	estimator := &gasEstimator{}
+	old := []*types.Transaction{}
+	for i := 0; i < gasSampleSize; i++ {
+		tx := types.NewTx(&types.LegacyTx{Nonce: uint64(i), Gas: 50_000})
+		estimator.observeAt(tx, start)
+		old = append(old, tx)
+	}
+	now := start.Add(gasSampleMinAge)
+	for i := 0; i < 100; i++ {
+		estimator.observeAt(types.NewTx(&types.LegacyTx{Nonce: uint64(100 + i), Gas: 50_000}), now)
+	}
+
+	// receipts are only available for the old transactions, not for the newest ones
+	rpcClient.EXPECT().BatchCall(gomock.Any()).DoAndReturn(func(batch []gethrpc.BatchElem) error {
+		if len(batch) != len(old) {
+			t.Fatalf("unexpected batch size, wanted %d, got %d", len(old), len(batch))
+		}
+		for i, elem := range batch {
+			if elem.Args[0] != old[i].Hash() {
+				t.Fatalf("unexpected transaction sampled: %v", elem.Args[0])
+			}
+			setGasUsed(t, elem, "0xa410")
+		}
+		return nil
+	})
+	if err := estimator.update(rpcClient, now); err != nil {
+		t.Fatalf("failed to update estimate: %v", err)
+	}
+	if got, want := estimator.get(), 42_000.0; got != want {
+		t.Errorf("unexpected estimate, wanted %v, got %v", want, got)
+	}
+}
+
+func TestGasEstimator_RecentSamplesAreNotRequested(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+
+	start := time.Unix(100, 0)
+	estimator := &gasEstimator{}
+	estimator.observeAt(types.NewTx(&types.LegacyTx{Gas: 50_000}), start)
+
+	// no receipts are requested before the transaction could be included
+	if err := estimator.update(rpcClient, start.Add(gasSampleMinAge/2)); err != nil {
+		t.Fatalf("failed to update estimate: %v", err)
+	}
+	if got := len(estimator.samples); got != 1 {
+		t.Errorf("sample should be retained, got %d samples", got)
+	}
+}
+
+func TestGasEstimator_SamplesAreKeptOnFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+
+	start := time.Unix(100, 0)
+	estimator := &gasEstimator{}
+	tx := types.NewTx(&types.LegacyTx{Gas: 50_000})
+	estimator.observeAt(tx, start)
+
+	now := start.Add(gasSampleMinAge)
+	rpcClient.EXPECT().BatchCall(gomock.Any()).Return(errors.New("connection refused"))
+	if err := estimator.update(rpcClient, now); err == nil {
+		t.Errorf("failed update should be reported")
+	}
+
+	rpcClient.EXPECT().BatchCall(gomock.Any()).DoAndReturn(func(batch []gethrpc.BatchElem) error {
+		if len(batch) != 1 || batch[0].Args[0] != tx.Hash() {
+			t.Fatalf("sample was lost after failed update")
+		}
+		setGasUsed(t, batch[0], "0xa410")
+		return nil
+	})
+	if err := estimator.update(rpcClient, now); err != nil {
+		t.Fatalf("failed to update estimate: %v", err)
+	}
+	if got, want := estimator.get(), 42_000.0; got != want {
+		t.Errorf("unexpected estimate, wanted %v, got %v", want, got)
+	}
+}
+
+func TestGasEstimator_OutdatedSamplesAreDiscarded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+
+	start := time.Unix(100, 0)
+	estimator := &gasEstimator{}
+	estimator.observeAt(types.NewTx(&types.LegacyTx{Gas: 50_000}), start)
+
+	rpcClient.EXPECT().BatchCall(gomock.Any()).Return(nil)
+	if err := estimator.update(rpcClient, start.Add(gasSampleMaxAge)); err != nil {
+		t.Fatalf("failed to update estimate: %v", err)
+	}
+	if got := len(estimator.samples); got != 0 {
+		t.Errorf("outdated sample should be discarded, got %d samples", got)
+	}
+}
+
+// setGasUsed sets the receipt of the given batch element to report the given gas usage.
+func setGasUsed(t *testing.T, elem gethrpc.BatchElem, gasUsed string) {
+	t.Helper()
+	if err := json.Unmarshal([]byte(`{"gasUsed":"`+gasUsed+`"}`), elem.Result); err != nil {
+		t.Fatalf("failed to set receipt: %v", err)
+	}
+}
@@ -17,18 +17,46 @@
 package controller
 
 import (
+	"encoding/json"
 	"github.com/Fantom-foundation/Norma/driver"
 	"github.com/Fantom-foundation/Norma/load/app"
 	"log"
 )
 
-func runGeneratorLoop(user app.User, trigger <-chan struct{}, network driver.Network) {
+func runGeneratorLoop(user app.User, sent *txRegistry, load *loadStats, gas *gasEstimator, trigger <-chan struct{}, network driver.Network) {
 	for range trigger {
+		load.issue()
 		tx, err := user.GenerateTx()
 		if err != nil {
 			log.Printf("failed to generate tx; %v", err)
 		} else {
+			sent.add(tx)
+			gas.observe(tx)
 			network.SendTransaction(tx)
+			load.submit()
 		}
 	}
 }
+
+// runQueryLoop sends a read-only request of the given user for each trigger. Requests
+// are sent synchronously, the latency and the outcome of requests are recorded by the
+// network, responses are discarded.
+func runQueryLoop(user app.Querier, head *headTracker, load *loadStats, trigger <-chan struct{}, network driver.Network) {
+	for range trigger {
+		load.issue()
+		query, err := user.GenerateQuery(head.get())
+		if err != nil {
+			log.Printf("failed to generate query; %v", err)
+			continue
+		}
+		var result json.RawMessage
+		send := network.SendQuery
+		if query.Archive {
+			send = network.SendArchiveQuery
+		}
+		if err := send(&result, query.Method, query.Args...); err != nil {
+			log.Printf("failed to send %s query; %v", query.Method, err)
+		}
+		load.submit()
+	}
+}
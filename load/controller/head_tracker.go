@@ -0,0 +1,87 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// headTrackingPeriod is the period in which the number of the latest block is updated.
+const headTrackingPeriod = time.Second
+
+// headTracker keeps track of the number of the latest block of the network, which
+// limits the blocks covered by read-only requests of query users.
+// Instances are thread-safe.
+type headTracker struct {
+	head atomic.Uint64
+}
+
+// get returns the number of the latest block observed so far.
+func (t *headTracker) get() uint64 {
+	return t.head.Load()
+}
+
+// run periodically updates the head until the context is done. The tracker owns the
+// RPC client it uses, which is re-dialed after a failed update.
+func (t *headTracker) run(ctx context.Context, network driver.Network) {
+	var rpcClient rpc.RpcClient
+	defer func() {
+		if rpcClient != nil {
+			rpcClient.Close()
+		}
+	}()
+	ticker := time.NewTicker(headTrackingPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if rpcClient == nil {
+				client, err := network.DialRandomRpc()
+				if err != nil {
+					log.Printf("failed to dial random RPC for head tracking; %v", err)
+					continue
+				}
+				rpcClient = client
+			}
+			if err := t.update(rpcClient); err != nil {
+				log.Printf("failed to update head block; %v", err)
+				rpcClient.Close()
+				rpcClient = nil
+			}
+		}
+	}
+}
+
+// update fetches the number of the latest block from the network.
+func (t *headTracker) update(rpcClient rpc.RpcClient) error {
+	var head hexutil.Uint64
+	if err := rpcClient.Call(&head, "eth_blockNumber"); err != nil {
+		return fmt.Errorf("failed to get block number; %v", err)
+	}
+	t.head.Store(uint64(head))
+	return nil
+}
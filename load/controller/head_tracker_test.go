@@ -0,0 +1,44 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang/mock/gomock"
+)
+
+func TestHeadTracker_IsUpdatedFromNetwork(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	rpcClient := rpc.NewMockRpcClient(ctrl)
+	rpcClient.EXPECT().Call(gomock.Any(), "eth_blockNumber").DoAndReturn(func(result interface{}, method string, args ...interface{}) error {
+		*result.(*hexutil.Uint64) = 42
+		return nil
+	})
+	rpcClient.EXPECT().Call(gomock.Any(), "eth_blockNumber").Return(errors.New("connection refused"))
+
+	tracker := &headTracker{}
+	if err := tracker.update(rpcClient); err != nil || tracker.get() != 42 {
+		t.Errorf("unexpected head, wanted 42, got %d, err %v", tracker.get(), err)
+	}
+	if err := tracker.update(rpcClient); err == nil || tracker.get() != 42 {
+		t.Errorf("failed update should be reported and keep the head, got %d, err %v", tracker.get(), err)
+	}
+}
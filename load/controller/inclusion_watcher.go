@@ -0,0 +1,150 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// inclusionPollingPeriod is the period in which the inclusion watcher checks
+// for new blocks. It bounds the resolution of measured round trip latencies.
+const inclusionPollingPeriod = 50 * time.Millisecond
+
+// inclusionWatcher follows the blocks of the network and notifies parties
+// waiting for the inclusion of transactions. Only blocks produced after the
+// watcher has been created are considered.
+// Instances are thread-safe.
+type inclusionWatcher struct {
+	network   driver.Network
+	rpcClient rpc.RpcClient
+	next      uint64 // < the next block to be processed
+	pending   map[common.Hash]chan struct{}
+	mutex     sync.Mutex
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+func newInclusionWatcher(network driver.Network) (*inclusionWatcher, error) {
+	rpcClient, err := network.DialRandomRpc()
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial random RPC; %v", err)
+	}
+	var head hexutil.Uint64
+	if err := rpcClient.Call(&head, "eth_blockNumber"); err != nil {
+		rpcClient.Close()
+		return nil, fmt.Errorf("failed to get current block number; %v", err)
+	}
+	w := &inclusionWatcher{
+		network:   network,
+		rpcClient: rpcClient,
+		next:      uint64(head) + 1,
+		pending:   map[common.Hash]chan struct{}{},
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	go w.run()
+	return w, nil
+}
+
+// watch registers the given transaction. The resulting channel is closed once
+// the transaction has been included in a block. To catch the inclusion, the
+// transaction must be registered before it is sent.
+func (w *inclusionWatcher) watch(hash common.Hash) <-chan struct{} {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	included := make(chan struct{})
+	w.pending[hash] = included
+	return included
+}
+
+// forget stops watching the given transaction.
+func (w *inclusionWatcher) forget(hash common.Hash) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	delete(w.pending, hash)
+}
+
+// close stops the watcher and releases its resources.
+func (w *inclusionWatcher) close() {
+	close(w.stop)
+	<-w.done
+	w.rpcClient.Close()
+}
+
+func (w *inclusionWatcher) run() {
+	defer close(w.done)
+	ticker := time.NewTicker(inclusionPollingPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			if err := w.poll(); err != nil {
+				log.Printf("failed to check for included transactions; %v", err)
+				w.reconnect()
+			}
+		}
+	}
+}
+
+// poll processes all blocks produced since the last poll.
+func (w *inclusionWatcher) poll() error {
+	var head hexutil.Uint64
+	if err := w.rpcClient.Call(&head, "eth_blockNumber"); err != nil {
+		return fmt.Errorf("failed to get current block number; %v", err)
+	}
+	for ; w.next <= uint64(head); w.next++ {
+		var block struct {
+			Transactions []common.Hash
+		}
+		if err := w.rpcClient.Call(&block, "eth_getBlockByNumber", hexutil.EncodeUint64(w.next), false); err != nil {
+			return fmt.Errorf("failed to get block %d; %v", w.next, err)
+		}
+		w.notify(block.Transactions)
+	}
+	return nil
+}
+
+func (w *inclusionWatcher) notify(hashes []common.Hash) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	for _, hash := range hashes {
+		if included, found := w.pending[hash]; found {
+			close(included)
+			delete(w.pending, hash)
+		}
+	}
+}
+
+func (w *inclusionWatcher) reconnect() {
+	rpcClient, err := w.network.DialRandomRpc()
+	if err != nil {
+		log.Printf("failed to dial random RPC; %v", err)
+		return
+	}
+	w.rpcClient.Close()
+	w.rpcClient = rpcClient
+}
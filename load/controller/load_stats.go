@@ -0,0 +1,111 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+)
+
+const (
+	// saturationCheckPeriod is the period in which the load produced by
+	// the generators is compared to the load requested by the shaper.
+	saturationCheckPeriod = 5 * time.Second
+	// saturationTolerance is the number of requested but not yet submitted
+	// transactions tolerated before the generator is considered saturated.
+	saturationTolerance = 100
+	// saturationRatio is the minimum fraction of the requested transactions
+	// that must be submitted within a check period.
+	saturationRatio = 0.9
+)
+
+// loadStats tracks the load produced by the load generator of an application
+// and detects situations where the generator can not keep up with the load
+// requested by the shaper.
+// Instances are thread-safe.
+type loadStats struct {
+	stats driver.LoadStats
+	mutex sync.Mutex
+
+	// state of the saturation detection
+	lastCheck     time.Time
+	lastRequested float64
+	lastSubmitted uint64
+	saturated     bool
+}
+
+func (s *loadStats) request(count float64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.stats.Requested += count
+}
+
+func (s *loadStats) issue() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.stats.Issued++
+}
+
+func (s *loadStats) submit() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.stats.Submitted++
+}
+
+func (s *loadStats) get() driver.LoadStats {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.stats
+}
+
+// check compares the requested and the submitted load since the last check and
+// logs a warning whenever the generator starts or stops being the bottleneck.
+func (s *loadStats) check(now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.lastCheck.IsZero() {
+		s.lastCheck = now
+		return
+	}
+	interval := now.Sub(s.lastCheck)
+	if interval < saturationCheckPeriod {
+		return
+	}
+
+	requested := s.stats.Requested - s.lastRequested
+	submitted := float64(s.stats.Submitted - s.lastSubmitted)
+	backlog := s.stats.Requested - float64(s.stats.Submitted)
+	saturated := backlog > saturationTolerance && submitted < requested*saturationRatio
+
+	if saturated {
+		s.stats.Saturated += interval
+		if !s.saturated {
+			log.Printf("WARNING: load generator can not keep up - requested %.1f tx/s, submitted %.1f tx/s, backlog %.0f txs",
+				requested/interval.Seconds(), submitted/interval.Seconds(), backlog)
+		}
+	} else if s.saturated {
+		log.Printf("load generator caught up with the requested load")
+	}
+
+	s.saturated = saturated
+	s.lastCheck = now
+	s.lastRequested = s.stats.Requested
+	s.lastSubmitted = s.stats.Submitted
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadStats_CountsRequestedIssuedAndSubmittedTransactions(t *testing.T) {
+	stats := &loadStats{}
+	stats.request(2.5)
+	stats.issue()
+	stats.issue()
+	stats.submit()
+
+	got := stats.get()
+	if got.Requested != 2.5 || got.Issued != 2 || got.Submitted != 1 {
+		t.Errorf("unexpected stats: %v", got)
+	}
+}
+
+func TestLoadStats_DetectsSaturatedGenerator(t *testing.T) {
+	stats := &loadStats{}
+	start := time.Unix(100, 0)
+	stats.check(start)
+
+	// the generator keeps up with the requested load
+	stats.request(1000)
+	for i := 0; i < 1000; i++ {
+		stats.submit()
+	}
+	stats.check(start.Add(saturationCheckPeriod))
+	if got := stats.get().Saturated; got != 0 {
+		t.Errorf("generator keeping up reported as saturated for %v", got)
+	}
+
+	// the generator falls behind
+	stats.request(1000)
+	for i := 0; i < 500; i++ {
+		stats.submit()
+	}
+	stats.check(start.Add(2 * saturationCheckPeriod))
+	if got, want := stats.get().Saturated, saturationCheckPeriod; got != want {
+		t.Errorf("unexpected saturation time, wanted %v, got %v", want, got)
+	}
+
+	// checks within the check period are ignored
+	stats.check(start.Add(2*saturationCheckPeriod + time.Second))
+	if got, want := stats.get().Saturated, saturationCheckPeriod; got != want {
+		t.Errorf("unexpected saturation time, wanted %v, got %v", want, got)
+	}
+}
+
+func TestLoadStats_SmallBacklogIsTolerated(t *testing.T) {
+	stats := &loadStats{}
+	start := time.Unix(100, 0)
+	stats.check(start)
+	stats.request(saturationTolerance)
+	stats.check(start.Add(saturationCheckPeriod))
+	if got := stats.get().Saturated; got != 0 {
+		t.Errorf("small backlog reported as saturation for %v", got)
+	}
+}
@@ -33,13 +33,14 @@ func TestMockedTrafficGenerating(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
-	var demoTx types.Transaction
+	demoTx := types.NewTx(&types.LegacyTx{})
 
 	numUsers := 2
 	mockedGenerator := app.NewMockUser(mockCtrl)
 
 	mockedRpcClient := rpc.NewMockRpcClient(mockCtrl)
 	mockedRpcClient.EXPECT().Close()
+	mockedRpcClient.EXPECT().Call(gomock.Any(), "eth_blockNumber").Return(nil)
 
 	mockedNetwork := driver.NewMockNetwork(mockCtrl)
 	mockedNetwork.EXPECT().DialRandomRpc().Return(mockedRpcClient, nil)
@@ -49,9 +50,9 @@ func TestMockedTrafficGenerating(t *testing.T) {
 	mockedApp.EXPECT().WaitUntilApplicationIsDeployed(mockedRpcClient).Return(nil)
 
 	// app should be called 10-times to generate 10 txs
-	mockedGenerator.EXPECT().GenerateTx().Return(&demoTx, nil).MinTimes(5).MaxTimes(11)
+	mockedGenerator.EXPECT().GenerateTx().Return(demoTx, nil).MinTimes(5).MaxTimes(11)
 	// network should be called 10-times to send 10 txs
-	mockedNetwork.EXPECT().SendTransaction(&demoTx).MinTimes(5).MaxTimes(11)
+	mockedNetwork.EXPECT().SendTransaction(demoTx).MinTimes(5).MaxTimes(11)
 
 	// use constant shaper
 	constantShaper := shaper.NewConstantShaper(100) // 100 txs/sec
@@ -71,3 +72,154 @@ func TestMockedTrafficGenerating(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestRejectedTransactionsAreSummedOverNodesAndClasses(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mockedNetwork := driver.NewMockNetwork(mockCtrl)
+	mockedNetwork.EXPECT().GetTransactionSubmissionErrors().Return(map[driver.SubmissionErrorKey]uint64{
+		{Node: "node-1", App: "A", Class: driver.SubmissionErrorNonceTooLow}: 2,
+		{Node: "node-2", App: "A", Class: driver.SubmissionErrorTxPoolFull}:  3,
+	})
+
+	ctrl := &AppController{network: mockedNetwork}
+	if got, err := ctrl.GetRejectedTransactions(); err != nil || got != 5 {
+		t.Errorf("unexpected number of rejected transactions, wanted 5, got %d, err %v", got, err)
+	}
+}
+
+func TestOverloadRejectionsCountOnlyPoolFullAndUnderpricedTransactions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mockedNetwork := driver.NewMockNetwork(mockCtrl)
+	mockedNetwork.EXPECT().GetTransactionSubmissionErrors().Return(map[driver.SubmissionErrorKey]uint64{
+		{Node: "node-1", App: "A", Class: driver.SubmissionErrorNonceTooLow}:  2,
+		{Node: "node-1", App: "A", Class: driver.SubmissionErrorAlreadyKnown}: 4,
+		{Node: "node-1", App: "A", Class: driver.SubmissionErrorConnection}:   8,
+		{Node: "node-2", App: "A", Class: driver.SubmissionErrorTxPoolFull}:   3,
+		{Node: "node-2", App: "A", Class: driver.SubmissionErrorUnderpriced}:  5,
+	})
+
+	ctrl := &AppController{network: mockedNetwork}
+	if got, err := ctrl.GetOverloadRejections(); err != nil || got != 8 {
+		t.Errorf("unexpected number of overload rejections, wanted 8, got %d, err %v", got, err)
+	}
+}
+
+func TestAppController_ComponentStatsAreReportedForCompositeApplications(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	mockedRpcClient := rpc.NewMockRpcClient(mockCtrl)
+
+	composite := app.NewMockCompositeApplication(mockCtrl)
+	composite.EXPECT().GetComponentStats(mockedRpcClient).Return([]app.ComponentStats{
+		{Name: "erc20", Sent: 5, Received: 4},
+		{Name: "store", Sent: 2, Received: 1},
+	}, nil)
+
+	ctrl := &AppController{application: composite, rpcClient: mockedRpcClient}
+	got, err := ctrl.GetComponentStats()
+	if err != nil {
+		t.Fatalf("failed to get component stats: %v", err)
+	}
+	want := []driver.ComponentStats{{Name: "erc20", Sent: 5, Received: 4}, {Name: "store", Sent: 2, Received: 1}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("unexpected component stats, wanted %v, got %v", want, got)
+	}
+
+	ctrl = &AppController{application: app.NewMockApplication(mockCtrl)}
+	if got, err := ctrl.GetComponentStats(); err != nil || got != nil {
+		t.Errorf("unexpected component stats of non-composite application, got %v, err %v", got, err)
+	}
+}
+
+func TestAppController_ExpectedRejectionsAreReportedForRejectingApplications(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+
+	rejecting := app.NewMockRejectingApplication(mockCtrl)
+	rejecting.EXPECT().GetExpectedRejections().Return(map[string]uint64{"txpool_invalid": 12})
+	rejecting.EXPECT().GetExpectedSubmissionErrors().Return(map[string]uint64{"Underpriced": 7})
+
+	ctrl := &AppController{application: rejecting}
+	if got := ctrl.GetExpectedRejections(); len(got) != 1 || got["txpool_invalid"] != 12 {
+		t.Errorf("unexpected expected rejections, got %v", got)
+	}
+	if got := ctrl.GetExpectedSubmissionErrors(); len(got) != 1 || got["Underpriced"] != 7 {
+		t.Errorf("unexpected expected submission errors, got %v", got)
+	}
+
+	ctrl = &AppController{application: app.NewMockApplication(mockCtrl)}
+	if got := ctrl.GetExpectedRejections(); got != nil {
+		t.Errorf("unexpected expected rejections of regular application, got %v", got)
+	}
+	if got := ctrl.GetExpectedSubmissionErrors(); got != nil {
+		t.Errorf("unexpected expected submission errors of regular application, got %v", got)
+	}
+}
+
+func TestMockedQueryGenerating(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	numUsers := 2
+	mockedQuerier := app.NewMockQuerier(mockCtrl)
+
+	mockedRpcClient := rpc.NewMockRpcClient(mockCtrl)
+	mockedRpcClient.EXPECT().Close()
+	mockedRpcClient.EXPECT().Call(gomock.Any(), "eth_blockNumber").Return(nil)
+
+	mockedNetwork := driver.NewMockNetwork(mockCtrl)
+	mockedNetwork.EXPECT().DialRandomRpc().Return(mockedRpcClient, nil)
+
+	mockedApp := app.NewMockQueryingApplication(mockCtrl)
+	mockedApp.EXPECT().CreateQuerier(mockedRpcClient).Return(mockedQuerier, nil).Times(numUsers)
+	mockedApp.EXPECT().WaitUntilApplicationIsDeployed(mockedRpcClient).Return(nil)
+
+	// users should be called 10-times to generate 10 queries, which are sent to the network
+	query := app.Query{Method: "eth_getBalance", Args: []interface{}{"0x0", "latest"}}
+	mockedQuerier.EXPECT().GenerateQuery(gomock.Any()).Return(query, nil).MinTimes(5).MaxTimes(11)
+	mockedNetwork.EXPECT().SendQuery(gomock.Any(), "eth_getBalance", "0x0", "latest").Return(nil).MinTimes(5).MaxTimes(11)
+
+	appController, err := NewAppController(mockedApp, shaper.NewConstantShaper(100), numUsers, mockedNetwork)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := appController.GetNumberOfUsers(); got != numUsers {
+		t.Errorf("unexpected number of users, wanted %d, got %d", numUsers, got)
+	}
+
+	// let the app run for 100 ms - should give 10 queries
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := appController.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAppController_PendingMessagesAreKeptUntilPickedUp(t *testing.T) {
+	ac := &AppController{trigger: make(chan struct{}, 10), load: &loadStats{}}
+
+	// all pending messages are handed out once generators pick them up
+	picked := make(chan int)
+	go func() {
+		count := 0
+		for i := 0; i < 25; i++ {
+			<-ac.trigger
+			count++
+		}
+		picked <- count
+	}()
+	if got := ac.releasePending(context.Background(), 25); got != 0 {
+		t.Errorf("unexpected number of pending messages, wanted 0, got %f", got)
+	}
+	if got := <-picked; got != 25 {
+		t.Errorf("unexpected number of picked up messages, wanted 25, got %d", got)
+	}
+
+	// if the context is done, the remaining messages are kept pending
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for i := 0; i < 10; i++ {
+		ac.trigger <- struct{}{}
+	}
+	if got := ac.releasePending(ctx, 5); got != 5 {
+		t.Errorf("unexpected number of pending messages, wanted 5, got %f", got)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"sync"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// txRegistryCapacity is the number of the latest transactions retained per user,
+// bounding the memory used by the registries of long runs.
+const txRegistryCapacity = 100_000
+
+// txRegistry records the nonces and hashes of the latest transactions a single
+// user has passed to the network for sending. It is used by the end-of-run checks
+// to verify that each of those transactions landed on the chain. Once the capacity
+// is reached, the oldest transactions are replaced by new ones.
+// Instances are thread-safe.
+type txRegistry struct {
+	transactions []driver.SentTransaction // < ring buffer of the latest transactions
+	next         int                      // < position of the oldest transaction once full
+	untracked    uint64                   // < number of replaced transactions
+	capacity     int
+	mutex        sync.Mutex
+}
+
+func newTxRegistry(capacity int) *txRegistry {
+	return &txRegistry{capacity: capacity}
+}
+
+func (r *txRegistry) add(tx *types.Transaction) {
+	sent := driver.SentTransaction{Nonce: tx.Nonce(), Hash: tx.Hash()}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if len(r.transactions) < r.capacity {
+		r.transactions = append(r.transactions, sent)
+		return
+	}
+	r.transactions[r.next] = sent
+	r.next = (r.next + 1) % r.capacity
+	r.untracked++
+}
+
+// get returns a copy of the retained transactions in sending order.
+func (r *txRegistry) get() driver.SentTransactionLog {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	res := make([]driver.SentTransaction, 0, len(r.transactions))
+	res = append(res, r.transactions[r.next:]...)
+	res = append(res, r.transactions[:r.next]...)
+	return driver.SentTransactionLog{Transactions: res, Untracked: r.untracked}
+}
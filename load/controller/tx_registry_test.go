@@ -0,0 +1,63 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package controller
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestTxRegistry_TransactionsAreReturnedInSendingOrder(t *testing.T) {
+	registry := newTxRegistry(10)
+	want := []driver.SentTransaction{}
+	for nonce := uint64(0); nonce < 5; nonce++ {
+		tx := newRegistryTestTx(nonce)
+		registry.add(tx)
+		want = append(want, driver.SentTransaction{Nonce: nonce, Hash: tx.Hash()})
+	}
+	got := registry.get()
+	if !reflect.DeepEqual(got.Transactions, want) || got.Untracked != 0 {
+		t.Errorf("unexpected sent transactions, wanted %v, got %v", want, got)
+	}
+}
+
+func TestTxRegistry_OldestTransactionsAreReplacedOnceFull(t *testing.T) {
+	registry := newTxRegistry(3)
+	for nonce := uint64(0); nonce < 7; nonce++ {
+		registry.add(newRegistryTestTx(nonce))
+	}
+	got := registry.get()
+	nonces := []uint64{}
+	for _, tx := range got.Transactions {
+		nonces = append(nonces, tx.Nonce)
+	}
+	if want := []uint64{4, 5, 6}; !reflect.DeepEqual(nonces, want) {
+		t.Errorf("unexpected retained nonces, wanted %v, got %v", want, nonces)
+	}
+	if got.Untracked != 4 {
+		t.Errorf("unexpected number of untracked transactions, wanted 4, got %d", got.Untracked)
+	}
+}
+
+func newRegistryTestTx(nonce uint64) *types.Transaction {
+	return types.NewTx(&types.LegacyTx{Nonce: nonce, To: &common.Address{}, Value: big.NewInt(1), Gas: 21_000})
+}
@@ -0,0 +1,208 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package shaper
+
+import (
+	"log"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/parser"
+)
+
+const (
+	// adaptiveUpdatePeriod is the period in which the rate of an adaptive shaper is adjusted.
+	adaptiveUpdatePeriod = time.Second
+	// convergenceTolerance is the maximum relative deviation of the measured service
+	// level from its target for the controller to be considered converged.
+	convergenceTolerance = 0.1
+	// convergenceWindow is the number of consecutive updates the service level needs
+	// to stay within the tolerance for the controller to be considered converged.
+	convergenceWindow = 10
+	// staleIntegralHalfLife is the half-life of the accumulated error while no new
+	// observations of the service level are available.
+	staleIntegralHalfLife = 5 * time.Second
+)
+
+// AdaptiveConfig defines the parameters of an adaptive shaper.
+type AdaptiveConfig struct {
+	Indicator driver.ServiceLevelIndicator // the controlled service level indicator
+	Target    float64                      // the targeted value of the indicator
+	Initial   float64                      // the initial rate in Tx/s
+	Max       float64                      // the maximum rate in Tx/s
+	Kp        float64                      // the proportional gain
+	Ki        float64                      // the integral gain
+	Kd        float64                      // the derivative gain
+}
+
+// adaptiveShaper adjusts the transaction rate using a PID controller such that a
+// service level indicator of the network meets a given target. The controller is
+// fed with the relative error (target - measured) / target, thus a positive error
+// indicates headroom for additional load. Once the error stayed within a tolerance
+// for several consecutive updates, the mean rate during those updates is reported
+// as the sustainable throughput of the network.
+//
+// Only new observations of the service level are fed to the controller. Without
+// new observations, e.g. since no transactions are included if the rate dropped
+// to zero, the accumulated error decays such that the rate returns to its initial
+// value and the network gets probed again.
+//
+// See: https://en.wikipedia.org/wiki/PID_controller
+type adaptiveShaper struct {
+	config     AdaptiveConfig
+	rate       float64   // < the current rate
+	integral   float64   // < the accumulated error in seconds
+	lastError  float64   // < the error at the last update
+	hasError   bool      // < true if an error has been observed before
+	position   uint64    // < the position of the last observation used
+	observed   bool      // < true if an observation has been used before
+	lastUpdate time.Time // < the time of the last update
+	window     []float64 // < the rates of the latest updates within the convergence tolerance
+	loadInfo   LoadInfoSource
+
+	sustainable      float64 // < the sustainable rate, valid if converged is true
+	converged        bool
+	sustainableMutex sync.Mutex
+}
+
+func NewAdaptiveShaper(config AdaptiveConfig) Shaper {
+	return &adaptiveShaper{
+		config: config,
+		rate:   config.Initial,
+		window: make([]float64, 0, convergenceWindow),
+	}
+}
+
+// parseAdaptiveConfig derives the configuration of an adaptive shaper from its
+// scenario description, filling in defaults for missing parameters.
+func parseAdaptiveConfig(adaptive *parser.Adaptive) AdaptiveConfig {
+	get := func(value *float32, fallback float64) float64 {
+		if value == nil {
+			return fallback
+		}
+		return float64(*value)
+	}
+	return AdaptiveConfig{
+		Indicator: driver.ServiceLevelIndicator(adaptive.Indicator),
+		Target:    float64(adaptive.Target),
+		Initial:   get(adaptive.Initial, 10),
+		Max:       get(adaptive.Max, math.Inf(1)),
+		Kp:        get(adaptive.Kp, 100),
+		Ki:        get(adaptive.Ki, 20),
+		Kd:        get(adaptive.Kd, 0),
+	}
+}
+
+func (s *adaptiveShaper) Start(start time.Time, info LoadInfoSource) {
+	s.lastUpdate = start
+	s.loadInfo = info
+}
+
+func (s *adaptiveShaper) GetNumMessagesInInterval(start time.Time, duration time.Duration) float64 {
+	if elapsed := start.Sub(s.lastUpdate); elapsed >= adaptiveUpdatePeriod {
+		s.lastUpdate = start
+		s.update(elapsed.Seconds())
+	}
+	return s.rate * duration.Seconds()
+}
+
+// update adjusts the rate based on the latest service level observed for the network.
+// If no observation is available at all, the rate is retained. If there is no new
+// observation since the last update, the accumulated error decays.
+func (s *adaptiveShaper) update(dt float64) {
+	measured, found := s.loadInfo.GetServiceLevel(s.config.Indicator)
+	if !found {
+		return
+	}
+	if s.observed && measured.Position <= s.position {
+		s.decay(dt)
+		return
+	}
+	s.position, s.observed = measured.Position, true
+	err := (s.config.Target - measured.Value) / s.config.Target
+
+	derivative := 0.0
+	if s.hasError {
+		derivative = (err - s.lastError) / dt
+	}
+	s.lastError, s.hasError = err, true
+
+	// Integrate the error only while the output is not saturated in the direction
+	// of the error to prevent the integral from winding up.
+	integral := s.integral + err*dt
+	rate := s.output(err, integral, derivative)
+	if (rate < s.config.Max || err < 0) && (rate > 0 || err > 0) {
+		s.integral = integral
+	} else {
+		rate = s.output(err, s.integral, derivative)
+	}
+	s.rate = math.Max(0, math.Min(s.config.Max, rate))
+
+	s.checkConvergence(err)
+}
+
+// decay reduces the accumulated error while no new observations are available and
+// moves the rate accordingly towards its initial value.
+func (s *adaptiveShaper) decay(dt float64) {
+	s.integral *= math.Pow(0.5, dt/staleIntegralHalfLife.Seconds())
+	s.hasError = false // < the derivative is undefined across the gap
+	s.rate = math.Max(0, math.Min(s.config.Max, s.output(0, s.integral, 0)))
+}
+
+func (s *adaptiveShaper) output(err, integral, derivative float64) float64 {
+	return s.config.Initial + s.config.Kp*err + s.config.Ki*integral + s.config.Kd*derivative
+}
+
+// checkConvergence records the current rate if the given error is within the
+// convergence tolerance and updates the sustainable rate once enough consecutive
+// updates met the tolerance.
+func (s *adaptiveShaper) checkConvergence(err float64) {
+	if math.Abs(err) >= convergenceTolerance {
+		s.window = s.window[:0]
+		return
+	}
+	if len(s.window) == convergenceWindow {
+		s.window = append(s.window[:0], s.window[1:]...)
+	}
+	s.window = append(s.window, s.rate)
+	if len(s.window) < convergenceWindow {
+		return
+	}
+
+	sum := 0.0
+	for _, rate := range s.window {
+		sum += rate
+	}
+	mean := sum / float64(len(s.window))
+
+	s.sustainableMutex.Lock()
+	defer s.sustainableMutex.Unlock()
+	if !s.converged {
+		log.Printf("adaptiveShaper: converged to a sustainable rate of %.1f Tx/s for %s <= %v", mean, s.config.Indicator, s.config.Target)
+	}
+	s.sustainable, s.converged = mean, true
+}
+
+// GetSustainableRate returns the mean rate of the latest period in which the
+// controlled service level stayed close to its target.
+func (s *adaptiveShaper) GetSustainableRate() (float64, bool) {
+	s.sustainableMutex.Lock()
+	defer s.sustainableMutex.Unlock()
+	return s.sustainable, s.converged
+}
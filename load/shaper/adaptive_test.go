@@ -0,0 +1,180 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package shaper
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver"
+	"github.com/Fantom-foundation/Norma/driver/parser"
+	"github.com/golang/mock/gomock"
+)
+
+func TestAdaptiveShaper_RateIsRetainedWithoutMeasurements(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	info := NewMockLoadInfoSource(ctrl)
+	info.EXPECT().GetServiceLevel(driver.TransactionLatencyP95).AnyTimes().Return(driver.ServiceLevel{}, false)
+
+	shaper := NewAdaptiveShaper(AdaptiveConfig{Indicator: driver.TransactionLatencyP95, Target: 200, Initial: 10, Max: math.Inf(1), Kp: 100, Ki: 20})
+	start := time.Now()
+	shaper.Start(start, info)
+
+	for i := 0; i < 10; i++ {
+		start = start.Add(time.Second)
+		if got, want := shaper.GetNumMessagesInInterval(start, time.Second), 10.0; got != want {
+			t.Errorf("invalid number of messages in step %d, wanted %f, got %f", i, want, got)
+		}
+	}
+	if _, found := shaper.(ThroughputEstimator).GetSustainableRate(); found {
+		t.Errorf("no sustainable rate should be reported without measurements")
+	}
+}
+
+func TestAdaptiveShaper_ConvergesToSustainableRate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	info := NewMockLoadInfoSource(ctrl)
+
+	// the simulated network has a latency of 100 ms growing by 100 ms for every
+	// 500 Tx/s, thus a rate of 500 Tx/s meets the targeted latency of 200 ms
+	shaper := NewAdaptiveShaper(AdaptiveConfig{Indicator: driver.TransactionLatencyP95, Target: 200, Initial: 10, Max: math.Inf(1), Kp: 100, Ki: 20})
+	block := uint64(0)
+	info.EXPECT().GetServiceLevel(driver.TransactionLatencyP95).AnyTimes().DoAndReturn(func(driver.ServiceLevelIndicator) (driver.ServiceLevel, bool) {
+		block++
+		return driver.ServiceLevel{Value: 100 + 100*shaper.(*adaptiveShaper).rate/500, Position: block}, true
+	})
+
+	start := time.Now()
+	shaper.Start(start, info)
+	for i := 0; i < 300; i++ {
+		start = start.Add(time.Second)
+		shaper.GetNumMessagesInInterval(start, time.Second)
+	}
+
+	rate, found := shaper.(ThroughputEstimator).GetSustainableRate()
+	if !found {
+		t.Fatalf("adaptive shaper failed to converge")
+	}
+	if math.Abs(rate-500) > 50 {
+		t.Errorf("unexpected sustainable rate, wanted 500 Tx/s, got %f", rate)
+	}
+}
+
+func TestAdaptiveShaper_RateIsLimitedWithoutWindingUp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	info := NewMockLoadInfoSource(ctrl)
+
+	overloaded := false
+	tick := uint64(0)
+	info.EXPECT().GetServiceLevel(driver.TxPoolPending).AnyTimes().DoAndReturn(func(driver.ServiceLevelIndicator) (driver.ServiceLevel, bool) {
+		tick++
+		if overloaded {
+			return driver.ServiceLevel{Value: 10000, Position: tick}, true
+		}
+		return driver.ServiceLevel{Value: 0, Position: tick}, true
+	})
+
+	shaper := NewAdaptiveShaper(AdaptiveConfig{Indicator: driver.TxPoolPending, Target: 1000, Initial: 10, Max: 50, Kp: 100, Ki: 20})
+	start := time.Now()
+	shaper.Start(start, info)
+
+	for i := 0; i < 100; i++ {
+		start = start.Add(time.Second)
+		if got, want := shaper.GetNumMessagesInInterval(start, time.Second), 50.0; got != want {
+			t.Fatalf("invalid number of messages in step %d, wanted %f, got %f", i, want, got)
+		}
+	}
+
+	// without an accumulated integral, the rate drops immediately on overload
+	overloaded = true
+	start = start.Add(time.Second)
+	if got, want := shaper.GetNumMessagesInInterval(start, time.Second), 0.0; got != want {
+		t.Errorf("invalid number of messages on overload, wanted %f, got %f", want, got)
+	}
+}
+
+func TestAdaptiveShaper_RecoversFromZeroRateWithoutNewObservations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	info := NewMockLoadInfoSource(ctrl)
+
+	// the latency is only observed for blocks including transactions, thus no new
+	// observations are made once the rate dropped to zero
+	info.EXPECT().GetServiceLevel(driver.TransactionLatencyP95).AnyTimes().Return(driver.ServiceLevel{Value: 10000, Position: 1}, true)
+
+	shaper := NewAdaptiveShaper(AdaptiveConfig{Indicator: driver.TransactionLatencyP95, Target: 200, Initial: 10, Max: math.Inf(1), Kp: 100, Ki: 20})
+	start := time.Now()
+	shaper.Start(start, info)
+
+	start = start.Add(time.Second)
+	if got := shaper.GetNumMessagesInInterval(start, time.Second); got != 0 {
+		t.Fatalf("rate should drop to zero on overload, got %f", got)
+	}
+
+	// the overload accumulated an error, which decays while no new observations arrive
+	shaper.(*adaptiveShaper).integral = -5
+	for i := 0; i < 5; i++ {
+		start = start.Add(time.Second)
+		if got := shaper.GetNumMessagesInInterval(start, time.Second); got != 0 {
+			t.Fatalf("rate should only recover gradually, got %f in step %d", got, i)
+		}
+	}
+	for i := 0; i < 100; i++ {
+		start = start.Add(time.Second)
+		shaper.GetNumMessagesInInterval(start, time.Second)
+	}
+	if got, want := shaper.(*adaptiveShaper).rate, 10.0; math.Abs(got-want) > 0.5 {
+		t.Errorf("rate did not recover, wanted %f, got %f", want, got)
+	}
+}
+
+func TestAdaptiveShaper_RepeatedObservationsAreNotIntegrated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	info := NewMockLoadInfoSource(ctrl)
+
+	// a single observation with plenty of headroom is reported over and over again
+	info.EXPECT().GetServiceLevel(driver.TransactionLatencyP95).AnyTimes().Return(driver.ServiceLevel{Value: 100, Position: 1}, true)
+
+	shaper := NewAdaptiveShaper(AdaptiveConfig{Indicator: driver.TransactionLatencyP95, Target: 200, Initial: 10, Max: math.Inf(1), Kp: 100, Ki: 20})
+	start := time.Now()
+	shaper.Start(start, info)
+
+	start = start.Add(time.Second)
+	first := shaper.GetNumMessagesInInterval(start, time.Second)
+	for i := 0; i < 10; i++ {
+		start = start.Add(time.Second)
+		if got := shaper.GetNumMessagesInInterval(start, time.Second); got > first {
+			t.Fatalf("rate should not grow without new observations, got %f after %f", got, first)
+		}
+	}
+}
+
+func TestParseRate_BuildsAdaptiveShaperWithDefaults(t *testing.T) {
+	rate := parser.Rate{Adaptive: &parser.Adaptive{Indicator: parser.LatencyP95Indicator, Target: 500}}
+	shaper, err := ParseRate(&rate)
+	if err != nil {
+		t.Fatalf("failed to parse rate: %v", err)
+	}
+	adaptive, ok := shaper.(*adaptiveShaper)
+	if !ok {
+		t.Fatalf("unexpected shaper type %T", shaper)
+	}
+	want := AdaptiveConfig{Indicator: driver.TransactionLatencyP95, Target: 500, Initial: 10, Max: math.Inf(1), Kp: 100, Ki: 20, Kd: 0}
+	if got := adaptive.config; got != want {
+		t.Errorf("unexpected configuration, wanted %v, got %v", want, got)
+	}
+}
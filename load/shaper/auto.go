@@ -23,7 +23,9 @@ import (
 
 // autoShaper implements an additive-increase/multiplicative-decrease load control
 // algorithm using the gap between submitted and received transactions of an application
-// as an overload signal.
+// as well as transactions rejected by the network due to a full or saturated transaction
+// pool as overload signals. Other rejections, e.g. of already known transactions or due
+// to connection problems, are no overload signals.
 //
 // See: https://en.wikipedia.org/wiki/Additive_increase/multiplicative_decrease
 type autoShaper struct {
@@ -31,6 +33,8 @@ type autoShaper struct {
 	decrease          float64 // the multiplicative decrease in a overload case
 	rate              float64 // < the current rate
 	lastOverflowCheck time.Time
+	lastRejected      uint64 // < the number of rejected transactions at the last check
+	lastOverloaded    uint64 // < the number of transactions rejected due to an overload at the last check
 	loadInfo          LoadInfoSource
 }
 
@@ -50,8 +54,10 @@ func (s *autoShaper) GetNumMessagesInInterval(start time.Time, duration time.Dur
 
 	// The goal of this shaper is to maximize throughput without creating an overload scenario.
 	// To detect overloads, the gap between the submitted and received transactions is tracked.
-	// If the gap becomes > twice the current rate per second, the transaction rate is reduced
-	// by the configurable `decrease` factor. Otherwise, the transaction rate is increased a
+	// Rejected transactions will never be received, thus they are not counted in the gap.
+	// If the gap becomes > twice the current rate per second, or new transactions have been
+	// rejected by the network due to an overload, the transaction rate is reduced by the
+	// configurable `decrease` factor. Otherwise, the transaction rate is increased a
 	// configurable `increase` constant.
 
 	// Periodically adjust the transfer rate.
@@ -59,9 +65,14 @@ func (s *autoShaper) GetNumMessagesInInterval(start time.Time, duration time.Dur
 		s.lastOverflowCheck = start
 
 		// Fetch the latest refresh rates.
-		gap := getProcessingGap(s.loadInfo)
+		rejected := getRejected(s.loadInfo, s.lastRejected)
+		overloaded := getOverloadRejections(s.loadInfo, s.lastOverloaded)
+		gap := getProcessingGap(s.loadInfo, rejected)
+		newRejections := overloaded > s.lastOverloaded
+		s.lastRejected = rejected
+		s.lastOverloaded = overloaded
 
-		if float64(gap) > 2*s.rate {
+		if float64(gap) > 2*s.rate || newRejections {
 			s.rate *= 1 - s.decrease
 		} else {
 			s.rate += s.increase
@@ -72,7 +83,7 @@ func (s *autoShaper) GetNumMessagesInInterval(start time.Time, duration time.Dur
 	return s.rate * duration.Seconds()
 }
 
-func getProcessingGap(info LoadInfoSource) uint64 {
+func getProcessingGap(info LoadInfoSource, rejected uint64) uint64 {
 	sent, err := info.GetSentTransactions()
 	if err != nil {
 		log.Printf("autoShaper: failed to fetch number of sent transactions: %v", err)
@@ -83,5 +94,30 @@ func getProcessingGap(info LoadInfoSource) uint64 {
 		log.Printf("autoShaper: failed to fetch number of received transactions: %v", err)
 		return 0
 	}
-	return sent - received
+	if sent < received+rejected {
+		return 0
+	}
+	return sent - received - rejected
+}
+
+// getRejected fetches the number of rejected transactions, falling back to
+// the given previous value if it is not available.
+func getRejected(info LoadInfoSource, previous uint64) uint64 {
+	rejected, err := info.GetRejectedTransactions()
+	if err != nil {
+		log.Printf("autoShaper: failed to fetch number of rejected transactions: %v", err)
+		return previous
+	}
+	return rejected
+}
+
+// getOverloadRejections fetches the number of transactions rejected due to an
+// overload, falling back to the given previous value if it is not available.
+func getOverloadRejections(info LoadInfoSource, previous uint64) uint64 {
+	rejected, err := info.GetOverloadRejections()
+	if err != nil {
+		log.Printf("autoShaper: failed to fetch number of overload rejections: %v", err)
+		return previous
+	}
+	return rejected
 }
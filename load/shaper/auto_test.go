@@ -30,6 +30,8 @@ func TestAutoShaper_GrowsAdditive(t *testing.T) {
 
 	info.EXPECT().GetSentTransactions().AnyTimes().Return(uint64(120), nil)
 	info.EXPECT().GetReceivedTransactions().AnyTimes().Return(uint64(120), nil)
+	info.EXPECT().GetRejectedTransactions().AnyTimes().Return(uint64(0), nil)
+	info.EXPECT().GetOverloadRejections().AnyTimes().Return(uint64(0), nil)
 
 	shaper := NewAutoShaper(10, 0.2)
 
@@ -51,6 +53,8 @@ func TestAutoShaper_ShrinksMultiplicative(t *testing.T) {
 
 	info.EXPECT().GetSentTransactions().AnyTimes().Return(uint64(100000), nil)
 	info.EXPECT().GetReceivedTransactions().AnyTimes().Return(uint64(0), nil)
+	info.EXPECT().GetRejectedTransactions().AnyTimes().Return(uint64(0), nil)
+	info.EXPECT().GetOverloadRejections().AnyTimes().Return(uint64(0), nil)
 
 	rate := 1000.0
 	shaper := NewAutoShaper(10, 0.2)
@@ -68,3 +72,64 @@ func TestAutoShaper_ShrinksMultiplicative(t *testing.T) {
 		start = start.Add(time.Second)
 	}
 }
+
+func TestAutoShaper_ShrinksOnRejections(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	info := NewMockLoadInfoSource(ctrl)
+
+	// the gap is fully explained by rejected transactions
+	rejected := uint64(0)
+	info.EXPECT().GetSentTransactions().AnyTimes().DoAndReturn(func() (uint64, error) { return 100 + rejected, nil })
+	info.EXPECT().GetReceivedTransactions().AnyTimes().Return(uint64(100), nil)
+	info.EXPECT().GetRejectedTransactions().AnyTimes().DoAndReturn(func() (uint64, error) { return rejected, nil })
+	info.EXPECT().GetOverloadRejections().AnyTimes().DoAndReturn(func() (uint64, error) { return rejected, nil })
+
+	rate := 1000.0
+	shaper := NewAutoShaper(10, 0.2)
+	shaper.(*autoShaper).rate = rate
+
+	start := time.Now()
+	shaper.Start(start, info)
+
+	for i := 0; i < 10; i++ {
+		// new rejections are reported in every second interval only
+		if i%2 == 1 {
+			rejected += 50
+			rate *= 0.8
+		} else {
+			rate += 10
+		}
+		start = start.Add(time.Second)
+		if got, want := shaper.GetNumMessagesInInterval(start, time.Second), rate; math.Abs(got-want) > 1e-6 {
+			t.Errorf("invalid number of messages in step %d, wanted %f, got %f", i, want, got)
+		}
+	}
+}
+
+func TestAutoShaper_IgnoresRejectionsNotCausedByOverload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	info := NewMockLoadInfoSource(ctrl)
+
+	// transactions are rejected, e.g. as already known, but none due to an overload
+	rejected := uint64(0)
+	info.EXPECT().GetSentTransactions().AnyTimes().DoAndReturn(func() (uint64, error) { return 100 + rejected, nil })
+	info.EXPECT().GetReceivedTransactions().AnyTimes().Return(uint64(100), nil)
+	info.EXPECT().GetRejectedTransactions().AnyTimes().DoAndReturn(func() (uint64, error) { return rejected, nil })
+	info.EXPECT().GetOverloadRejections().AnyTimes().Return(uint64(0), nil)
+
+	rate := 1000.0
+	shaper := NewAutoShaper(10, 0.2)
+	shaper.(*autoShaper).rate = rate
+
+	start := time.Now()
+	shaper.Start(start, info)
+
+	for i := 0; i < 10; i++ {
+		rejected += 50
+		rate += 10
+		start = start.Add(time.Second)
+		if got, want := shaper.GetNumMessagesInInterval(start, time.Second), rate; math.Abs(got-want) > 1e-6 {
+			t.Errorf("invalid number of messages in step %d, wanted %f, got %f", i, want, got)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package shaper
+
+import (
+	"math/rand"
+)
+
+// BurstsShaper is used to send txs in bursts of a fixed rate separated by idle
+// periods. The lengths of bursts and idle periods are exponentially distributed.
+type BurstsShaper struct {
+	piecewiseShaper
+}
+
+func NewBurstsShaper(rate, burstLength, idleLength float64, seed int64) *BurstsShaper {
+	random := rand.New(rand.NewSource(seed))
+	inBurst := false
+	res := &BurstsShaper{}
+	res.nextSegment = func() (float64, float64) {
+		inBurst = !inBurst
+		if inBurst {
+			return random.ExpFloat64() * burstLength, rate
+		}
+		return random.ExpFloat64() * idleLength, 0
+	}
+	return res
+}
@@ -0,0 +1,179 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package shaper
+
+import (
+	"math"
+	"time"
+)
+
+// SequencePhase is a single phase of a SequenceShaper.
+type SequencePhase struct {
+	Duration time.Duration // < zero for a phase lasting until the end
+	Shaper   Shaper
+}
+
+// SequenceShaper is used to send txs following a list of shapes, each for a given
+// duration. Each shape is started at the beginning of its phase. After the last
+// phase ended, no more messages are produced.
+type SequenceShaper struct {
+	phases  []SequencePhase
+	started int // < number of phases started so far
+	source  LoadInfoSource
+	// startTimeStamp is the wall-time when the sequence got started.
+	startTimeStamp time.Time
+}
+
+func NewSequenceShaper(phases []SequencePhase) *SequenceShaper {
+	return &SequenceShaper{
+		phases: phases,
+	}
+}
+
+func (s *SequenceShaper) Start(start time.Time, source LoadInfoSource) {
+	s.startTimeStamp = start
+	s.source = source
+	s.started = 0
+}
+
+// GetNumMessagesInInterval provides the number of messages to be produced
+// in the given time interval. Intervals are expected to be queried in order.
+func (s *SequenceShaper) GetNumMessagesInInterval(start time.Time, duration time.Duration) float64 {
+	end := start.Add(duration)
+	count := 0.0
+	phaseStart := s.startTimeStamp
+	for i, phase := range s.phases {
+		phaseEnd := phaseStart.Add(phase.Duration)
+		open := phase.Duration <= 0
+		if !end.After(phaseStart) {
+			break
+		}
+		if open || phaseEnd.After(start) {
+			from, to := start, end
+			if from.Before(phaseStart) {
+				from = phaseStart
+			}
+			if !open && to.After(phaseEnd) {
+				to = phaseEnd
+			}
+			if i >= s.started {
+				phase.Shaper.Start(phaseStart, s.source)
+				s.started = i + 1
+			}
+			count += phase.Shaper.GetNumMessagesInInterval(from, to.Sub(from))
+		}
+		if open {
+			break
+		}
+		phaseStart = phaseEnd
+	}
+	return count
+}
+
+// GetSustainableRate forwards the estimate of the latest phase providing one. Phases
+// not started yet provide no estimate.
+func (s *SequenceShaper) GetSustainableRate() (float64, bool) {
+	for i := len(s.phases) - 1; i >= 0; i-- {
+		if estimator, ok := s.phases[i].Shaper.(ThroughputEstimator); ok {
+			if rate, found := estimator.GetSustainableRate(); found {
+				return rate, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// SumShaper is used to send txs following the sum of multiple shapes.
+type SumShaper struct {
+	shapers []Shaper
+}
+
+func NewSumShaper(shapers []Shaper) *SumShaper {
+	return &SumShaper{
+		shapers: shapers,
+	}
+}
+
+func (s *SumShaper) Start(start time.Time, source LoadInfoSource) {
+	for _, shaper := range s.shapers {
+		shaper.Start(start, source)
+	}
+}
+
+func (s *SumShaper) GetNumMessagesInInterval(start time.Time, duration time.Duration) float64 {
+	count := 0.0
+	for _, shaper := range s.shapers {
+		count += shaper.GetNumMessagesInInterval(start, duration)
+	}
+	return count
+}
+
+// GetSustainableRate sums up the estimates of all shapes providing one, false if
+// none of them does.
+func (s *SumShaper) GetSustainableRate() (float64, bool) {
+	sum, found := 0.0, false
+	for _, shaper := range s.shapers {
+		if estimator, ok := shaper.(ThroughputEstimator); ok {
+			if rate, ok := estimator.GetSustainableRate(); ok {
+				sum += rate
+				found = true
+			}
+		}
+	}
+	return sum, found
+}
+
+// ClampShaper is used to limit the rate of another shape to a given range.
+type ClampShaper struct {
+	shaper       Shaper
+	minFrequency float64
+	maxFrequency float64
+}
+
+// NewClampShaper creates a shaper limiting the rate of the given shaper to the
+// given range. Use math.Inf(1) as the maximum for an unlimited rate.
+func NewClampShaper(shaper Shaper, minFrequency, maxFrequency float64) *ClampShaper {
+	return &ClampShaper{
+		shaper:       shaper,
+		minFrequency: minFrequency,
+		maxFrequency: maxFrequency,
+	}
+}
+
+func (s *ClampShaper) Start(start time.Time, source LoadInfoSource) {
+	s.shaper.Start(start, source)
+}
+
+func (s *ClampShaper) GetNumMessagesInInterval(start time.Time, duration time.Duration) float64 {
+	count := s.shaper.GetNumMessagesInInterval(start, duration)
+	seconds := duration.Seconds()
+	return math.Min(math.Max(count, s.minFrequency*seconds), s.maxFrequency*seconds)
+}
+
+// GetSustainableRate forwards the estimate of the clamped shape, limited to the
+// range of the clamp.
+func (s *ClampShaper) GetSustainableRate() (float64, bool) {
+	estimator, ok := s.shaper.(ThroughputEstimator)
+	if !ok {
+		return 0, false
+	}
+	rate, found := estimator.GetSustainableRate()
+	if !found {
+		return 0, false
+	}
+	return math.Min(math.Max(rate, s.minFrequency), s.maxFrequency), true
+}
@@ -0,0 +1,191 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package shaper
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver/parser"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSequenceShaper(t *testing.T) {
+	tests := map[string]struct {
+		phases   []SequencePhase
+		from     time.Duration
+		to       time.Duration
+		expected float64
+	}{
+		"within first phase": {
+			[]SequencePhase{{10 * time.Second, NewConstantShaper(10)}, {0, NewConstantShaper(20)}},
+			0, 5 * time.Second, 50,
+		},
+		"spanning two phases": {
+			[]SequencePhase{{10 * time.Second, NewConstantShaper(10)}, {0, NewConstantShaper(20)}},
+			5 * time.Second, 15 * time.Second, 150,
+		},
+		"open last phase": {
+			[]SequencePhase{{10 * time.Second, NewConstantShaper(10)}, {0, NewConstantShaper(20)}},
+			100 * time.Second, 110 * time.Second, 200,
+		},
+		"after the last phase": {
+			[]SequencePhase{{10 * time.Second, NewConstantShaper(10)}},
+			5 * time.Second, 15 * time.Second, 50,
+		},
+		"phases start with their own time": {
+			[]SequencePhase{{5 * time.Second, NewConstantShaper(0)}, {0, NewSlopeShaper(0, 2)}},
+			5 * time.Second, 10 * time.Second, 25,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			shaper := NewSequenceShaper(test.phases)
+			start := time.Now()
+			shaper.Start(start, nil)
+			got := shaper.GetNumMessagesInInterval(start.Add(test.from), test.to-test.from)
+			if math.Abs(got-test.expected) > 1e-6 {
+				t.Errorf("unexpected number of messages, wanted %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestSumShaper(t *testing.T) {
+	shaper := NewSumShaper([]Shaper{NewConstantShaper(10), NewSlopeShaper(0, 2)})
+	start := time.Now()
+	shaper.Start(start, nil)
+	if got, want := shaper.GetNumMessagesInInterval(start, 10*time.Second), 200.0; math.Abs(got-want) > 1e-6 {
+		t.Errorf("unexpected number of messages, wanted %v, got %v", want, got)
+	}
+}
+
+func TestClampShaper(t *testing.T) {
+	shaper := NewClampShaper(NewSlopeShaper(0, 10), 5, 20)
+	start := time.Now()
+	shaper.Start(start, nil)
+
+	tests := []struct {
+		from     time.Duration
+		expected float64
+	}{
+		{0, 5},                  // below the minimum rate
+		{time.Second, 15},       // within the range
+		{10 * time.Second, 20},  // above the maximum rate
+		{100 * time.Second, 20}, // far above the maximum rate
+	}
+	for _, test := range tests {
+		got := shaper.GetNumMessagesInInterval(start.Add(test.from), time.Second)
+		if math.Abs(got-test.expected) > 1e-6 {
+			t.Errorf("unexpected number of messages at %v, wanted %v, got %v", test.from, test.expected, got)
+		}
+	}
+}
+
+func TestParseRate_BuildsCompositeShapers(t *testing.T) {
+	spec := `
+sequence:
+  - duration: 60
+    rate:
+      constant: 100
+  - duration: 60
+    rate:
+      clamp:
+        max: 1000
+        rate:
+          slope:
+            start: 100
+            increment: 20
+  - rate:
+      sum:
+        - constant: 500
+        - wave:
+            max: 100
+            period: 10
+`
+	var rate parser.Rate
+	if err := yaml.Unmarshal([]byte(spec), &rate); err != nil {
+		t.Fatalf("failed to parse rate: %v", err)
+	}
+	if err := rate.Check(&parser.Scenario{}); err != nil {
+		t.Fatalf("invalid rate: %v", err)
+	}
+	shaper, err := ParseRate(&rate)
+	if err != nil {
+		t.Fatalf("failed to create shaper: %v", err)
+	}
+
+	start := time.Now()
+	shaper.Start(start, nil)
+	tests := []struct {
+		from     time.Duration
+		expected float64
+	}{
+		{0, 1000},                  // constant 100 Tx/s
+		{60 * time.Second, 2000},   // slope from 100 to 300 Tx/s
+		{105 * time.Second, 10000}, // slope clamped to 1000 Tx/s
+		{120 * time.Second, 5500},  // sum of constant and a full wave
+	}
+	for _, test := range tests {
+		got := shaper.GetNumMessagesInInterval(start.Add(test.from), 10*time.Second)
+		if math.Abs(got-test.expected) > 1e-3 {
+			t.Errorf("unexpected number of messages at %v, wanted %v, got %v", test.from, test.expected, got)
+		}
+	}
+}
+
+func TestCompositeShapers_ForwardSustainableRate(t *testing.T) {
+	converged := func(rate float64) Shaper {
+		return &adaptiveShaper{sustainable: rate, converged: true}
+	}
+	tests := map[string]struct {
+		shaper Shaper
+		rate   float64
+		found  bool
+	}{
+		"sequence without estimate": {
+			NewSequenceShaper([]SequencePhase{{10 * time.Second, NewConstantShaper(10)}}), 0, false,
+		},
+		"sequence with estimate of latest phase": {
+			NewSequenceShaper([]SequencePhase{{10 * time.Second, converged(100)}, {10 * time.Second, converged(200)}, {0, &adaptiveShaper{}}}), 200, true,
+		},
+		"sum of estimates": {
+			NewSumShaper([]Shaper{converged(100), NewConstantShaper(10), converged(50)}), 150, true,
+		},
+		"sum without estimate": {
+			NewSumShaper([]Shaper{NewConstantShaper(10)}), 0, false,
+		},
+		"clamped estimate": {
+			NewClampShaper(converged(500), 0, 300), 300, true,
+		},
+		"nested estimate": {
+			NewClampShaper(NewSumShaper([]Shaper{converged(100)}), 0, math.Inf(1)), 100, true,
+		},
+	}
+	for name, test := range tests {
+		estimator, ok := test.shaper.(ThroughputEstimator)
+		if !ok {
+			t.Fatalf("%s: shaper is no throughput estimator", name)
+		}
+		rate, found := estimator.GetSustainableRate()
+		if rate != test.rate || found != test.found {
+			t.Errorf("%s: unexpected sustainable rate, wanted %f (%t), got %f (%t)", name, test.rate, test.found, rate, found)
+		}
+	}
+}
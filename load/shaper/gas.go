@@ -0,0 +1,55 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package shaper
+
+import (
+	"time"
+)
+
+// fallbackGasPerTransaction is the gas per transaction assumed as long as no
+// estimate is available, which is the minimum gas any transaction uses.
+const fallbackGasPerTransaction = 21_000
+
+// GasRateShaper converts a traffic shape expressed in gas/s into Tx/s using the
+// gas per transaction estimate provided by the load info source.
+type GasRateShaper struct {
+	shape  Shaper
+	source LoadInfoSource
+}
+
+func NewGasRateShaper(shape Shaper) *GasRateShaper {
+	return &GasRateShaper{
+		shape: shape,
+	}
+}
+
+func (s *GasRateShaper) Start(start time.Time, source LoadInfoSource) {
+	s.source = source
+	s.shape.Start(start, source)
+}
+
+func (s *GasRateShaper) GetNumMessagesInInterval(start time.Time, duration time.Duration) float64 {
+	gas := s.shape.GetNumMessagesInInterval(start, duration)
+	gasPerTx := 0.0
+	if s.source != nil {
+		gasPerTx, _ = s.source.GetGasPerTransaction()
+	}
+	if gasPerTx <= 0 {
+		gasPerTx = fallbackGasPerTransaction
+	}
+	return gas / gasPerTx
+}
@@ -0,0 +1,58 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package shaper
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestGasRateShaper_ConvertsGasToTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	source := NewMockLoadInfoSource(ctrl)
+	source.EXPECT().GetGasPerTransaction().Return(50_000.0, nil)
+
+	shaper := NewGasRateShaper(NewConstantShaper(1_000_000))
+	now := time.Now()
+	shaper.Start(now, source)
+
+	if got, want := shaper.GetNumMessagesInInterval(now, time.Second), 20.0; got != want {
+		t.Errorf("unexpected number of messages, wanted %v, got %v", want, got)
+	}
+}
+
+func TestGasRateShaper_UsesFallbackWithoutEstimate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	source := NewMockLoadInfoSource(ctrl)
+	gomock.InOrder(
+		source.EXPECT().GetGasPerTransaction().Return(0.0, nil),
+		source.EXPECT().GetGasPerTransaction().Return(0.0, fmt.Errorf("injected error")),
+	)
+
+	shaper := NewGasRateShaper(NewConstantShaper(2 * fallbackGasPerTransaction))
+	now := time.Now()
+	shaper.Start(now, source)
+
+	for i := 0; i < 2; i++ {
+		if got, want := shaper.GetNumMessagesInInterval(now, time.Second), 2.0; got != want {
+			t.Errorf("unexpected number of messages, wanted %v, got %v", want, got)
+		}
+	}
+}
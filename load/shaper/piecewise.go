@@ -0,0 +1,59 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package shaper
+
+import (
+	"time"
+)
+
+// piecewiseShaper is used to send txs at rates that are constant within
+// consecutive segments of time. Segments are produced lazily by a segment
+// source, thus intervals are expected to be queried in order.
+type piecewiseShaper struct {
+	// nextSegment provides the length in seconds and the rate of the next segment.
+	nextSegment func() (length float64, rate float64)
+	// segmentEnd is the end of the current segment relative to the start.
+	segmentEnd float64
+	rate       float64
+	// startTimeStamp is the wall-time when the shaper got started.
+	startTimeStamp time.Time
+}
+
+func (s *piecewiseShaper) Start(start time.Time, _ LoadInfoSource) {
+	s.startTimeStamp = start
+	s.segmentEnd, s.rate = s.nextSegment()
+}
+
+func (s *piecewiseShaper) GetNumMessagesInInterval(start time.Time, duration time.Duration) float64 {
+	x := start.Sub(s.startTimeStamp).Seconds()
+	y := x + duration.Seconds()
+	count := 0.0
+	for x < y {
+		for s.segmentEnd <= x {
+			length, rate := s.nextSegment()
+			s.segmentEnd += length
+			s.rate = rate
+		}
+		end := y
+		if s.segmentEnd < end {
+			end = s.segmentEnd
+		}
+		count += s.rate * (end - x)
+		x = end
+	}
+	return count
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package shaper
+
+import (
+	"math/rand"
+	"time"
+)
+
+// PoissonShaper is used to send txs following a Poisson process, i.e. with
+// exponentially distributed times between consecutive transactions.
+type PoissonShaper struct {
+	mean   float64
+	random *rand.Rand
+	// nextArrival is the time of the next transaction relative to the start.
+	nextArrival float64
+	// startTimeStamp is the wall-time when the shaper got started.
+	startTimeStamp time.Time
+}
+
+func NewPoissonShaper(mean float64, seed int64) *PoissonShaper {
+	return &PoissonShaper{
+		mean:   mean,
+		random: rand.New(rand.NewSource(seed)),
+	}
+}
+
+func (s *PoissonShaper) Start(start time.Time, _ LoadInfoSource) {
+	s.startTimeStamp = start
+	s.nextArrival = s.getInterArrivalTime()
+}
+
+// GetNumMessagesInInterval provides the number of transactions arriving in the
+// given time interval. Intervals are expected to be queried in order.
+func (s *PoissonShaper) GetNumMessagesInInterval(start time.Time, duration time.Duration) float64 {
+	if s.mean <= 0 {
+		return 0
+	}
+	end := start.Add(duration).Sub(s.startTimeStamp).Seconds()
+	count := 0
+	for s.nextArrival < end {
+		count++
+		s.nextArrival += s.getInterArrivalTime()
+	}
+	return float64(count)
+}
+
+func (s *PoissonShaper) getInterArrivalTime() float64 {
+	if s.mean <= 0 {
+		return 0
+	}
+	return s.random.ExpFloat64() / s.mean
+}
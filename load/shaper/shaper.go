@@ -18,8 +18,11 @@ package shaper
 
 import (
 	"fmt"
+	"log"
+	"math"
 	"time"
 
+	"github.com/Fantom-foundation/Norma/driver"
 	"github.com/Fantom-foundation/Norma/driver/parser"
 )
 
@@ -41,10 +44,43 @@ type Shaper interface {
 type LoadInfoSource interface {
 	GetSentTransactions() (uint64, error)
 	GetReceivedTransactions() (uint64, error)
+	// GetRejectedTransactions obtains the number of sent transactions the
+	// network refused to accept.
+	GetRejectedTransactions() (uint64, error)
+	// GetOverloadRejections obtains the number of sent transactions the
+	// network refused to accept since its transaction pool was full or
+	// saturated, i.e. the subset of rejections indicating an overload.
+	GetOverloadRejections() (uint64, error)
+	// GetGasPerTransaction obtains an estimate of the gas used by a single
+	// transaction, 0 if no estimate is available yet.
+	GetGasPerTransaction() (float64, error)
+	// GetServiceLevel obtains the latest value of the given service level
+	// indicator of the network, false if no value is available.
+	GetServiceLevel(indicator driver.ServiceLevelIndicator) (driver.ServiceLevel, bool)
+}
+
+// ThroughputEstimator is implemented by shapers deriving an estimate of the
+// throughput the network is able to sustain from the observed load state.
+type ThroughputEstimator interface {
+	// GetSustainableRate returns the estimated sustainable rate in Tx/s, false
+	// if no estimate is available yet.
+	GetSustainableRate() (float64, bool)
 }
 
 // ParseRate parses rate from the parser.
 func ParseRate(rate *parser.Rate) (Shaper, error) {
+	shaper, err := parseShape(rate)
+	if err != nil {
+		return nil, err
+	}
+	if rate != nil && rate.Unit == parser.GasUnit {
+		return NewGasRateShaper(shaper), nil
+	}
+	return shaper, nil
+}
+
+// parseShape parses the shape of the given rate, ignoring its unit.
+func parseShape(rate *parser.Rate) (Shaper, error) {
 	// return default constant shaper if rate is not specified
 	if rate == nil {
 		return NewConstantShaper(0), nil
@@ -74,6 +110,81 @@ func ParseRate(rate *parser.Rate) (Shaper, error) {
 		}
 		return NewWaveShaper(min, rate.Wave.Max, rate.Wave.Period), nil
 	}
+	if rate.Trace != nil {
+		points, err := parser.ReadTrace(rate.Trace.File)
+		if err != nil {
+			return nil, err
+		}
+		scale := 1.0
+		if rate.Trace.Scale != nil {
+			scale = float64(*rate.Trace.Scale)
+		}
+		return NewTraceShaper(points, rate.Trace.Loop, scale), nil
+	}
+	if rate.Poisson != nil {
+		return NewPoissonShaper(float64(rate.Poisson.Mean), getSeed("poisson", rate.Poisson.Seed)), nil
+	}
+	if rate.Bursts != nil {
+		return NewBurstsShaper(float64(rate.Bursts.Rate), float64(rate.Bursts.Length), float64(rate.Bursts.Idle), getSeed("bursts", rate.Bursts.Seed)), nil
+	}
+	if rate.Steps != nil {
+		return NewStepsShaper(float64(rate.Steps.Min), float64(rate.Steps.Max), float64(rate.Steps.Interval), getSeed("steps", rate.Steps.Seed)), nil
+	}
+	if rate.Sequence != nil {
+		phases := make([]SequencePhase, 0, len(rate.Sequence))
+		for i := range rate.Sequence {
+			shaper, err := parseShape(&rate.Sequence[i].Rate)
+			if err != nil {
+				return nil, err
+			}
+			duration := time.Duration(0)
+			if rate.Sequence[i].Duration != nil {
+				duration = time.Duration(float64(*rate.Sequence[i].Duration) * float64(time.Second))
+			}
+			phases = append(phases, SequencePhase{Duration: duration, Shaper: shaper})
+		}
+		return NewSequenceShaper(phases), nil
+	}
+	if rate.Sum != nil {
+		shapers := make([]Shaper, 0, len(rate.Sum))
+		for i := range rate.Sum {
+			shaper, err := parseShape(&rate.Sum[i])
+			if err != nil {
+				return nil, err
+			}
+			shapers = append(shapers, shaper)
+		}
+		return NewSumShaper(shapers), nil
+	}
+	if rate.Clamp != nil {
+		shaper, err := parseShape(&rate.Clamp.Rate)
+		if err != nil {
+			return nil, err
+		}
+		min, max := 0.0, math.Inf(1)
+		if rate.Clamp.Min != nil {
+			min = float64(*rate.Clamp.Min)
+		}
+		if rate.Clamp.Max != nil {
+			max = float64(*rate.Clamp.Max)
+		}
+		return NewClampShaper(shaper, min, max), nil
+	}
+	if rate.Adaptive != nil {
+		return NewAdaptiveShaper(parseAdaptiveConfig(rate.Adaptive)), nil
+	}
 
 	return nil, fmt.Errorf("unknown rate type")
 }
+
+// getSeed returns the configured seed of the random source of a stochastic shaper of
+// the given kind. If no seed is configured, a time-based seed is chosen and logged,
+// such that the produced load is random but can be reproduced.
+func getSeed(kind string, seed *int64) int64 {
+	if seed != nil {
+		return *seed
+	}
+	res := time.Now().UnixNano()
+	log.Printf("no seed configured for %s rate, using seed %d", kind, res)
+	return res
+}
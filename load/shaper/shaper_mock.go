@@ -24,6 +24,7 @@ import (
 	reflect "reflect"
 	time "time"
 
+	driver "github.com/Fantom-foundation/Norma/driver"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -99,6 +100,36 @@ func (m *MockLoadInfoSource) EXPECT() *MockLoadInfoSourceMockRecorder {
 	return m.recorder
 }
 
+// GetGasPerTransaction mocks base method.
+func (m *MockLoadInfoSource) GetGasPerTransaction() (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGasPerTransaction")
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGasPerTransaction indicates an expected call of GetGasPerTransaction.
+func (mr *MockLoadInfoSourceMockRecorder) GetGasPerTransaction() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGasPerTransaction", reflect.TypeOf((*MockLoadInfoSource)(nil).GetGasPerTransaction))
+}
+
+// GetOverloadRejections mocks base method.
+func (m *MockLoadInfoSource) GetOverloadRejections() (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverloadRejections")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverloadRejections indicates an expected call of GetOverloadRejections.
+func (mr *MockLoadInfoSourceMockRecorder) GetOverloadRejections() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverloadRejections", reflect.TypeOf((*MockLoadInfoSource)(nil).GetOverloadRejections))
+}
+
 // GetReceivedTransactions mocks base method.
 func (m *MockLoadInfoSource) GetReceivedTransactions() (uint64, error) {
 	m.ctrl.T.Helper()
@@ -114,6 +145,21 @@ func (mr *MockLoadInfoSourceMockRecorder) GetReceivedTransactions() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReceivedTransactions", reflect.TypeOf((*MockLoadInfoSource)(nil).GetReceivedTransactions))
 }
 
+// GetRejectedTransactions mocks base method.
+func (m *MockLoadInfoSource) GetRejectedTransactions() (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRejectedTransactions")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRejectedTransactions indicates an expected call of GetRejectedTransactions.
+func (mr *MockLoadInfoSourceMockRecorder) GetRejectedTransactions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRejectedTransactions", reflect.TypeOf((*MockLoadInfoSource)(nil).GetRejectedTransactions))
+}
+
 // GetSentTransactions mocks base method.
 func (m *MockLoadInfoSource) GetSentTransactions() (uint64, error) {
 	m.ctrl.T.Helper()
@@ -128,3 +174,56 @@ func (mr *MockLoadInfoSourceMockRecorder) GetSentTransactions() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSentTransactions", reflect.TypeOf((*MockLoadInfoSource)(nil).GetSentTransactions))
 }
+
+// GetServiceLevel mocks base method.
+func (m *MockLoadInfoSource) GetServiceLevel(indicator driver.ServiceLevelIndicator) (driver.ServiceLevel, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceLevel", indicator)
+	ret0, _ := ret[0].(driver.ServiceLevel)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetServiceLevel indicates an expected call of GetServiceLevel.
+func (mr *MockLoadInfoSourceMockRecorder) GetServiceLevel(indicator interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceLevel", reflect.TypeOf((*MockLoadInfoSource)(nil).GetServiceLevel), indicator)
+}
+
+// MockThroughputEstimator is a mock of ThroughputEstimator interface.
+type MockThroughputEstimator struct {
+	ctrl     *gomock.Controller
+	recorder *MockThroughputEstimatorMockRecorder
+}
+
+// MockThroughputEstimatorMockRecorder is the mock recorder for MockThroughputEstimator.
+type MockThroughputEstimatorMockRecorder struct {
+	mock *MockThroughputEstimator
+}
+
+// NewMockThroughputEstimator creates a new mock instance.
+func NewMockThroughputEstimator(ctrl *gomock.Controller) *MockThroughputEstimator {
+	mock := &MockThroughputEstimator{ctrl: ctrl}
+	mock.recorder = &MockThroughputEstimatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockThroughputEstimator) EXPECT() *MockThroughputEstimatorMockRecorder {
+	return m.recorder
+}
+
+// GetSustainableRate mocks base method.
+func (m *MockThroughputEstimator) GetSustainableRate() (float64, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSustainableRate")
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetSustainableRate indicates an expected call of GetSustainableRate.
+func (mr *MockThroughputEstimatorMockRecorder) GetSustainableRate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSustainableRate", reflect.TypeOf((*MockThroughputEstimator)(nil).GetSustainableRate))
+}
@@ -0,0 +1,36 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package shaper
+
+import (
+	"math/rand"
+)
+
+// StepsShaper is used to send txs at a rate changing in regular intervals to a
+// rate drawn uniformly at random from a given range.
+type StepsShaper struct {
+	piecewiseShaper
+}
+
+func NewStepsShaper(minFrequency, maxFrequency, interval float64, seed int64) *StepsShaper {
+	random := rand.New(rand.NewSource(seed))
+	res := &StepsShaper{}
+	res.nextSegment = func() (float64, float64) {
+		return interval, minFrequency + random.Float64()*(maxFrequency-minFrequency)
+	}
+	return res
+}
@@ -0,0 +1,145 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package shaper
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// sample queries the given shaper in consecutive intervals of the given length.
+func sample(shaper Shaper, interval time.Duration, count int) []float64 {
+	start := time.Now()
+	shaper.Start(start, nil)
+	res := make([]float64, count)
+	for i := range res {
+		res[i] = shaper.GetNumMessagesInInterval(start.Add(time.Duration(i)*interval), interval)
+	}
+	return res
+}
+
+func sum(values []float64) float64 {
+	res := 0.0
+	for _, value := range values {
+		res += value
+	}
+	return res
+}
+
+func TestPoissonShaper_ProducesMeanRate(t *testing.T) {
+	counts := sample(NewPoissonShaper(100, 1), 10*time.Millisecond, 100_000)
+	if got, want := sum(counts)/1000, 100.0; math.Abs(got-want) > 2 {
+		t.Errorf("unexpected mean rate, wanted %v, got %v", want, got)
+	}
+	for _, count := range counts {
+		if count != math.Trunc(count) || count < 0 {
+			t.Fatalf("invalid number of arrivals: %v", count)
+		}
+	}
+}
+
+func TestPoissonShaper_ZeroMeanProducesNoMessages(t *testing.T) {
+	if got := sum(sample(NewPoissonShaper(0, 1), time.Second, 10)); got != 0 {
+		t.Errorf("unexpected number of messages: %v", got)
+	}
+}
+
+func TestBurstsShaper_ProducesMeanRate(t *testing.T) {
+	counts := sample(NewBurstsShaper(100, 1, 3, 1), 100*time.Millisecond, 100_000)
+	if got, want := sum(counts)/10_000, 25.0; math.Abs(got-want) > 2 {
+		t.Errorf("unexpected mean rate, wanted %v, got %v", want, got)
+	}
+	idle := 0
+	for _, count := range counts {
+		if count < 0 || count > 10+1e-9 {
+			t.Fatalf("invalid number of messages: %v", count)
+		}
+		if count == 0 {
+			idle++
+		}
+	}
+	if idle == 0 {
+		t.Errorf("no idle intervals produced")
+	}
+}
+
+func TestBurstsShaper_WithoutIdlePeriodsIsConstant(t *testing.T) {
+	for _, count := range sample(NewBurstsShaper(100, 1, 0, 1), 100*time.Millisecond, 1000) {
+		if math.Abs(count-10) > 1e-6 {
+			t.Fatalf("unexpected number of messages, wanted 10, got %v", count)
+		}
+	}
+}
+
+func TestStepsShaper_RatesAreWithinRange(t *testing.T) {
+	counts := sample(NewStepsShaper(10, 20, 1, 1), time.Second, 1000)
+	distinct := map[float64]bool{}
+	for _, count := range counts {
+		if count < 10 || count > 20 {
+			t.Fatalf("rate out of range: %v", count)
+		}
+		distinct[count] = true
+	}
+	if len(distinct) < 2 {
+		t.Errorf("rate does not change")
+	}
+	if got, want := sum(counts)/1000, 15.0; math.Abs(got-want) > 0.5 {
+		t.Errorf("unexpected mean rate, wanted %v, got %v", want, got)
+	}
+}
+
+func TestStochasticShapers_AreReproducible(t *testing.T) {
+	factories := map[string]func(seed int64) Shaper{
+		"poisson": func(seed int64) Shaper { return NewPoissonShaper(100, seed) },
+		"bursts":  func(seed int64) Shaper { return NewBurstsShaper(100, 1, 1, seed) },
+		"steps":   func(seed int64) Shaper { return NewStepsShaper(10, 100, 1, seed) },
+	}
+	for name, factory := range factories {
+		t.Run(name, func(t *testing.T) {
+			a := sample(factory(1), 100*time.Millisecond, 1000)
+			b := sample(factory(1), 100*time.Millisecond, 1000)
+			c := sample(factory(2), 100*time.Millisecond, 1000)
+			same, different := true, false
+			for i := range a {
+				same = same && math.Abs(a[i]-b[i]) < 1e-9
+				different = different || math.Abs(a[i]-c[i]) > 1e-9
+			}
+			if !same {
+				t.Errorf("shaper with the same seed produced different load")
+			}
+			if !different {
+				t.Errorf("shaper with different seeds produced the same load")
+			}
+		})
+	}
+}
+
+func TestGetSeed_ConfiguredSeedIsUsed(t *testing.T) {
+	seed := int64(42)
+	if got := getSeed("poisson", &seed); got != seed {
+		t.Errorf("unexpected seed, wanted %d, got %d", seed, got)
+	}
+}
+
+func TestGetSeed_UnsetSeedsDiffer(t *testing.T) {
+	first := getSeed("poisson", nil)
+	time.Sleep(time.Millisecond)
+	if second := getSeed("poisson", nil); first == second {
+		t.Errorf("unset seeds should differ, got %d twice", first)
+	}
+}
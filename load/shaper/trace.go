@@ -0,0 +1,102 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package shaper
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver/parser"
+)
+
+// TraceShaper is used to send txs following a recorded time series of rates.
+// Rates between the points of the series are interpolated linearly. Before the
+// first point, the rate of the first point is used. After the last point, the
+// series is either restarted or its last rate is kept.
+type TraceShaper struct {
+	points []parser.TracePoint
+	loop   bool
+	scale  float64
+	// integrals contains for each point the number of messages to be sent from
+	// the start of the trace until the time of the point.
+	integrals []float64
+	// startTimeStamp is the wall-time when the trace got started.
+	startTimeStamp time.Time
+}
+
+func NewTraceShaper(points []parser.TracePoint, loop bool, scale float64) *TraceShaper {
+	integrals := make([]float64, len(points))
+	for i, point := range points {
+		if i == 0 {
+			integrals[i] = point.Rate * point.Time
+			continue
+		}
+		prev := points[i-1]
+		integrals[i] = integrals[i-1] + (prev.Rate+point.Rate)/2*(point.Time-prev.Time)
+	}
+	return &TraceShaper{
+		points:    points,
+		loop:      loop,
+		scale:     scale,
+		integrals: integrals,
+	}
+}
+
+func (s *TraceShaper) Start(start time.Time, _ LoadInfoSource) {
+	s.startTimeStamp = start
+}
+
+// GetNumMessagesInInterval provides the number of messages to be produced
+// in the given time interval.
+func (s *TraceShaper) GetNumMessagesInInterval(start time.Time, duration time.Duration) float64 {
+	x := start.Sub(s.startTimeStamp).Seconds()
+	y := x + duration.Seconds()
+	return math.Max(s.scale*(s.integral(y)-s.integral(x)), 0)
+}
+
+// integral computes the number of messages to be sent from the start of the trace
+// until the given time, taking the looping of the trace into account.
+func (s *TraceShaper) integral(t float64) float64 {
+	if len(s.points) == 0 {
+		return 0
+	}
+	last := len(s.points) - 1
+	period := s.points[last].Time
+	if !s.loop || period <= 0 || t < period {
+		return s.integralOnce(t)
+	}
+	rounds := math.Floor(t / period)
+	return rounds*s.integrals[last] + s.integralOnce(t-rounds*period)
+}
+
+// integralOnce computes the number of messages to be sent from the start of a
+// single replay of the trace until the given time.
+func (s *TraceShaper) integralOnce(t float64) float64 {
+	first, last := s.points[0], s.points[len(s.points)-1]
+	if t <= first.Time {
+		return first.Rate * t
+	}
+	if t >= last.Time {
+		return s.integrals[len(s.points)-1] + last.Rate*(t-last.Time)
+	}
+	// find the segment [points[i-1], points[i]] containing t
+	i := sort.Search(len(s.points), func(i int) bool { return s.points[i].Time > t })
+	prev, next := s.points[i-1], s.points[i]
+	rate := prev.Rate + (next.Rate-prev.Rate)*(t-prev.Time)/(next.Time-prev.Time)
+	return s.integrals[i-1] + (prev.Rate+rate)/2*(t-prev.Time)
+}
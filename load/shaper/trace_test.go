@@ -0,0 +1,73 @@
+// Copyright 2024 Fantom Foundation
+// This file is part of Norma System Testing Infrastructure for Sonic.
+//
+// Norma is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Norma is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Norma. If not, see <http://www.gnu.org/licenses/>.
+
+package shaper
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Fantom-foundation/Norma/driver/parser"
+)
+
+func TestTraceShaper(t *testing.T) {
+	ramp := []parser.TracePoint{{Time: 0, Rate: 0}, {Time: 10, Rate: 10}, {Time: 20, Rate: 10}}
+	delayed := []parser.TracePoint{{Time: 5, Rate: 4}, {Time: 10, Rate: 4}}
+
+	tests := []struct {
+		// Shaper properties
+		points []parser.TracePoint
+		loop   bool
+		scale  float64
+		// Query properties
+		from     time.Duration
+		to       time.Duration
+		expected float64
+	}{
+		// Increasing rate
+		{ramp, false, 1, 0, 10 * time.Second, 50},
+		// Constant rate
+		{ramp, false, 1, 10 * time.Second, 20 * time.Second, 100},
+		// Interval covering two segments
+		{ramp, false, 1, 5 * time.Second, 15 * time.Second, 87.5},
+		// The last rate is kept after the end of the trace
+		{ramp, false, 1, 20 * time.Second, 30 * time.Second, 100},
+		// The trace is restarted after its end
+		{ramp, true, 1, 20 * time.Second, 30 * time.Second, 50},
+		// Interval spanning multiple rounds
+		{ramp, true, 1, 0, 50 * time.Second, 350},
+		// Rates are scaled
+		{ramp, false, 2, 0, 10 * time.Second, 100},
+		// The first rate is used before the first point
+		{delayed, false, 1, 0, 5 * time.Second, 20},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("points=%v,loop=%t,scale=%f,from=%v,to=%v",
+			test.points, test.loop, test.scale, test.from, test.to,
+		), func(t *testing.T) {
+			shaper := NewTraceShaper(test.points, test.loop, test.scale)
+			start := time.Now()
+			shaper.Start(start, nil)
+			got := shaper.GetNumMessagesInInterval(start.Add(test.from), test.to-test.from)
+			if math.Abs(got-test.expected) > 1e-6 {
+				t.Errorf("unexpected number of messages, wanted %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
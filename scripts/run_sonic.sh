@@ -9,8 +9,15 @@ echo "Sonic is going to export its services on ${external_ip}"
 mkdir /datadir
 ./sonictool --datadir=/datadir genesis fake ${VALIDATORS_COUNT}
 
+# Archive nodes are run in the RPC mode maintaining the history of the state.
+mode_flags=""
+if [ "${ARCHIVE}" = "true" ]; then
+    mode_flags="--mode rpc"
+fi
+
 # Start sonic as part of a fake net with RPC service.
 ./sonicd --fakenet ${VALIDATOR_NUMBER}/${VALIDATORS_COUNT} \
+    ${mode_flags} \
     --datadir=/datadir \
     --http --http.addr 0.0.0.0 --http.port 18545 --http.api admin,eth,ftm \
     --ws --ws.addr 0.0.0.0 --ws.port 18546 --ws.api admin,eth,ftm \